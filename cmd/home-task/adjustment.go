@@ -0,0 +1,177 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func newAdjustmentCommand(a *app) *cobra.Command {
+	adjustment := &cobra.Command{
+		Use:   "adjustment",
+		Short: "Review manual adjustments: list, show, approve and reject",
+		Long: "Adjustments are requested with \"balance adjust\". The CLI trusts --operator, since access to it is " +
+			"access to the database; use the admin HTTP API where four-eyes approval has to be enforced.",
+	}
+
+	var (
+		approval api.AdjustmentApprovalRequest
+		operator string
+	)
+
+	approve := &cobra.Command{
+		Use:   "approve ADJUSTMENT_ID",
+		Short: "Approve and post a pending adjustment requested by another operator",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if err := newValidator(container.Currencies).ValidateStruct(approval); err != nil {
+				return err
+			}
+
+			ctx, err := operatorContext(cmd.Context(), operator)
+			if err != nil {
+				return err
+			}
+
+			approved, err := container.AdjustmentService.ApproveAdjustment(ctx, args[0], approval)
+			if err != nil {
+				return err
+			}
+
+			logAdjustment(ctx, approved, "Manual balance adjustment approved")
+
+			return writeJSON(cmd.OutOrStdout(), approved)
+		},
+	}
+	approve.Flags().StringVar(&approval.Comment, "comment", "", "optional note recorded with the approval")
+	addOperatorFlag(approve, &operator)
+
+	var rejection api.AdjustmentRejectionRequest
+
+	reject := &cobra.Command{
+		Use:   "reject ADJUSTMENT_ID",
+		Short: "Reject a pending adjustment, or withdraw your own",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if err := newValidator(container.Currencies).ValidateStruct(rejection); err != nil {
+				return err
+			}
+
+			ctx, err := operatorContext(cmd.Context(), operator)
+			if err != nil {
+				return err
+			}
+
+			rejected, err := container.AdjustmentService.RejectAdjustment(ctx, args[0], rejection)
+			if err != nil {
+				return err
+			}
+
+			logAdjustment(ctx, rejected, "Manual balance adjustment rejected")
+
+			return writeJSON(cmd.OutOrStdout(), rejected)
+		},
+	}
+	reject.Flags().StringVar(&rejection.Comment, "comment", "", "why the adjustment is rejected")
+	addOperatorFlag(reject, &operator)
+
+	_ = reject.MarkFlagRequired("comment")
+
+	var list api.AdjustmentListRequest
+
+	listCommand := &cobra.Command{
+		Use:   "list",
+		Short: "List adjustments, newest first",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if err := newValidator(container.Currencies).ValidateStruct(list); err != nil {
+				return err
+			}
+
+			adjustments, err := container.AdjustmentService.ListAdjustments(cmd.Context(), list)
+			if err != nil {
+				return err
+			}
+
+			return writeJSON(cmd.OutOrStdout(), adjustments)
+		},
+	}
+	listCommand.Flags().StringVar(&list.Status, "status", "", "only this status: pending, posted or rejected")
+	listCommand.Flags().Uint64Var(&list.UserID, "user-id", 0, "only the adjustments of this user")
+	listCommand.Flags().IntVar(&list.Limit, "limit", 0, "at most this many, 1-100 (default 20)")
+
+	adjustment.AddCommand(
+		listCommand,
+		&cobra.Command{
+			Use:   "show ADJUSTMENT_ID",
+			Short: "Print an adjustment with its audit trail",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				container, err := a.container(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				found, err := container.AdjustmentService.GetAdjustment(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+
+				return writeJSON(cmd.OutOrStdout(), found)
+			},
+		},
+		approve,
+		reject,
+	)
+
+	return adjustment
+}
+
+func addOperatorFlag(cmd *cobra.Command, operator *string) {
+	cmd.Flags().StringVar(operator, "operator", os.Getenv("USER"), "who is acting, recorded in the audit trail")
+}
+
+// operatorContext makes operator the principal that the adjustment service records.
+func operatorContext(ctx context.Context, operator string) (context.Context, error) {
+	if operator == "" {
+		return nil, errors.New("--operator is required when $USER is not set")
+	}
+
+	return auth.WithPrincipal(ctx, auth.OperatorPrincipal(operator)), nil
+}
+
+// logAdjustment writes an adjustment step to the log as well, for log-based alerting.
+func logAdjustment(ctx context.Context, adjustment api.AdjustmentResponse, message string) {
+	logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"adjustment_id":  adjustment.AdjustmentID,
+		"user_id":        adjustment.UserID,
+		"transaction_id": adjustment.TransactionID,
+		"amount":         adjustment.Amount,
+		"currency":       adjustment.Currency,
+		"reason_code":    adjustment.ReasonCode,
+		"status":         adjustment.Status,
+		"requested_by":   adjustment.RequestedBy,
+		"decided_by":     adjustment.DecidedBy,
+	}).Info(message)
+}
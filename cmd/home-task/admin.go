@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+// Output formats of the admin commands.
+const (
+	formatJSON  = "json"
+	formatJSONL = "jsonl"
+	formatCSV   = "csv"
+
+	bitSize = 64
+)
+
+func parseUserID(value string) (uint64, error) {
+	userID, err := strconv.ParseUint(value, decimalBase, bitSize)
+	if err != nil || userID == 0 {
+		return 0, fmt.Errorf("invalid user ID %q", value)
+	}
+
+	return userID, nil
+}
+
+// newValidator validates command input with the rules the HTTP handlers apply to request bodies.
+func newValidator(currencies *currency.Registry) *validation.Validator {
+	return validation.NewValidator(validation.WithCurrencies(currencies))
+}
+
+func writeJSON(out io.Writer, value any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode output: %w", err)
+	}
+
+	return nil
+}
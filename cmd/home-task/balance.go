@@ -0,0 +1,111 @@
+package main
+
+import (
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+// manualAdjustmentPrefix starts the generated transaction IDs of manual adjustments.
+const manualAdjustmentPrefix = "manual:"
+
+func newBalanceCommand(a *app) *cobra.Command {
+	balance := &cobra.Command{
+		Use:   "balance",
+		Short: "Inspect and adjust wallet balances",
+	}
+
+	var showCurrency string
+
+	show := &cobra.Command{
+		Use:   "show USER_ID",
+		Short: "Print the balance of a user in one currency",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := parseUserID(args[0])
+			if err != nil {
+				return err
+			}
+
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			found, err := container.UserService.GetBalance(cmd.Context(), userID, showCurrency)
+			if err != nil {
+				return err
+			}
+
+			return writeJSON(cmd.OutOrStdout(), found)
+		},
+	}
+	show.Flags().StringVar(&showCurrency, "currency", "", "currency code, defaults to the default currency")
+
+	var (
+		req      api.AdjustmentRequest
+		operator string
+	)
+
+	adjust := &cobra.Command{
+		Use:   "adjust USER_ID",
+		Short: "Request a manual adjustment, recorded with the operator, the reason code and a comment",
+		Long: "Credits (positive --amount) or debits (negative --amount) a wallet against the manual_adjustment " +
+			"house account. The adjustment is an ordinary ledger transaction: it is idempotent by --transaction-id, " +
+			"respects freezes and cannot overdraw the wallet. With adjustment.four_eyes set it stays pending until " +
+			"another operator approves it with \"adjustment approve\". It prints the adjustment and its audit trail.",
+		Example: `  home-task balance adjust 42 --amount -12.50 --currency EUR --reason-code correction \
+    --comment "Duplicate payout, ticket OPS-123"`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := parseUserID(args[0])
+			if err != nil {
+				return err
+			}
+
+			if req.TransactionID == "" {
+				req.TransactionID = manualAdjustmentPrefix + uuid.NewString()
+			}
+
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if err := newValidator(container.Currencies).ValidateStruct(req); err != nil {
+				return err
+			}
+
+			ctx, err := operatorContext(cmd.Context(), operator)
+			if err != nil {
+				return err
+			}
+
+			adjustment, err := container.AdjustmentService.RequestAdjustment(ctx, req, userID)
+			if err != nil {
+				return err
+			}
+
+			logAdjustment(ctx, adjustment, "Manual balance adjustment requested")
+
+			return writeJSON(cmd.OutOrStdout(), adjustment)
+		},
+	}
+	adjust.Flags().StringVar(&req.Amount, "amount", "", "signed amount in major units, negative to debit")
+	adjust.Flags().StringVar(&req.Currency, "currency", "", "currency code, defaults to the default currency")
+	adjust.Flags().StringVar(&req.ReasonCode, "reason-code", "",
+		"goodwill, compensation, correction, chargeback or promotion")
+	adjust.Flags().StringVar(&req.Comment, "comment", "", "why the balance is adjusted, e.g. a ticket reference")
+	adjust.Flags().StringVar(&req.TransactionID, "transaction-id", "",
+		"idempotency key; reuse it to retry safely (default: a new "+manualAdjustmentPrefix+"<uuid>)")
+	addOperatorFlag(adjust, &operator)
+
+	_ = adjust.MarkFlagRequired("amount")
+	_ = adjust.MarkFlagRequired("reason-code")
+	_ = adjust.MarkFlagRequired("comment")
+
+	balance.AddCommand(show, adjust)
+
+	return balance
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+const exportFileMode = 0o600
+
+// Tables the export command can dump.
+const (
+	exportUsers        = "users"
+	exportWallets      = "wallets"
+	exportTransactions = "transactions"
+)
+
+func newExportCommand(a *app) *cobra.Command {
+	var (
+		format string
+		output string
+		userID uint64
+	)
+
+	export := &cobra.Command{
+		Use:       "export users|wallets|transactions",
+		Short:     "Dump a table as JSON lines or CSV",
+		Long:      "Streams every row of the table in primary key order, formatted like the HTTP API does.",
+		Args:      cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{exportUsers, exportWallets, exportTransactions},
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			if format != formatJSONL && format != formatCSV {
+				return fmt.Errorf("unknown output format %q", format)
+			}
+
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+
+			if output != "" {
+				file, err := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, exportFileMode)
+				if err != nil {
+					return fmt.Errorf("failed to create export file: %w", err)
+				}
+
+				defer func() {
+					if closeErr := file.Close(); closeErr != nil && err == nil {
+						err = fmt.Errorf("failed to close export file: %w", closeErr)
+					}
+				}()
+
+				out = file
+			}
+
+			buffered := bufio.NewWriter(out)
+			rows := newRowWriter(buffered, format)
+
+			if err := runExport(cmd, container.ExportService, args[0], userID, rows); err != nil {
+				return err
+			}
+
+			if err := rows.flush(); err != nil {
+				return err
+			}
+
+			if err := buffered.Flush(); err != nil {
+				return fmt.Errorf("failed to write export: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	export.Flags().StringVar(&format, "format", formatJSONL, "output format: jsonl or csv")
+	export.Flags().StringVarP(&output, "output", "o", "", "write to this file instead of stdout")
+	export.Flags().Uint64Var(&userID, "user-id", 0, "only export the transactions of this user")
+
+	return export
+}
+
+func runExport(cmd *cobra.Command, exports service.ExportService, table string, userID uint64, rows *rowWriter) error {
+	switch table {
+	case exportUsers:
+		rows.header("user_id", "external_id", "status", "freeze_scope", "created_at", "updated_at", "closed_at")
+
+		return exports.ExportUsers(cmd.Context(), func(user api.UserResponse) error {
+			closedAt := ""
+			if user.ClosedAt != nil {
+				closedAt = formatTime(*user.ClosedAt)
+			}
+
+			return rows.write(user, strconv.FormatUint(user.UserID, decimalBase), user.ExternalID, user.Status,
+				user.FreezeScope, formatTime(user.CreatedAt), formatTime(user.UpdatedAt), closedAt)
+		})
+	case exportWallets:
+		rows.header("user_id", "currency", "balance", "available", "reserved")
+
+		return exports.ExportWallets(cmd.Context(), func(wallet api.BalanceResponse) error {
+			return rows.write(wallet, strconv.FormatUint(wallet.UserID, decimalBase), wallet.Currency,
+				wallet.Balance, wallet.Available, wallet.Reserved)
+		})
+	default:
+		rows.header("user_id", "transaction_id", "state", "source_type", "amount", "currency", "processed_at",
+			"reversal_of", "principal", "reason")
+
+		return exports.ExportTransactions(cmd.Context(), userID, func(transaction api.TransactionDetails) error {
+			return rows.write(transaction, strconv.FormatUint(transaction.UserID, decimalBase),
+				transaction.TransactionID, transaction.State, transaction.SourceType, transaction.Amount,
+				transaction.Currency, formatTime(transaction.ProcessedAt), transaction.ReversalOf,
+				transaction.Principal, transaction.Reason)
+		})
+	}
+}
+
+// rowWriter writes exported rows either as JSON lines of the API representation or as CSV records.
+type rowWriter struct {
+	json *json.Encoder
+	csv  *csv.Writer
+}
+
+func newRowWriter(out io.Writer, format string) *rowWriter {
+	if format == formatCSV {
+		return &rowWriter{csv: csv.NewWriter(out)}
+	}
+
+	return &rowWriter{json: json.NewEncoder(out)}
+}
+
+// header is written as the first CSV record. It is ignored for JSON lines. Write errors surface in flush.
+func (w *rowWriter) header(columns ...string) {
+	if w.csv != nil {
+		_ = w.csv.Write(columns)
+	}
+}
+
+func (w *rowWriter) write(value any, record ...string) error {
+	if w.json != nil {
+		if err := w.json.Encode(value); err != nil {
+			return fmt.Errorf("failed to encode row: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := w.csv.Write(record); err != nil {
+		return fmt.Errorf("failed to write row: %w", err)
+	}
+
+	return nil
+}
+
+func (w *rowWriter) flush() error {
+	if w.csv == nil {
+		return nil
+	}
+
+	w.csv.Flush()
+
+	if err := w.csv.Error(); err != nil {
+		return fmt.Errorf("failed to write rows: %w", err)
+	}
+
+	return nil
+}
+
+func formatTime(value time.Time) string {
+	return value.UTC().Format(time.RFC3339Nano)
+}
@@ -2,34 +2,268 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
 
 	"github.com/TiPSYDiPSY/home-task/internal/api"
 	"github.com/TiPSYDiPSY/home-task/internal/config"
 	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	"github.com/TiPSYDiPSY/home-task/internal/jobs"
 	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/stream"
 )
 
+// version is set at build time with -ldflags "-X main.version=...".
+var version = "dev"
+
 func main() {
 	ctx := context.Background()
 
-	shutdown := config.InitTracer()
-	defer shutdown()
+	a := &app{}
+
+	err := newRootCommand(a).ExecuteContext(ctx)
+
+	if a.shutdownTracer != nil {
+		a.shutdownTracer()
+	}
+
+	// Cobra has already printed the error.
+	switch {
+	case err == nil:
+	case errors.Is(err, errDrift):
+		os.Exit(exitDrift)
+	default:
+		os.Exit(exitError)
+	}
+}
+
+// app holds the state shared by the commands: the configuration loaded before any of them runs
+// and the tracer it installed.
+type app struct {
+	config         *config.ServerConfig
+	shutdownTracer func()
+}
+
+func newRootCommand(a *app) *cobra.Command {
+	root := &cobra.Command{
+		Use:     "home-task",
+		Short:   "Wallet service: runs the HTTP API and its maintenance tasks",
+		Version: version,
+		Long: "Configuration is read from the YAML file given by --config or $" + config.ConfigFileEnv +
+			", then the environment, then the flags. Without a command the server is started.",
+		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			servConfig, err := config.Load(cmd.Flags())
+			if err != nil {
+				return err
+			}
 
-	servConfig := config.NewServerConfig()
+			a.config = servConfig
+
+			if a.shutdownTracer, err = servConfig.Tracing.InitTracer(cmd.Context(), version); err != nil {
+				return fmt.Errorf("failed to initialise tracing: %w", err)
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return serve(cmd.Context(), a.config)
+		},
+	}
+
+	config.BindFlags(root.PersistentFlags())
+
+	root.AddCommand(
+		&cobra.Command{
+			Use:   "serve",
+			Short: "Start the HTTP server",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return serve(cmd.Context(), a.config)
+			},
+		},
+		newMigrateCommand(a),
+		newReconcileCommand(a),
+		newConfigCommand(a),
+		newUserCommand(a),
+		newBalanceCommand(a),
+		newTxCommand(a),
+		newExportCommand(a),
+		newAdjustmentCommand(a),
+	)
+
+	return root
+}
+
+// container connects to the database and builds the services the admin commands work through, so
+// they apply the same rules as the HTTP API.
+func (a *app) container(ctx context.Context) (service.Container, error) {
+	ds, err := db.NewPostgresDBDataStore(ctx, a.config.DatabaseConnectionDetails)
+	if err != nil {
+		return service.Container{}, fmt.Errorf("connect to DB failed with error: %w", err)
+	}
+
+	return newContainer(ds, a.config)
+}
+
+func newConfigCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "config",
+		Short: "Validate the configuration and print it with secrets redacted",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return a.config.Dump(cmd.OutOrStdout())
+		},
+	}
+}
+
+func serve(ctx context.Context, servConfig *config.ServerConfig) error {
 	logger := logrus.WithContext(ctx)
 
 	ds, err := db.NewPostgresDBDataStore(ctx, servConfig.DatabaseConnectionDetails)
 	if err != nil {
-		logger.WithError(err).Fatal("Connect to DB failed with error")
+		return fmt.Errorf("connect to DB failed with error: %w", err)
+	}
+
+	if servConfig.Migration.OnStart {
+		if _, err := ds.MigrateUp(ctx); err != nil {
+			return fmt.Errorf("failed to run database migrations: %w", err)
+		}
+	}
+
+	if servConfig.Migration.Seed {
+		if err := ds.SeedPredefinedUsers(ctx); err != nil {
+			return fmt.Errorf("failed to seed database: %w", err)
+		}
+	}
+
+	container, err := newContainer(ds, servConfig)
+	if err != nil {
+		return err
+	}
+
+	// Only the HTTP API authenticates callers. The other commands load the same configuration but
+	// never build the verifier or the authenticator.
+	if container.SignatureVerifier, err = servConfig.Signing.Verifier(); err != nil {
+		return fmt.Errorf("invalid request signing configuration: %w", err)
+	}
+
+	if container.SignatureVerifier == nil {
+		logger.Warn("Request signing is disabled, the Source-Type header is trusted as sent")
 	}
 
-	if err := ds.RunAutoMigrate(ctx); err != nil {
-		logger.WithError(err).Fatal("Failed to run database migrations")
+	if container.Authenticator, err = servConfig.Auth.Authenticator(); err != nil {
+		return fmt.Errorf("invalid authentication configuration: %w", err)
 	}
 
-	container := service.NewContainer(ds)
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
+	go jobs.NewHoldExpiryJob(container.HoldService, servConfig.HoldExpiry.Interval).Run(jobsCtx)
+
+	if servConfig.Reconciliation.Interval > 0 {
+		go jobs.NewReconciliationJob(
+			container.ReconciliationService, servConfig.Reconciliation.Interval, servConfig.Reconciliation.Repair,
+		).Run(jobsCtx)
+	}
+
+	publisher, closeSink, err := servConfig.Outbox.Publisher()
+	if err != nil {
+		return fmt.Errorf("invalid outbox configuration: %w", err)
+	}
+
+	if closeSink != nil {
+		defer func() {
+			// The relay must stop writing first. A write that still fails is retried on the next start.
+			stopJobs()
+
+			if err := closeSink.Close(); err != nil {
+				logger.WithError(err).Error("Failed to close outbox sink")
+			}
+		}()
+	}
+
+	// Webhook deliveries are fanned out by the relay, so they only ever carry committed changes.
+	var publishers []events.EventPublisher
+	if publisher != nil {
+		publishers = append(publishers, publisher)
+	}
+
+	if servConfig.Webhooks.Enabled {
+		publishers = append(publishers, container.WebhookService)
+		go jobs.NewWebhookDeliveryJob(container.WebhookService, servConfig.Webhooks.Interval).Run(jobsCtx)
+	}
+
+	if servConfig.Stream.Enabled {
+		notifier, closeNotifier, err := startBalanceStreams(jobsCtx, ds, servConfig, &container)
+		if err != nil {
+			return err
+		}
+
+		if closeNotifier != nil {
+			defer func() {
+				stopJobs()
+
+				if err := closeNotifier.Close(); err != nil {
+					logger.WithError(err).Error("Failed to close balance stream notifier")
+				}
+			}()
+		}
+
+		publishers = append(publishers, notifier)
+	}
+
+	if len(publishers) > 0 {
+		outboxService := service.NewOutboxService(
+			ds, container.Currencies, events.NewMultiPublisher(publishers...), servConfig.Outbox,
+		)
+		go jobs.NewOutboxRelayJob(outboxService, servConfig.Outbox.Interval).Run(jobsCtx)
+	} else {
+		logger.Warn("No outbox sink is configured and webhooks and streams are disabled, " +
+			"balance change events are not published")
+	}
 
 	api.StartServer(ctx, servConfig, container)
+
+	return nil
+}
+
+// startBalanceStreams runs the hub that fans the events of the notifier out to the balance streams
+// of this replica. The relay publishes to the returned notifier, which reaches every replica.
+func startBalanceStreams(
+	ctx context.Context, ds *db.PostgresDBDataStore, servConfig *config.ServerConfig, container *service.Container,
+) (stream.Notifier, io.Closer, error) {
+	notifier, closeNotifier, err := servConfig.Stream.NewNotifier(servConfig.DatabaseConnectionDetails)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid stream configuration: %w", err)
+	}
+
+	hub := stream.NewHub(notifier, servConfig.Stream.Buffer)
+	go hub.Run(ctx)
+
+	container.BalanceStreamService = service.NewBalanceStreamService(
+		ds, container.Currencies, hub, servConfig.Stream,
+	)
+
+	return notifier, closeNotifier, nil
+}
+
+func newContainer(ds *db.PostgresDBDataStore, servConfig *config.ServerConfig) (service.Container, error) {
+	currencies, err := servConfig.Currency.Registry()
+	if err != nil {
+		return service.Container{}, fmt.Errorf("invalid currency configuration: %w", err)
+	}
+
+	amountPolicy, err := servConfig.Amount.Policy()
+	if err != nil {
+		return service.Container{}, fmt.Errorf("invalid amount limits configuration: %w", err)
+	}
+
+	return service.NewContainer(ds, currencies, amountPolicy, servConfig.Adjustment, servConfig.Webhooks), nil
 }
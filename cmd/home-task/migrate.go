@@ -0,0 +1,130 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+)
+
+func newMigrateCommand(a *app) *cobra.Command {
+	migrate := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply, revert or list database migrations",
+	}
+
+	var steps int
+
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "Revert the most recent migrations",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if steps < 1 {
+				return errors.New("--steps must be at least 1")
+			}
+
+			ds, err := db.NewPostgresDBDataStore(cmd.Context(), a.config.DatabaseConnectionDetails)
+			if err != nil {
+				return fmt.Errorf("connect to DB failed with error: %w", err)
+			}
+
+			reverted, err := ds.MigrateDown(cmd.Context(), steps)
+			if err != nil {
+				return fmt.Errorf("failed to revert migrations: %w", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "reverted %d migration(s)\n", len(reverted))
+
+			return nil
+		},
+	}
+	down.Flags().IntVar(&steps, "steps", 1, "number of migrations to revert")
+
+	migrate.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Apply pending migrations",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				ds, err := db.NewPostgresDBDataStore(cmd.Context(), a.config.DatabaseConnectionDetails)
+				if err != nil {
+					return fmt.Errorf("connect to DB failed with error: %w", err)
+				}
+
+				applied, err := ds.MigrateUp(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to apply migrations: %w", err)
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "applied %d migration(s)\n", len(applied))
+
+				return nil
+			},
+		},
+		down,
+		&cobra.Command{
+			Use:   "status",
+			Short: "List migrations and when they were applied",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				ds, err := db.NewPostgresDBDataStore(cmd.Context(), a.config.DatabaseConnectionDetails)
+				if err != nil {
+					return fmt.Errorf("connect to DB failed with error: %w", err)
+				}
+
+				statuses, err := ds.MigrationStatus(cmd.Context())
+				if err != nil {
+					return fmt.Errorf("failed to read migration status: %w", err)
+				}
+
+				return writeMigrationStatus(cmd.OutOrStdout(), statuses)
+			},
+		},
+		&cobra.Command{
+			Use:   "seed",
+			Short: "Create the predefined demo users",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				ds, err := db.NewPostgresDBDataStore(cmd.Context(), a.config.DatabaseConnectionDetails)
+				if err != nil {
+					return fmt.Errorf("connect to DB failed with error: %w", err)
+				}
+
+				if err := ds.SeedPredefinedUsers(cmd.Context()); err != nil {
+					return fmt.Errorf("failed to seed database: %w", err)
+				}
+
+				return nil
+			},
+		},
+	)
+
+	return migrate
+}
+
+func writeMigrationStatus(out io.Writer, statuses []db.MigrationStatus) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0) //nolint: mnd // Column padding
+
+	fmt.Fprintln(writer, "VERSION\tNAME\tAPPLIED AT")
+
+	for _, status := range statuses {
+		appliedAt := "pending"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(writer, "%04d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+	}
+
+	if err := writer.Flush(); err != nil {
+		return fmt.Errorf("failed to flush migration status: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+// Exit codes of the binary. The reconcile command exits with exitDrift so it can be scheduled
+// externally and alert on drift.
+const (
+	exitError = 1
+	exitDrift = 2
+
+	decimalBase = 10
+)
+
+// errDrift is returned by the reconcile command when drift was found and left unrepaired.
+var errDrift = errors.New("balance drift found")
+
+// newReconcileCommand implements "home-task reconcile [--format json|csv] [--repair]". It writes
+// the report to stdout.
+func newReconcileCommand(a *app) *cobra.Command {
+	var (
+		format string
+		repair bool
+	)
+
+	reconcile := &cobra.Command{
+		Use:   "reconcile",
+		Short: "Compare cached balances with the ledger and optionally repair the drift",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if format != "json" && format != "csv" {
+				return fmt.Errorf("unknown report format %q", format)
+			}
+
+			ds, err := db.NewPostgresDBDataStore(cmd.Context(), a.config.DatabaseConnectionDetails)
+			if err != nil {
+				return fmt.Errorf("connect to DB failed with error: %w", err)
+			}
+
+			container, err := newContainer(ds, a.config)
+			if err != nil {
+				return err
+			}
+
+			report, err := container.ReconciliationService.Reconcile(cmd.Context(), repair)
+			if err != nil {
+				return fmt.Errorf("failed to reconcile balances: %w", err)
+			}
+
+			if err := writeReconciliationReport(cmd.OutOrStdout(), format, report); err != nil {
+				return fmt.Errorf("failed to write reconciliation report: %w", err)
+			}
+
+			if len(report.Drifts) > 0 && !repair {
+				return errDrift
+			}
+
+			return nil
+		},
+	}
+
+	reconcile.Flags().StringVar(&format, "format", "json", "report format: json or csv")
+	reconcile.Flags().BoolVar(&repair, "repair", false, "write adjustment transactions for the drift found")
+
+	return reconcile
+}
+
+func writeReconciliationReport(out io.Writer, format string, report api.ReconciliationReport) error {
+	if format == "json" {
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("failed to encode report: %w", err)
+		}
+
+		return nil
+	}
+
+	writer := csv.NewWriter(out)
+
+	records := [][]string{{"user_id", "currency", "balance", "computed_balance", "drift", "repaired"}}
+	for _, drift := range report.Drifts {
+		records = append(records, []string{
+			strconv.FormatUint(drift.UserID, decimalBase),
+			drift.Currency,
+			drift.Balance,
+			drift.ComputedBalance,
+			drift.Drift,
+			strconv.FormatBool(drift.Repaired),
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func newTxCommand(a *app) *cobra.Command {
+	tx := &cobra.Command{
+		Use:   "tx",
+		Short: "Look up transactions",
+	}
+
+	var (
+		req    api.TransactionListRequest
+		format string
+	)
+
+	list := &cobra.Command{
+		Use:   "list USER_ID",
+		Short: "List a user's transactions, newest first",
+		Long: "Lists one page of transactions with the filters of GET /user/{userID}/transactions. " +
+			"In JSON the next page is given by nextCursor, to be passed back with --cursor.",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := parseUserID(args[0])
+			if err != nil {
+				return err
+			}
+
+			if format != formatJSON && format != formatCSV {
+				return fmt.Errorf("unknown output format %q", format)
+			}
+
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			if err := newValidator(container.Currencies).ValidateStruct(req); err != nil {
+				return err
+			}
+
+			page, err := container.UserService.ListTransactions(cmd.Context(), userID, req)
+			if err != nil {
+				return err
+			}
+
+			if format == formatJSON {
+				return writeJSON(cmd.OutOrStdout(), page)
+			}
+
+			return writeTransactionsCSV(cmd.OutOrStdout(), page.Transactions)
+		},
+	}
+	list.Flags().IntVar(&req.Limit, "limit", 0, "page size, 1-100 (default 20)")
+	list.Flags().StringVar(&req.Cursor, "cursor", "", "nextCursor of the previous page")
+	list.Flags().StringVar(&req.Currency, "currency", "", "only this currency")
+	list.Flags().StringVar(&req.State, "state", "", "only this state: win, lose, reversal or adjustment")
+	list.Flags().StringVar(&req.SourceType, "source-type", "", "only this Source-Type: game, server or payment")
+	list.Flags().StringVar(&req.From, "from", "", "processed at or after this RFC 3339 time")
+	list.Flags().StringVar(&req.To, "to", "", "processed at or before this RFC 3339 time")
+	list.Flags().StringVar(&req.MinAmount, "min-amount", "", "minimum absolute amount")
+	list.Flags().StringVar(&req.MaxAmount, "max-amount", "", "maximum absolute amount")
+	list.Flags().StringVar(&format, "format", formatJSON, "output format: json or csv")
+
+	tx.AddCommand(
+		&cobra.Command{
+			Use:   "show USER_ID TRANSACTION_ID",
+			Short: "Print a transaction with the principal that requested it and the adjustment reason",
+			Args:  cobra.ExactArgs(2), //nolint: mnd // User and transaction ID
+			RunE: func(cmd *cobra.Command, args []string) error {
+				userID, err := parseUserID(args[0])
+				if err != nil {
+					return err
+				}
+
+				container, err := a.container(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				found, err := container.UserService.GetTransaction(cmd.Context(), userID, args[1])
+				if err != nil {
+					return err
+				}
+
+				return writeJSON(cmd.OutOrStdout(), found)
+			},
+		},
+		list,
+	)
+
+	return tx
+}
+
+func writeTransactionsCSV(out io.Writer, transactions []api.TransactionResponse) error {
+	writer := csv.NewWriter(out)
+
+	records := [][]string{{"transaction_id", "state", "source_type", "amount", "currency", "processed_at", "reversal_of"}}
+	for _, transaction := range transactions {
+		records = append(records, []string{
+			transaction.TransactionID,
+			transaction.State,
+			transaction.SourceType,
+			transaction.Amount,
+			transaction.Currency,
+			formatTime(transaction.ProcessedAt),
+			transaction.ReversalOf,
+		})
+	}
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("failed to write transactions: %w", err)
+	}
+
+	return nil
+}
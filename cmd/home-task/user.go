@@ -0,0 +1,135 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func newUserCommand(a *app) *cobra.Command {
+	user := &cobra.Command{
+		Use:   "user",
+		Short: "Create, inspect, freeze and unfreeze user accounts",
+	}
+
+	var externalID string
+
+	create := &cobra.Command{
+		Use:   "create",
+		Short: "Create a user and print it",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			req := api.CreateUserRequest{ExternalID: externalID}
+			if err := newValidator(container.Currencies).ValidateStruct(req); err != nil {
+				return err
+			}
+
+			created, err := container.AccountService.CreateUser(cmd.Context(), req)
+			if err != nil {
+				return err
+			}
+
+			return writeJSON(cmd.OutOrStdout(), created)
+		},
+	}
+	create.Flags().StringVar(&externalID, "external-id", "", "the caller's own identifier for the account")
+
+	var scope string
+
+	freeze := &cobra.Command{
+		Use:   "freeze USER_ID",
+		Short: "Block money movements of a user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			userID, err := parseUserID(args[0])
+			if err != nil {
+				return err
+			}
+
+			container, err := a.container(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			req := api.FreezeRequest{Scope: scope}
+			if err := newValidator(container.Currencies).ValidateStruct(req); err != nil {
+				return err
+			}
+
+			frozen, err := container.AccountService.FreezeUser(cmd.Context(), req, userID)
+			if err != nil {
+				return err
+			}
+
+			return writeJSON(cmd.OutOrStdout(), frozen)
+		},
+	}
+	freeze.Flags().StringVar(&scope, "scope", "all", "movements to block: debits, credits or all")
+
+	user.AddCommand(
+		create,
+		&cobra.Command{
+			Use:   "show USER_ID",
+			Short: "Print a user and their wallets",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				userID, err := parseUserID(args[0])
+				if err != nil {
+					return err
+				}
+
+				container, err := a.container(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				found, err := container.AccountService.GetUser(cmd.Context(), userID)
+				if err != nil {
+					return err
+				}
+
+				wallets, err := container.UserService.ListWallets(cmd.Context(), userID)
+				if err != nil {
+					return err
+				}
+
+				return writeJSON(cmd.OutOrStdout(), struct {
+					api.UserResponse
+
+					Wallets []api.BalanceResponse `json:"wallets"`
+				}{found, wallets.Wallets})
+			},
+		},
+		freeze,
+		&cobra.Command{
+			Use:   "unfreeze USER_ID",
+			Short: "Lift the freeze of a user",
+			Args:  cobra.ExactArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				userID, err := parseUserID(args[0])
+				if err != nil {
+					return err
+				}
+
+				container, err := a.container(cmd.Context())
+				if err != nil {
+					return err
+				}
+
+				unfrozen, err := container.AccountService.UnfreezeUser(cmd.Context(), userID)
+				if err != nil {
+					return err
+				}
+
+				return writeJSON(cmd.OutOrStdout(), unfrozen)
+			},
+		},
+	)
+
+	return user
+}
@@ -0,0 +1,142 @@
+package amount
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+// Limit bounds a single transaction amount in major units of the transaction currency.
+// A nil bound is not enforced.
+type Limit struct {
+	Min *decimal.Decimal
+	Max *decimal.Decimal
+}
+
+// Policy decides whether an amount may be posted for a given Source-Type.
+type Policy struct {
+	limits map[string]Limit
+}
+
+const limitParts = 3
+
+var (
+	ErrInvalidLimit = errors.New("invalid amount limit")
+
+	maxMinorUnits = decimal.NewFromInt(math.MaxInt64)
+)
+
+// NewPolicy returns a policy enforcing limits per source type. Source types without an entry
+// only need a positive amount that fits into int64 minor units.
+func NewPolicy(limits map[string]Limit) Policy {
+	normalized := make(map[string]Limit, len(limits))
+	for sourceType, limit := range limits {
+		normalized[strings.ToLower(sourceType)] = limit
+	}
+
+	return Policy{
+		limits: normalized,
+	}
+}
+
+// ToMinorUnits checks value against the policy for sourceType and converts it to minor units of cur.
+// Positivity is checked on the minor units, so no amount is ever posted as zero.
+func (p Policy) ToMinorUnits(sourceType string, value decimal.Decimal, cur currency.Currency) (int64, error) {
+	if !value.IsPositive() {
+		return 0, errs.ErrAmountNotPositive
+	}
+
+	if limit, ok := p.limits[strings.ToLower(sourceType)]; ok {
+		if limit.Min != nil && value.LessThan(*limit.Min) {
+			return 0, fmt.Errorf("%w of %s for %s", errs.ErrAmountBelowMinimum, limit.Min.String(), sourceType)
+		}
+
+		if limit.Max != nil && value.GreaterThan(*limit.Max) {
+			return 0, fmt.Errorf("%w of %s for %s", errs.ErrAmountAboveMaximum, limit.Max.String(), sourceType)
+		}
+	}
+
+	minorUnits, err := ToMinorUnits(value, cur)
+	if err != nil {
+		return 0, err
+	}
+
+	if minorUnits <= 0 {
+		return 0, errs.ErrAmountNotPositive
+	}
+
+	return minorUnits, nil
+}
+
+// ToMinorUnits converts value to minor units of cur. Values with more decimal places than cur
+// allows, written out or in exponent notation such as "1e-3", are rejected instead of truncated,
+// and so are values that do not fit into an int64.
+func ToMinorUnits(value decimal.Decimal, cur currency.Currency) (int64, error) {
+	shifted := value.Shift(cur.Exponent)
+	if !shifted.Equal(shifted.Truncate(0)) {
+		return 0, errs.ErrInvalidAmountFormat
+	}
+
+	if shifted.Abs().GreaterThan(maxMinorUnits) {
+		return 0, errs.ErrAmountOverflow
+	}
+
+	return cur.ToMinorUnits(value), nil
+}
+
+// ParseLimits parses limits in the form "game:0.01:1000,payment:1:" where either bound may be empty.
+func ParseLimits(value string) (map[string]Limit, error) {
+	limits := make(map[string]Limit)
+
+	if strings.TrimSpace(value) == "" {
+		return limits, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(item), ":")
+		if len(parts) != limitParts || parts[0] == "" {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLimit, item)
+		}
+
+		minimum, err := parseBound(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLimit, item)
+		}
+
+		maximum, err := parseBound(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLimit, item)
+		}
+
+		if minimum != nil && maximum != nil && minimum.GreaterThan(*maximum) {
+			return nil, fmt.Errorf("%w: %q has minimum above maximum", ErrInvalidLimit, item)
+		}
+
+		limits[strings.ToLower(parts[0])] = Limit{Min: minimum, Max: maximum}
+	}
+
+	return limits, nil
+}
+
+func parseBound(value string) (*decimal.Decimal, error) {
+	if value == "" {
+		return nil, nil //nolint: nilnil // Empty bound means "not enforced"
+	}
+
+	bound, err := decimal.NewFromString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if bound.IsNegative() {
+		return nil, ErrInvalidLimit
+	}
+
+	return &bound, nil
+}
@@ -0,0 +1,107 @@
+package amount
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+func TestPolicyToMinorUnits(t *testing.T) {
+	limits, err := ParseLimits("game:0.10:100,payment::1000")
+	assert.NoError(t, err)
+
+	policy := NewPolicy(limits)
+	usd := currency.Currency{Code: "USD", Exponent: 2}
+
+	tests := []struct {
+		name          string
+		sourceType    string
+		amount        string
+		cur           currency.Currency
+		wantUnits     int64
+		expectedError error
+	}{
+		{name: "within limits", sourceType: "game", amount: "10.50", cur: usd, wantUnits: 1050},
+		{name: "equal to minimum", sourceType: "game", amount: "0.10", cur: usd, wantUnits: 10},
+		{name: "equal to maximum", sourceType: "GAME", amount: "100", cur: usd, wantUnits: 10000},
+		{name: "zero", sourceType: "game", amount: "0", cur: usd, expectedError: errs.ErrAmountNotPositive},
+		{name: "negative", sourceType: "server", amount: "-5", cur: usd, expectedError: errs.ErrAmountNotPositive},
+		{name: "below minimum", sourceType: "game", amount: "0.09", cur: usd, expectedError: errs.ErrAmountBelowMinimum},
+		{name: "above maximum", sourceType: "payment", amount: "1000.01", cur: usd, expectedError: errs.ErrAmountAboveMaximum},
+		{name: "no limits for source", sourceType: "server", amount: "1000000", cur: usd, wantUnits: 100000000},
+		{name: "exponent notation", sourceType: "server", amount: "1E2", cur: usd, wantUnits: 10000},
+		{
+			name:          "exponent notation below the minor unit",
+			sourceType:    "server",
+			amount:        "1e-3",
+			cur:           usd,
+			expectedError: errs.ErrInvalidAmountFormat,
+		},
+		{
+			name:          "more decimal places than the currency allows",
+			sourceType:    "server",
+			amount:        "0.001",
+			cur:           currency.Currency{Code: "EUR", Exponent: 2},
+			expectedError: errs.ErrInvalidAmountFormat,
+		},
+		{
+			name:       "largest representable amount",
+			sourceType: "server",
+			amount:     "92233720368547758.07",
+			cur:        usd,
+			wantUnits:  9223372036854775807,
+		},
+		{
+			name:          "overflow",
+			sourceType:    "server",
+			amount:        "92233720368547758.08",
+			cur:           usd,
+			expectedError: errs.ErrAmountOverflow,
+		},
+		{
+			name:          "overflow with larger exponent",
+			sourceType:    "server",
+			amount:        "9223372036854776",
+			cur:           currency.Currency{Code: "KWD", Exponent: 3},
+			expectedError: errs.ErrAmountOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			units, err := policy.ToMinorUnits(tt.sourceType, decimal.RequireFromString(tt.amount), tt.cur)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnits, units)
+		})
+	}
+}
+
+func TestParseLimits(t *testing.T) {
+	limits, err := ParseLimits("Game:1:100, payment::500,server:2:")
+	assert.NoError(t, err)
+	assert.Len(t, limits, 3)
+	assert.Equal(t, "1", limits["game"].Min.String())
+	assert.Equal(t, "100", limits["game"].Max.String())
+	assert.Nil(t, limits["payment"].Min)
+	assert.Nil(t, limits["server"].Max)
+
+	limits, err = ParseLimits("")
+	assert.NoError(t, err)
+	assert.Empty(t, limits)
+
+	for _, invalid := range []string{"game", "game:1", ":1:2", "game:x:2", "game:5:1", "game:-1:2"} {
+		_, err := ParseLimits(invalid)
+		assert.ErrorIs(t, err, ErrInvalidLimit, invalid)
+	}
+}
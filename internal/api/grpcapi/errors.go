@@ -0,0 +1,65 @@
+package grpcapi
+
+import (
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+)
+
+// grpcCodes maps the codes of the error table the REST handlers answer with to gRPC codes.
+var grpcCodes = map[string]codes.Code{
+	response.CodeAuthenticationRequired: codes.Unauthenticated,
+
+	response.CodeUserNotFound:      codes.NotFound,
+	response.CodeUserFrozen:        codes.FailedPrecondition,
+	response.CodeUserClosed:        codes.FailedPrecondition,
+	response.CodeExternalIDTaken:   codes.AlreadyExists,
+	response.CodeBalanceNotZero:    codes.FailedPrecondition,
+	response.CodeInsufficientFunds: codes.FailedPrecondition,
+
+	response.CodeInvalidAmount:       codes.InvalidArgument,
+	response.CodeAmountNotPositive:   codes.InvalidArgument,
+	response.CodeAmountZero:          codes.InvalidArgument,
+	response.CodeAmountBelowMinimum:  codes.InvalidArgument,
+	response.CodeAmountAboveMaximum:  codes.InvalidArgument,
+	response.CodeAmountOutOfRange:    codes.InvalidArgument,
+	response.CodeUnsupportedCurrency: codes.InvalidArgument,
+	response.CodeCurrencyMismatch:    codes.FailedPrecondition,
+	response.CodeInvalidCursor:       codes.InvalidArgument,
+	response.CodeInvalidTimeFormat:   codes.InvalidArgument,
+
+	response.CodeDuplicateTransaction: codes.AlreadyExists,
+	response.CodeTransactionNotFound:  codes.NotFound,
+	response.CodeAlreadyReversed:      codes.FailedPrecondition,
+	response.CodeNotReversible:        codes.FailedPrecondition,
+
+	response.CodeHoldNotFound:  codes.NotFound,
+	response.CodeDuplicateHold: codes.AlreadyExists,
+	response.CodeHoldNotActive: codes.FailedPrecondition,
+
+	response.CodeAdjustmentNotFound:   codes.NotFound,
+	response.CodeAdjustmentNotPending: codes.FailedPrecondition,
+	response.CodeSelfApproval:         codes.PermissionDenied,
+
+	response.CodeWebhookSubscriptionNotFound: codes.NotFound,
+	response.CodeWebhookDeliveryNotFound:     codes.NotFound,
+	response.CodeWebhookDeliveryNotDead:      codes.FailedPrecondition,
+
+	response.CodeStreamsUnavailable: codes.Unavailable,
+}
+
+// statusError maps an error of the services to the gRPC status a client receives, from the same
+// table the REST handlers answer with. An unknown error becomes Internal with message, so its
+// details never reach the client.
+func statusError(err error, message string) error {
+	if code, detail, ok := response.Lookup(err); ok {
+		if grpcCode, ok := grpcCodes[code]; ok {
+			return status.Error(grpcCode, detail)
+		}
+
+		return status.Error(codes.Unknown, detail)
+	}
+
+	return status.Error(codes.Internal, message)
+}
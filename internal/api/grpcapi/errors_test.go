@@ -0,0 +1,104 @@
+package grpcapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+func TestStatusError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name:        "user not found",
+			err:         fmt.Errorf("lookup: %w", customErrors.ErrUserNotFound),
+			wantCode:    codes.NotFound,
+			wantMessage: "user not found",
+		},
+		{
+			name:        "transaction exists",
+			err:         customErrors.ErrTransactionExists,
+			wantCode:    codes.AlreadyExists,
+			wantMessage: "transaction ID already used with a different payload",
+		},
+		{
+			name:        "insufficient funds",
+			err:         customErrors.ErrInsufficientFunds,
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "insufficient funds for this transaction",
+		},
+		{
+			name:        "account frozen",
+			err:         customErrors.ErrAccountFrozen,
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "account is frozen for this operation",
+		},
+		{
+			name:        "unsupported currency",
+			err:         customErrors.ErrUnsupportedCurrency,
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "unsupported currency",
+		},
+		{
+			name:        "invalid cursor",
+			err:         customErrors.ErrInvalidCursor,
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid cursor",
+		},
+		{
+			name:        "error text as message",
+			err:         fmt.Errorf("%w: maximum is 100.00 USD", customErrors.ErrAmountAboveMaximum),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "amount is above the maximum: maximum is 100.00 USD",
+		},
+		{
+			name:        "amount zero",
+			err:         customErrors.ErrAmountZero,
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "amount must not be zero",
+		},
+		{
+			name:        "hold not active",
+			err:         customErrors.ErrHoldNotActive,
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "hold is already settled, released or expired",
+		},
+		{
+			name:        "self approval",
+			err:         customErrors.ErrSelfApproval,
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "adjustments must be approved by a different operator",
+		},
+		{
+			name:        "streams unavailable",
+			err:         customErrors.ErrStreamsUnavailable,
+			wantCode:    codes.Unavailable,
+			wantMessage: "balance streams are unavailable",
+		},
+		{
+			name:        "unknown error",
+			err:         errors.New("connection refused"),
+			wantCode:    codes.Internal,
+			wantMessage: "failed to process transaction",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := status.Convert(statusError(tt.err, "failed to process transaction"))
+
+			assert.Equal(t, tt.wantCode, st.Code())
+			assert.Equal(t, tt.wantMessage, st.Message())
+		})
+	}
+}
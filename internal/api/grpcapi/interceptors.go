@@ -0,0 +1,258 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/grpcapi/walletv1"
+	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
+	"github.com/TiPSYDiPSY/home-task/internal/signing"
+)
+
+// Metadata keys are the REST headers, lower-cased as gRPC requires.
+var (
+	apiKeyKey        = strings.ToLower(auth.APIKeyHeader)
+	authorizationKey = strings.ToLower(auth.AuthorizationHeader)
+	sourceTypeKey    = "source-type"
+	signatureKey     = strings.ToLower(signing.SignatureHeader)
+	timestampKey     = strings.ToLower(signing.TimestampHeader)
+)
+
+// methodRule is what the REST routes express with RequireScope and RequireSourceType.
+type methodRule struct {
+	scope             string
+	requireSourceType bool
+}
+
+var methodRules = map[string]methodRule{
+	walletv1.WalletService_GetBalance_FullMethodName:       {scope: auth.ScopeBalanceRead},
+	walletv1.WalletService_ApplyTransaction_FullMethodName: {scope: auth.ScopeTransactionWrite, requireSourceType: true},
+	walletv1.WalletService_ListTransactions_FullMethodName: {scope: auth.ScopeTransactionRead},
+}
+
+// MetricsInterceptor counts requests and their latency by method and status code.
+func MetricsInterceptor(
+	ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler,
+) (any, error) {
+	start := time.Now()
+
+	resp, err := handler(ctx, req)
+
+	metrics.ObserveGRPCRequest(info.FullMethod, status.Code(err).String(), time.Since(start))
+
+	return resp, err
+}
+
+// LoggingInterceptor traces and logs every request like the logging middleware of the REST API,
+// continuing the caller's trace when the metadata carries a traceparent.
+func LoggingInterceptor(serviceName string) grpc.UnaryServerInterceptor {
+	tracer := otel.Tracer(serviceName)
+
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+
+		ctx, span := tracer.Start(ctx, "grpc_request",
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("rpc.system", "grpc"),
+				attribute.String("rpc.method", info.FullMethod),
+			),
+		)
+		defer span.End()
+
+		traceID, spanID := "invalid_trace", "invalid_span"
+		if spanContext := span.SpanContext(); spanContext.IsValid() {
+			traceID = spanContext.TraceID().String()
+			spanID = spanContext.SpanID().String()
+		}
+
+		logrus.WithContext(ctx).WithFields(logrus.Fields{
+			"grpc_method": info.FullMethod,
+			"source_type": firstValue(md, sourceTypeKey),
+			"trace_id":    traceID,
+			"span_id":     spanID,
+		}).Info("gRPC Request Started")
+
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+
+		if isServerError(code) {
+			span.SetStatus(otelcodes.Error, code.String())
+		}
+
+		completionLogFields := logrus.Fields{
+			"grpc_method": info.FullMethod,
+			"status_code": code.String(),
+			"duration_ms": time.Since(start).Milliseconds(),
+			"trace_id":    traceID,
+			"span_id":     spanID,
+		}
+
+		if err != nil {
+			completionLogFields["error"] = status.Convert(err).Message()
+		}
+
+		logrus.WithContext(ctx).WithFields(completionLogFields).Info("gRPC Request Completed")
+
+		return resp, err
+	}
+}
+
+// AuthInterceptor resolves the principal of a request the way the Authenticate middleware does,
+// then enforces the scope and Source-Type rules of the method. A method without rules is denied.
+// A nil verifier trusts the source-type metadata as sent.
+func AuthInterceptor(authenticator *auth.Authenticator, verifier *signing.Verifier) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		rule, ok := methodRules[info.FullMethod]
+		if !ok {
+			return nil, status.Error(codes.PermissionDenied, "method is not available")
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+
+		ctx, err := authenticate(ctx, md, authenticator, verifier, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+
+		principal, _ := auth.PrincipalFromContext(ctx)
+		if !principal.HasScope(rule.scope) {
+			return nil, status.Error(codes.PermissionDenied, "missing scope "+rule.scope)
+		}
+
+		if rule.requireSourceType && middleware.GetSourceType(ctx) == "" {
+			_, message := middleware.ParseSourceType(firstValue(md, sourceTypeKey))
+
+			return nil, status.Error(codes.InvalidArgument, message)
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func authenticate(
+	ctx context.Context, md metadata.MD, authenticator *auth.Authenticator, verifier *signing.Verifier,
+	method string, req any,
+) (context.Context, error) {
+	principal, err := authenticator.AuthenticateCredentials(firstValue(md, apiKeyKey), firstValue(md, authorizationKey))
+	if errors.Is(err, auth.ErrNoCredentials) {
+		return authenticateSource(ctx, md, verifier, method, req)
+	}
+
+	if err != nil {
+		logrus.WithContext(ctx).WithError(err).Warn("Request authentication failed")
+
+		return nil, status.Error(codes.Unauthenticated, "invalid credentials")
+	}
+
+	ctx = auth.WithPrincipal(ctx, principal)
+
+	// Credentials do not name a source, so a source type only selects the counterparty.
+	if sourceType, message := middleware.ParseSourceType(firstValue(md, sourceTypeKey)); message == "" {
+		ctx = context.WithValue(ctx, middleware.SourceTypeKey, sourceType)
+	}
+
+	return ctx, nil
+}
+
+// authenticateSource verifies the signature of a source the way the SignatureVerifier middleware
+// does. The signed method is POST, the path is the full gRPC method and the body is the
+// deterministic protobuf encoding of the request message.
+func authenticateSource(
+	ctx context.Context, md metadata.MD, verifier *signing.Verifier, method string, req any,
+) (context.Context, error) {
+	value := firstValue(md, sourceTypeKey)
+	if strings.TrimSpace(value) == "" {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	sourceType, message := middleware.ParseSourceType(value)
+	if message != "" {
+		return nil, status.Error(codes.InvalidArgument, message)
+	}
+
+	if verifier != nil {
+		message, ok := req.(proto.Message)
+		if !ok {
+			return nil, status.Error(codes.Internal, "request cannot be verified")
+		}
+
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		if err != nil {
+			return nil, status.Error(codes.Internal, "request cannot be verified")
+		}
+
+		if err := verifier.Verify(sourceType, http.MethodPost, method,
+			firstValue(md, timestampKey), body, firstValue(md, signatureKey)); err != nil {
+			logrus.WithContext(ctx).WithError(err).WithField("source_type", sourceType).
+				Warn("Request signature verification failed")
+
+			return nil, status.Error(codes.Unauthenticated, middleware.SignatureErrorMessage(err))
+		}
+	}
+
+	ctx = context.WithValue(ctx, middleware.SourceTypeKey, sourceType)
+	ctx = auth.WithPrincipal(ctx, auth.SourcePrincipal(sourceType))
+
+	return ctx, nil
+}
+
+// isServerError tells the codes that, like a 5xx response, are the server's fault.
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		return true
+	default:
+		return false
+	}
+}
+
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+// metadataCarrier lets the trace propagator read incoming metadata.
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return firstValue(metadata.MD(c), key)
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
@@ -0,0 +1,27 @@
+// Package grpcapi serves the wallet API over gRPC, next to the REST API and on top of the same services.
+package grpcapi
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/grpcapi/walletv1"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+// NewServer builds the gRPC server. Its interceptors run in the order of the REST middleware:
+// metrics, then logging and tracing, then authentication.
+func NewServer(container service.Container, serviceName string) *grpc.Server {
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		MetricsInterceptor,
+		LoggingInterceptor(serviceName),
+		AuthInterceptor(container.Authenticator, container.SignatureVerifier),
+	))
+
+	walletv1.RegisterWalletServiceServer(server, &walletServer{
+		userService: container.UserService,
+		valid:       validation.NewValidator(validation.WithCurrencies(container.Currencies)),
+	})
+
+	return server
+}
@@ -0,0 +1,395 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/grpcapi/walletv1"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/signing"
+)
+
+// newTestClient serves container over an in-memory connection.
+func newTestClient(t *testing.T, container service.Container) walletv1.WalletServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	server := NewServer(container, "home-task-test")
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return walletv1.NewWalletServiceClient(conn)
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	apiKeys, err := auth.ParseAPIKeys([]byte(`[{"id": "backoffice", "hash": "` + auth.HashAPIKey("backoffice-key") +
+		`", "scopes": ["balance:read", "transaction:write"]}]`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		metadata       []string
+		call           func(ctx context.Context, client walletv1.WalletServiceClient) error
+		wantCode       codes.Code
+		wantMessage    string
+		wantSourceType string
+	}{
+		{
+			name:     "source authentication",
+			metadata: []string{"source-type", "Game"},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) error {
+				_, err := client.ApplyTransaction(ctx, &walletv1.ApplyTransactionRequest{
+					UserId: 1, State: "win", Amount: "10.00", TransactionId: "txn-1",
+				})
+
+				return err
+			},
+			wantCode:       codes.OK,
+			wantSourceType: "game",
+		},
+		{
+			name:     "API key with source type",
+			metadata: []string{"x-api-key", "backoffice-key", "source-type", "payment"},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) error {
+				_, err := client.ApplyTransaction(ctx, &walletv1.ApplyTransactionRequest{
+					UserId: 1, State: "win", Amount: "10.00", TransactionId: "txn-1",
+				})
+
+				return err
+			},
+			wantCode:       codes.OK,
+			wantSourceType: "payment",
+		},
+		{
+			name:     "API key without source type",
+			metadata: []string{"x-api-key", "backoffice-key"},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) error {
+				_, err := client.ApplyTransaction(ctx, &walletv1.ApplyTransactionRequest{
+					UserId: 1, State: "win", Amount: "10.00", TransactionId: "txn-1",
+				})
+
+				return err
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "Source-Type header is required",
+		},
+		{
+			name:     "API key without scope",
+			metadata: []string{"x-api-key", "backoffice-key"},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) error {
+				_, err := client.ListTransactions(ctx, &walletv1.ListTransactionsRequest{UserId: 1})
+
+				return err
+			},
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "missing scope transaction:read",
+		},
+		{
+			name:     "unknown API key",
+			metadata: []string{"x-api-key", "other-key", "source-type", "game"},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) error {
+				_, err := client.GetBalance(ctx, &walletv1.GetBalanceRequest{UserId: 1})
+
+				return err
+			},
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "invalid credentials",
+		},
+		{
+			name: "no credentials",
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) error {
+				_, err := client.GetBalance(ctx, &walletv1.GetBalanceRequest{UserId: 1})
+
+				return err
+			},
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "authentication required",
+		},
+		{
+			name:     "invalid source type",
+			metadata: []string{"source-type", "casino"},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) error {
+				_, err := client.GetBalance(ctx, &walletv1.GetBalanceRequest{UserId: 1})
+
+				return err
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "Source-Type must be one of: game, server, payment",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := service.NewMockUserService(t)
+			userService.EXPECT().GetBalance(mock.Anything, mock.Anything, mock.Anything).
+				Return(api.BalanceResponse{UserID: 1, Balance: "0.00"}, nil).Maybe()
+			userService.EXPECT().UpdateBalance(mock.Anything, mock.Anything, uint64(1), mock.Anything).
+				RunAndReturn(func(_ context.Context, _ api.TransactionRequest, _ uint64, sourceType string) error {
+					assert.Equal(t, tt.wantSourceType, sourceType)
+
+					return nil
+				}).Maybe()
+
+			client := newTestClient(t, service.Container{
+				UserService:   userService,
+				Authenticator: auth.NewAuthenticator(apiKeys, nil),
+			})
+
+			ctx := metadata.NewOutgoingContext(t.Context(), metadata.Pairs(tt.metadata...))
+
+			st := status.Convert(tt.call(ctx, client))
+
+			assert.Equal(t, tt.wantCode, st.Code())
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantMessage, st.Message())
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor_Signature(t *testing.T) {
+	verifier := signing.NewVerifier(map[string][]string{"game": {"game-secret"}}, time.Minute)
+
+	req := &walletv1.ApplyTransactionRequest{UserId: 1, State: "win", Amount: "10.00", TransactionId: "txn-1"}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	sign := func(secret string, message proto.Message) string {
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		require.NoError(t, err)
+
+		return signing.Sign([]byte(secret), http.MethodPost, walletv1.WalletService_ApplyTransaction_FullMethodName,
+			now, body)
+	}
+
+	tests := []struct {
+		name        string
+		signature   string
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name:      "valid signature",
+			signature: sign("game-secret", req),
+			wantCode:  codes.OK,
+		},
+		{
+			name:        "signed by another secret",
+			signature:   sign("payment-secret", req),
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "invalid request signature",
+		},
+		{
+			name: "signed another request",
+			signature: sign("game-secret", &walletv1.ApplyTransactionRequest{
+				UserId: 1, State: "win", Amount: "1000.00", TransactionId: "txn-1",
+			}),
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "invalid request signature",
+		},
+		{
+			name:        "missing signature",
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "invalid request signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := service.NewMockUserService(t)
+			if tt.wantCode == codes.OK {
+				userService.EXPECT().UpdateBalance(mock.Anything, mock.Anything, uint64(1), "game").Return(nil)
+			}
+
+			client := newTestClient(t, service.Container{
+				UserService:       userService,
+				Authenticator:     auth.NewAuthenticator(nil, nil),
+				SignatureVerifier: verifier,
+			})
+
+			ctx := metadata.NewOutgoingContext(t.Context(), metadata.Pairs(
+				"source-type", "game",
+				"x-signature-timestamp", now,
+				"x-signature", tt.signature,
+			))
+
+			_, err := client.ApplyTransaction(ctx, req)
+
+			st := status.Convert(err)
+			assert.Equal(t, tt.wantCode, st.Code())
+
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantMessage, st.Message())
+			}
+		})
+	}
+}
+
+func TestWalletServer(t *testing.T) {
+	processedAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		setupMock   func(userService *service.MockUserService)
+		call        func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error)
+		want        proto.Message
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name: "get balance",
+			setupMock: func(userService *service.MockUserService) {
+				userService.EXPECT().GetBalance(mock.Anything, uint64(1), "EUR").
+					Return(api.BalanceResponse{UserID: 1, Currency: "EUR", Balance: "10.00", Available: "10.00"}, nil)
+			},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error) {
+				return client.GetBalance(ctx, &walletv1.GetBalanceRequest{UserId: 1, Currency: "EUR"})
+			},
+			want: &walletv1.GetBalanceResponse{UserId: 1, Currency: "EUR", Balance: "10.00", Available: "10.00"},
+		},
+		{
+			name: "get balance of unknown user",
+			setupMock: func(userService *service.MockUserService) {
+				userService.EXPECT().GetBalance(mock.Anything, uint64(2), "").
+					Return(api.BalanceResponse{}, customErrors.ErrUserNotFound)
+			},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error) {
+				return client.GetBalance(ctx, &walletv1.GetBalanceRequest{UserId: 2})
+			},
+			wantCode:    codes.NotFound,
+			wantMessage: "user not found",
+		},
+		{
+			name:      "get balance without user ID",
+			setupMock: func(*service.MockUserService) {},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error) {
+				return client.GetBalance(ctx, &walletv1.GetBalanceRequest{})
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "user ID must be positive",
+		},
+		{
+			name:      "apply invalid transaction",
+			setupMock: func(*service.MockUserService) {},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error) {
+				return client.ApplyTransaction(ctx, &walletv1.ApplyTransactionRequest{
+					UserId: 1, State: "draw", Amount: "10.00", TransactionId: "txn-1",
+				})
+			},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "apply transaction with insufficient funds",
+			setupMock: func(userService *service.MockUserService) {
+				userService.EXPECT().UpdateBalance(mock.Anything, api.TransactionRequest{
+					State: "lose", Amount: "10.00", TransactionID: "txn-1",
+				}, uint64(1), "game").Return(customErrors.ErrInsufficientFunds)
+			},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error) {
+				return client.ApplyTransaction(ctx, &walletv1.ApplyTransactionRequest{
+					UserId: 1, State: "lose", Amount: "10.00", TransactionId: "txn-1",
+				})
+			},
+			wantCode:    codes.FailedPrecondition,
+			wantMessage: "insufficient funds for this transaction",
+		},
+		{
+			name: "list transactions",
+			setupMock: func(userService *service.MockUserService) {
+				userService.EXPECT().ListTransactions(mock.Anything, uint64(1), api.TransactionListRequest{
+					Limit: 1, From: "2025-08-18T00:00:00Z",
+				}).Return(api.TransactionListResponse{
+					UserID: 1,
+					Transactions: []api.TransactionResponse{{
+						TransactionID: "txn-1", State: "win", SourceType: "game", Amount: "10.00", Currency: "EUR",
+						ProcessedAt: processedAt,
+					}},
+					NextCursor: "next",
+				}, nil)
+			},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error) {
+				return client.ListTransactions(ctx, &walletv1.ListTransactionsRequest{
+					UserId: 1, Limit: 1, From: timestamppb.New(time.Date(2025, 8, 18, 0, 0, 0, 0, time.UTC)),
+				})
+			},
+			want: &walletv1.ListTransactionsResponse{
+				UserId: 1,
+				Transactions: []*walletv1.Transaction{{
+					TransactionId: "txn-1", State: "win", SourceType: "game", Amount: "10.00", Currency: "EUR",
+					ProcessedAt: timestamppb.New(processedAt),
+				}},
+				NextCursor: "next",
+			},
+		},
+		{
+			name:      "list transactions with invalid time",
+			setupMock: func(*service.MockUserService) {},
+			call: func(ctx context.Context, client walletv1.WalletServiceClient) (proto.Message, error) {
+				return client.ListTransactions(ctx, &walletv1.ListTransactionsRequest{
+					UserId: 1, To: &timestamppb.Timestamp{Nanos: -1},
+				})
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "invalid time format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userService := service.NewMockUserService(t)
+			tt.setupMock(userService)
+
+			client := newTestClient(t, service.Container{UserService: userService})
+
+			ctx := metadata.NewOutgoingContext(t.Context(), metadata.Pairs("source-type", "game"))
+
+			resp, err := tt.call(ctx, client)
+
+			st := status.Convert(err)
+			require.Equal(t, tt.wantCode, st.Code(), st.Message())
+
+			if tt.wantCode != codes.OK {
+				if tt.wantMessage != "" {
+					assert.Equal(t, tt.wantMessage, st.Message())
+				}
+
+				return
+			}
+
+			assert.True(t, proto.Equal(tt.want, resp), "got %v", resp)
+		})
+	}
+}
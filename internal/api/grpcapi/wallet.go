@@ -0,0 +1,146 @@
+package grpcapi
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/grpcapi/walletv1"
+	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+// walletServer serves the WalletService on top of the same UserService and validation as the
+// REST handlers, so both APIs behave alike.
+type walletServer struct {
+	walletv1.UnimplementedWalletServiceServer
+
+	userService service.UserService
+	valid       *validation.Validator
+}
+
+func (s *walletServer) GetBalance(
+	ctx context.Context, req *walletv1.GetBalanceRequest,
+) (*walletv1.GetBalanceResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user ID must be positive")
+	}
+
+	balance, err := s.userService.GetBalance(ctx, req.GetUserId(), req.GetCurrency())
+	if err != nil {
+		return nil, statusError(err, "internal server error")
+	}
+
+	return &walletv1.GetBalanceResponse{
+		UserId:    balance.UserID,
+		Currency:  balance.Currency,
+		Balance:   balance.Balance,
+		Available: balance.Available,
+		Reserved:  balance.Reserved,
+	}, nil
+}
+
+func (s *walletServer) ApplyTransaction(
+	ctx context.Context, req *walletv1.ApplyTransactionRequest,
+) (*walletv1.ApplyTransactionResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user ID must be positive")
+	}
+
+	request := api.TransactionRequest{
+		State:         req.GetState(),
+		Amount:        req.GetAmount(),
+		Currency:      req.GetCurrency(),
+		TransactionID: req.GetTransactionId(),
+	}
+
+	if err := s.valid.ValidateStruct(&request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := s.userService.UpdateBalance(ctx, request, req.GetUserId(), middleware.GetSourceType(ctx)); err != nil {
+		return nil, statusError(err, "failed to process transaction")
+	}
+
+	return &walletv1.ApplyTransactionResponse{}, nil
+}
+
+func (s *walletServer) ListTransactions(
+	ctx context.Context, req *walletv1.ListTransactionsRequest,
+) (*walletv1.ListTransactionsResponse, error) {
+	if req.GetUserId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "user ID must be positive")
+	}
+
+	request, err := toTransactionListRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.valid.ValidateStruct(&request); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	transactions, err := s.userService.ListTransactions(ctx, req.GetUserId(), request)
+	if err != nil {
+		return nil, statusError(err, "internal server error")
+	}
+
+	response := &walletv1.ListTransactionsResponse{
+		UserId:       transactions.UserID,
+		Transactions: make([]*walletv1.Transaction, 0, len(transactions.Transactions)),
+		NextCursor:   transactions.NextCursor,
+	}
+
+	for _, transaction := range transactions.Transactions {
+		response.Transactions = append(response.Transactions, &walletv1.Transaction{
+			TransactionId: transaction.TransactionID,
+			State:         transaction.State,
+			SourceType:    transaction.SourceType,
+			Amount:        transaction.Amount,
+			Currency:      transaction.Currency,
+			ProcessedAt:   timestamppb.New(transaction.ProcessedAt),
+			ReversalOf:    transaction.ReversalOf,
+		})
+	}
+
+	return response, nil
+}
+
+// toTransactionListRequest turns the timestamps of req into the RFC 3339 strings of the REST query.
+func toTransactionListRequest(req *walletv1.ListTransactionsRequest) (api.TransactionListRequest, error) {
+	request := api.TransactionListRequest{
+		Cursor:     req.GetCursor(),
+		Limit:      int(req.GetLimit()),
+		Currency:   req.GetCurrency(),
+		State:      req.GetState(),
+		SourceType: req.GetSourceType(),
+		MinAmount:  req.GetMinAmount(),
+		MaxAmount:  req.GetMaxAmount(),
+	}
+
+	for _, bound := range []struct {
+		timestamp *timestamppb.Timestamp
+		value     *string
+	}{
+		{req.GetFrom(), &request.From},
+		{req.GetTo(), &request.To},
+	} {
+		if bound.timestamp == nil {
+			continue
+		}
+
+		if err := bound.timestamp.CheckValid(); err != nil {
+			return api.TransactionListRequest{}, status.Error(codes.InvalidArgument, "invalid time format")
+		}
+
+		*bound.value = bound.timestamp.AsTime().Format(time.RFC3339Nano)
+	}
+
+	return request, nil
+}
@@ -0,0 +1,58 @@
+package admin
+
+import (
+	"github.com/go-chi/chi/v5"
+	chimiddleware "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/handler/admin/handlers/operator"
+	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+// Init mounts the operator endpoints under /admin. Every route needs the admin scope, which only
+// back-office credentials can carry, so the caller is always an identifiable operator.
+func Init(c config.HTTPConfig, container service.Container, mainRouter *chi.Mux) {
+	subRouter := chi.NewRouter()
+
+	loggingMiddleware := middleware.NewLoggingMiddleware(middleware.LoggingConfig{
+		BodyLoggingEnabled: c.BodyLogging,
+		ServiceName:        "home-task",
+	})
+
+	subRouter.Use(middleware.Metrics)
+	subRouter.Use(loggingMiddleware.Middleware)
+
+	valid := validation.NewValidator(validation.WithCurrencies(container.Currencies))
+
+	authenticateSource := middleware.SourceTypeValidator
+	if container.SignatureVerifier != nil {
+		authenticateSource = middleware.SignatureVerifier(container.SignatureVerifier)
+	}
+
+	subRouter.Use(middleware.Authenticate(container.Authenticator, authenticateSource))
+	subRouter.Use(middleware.RequireScope(auth.ScopeAdmin))
+
+	subRouter.Group(func(r chi.Router) {
+		r.Use(chimiddleware.AllowContentType("application/json"))
+		r.Use(middleware.HTTPVersionValidator)
+		r.Post("/users/{userID}/adjustments", operator.RequestAdjustment(container.AdjustmentService, valid))
+		r.Post("/adjustments/{adjustmentID}/approve", operator.ApproveAdjustment(container.AdjustmentService, valid))
+		r.Post("/adjustments/{adjustmentID}/reject", operator.RejectAdjustment(container.AdjustmentService, valid))
+		r.Post("/webhooks", operator.CreateWebhookSubscription(container.WebhookService, valid))
+		r.Put("/webhooks/{subscriptionID}", operator.UpdateWebhookSubscription(container.WebhookService, valid))
+	})
+
+	subRouter.Get("/adjustments", operator.ListAdjustments(container.AdjustmentService, valid))
+	subRouter.Get("/adjustments/{adjustmentID}", operator.GetAdjustment(container.AdjustmentService))
+	subRouter.Get("/webhooks", operator.ListWebhookSubscriptions(container.WebhookService))
+	subRouter.Get("/webhooks/{subscriptionID}", operator.GetWebhookSubscription(container.WebhookService))
+	subRouter.Delete("/webhooks/{subscriptionID}", operator.DeleteWebhookSubscription(container.WebhookService))
+	subRouter.Get("/webhook-deliveries", operator.ListWebhookDeliveries(container.WebhookService, valid))
+	subRouter.Get("/webhook-deliveries/{deliveryID}", operator.GetWebhookDelivery(container.WebhookService))
+	subRouter.Post("/webhook-deliveries/{deliveryID}/redeliver", operator.RedeliverWebhookDelivery(container.WebhookService))
+
+	mainRouter.Mount("/admin", subRouter)
+}
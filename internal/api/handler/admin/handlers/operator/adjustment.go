@@ -0,0 +1,203 @@
+package operator
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+// RequestAdjustment answers 201 when the adjustment was posted and 202 when it awaits approval.
+func RequestAdjustment(adjustmentService service.AdjustmentService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		var request api.AdjustmentRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		adjustment, err := adjustmentService.RequestAdjustment(ctx, request, userID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to request adjustment")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		status := http.StatusCreated
+		if adjustment.Status == api.AdjustmentStatusPending {
+			status = http.StatusAccepted
+		}
+
+		response.JSON(ctx, w, status, adjustment)
+	}
+}
+
+func ApproveAdjustment(adjustmentService service.AdjustmentService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// The body is optional: an approval needs no comment.
+		var request api.AdjustmentApprovalRequest
+		if r.ContentLength != 0 {
+			if err := decodeJSONBody(r, &request); err != nil {
+				logger.WithError(err).Error("Failed to decode request body")
+				response.BadRequest(ctx, w, err.Error())
+
+				return
+			}
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		adjustment, err := adjustmentService.ApproveAdjustment(ctx, chi.URLParam(r, "adjustmentID"), request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to approve adjustment")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, adjustment)
+	}
+}
+
+func RejectAdjustment(adjustmentService service.AdjustmentService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request api.AdjustmentRejectionRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		adjustment, err := adjustmentService.RejectAdjustment(ctx, chi.URLParam(r, "adjustmentID"), request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to reject adjustment")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, adjustment)
+	}
+}
+
+func GetAdjustment(adjustmentService service.AdjustmentService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		adjustment, err := adjustmentService.GetAdjustment(r.Context(), chi.URLParam(r, "adjustmentID"))
+		if err != nil {
+			response.FromError(r.Context(), w, err)
+
+			return
+		}
+
+		response.JSON(r.Context(), w, http.StatusOK, adjustment)
+	}
+}
+
+func ListAdjustments(adjustmentService service.AdjustmentService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		request, err := parseAdjustmentListRequest(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		adjustments, err := adjustmentService.ListAdjustments(ctx, request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to list adjustments")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, adjustments)
+	}
+}
+
+func parseAdjustmentListRequest(r *http.Request) (api.AdjustmentListRequest, error) {
+	query := r.URL.Query()
+
+	request := api.AdjustmentListRequest{
+		Status: query.Get("status"),
+	}
+
+	if userID := query.Get("userId"); userID != "" {
+		parsed, err := strconv.ParseUint(userID, DecimalBase, BitSize)
+		if err != nil {
+			return api.AdjustmentListRequest{}, errors.New("invalid user ID format")
+		}
+
+		request.UserID = parsed
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return api.AdjustmentListRequest{}, errors.New("invalid limit format")
+		}
+
+		request.Limit = parsed
+	}
+
+	return request, nil
+}
@@ -0,0 +1,324 @@
+package operator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+func newAdminRequest(t *testing.T, params map[string]string, body any) *http.Request {
+	t.Helper()
+
+	bodyBytes, err := json.Marshal(body)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/placeholder", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	for key, value := range params {
+		rctx.URLParams.Add(key, value)
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestRequestAdjustment(t *testing.T) {
+	requestedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	request := api.AdjustmentRequest{
+		Amount:        "-5.00",
+		TransactionID: "manual-1",
+		ReasonCode:    api.AdjustmentReasonCorrection,
+		Comment:       "Duplicate payout, OPS-123",
+	}
+	adjustment := api.AdjustmentResponse{
+		AdjustmentID:  "9b2f4c1e-7d7a-4a39-9a8e-0f3f4a4f3b11",
+		UserID:        1,
+		TransactionID: "manual-1",
+		Amount:        "-5.00",
+		Currency:      "USD",
+		ReasonCode:    api.AdjustmentReasonCorrection,
+		Comment:       "Duplicate payout, OPS-123",
+		RequestedBy:   "jwt:alice",
+		RequestedAt:   requestedAt,
+		Audit:         []api.AdjustmentAuditRecord{{Action: "requested", Actor: "jwt:alice", At: requestedAt}},
+	}
+
+	withStatus := func(status string) api.AdjustmentResponse {
+		adjustment := adjustment
+		adjustment.Status = status
+
+		return adjustment
+	}
+
+	tests := []struct {
+		name         string
+		body         any
+		prepareMocks func(*service.MockAdjustmentService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "posted at once",
+			body: request,
+			prepareMocks: func(mockService *service.MockAdjustmentService) {
+				mockService.EXPECT().RequestAdjustment(mock.Anything, request, uint64(1)).
+					Return(withStatus(api.AdjustmentStatusPosted), nil)
+			},
+			wantHTTPCode: http.StatusCreated,
+			wantBody: `{
+				"adjustmentId": "9b2f4c1e-7d7a-4a39-9a8e-0f3f4a4f3b11",
+				"userId": 1,
+				"transactionId": "manual-1",
+				"amount": "-5.00",
+				"currency": "USD",
+				"reasonCode": "correction",
+				"comment": "Duplicate payout, OPS-123",
+				"status": "posted",
+				"requestedBy": "jwt:alice",
+				"requestedAt": "2026-03-01T09:30:00Z",
+				"audit": [{"action": "requested", "actor": "jwt:alice", "at": "2026-03-01T09:30:00Z"}]
+			}`,
+		},
+		{
+			name: "awaiting approval",
+			body: request,
+			prepareMocks: func(mockService *service.MockAdjustmentService) {
+				mockService.EXPECT().RequestAdjustment(mock.Anything, request, uint64(1)).
+					Return(withStatus(api.AdjustmentStatusPending), nil)
+			},
+			wantHTTPCode: http.StatusAccepted,
+			wantBody: `{
+				"adjustmentId": "9b2f4c1e-7d7a-4a39-9a8e-0f3f4a4f3b11",
+				"userId": 1,
+				"transactionId": "manual-1",
+				"amount": "-5.00",
+				"currency": "USD",
+				"reasonCode": "correction",
+				"comment": "Duplicate payout, OPS-123",
+				"status": "pending",
+				"requestedBy": "jwt:alice",
+				"requestedAt": "2026-03-01T09:30:00Z",
+				"audit": [{"action": "requested", "actor": "jwt:alice", "at": "2026-03-01T09:30:00Z"}]
+			}`,
+		},
+		{
+			name:         "missing reason code and comment",
+			body:         api.AdjustmentRequest{Amount: "5.00", TransactionID: "manual-2"},
+			prepareMocks: func(*service.MockAdjustmentService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: reasonCode is required, comment is required",
+				"errors": [
+					{"field": "reasonCode", "message": "reasonCode is required"},
+					{"field": "comment", "message": "comment is required"}
+				]
+			}`,
+		},
+		{
+			name: "unknown reason code",
+			body: api.AdjustmentRequest{
+				Amount: "5.00", TransactionID: "manual-2", ReasonCode: "because", Comment: "x",
+			},
+			prepareMocks: func(*service.MockAdjustmentService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: reasonCode must be one of [goodwill compensation correction chargeback promotion]",
+				"errors": [{
+					"field": "reasonCode",
+					"message": "reasonCode must be one of [goodwill compensation correction chargeback promotion]"
+				}]
+			}`,
+		},
+		{
+			name: "zero amount",
+			body: request,
+			prepareMocks: func(mockService *service.MockAdjustmentService) {
+				mockService.EXPECT().RequestAdjustment(mock.Anything, request, uint64(1)).
+					Return(api.AdjustmentResponse{}, errs.ErrAmountZero)
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "AMOUNT_ZERO",
+				"detail": "amount must not be zero"
+			}`,
+		},
+		{
+			name: "transaction ID reused",
+			body: request,
+			prepareMocks: func(mockService *service.MockAdjustmentService) {
+				mockService.EXPECT().RequestAdjustment(mock.Anything, request, uint64(1)).
+					Return(api.AdjustmentResponse{}, errs.ErrAdjustmentExists)
+			},
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "DUPLICATE_TRANSACTION",
+				"detail": "transaction ID already used with a different payload"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockAdjustmentService(t)
+			tt.prepareMocks(mockService)
+
+			rr := httptest.NewRecorder()
+			RequestAdjustment(mockService, validation.NewValidator()).
+				ServeHTTP(rr, newAdminRequest(t, map[string]string{"userID": "1"}, tt.body))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestApproveAdjustment(t *testing.T) {
+	const adjustmentID = "9b2f4c1e-7d7a-4a39-9a8e-0f3f4a4f3b11"
+
+	tests := []struct {
+		name         string
+		serviceErr   error
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name:         "approved",
+			wantHTTPCode: http.StatusOK,
+			wantBody: `{
+				"adjustmentId": "9b2f4c1e-7d7a-4a39-9a8e-0f3f4a4f3b11",
+				"userId": 1,
+				"transactionId": "manual-1",
+				"amount": "5.00",
+				"currency": "USD",
+				"reasonCode": "goodwill",
+				"comment": "Late payout",
+				"status": "posted",
+				"requestedBy": "jwt:alice",
+				"requestedAt": "0001-01-01T00:00:00Z",
+				"decidedBy": "jwt:bob"
+			}`,
+		},
+		{
+			name:         "own adjustment",
+			serviceErr:   errs.ErrSelfApproval,
+			wantHTTPCode: http.StatusForbidden,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Forbidden",
+				"status": 403,
+				"code": "SELF_APPROVAL",
+				"detail": "adjustments must be approved by a different operator"
+			}`,
+		},
+		{
+			name:         "already decided",
+			serviceErr:   errs.ErrAdjustmentNotPending,
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "ADJUSTMENT_NOT_PENDING",
+				"detail": "adjustment is already posted or rejected"
+			}`,
+		},
+		{
+			name:         "unknown adjustment",
+			serviceErr:   errs.ErrAdjustmentNotFound,
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "ADJUSTMENT_NOT_FOUND",
+				"detail": "adjustment not found"
+			}`,
+		},
+		{
+			name:         "frozen account",
+			serviceErr:   errs.ErrAccountFrozen,
+			wantHTTPCode: http.StatusForbidden,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Forbidden",
+				"status": 403,
+				"code": "USER_FROZEN",
+				"detail": "account is frozen for this operation"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockAdjustmentService(t)
+
+			var approved api.AdjustmentResponse
+			if tt.serviceErr == nil {
+				approved = api.AdjustmentResponse{
+					AdjustmentID: adjustmentID, UserID: 1, TransactionID: "manual-1", Amount: "5.00", Currency: "USD",
+					ReasonCode: "goodwill", Comment: "Late payout", Status: "posted",
+					RequestedBy: "jwt:alice", DecidedBy: "jwt:bob",
+				}
+			}
+
+			mockService.EXPECT().ApproveAdjustment(mock.Anything, adjustmentID, api.AdjustmentApprovalRequest{}).
+				Return(approved, tt.serviceErr)
+
+			req := newAdminRequest(t, map[string]string{"adjustmentID": adjustmentID}, nil)
+			req.Body = http.NoBody
+			req.ContentLength = 0
+
+			rr := httptest.NewRecorder()
+			ApproveAdjustment(mockService, validation.NewValidator()).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestRejectAdjustment_RequiresComment(t *testing.T) {
+	mockService := service.NewMockAdjustmentService(t)
+
+	rr := httptest.NewRecorder()
+	RejectAdjustment(mockService, validation.NewValidator()).ServeHTTP(rr,
+		newAdminRequest(t, map[string]string{"adjustmentID": "id"}, api.AdjustmentRejectionRequest{}))
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"code": "VALIDATION_FAILED",
+		"detail": "validation failed: comment is required",
+		"errors": [{"field": "comment", "message": "comment is required"}]
+	}`, rr.Body.String())
+}
@@ -0,0 +1,48 @@
+package operator
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	DecimalBase        = 10
+	BitSize            = 64
+	MaxRequestBodySize = 1024
+)
+
+func decodeJSONBody(r *http.Request, dst any) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestBodySize))
+	if err != nil {
+		return errors.New("invalid request body")
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		return errors.New("invalid JSON format")
+	}
+
+	return nil
+}
+
+func parseUserID(r *http.Request) (uint64, error) {
+	userIDStr := chi.URLParam(r, "userID")
+	if userIDStr == "" {
+		return 0, errors.New("user ID is required")
+	}
+
+	userID, err := strconv.ParseUint(userIDStr, DecimalBase, BitSize)
+	if err != nil {
+		return 0, errors.New("invalid user ID format")
+	}
+
+	if userID == 0 {
+		return 0, errors.New("user ID must be positive")
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,210 @@
+package operator
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+// CreateWebhookSubscription answers 201 with the subscription, including the signing secret that
+// is never shown again.
+func CreateWebhookSubscription(webhookService service.WebhookService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request api.WebhookSubscriptionRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		subscription, err := webhookService.CreateSubscription(ctx, request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to create webhook subscription")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusCreated, subscription)
+	}
+}
+
+func UpdateWebhookSubscription(webhookService service.WebhookService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var request api.WebhookSubscriptionRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		subscription, err := webhookService.UpdateSubscription(ctx, chi.URLParam(r, "subscriptionID"), request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to update webhook subscription")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, subscription)
+	}
+}
+
+func GetWebhookSubscription(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscription, err := webhookService.GetSubscription(r.Context(), chi.URLParam(r, "subscriptionID"))
+		if err != nil {
+			response.FromError(r.Context(), w, err)
+
+			return
+		}
+
+		response.JSON(r.Context(), w, http.StatusOK, subscription)
+	}
+}
+
+func ListWebhookSubscriptions(webhookService service.WebhookService) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		subscriptions, err := webhookService.ListSubscriptions(r.Context())
+		if err != nil {
+			logger.WithError(err).Warn("Failed to list webhook subscriptions")
+			response.FromError(r.Context(), w, err)
+
+			return
+		}
+
+		response.JSON(r.Context(), w, http.StatusOK, subscriptions)
+	}
+}
+
+// DeleteWebhookSubscription answers 204. Pending deliveries of the subscription are dropped with it.
+func DeleteWebhookSubscription(webhookService service.WebhookService) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := webhookService.DeleteSubscription(r.Context(), chi.URLParam(r, "subscriptionID")); err != nil {
+			logger.WithError(err).Warn("Failed to delete webhook subscription")
+			response.FromError(r.Context(), w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func ListWebhookDeliveries(webhookService service.WebhookService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		request, err := parseWebhookDeliveryListRequest(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		deliveries, err := webhookService.ListDeliveries(ctx, request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to list webhook deliveries")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, deliveries)
+	}
+}
+
+func GetWebhookDelivery(webhookService service.WebhookService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		delivery, err := webhookService.GetDelivery(r.Context(), chi.URLParam(r, "deliveryID"))
+		if err != nil {
+			response.FromError(r.Context(), w, err)
+
+			return
+		}
+
+		response.JSON(r.Context(), w, http.StatusOK, delivery)
+	}
+}
+
+// RedeliverWebhookDelivery queues a dead-lettered delivery again and answers 202.
+func RedeliverWebhookDelivery(webhookService service.WebhookService) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		delivery, err := webhookService.RedeliverDelivery(r.Context(), chi.URLParam(r, "deliveryID"))
+		if err != nil {
+			logger.WithError(err).Warn("Failed to redeliver webhook delivery")
+			response.FromError(r.Context(), w, err)
+
+			return
+		}
+
+		response.JSON(r.Context(), w, http.StatusAccepted, delivery)
+	}
+}
+
+func parseWebhookDeliveryListRequest(r *http.Request) (api.WebhookDeliveryListRequest, error) {
+	query := r.URL.Query()
+
+	request := api.WebhookDeliveryListRequest{
+		SubscriptionID: query.Get("subscriptionId"),
+		Status:         query.Get("status"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return api.WebhookDeliveryListRequest{}, errors.New("invalid limit format")
+		}
+
+		request.Limit = parsed
+	}
+
+	return request, nil
+}
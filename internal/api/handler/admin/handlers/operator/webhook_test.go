@@ -0,0 +1,249 @@
+package operator
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	createdAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	request := api.WebhookSubscriptionRequest{
+		URL:        "https://partner.example.com/hooks",
+		States:     []string{"win"},
+		Currencies: []string{"USD"},
+		MinAmount:  "100",
+	}
+
+	tests := []struct {
+		name         string
+		body         any
+		prepareMocks func(*service.MockWebhookService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "created",
+			body: request,
+			prepareMocks: func(mockService *service.MockWebhookService) {
+				mockService.EXPECT().CreateSubscription(mock.Anything, request).Return(api.WebhookSubscriptionResponse{
+					SubscriptionID: "5f0c6d8e-2b8a-4c1e-9d43-0b7c3f1a2e11",
+					URL:            "https://partner.example.com/hooks",
+					SourceTypes:    []string{},
+					States:         []string{"win"},
+					Currencies:     []string{"USD"},
+					MinAmount:      "100",
+					Active:         true,
+					Secret:         "whsec_abc",
+					CreatedBy:      "jwt:alice",
+					CreatedAt:      createdAt,
+					UpdatedAt:      createdAt,
+				}, nil)
+			},
+			wantHTTPCode: http.StatusCreated,
+			wantBody: `{
+				"subscriptionId": "5f0c6d8e-2b8a-4c1e-9d43-0b7c3f1a2e11",
+				"url": "https://partner.example.com/hooks",
+				"sourceTypes": [],
+				"states": ["win"],
+				"currencies": ["USD"],
+				"minAmount": "100",
+				"active": true,
+				"secret": "whsec_abc",
+				"createdBy": "jwt:alice",
+				"createdAt": "2026-03-01T09:30:00Z",
+				"updatedAt": "2026-03-01T09:30:00Z"
+			}`,
+		},
+		{
+			name:         "invalid URL",
+			body:         api.WebhookSubscriptionRequest{URL: "partner.example.com"},
+			prepareMocks: func(*service.MockWebhookService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: url is invalid",
+				"errors": [{"field": "url", "message": "url is invalid"}]
+			}`,
+		},
+		{
+			name: "negative minimum amount",
+			body: request,
+			prepareMocks: func(mockService *service.MockWebhookService) {
+				mockService.EXPECT().CreateSubscription(mock.Anything, request).
+					Return(api.WebhookSubscriptionResponse{}, errs.ErrInvalidAmountFormat)
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_AMOUNT",
+				"detail": "invalid amount format"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockWebhookService(t)
+			tt.prepareMocks(mockService)
+
+			rr := httptest.NewRecorder()
+			CreateWebhookSubscription(mockService, validation.NewValidator()).
+				ServeHTTP(rr, newAdminRequest(t, nil, tt.body))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestDeleteWebhookSubscription(t *testing.T) {
+	const subscriptionID = "5f0c6d8e-2b8a-4c1e-9d43-0b7c3f1a2e11"
+
+	mockService := service.NewMockWebhookService(t)
+	mockService.EXPECT().DeleteSubscription(mock.Anything, subscriptionID).Return(nil).Once()
+	mockService.EXPECT().DeleteSubscription(mock.Anything, subscriptionID).
+		Return(errs.ErrWebhookSubscriptionNotFound).Once()
+
+	req := newAdminRequest(t, map[string]string{"subscriptionID": subscriptionID}, nil)
+
+	rr := httptest.NewRecorder()
+	DeleteWebhookSubscription(mockService).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Empty(t, rr.Body.String())
+
+	rr = httptest.NewRecorder()
+	DeleteWebhookSubscription(mockService).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestRedeliverWebhookDelivery(t *testing.T) {
+	const deliveryID = "0d7e3c52-9b1f-4d8e-a6a2-6f1e9c3b4d21"
+
+	tests := []struct {
+		name         string
+		serviceErr   error
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name:         "queued again",
+			wantHTTPCode: http.StatusAccepted,
+			wantBody: `{
+				"deliveryId": "0d7e3c52-9b1f-4d8e-a6a2-6f1e9c3b4d21",
+				"subscriptionId": "5f0c6d8e-2b8a-4c1e-9d43-0b7c3f1a2e11",
+				"eventId": "8c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f",
+				"eventType": "balance.changed",
+				"status": "pending",
+				"attempts": 0,
+				"createdAt": "0001-01-01T00:00:00Z"
+			}`,
+		},
+		{
+			name:         "not dead-lettered",
+			serviceErr:   errs.ErrWebhookDeliveryNotDead,
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "WEBHOOK_DELIVERY_NOT_DEAD",
+				"detail": "only dead-lettered deliveries can be redelivered"
+			}`,
+		},
+		{
+			name:         "unknown delivery",
+			serviceErr:   errs.ErrWebhookDeliveryNotFound,
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "WEBHOOK_DELIVERY_NOT_FOUND",
+				"detail": "webhook delivery not found"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var delivery api.WebhookDeliveryResponse
+			if tt.serviceErr == nil {
+				delivery = api.WebhookDeliveryResponse{
+					DeliveryID:     deliveryID,
+					SubscriptionID: "5f0c6d8e-2b8a-4c1e-9d43-0b7c3f1a2e11",
+					EventID:        "8c1e2f3a-4b5c-4d6e-8f70-1a2b3c4d5e6f",
+					EventType:      "balance.changed",
+					Status:         api.WebhookDeliveryStatusPending,
+				}
+			}
+
+			mockService := service.NewMockWebhookService(t)
+			mockService.EXPECT().RedeliverDelivery(mock.Anything, deliveryID).Return(delivery, tt.serviceErr)
+
+			rr := httptest.NewRecorder()
+			RedeliverWebhookDelivery(mockService).
+				ServeHTTP(rr, newAdminRequest(t, map[string]string{"deliveryID": deliveryID}, nil))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestListWebhookDeliveries_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantBody string
+	}{
+		{
+			name:  "malformed limit",
+			query: "?limit=ten",
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid limit format"
+			}`,
+		},
+		{
+			name:  "unknown status",
+			query: "?status=lost",
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: status must be one of [pending delivered dead]",
+				"errors": [{"field": "status", "message": "status must be one of [pending delivered dead]"}]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			ListWebhookDeliveries(service.NewMockWebhookService(t), validation.NewValidator()).
+				ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/admin/webhook-deliveries"+tt.query, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package operation
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+)
+
+func Live(healthService service.HealthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeHealth(w, r, healthService.Live(r.Context()))
+	}
+}
+
+func Ready(healthService service.HealthService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		health := healthService.Ready(r.Context())
+		if health.Status != api.HealthStatusOK {
+			logrus.WithContext(r.Context()).WithField("checks", health.Checks).Warn("Readiness check failed")
+		}
+
+		writeHealth(w, r, health)
+	}
+}
+
+func writeHealth(w http.ResponseWriter, r *http.Request, health api.HealthResponse) {
+	statusCode := http.StatusOK
+	if health.Status != api.HealthStatusOK {
+		statusCode = http.StatusServiceUnavailable
+	}
+
+	// Probes must see the current state, not a cached one.
+	w.Header().Set("Cache-Control", "no-store")
+	response.JSON(r.Context(), w, statusCode, health)
+}
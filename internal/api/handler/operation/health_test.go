@@ -0,0 +1,66 @@
+package operation
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		name         string
+		health       api.HealthResponse
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "ready",
+			health: api.HealthResponse{
+				Status: api.HealthStatusOK,
+				Checks: map[string]api.HealthCheck{"database": {Status: api.HealthStatusOK, DurationMs: 1}},
+			},
+			wantHTTPCode: http.StatusOK,
+			wantBody:     `{"status":"ok","checks":{"database":{"status":"ok","durationMs":1}}}`,
+		},
+		{
+			name: "not ready",
+			health: api.HealthResponse{
+				Status: api.HealthStatusFail,
+				Checks: map[string]api.HealthCheck{"shutdown": {Status: api.HealthStatusFail, Error: "server is shutting down"}},
+			},
+			wantHTTPCode: http.StatusServiceUnavailable,
+			wantBody:     `{"status":"fail","checks":{"shutdown":{"status":"fail","error":"server is shutting down","durationMs":0}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockHealthService(t)
+			mockService.EXPECT().Ready(mock.Anything).Return(tt.health)
+
+			rr := httptest.NewRecorder()
+			Ready(mockService).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz/ready", nil))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestLive(t *testing.T) {
+	mockService := service.NewMockHealthService(t)
+	mockService.EXPECT().Live(mock.Anything).Return(api.HealthResponse{Status: api.HealthStatusOK})
+
+	rr := httptest.NewRecorder()
+	Live(mockService).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz/live", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rr.Body.String())
+}
@@ -3,8 +3,16 @@ package operation
 import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/openapi"
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
 )
 
-func Init(r *chi.Mux) {
+func Init(container service.Container, r *chi.Mux) {
 	r.Use(middleware.Heartbeat("/ping"))
+	r.Handle("/metrics", metrics.Handler())
+	r.Get("/healthz/live", Live(container.HealthService))
+	r.Get("/healthz/ready", Ready(container.HealthService))
+	r.Get("/openapi.json", openapi.Handler())
 }
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+)
+
+// Authenticate resolves the principal of a request. Back-office callers present an API key or a
+// bearer token; requests without either are authenticated as their Source-Type by sourceAuth.
+func Authenticate(
+	authenticator *auth.Authenticator, sourceAuth func(http.Handler) http.Handler,
+) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		viaSource := sourceAuth(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			principal, err := authenticator.Authenticate(r)
+			if errors.Is(err, auth.ErrNoCredentials) {
+				if strings.TrimSpace(r.Header.Get("Source-Type")) == "" {
+					response.Error(ctx, w, http.StatusUnauthorized, response.CodeAuthenticationRequired, "authentication required")
+
+					return
+				}
+
+				viaSource.ServeHTTP(w, r)
+
+				return
+			}
+
+			if err != nil {
+				logrus.WithContext(ctx).WithError(err).Warn("Request authentication failed")
+				response.Error(ctx, w, http.StatusUnauthorized, response.CodeInvalidCredentials, "invalid credentials")
+
+				return
+			}
+
+			ctx = auth.WithPrincipal(ctx, principal)
+
+			// Credentials do not name a source, so a Source-Type header only selects the counterparty.
+			if sourceType, message := parseSourceType(r); message == "" {
+				ctx = context.WithValue(ctx, SourceTypeKey, sourceType)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequireScope rejects requests whose principal was not granted scope.
+func RequireScope(scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			principal, ok := auth.PrincipalFromContext(ctx)
+			if !ok {
+				response.Error(ctx, w, http.StatusUnauthorized, response.CodeAuthenticationRequired, "authentication required")
+
+				return
+			}
+
+			if !principal.HasScope(scope) {
+				response.Error(ctx, w, http.StatusForbidden, response.CodeMissingScope, "missing scope "+scope)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireSourceType makes sure the request names the Source-Type that money moves against. Source
+// authentication already sets it; a credential principal has to send the header.
+func RequireSourceType(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if GetSourceType(r.Context()) == "" {
+			_, message := parseSourceType(r)
+			response.Error(r.Context(), w, http.StatusBadRequest, response.CodeInvalidSourceType, message)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,180 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+)
+
+func TestAuthenticate(t *testing.T) {
+	apiKeys, err := auth.ParseAPIKeys([]byte(`[{"id": "backoffice", "hash": "` + auth.HashAPIKey("backoffice-key") +
+		`", "scopes": ["balance:read", "transaction:write"]}]`))
+	require.NoError(t, err)
+
+	authenticator := auth.NewAuthenticator(apiKeys, nil)
+
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		scope          string
+		wantHTTPCode   int
+		wantBody       string
+		wantPrincipal  string
+		wantSourceType string
+	}{
+		{
+			name:           "source authentication",
+			headers:        map[string]string{"Source-Type": "game"},
+			scope:          auth.ScopeTransactionWrite,
+			wantHTTPCode:   http.StatusOK,
+			wantPrincipal:  "source:game",
+			wantSourceType: "game",
+		},
+		{
+			name:           "API key with Source-Type",
+			headers:        map[string]string{auth.APIKeyHeader: "backoffice-key", "Source-Type": "Payment"},
+			scope:          auth.ScopeTransactionWrite,
+			wantHTTPCode:   http.StatusOK,
+			wantPrincipal:  "api_key:backoffice",
+			wantSourceType: "payment",
+		},
+		{
+			name:          "API key without Source-Type",
+			headers:       map[string]string{auth.APIKeyHeader: "backoffice-key"},
+			scope:         auth.ScopeBalanceRead,
+			wantHTTPCode:  http.StatusOK,
+			wantPrincipal: "api_key:backoffice",
+		},
+		{
+			name:         "API key without scope",
+			headers:      map[string]string{auth.APIKeyHeader: "backoffice-key"},
+			scope:        auth.ScopeAccountWrite,
+			wantHTTPCode: http.StatusForbidden,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Forbidden",
+				"status": 403,
+				"code": "MISSING_SCOPE",
+				"detail": "missing scope account:write"
+			}`,
+		},
+		{
+			name:         "wrong API key",
+			headers:      map[string]string{auth.APIKeyHeader: "guess", "Source-Type": "game"},
+			scope:        auth.ScopeBalanceRead,
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "INVALID_CREDENTIALS",
+				"detail": "invalid credentials"
+			}`,
+		},
+		{
+			name:         "no credentials",
+			scope:        auth.ScopeBalanceRead,
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "AUTHENTICATION_REQUIRED",
+				"detail": "authentication required"
+			}`,
+		},
+		{
+			name:         "invalid Source-Type",
+			headers:      map[string]string{"Source-Type": "casino"},
+			scope:        auth.ScopeBalanceRead,
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type must be one of: game, server, payment"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPrincipal, gotSourceType string
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				principal, _ := auth.PrincipalFromContext(r.Context())
+				gotPrincipal = principal.String()
+				gotSourceType = GetSourceType(r.Context())
+
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/user/1/balance", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+
+			rr := httptest.NewRecorder()
+			Authenticate(authenticator, SourceTypeValidator)(RequireScope(tt.scope)(next)).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+
+			if tt.wantHTTPCode == http.StatusOK {
+				assert.Equal(t, tt.wantPrincipal, gotPrincipal)
+				assert.Equal(t, tt.wantSourceType, gotSourceType)
+			} else {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestRequireScope_NoPrincipal(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	rr := httptest.NewRecorder()
+	RequireScope(auth.ScopeBalanceRead)(next).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/user/1/balance", nil))
+
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestRequireSourceType(t *testing.T) {
+	apiKeys, err := auth.ParseAPIKeys([]byte(`[{"id": "backoffice", "hash": "` + auth.HashAPIKey("backoffice-key") +
+		`", "scopes": ["admin"]}]`))
+	require.NoError(t, err)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := Authenticate(auth.NewAuthenticator(apiKeys, nil), SourceTypeValidator)(RequireSourceType(next))
+
+	req := httptest.NewRequest(http.MethodPost, "/user/1/transaction", nil)
+	req.Header.Set(auth.APIKeyHeader, "backoffice-key")
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"code": "INVALID_SOURCE_TYPE",
+		"detail": "Source-Type header is required"
+	}`, rr.Body.String())
+
+	req.Header.Set("Source-Type", "server")
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
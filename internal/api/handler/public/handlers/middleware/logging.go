@@ -1,19 +1,33 @@
 package middleware
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"regexp"
 	"slices"
 	"time"
 
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
+// sensitiveBodyField matches the JSON members whose values must not reach the logs, such as the
+// signing secret of a new webhook subscription.
+var sensitiveBodyField = regexp.MustCompile(`("secret"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+// redactBody replaces the values of sensitive JSON members in a logged body.
+func redactBody(body string) string {
+	return sensitiveBodyField.ReplaceAllString(body, `${1}"[REDACTED]"`)
+}
+
 type LoggingConfig struct {
 	BodyLoggingEnabled bool
 	ServiceName        string
@@ -44,7 +58,8 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 }
 
 func (lrw *loggingResponseWriter) Write(data []byte) (int, error) {
-	if lrw.body != nil {
+	// An event stream is open for as long as the client stays, which no buffer should follow.
+	if lrw.body != nil && lrw.Header().Get("Content-Type") != "text/event-stream" {
 		if _, err := lrw.body.Write(data); err != nil {
 			logrus.WithError(err).Warn("Failed to write to response buffer")
 		}
@@ -58,6 +73,23 @@ func (lrw *loggingResponseWriter) Write(data []byte) (int, error) {
 	return n, nil
 }
 
+// Unwrap lets http.ResponseController reach the flushing and deadline methods of the connection.
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
+// Hijack hands the connection over to a WebSocket, which takes it over after the 101 response.
+func (lrw *loggingResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, rw, err := http.NewResponseController(lrw.ResponseWriter).Hijack()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+
+	lrw.statusCode = http.StatusSwitchingProtocols
+
+	return conn, rw, nil
+}
+
 func NewLoggingMiddleware(config LoggingConfig) *LoggingMiddleware {
 	serviceName := config.ServiceName
 	if serviceName == "" {
@@ -74,7 +106,10 @@ func NewLoggingMiddleware(config LoggingConfig) *LoggingMiddleware {
 // nolint: funlen,gocognit // 62 lines is acceptable for this middleware instead of 60 lines
 func (m *LoggingMiddleware) Middleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		ctx, span := m.Tracer.Start(r.Context(), "http_request",
+		// Continue the caller's trace when the request carries a traceparent header.
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+		ctx, span := m.Tracer.Start(ctx, "http_request",
 			trace.WithSpanKind(trace.SpanKindServer),
 			trace.WithAttributes(
 				attribute.String("http.method", r.Method),
@@ -108,7 +143,7 @@ func (m *LoggingMiddleware) Middleware(next http.Handler) http.Handler {
 			bodyBytes, err := io.ReadAll(r.Body)
 			if err == nil {
 				r.Body = io.NopCloser(bytes.NewReader(bodyBytes))
-				body = string(bodyBytes)
+				body = redactBody(string(bodyBytes))
 			}
 		}
 
@@ -132,6 +167,12 @@ func (m *LoggingMiddleware) Middleware(next http.Handler) http.Handler {
 
 		duration := time.Since(start)
 
+		span.SetAttributes(attribute.Int("http.status_code", lrw.statusCode))
+
+		if lrw.statusCode >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(lrw.statusCode))
+		}
+
 		completionLogFields := logrus.Fields{
 			"http_method":   r.Method,
 			"response_body": body,
@@ -143,7 +184,7 @@ func (m *LoggingMiddleware) Middleware(next http.Handler) http.Handler {
 		}
 
 		if m.Config.BodyLoggingEnabled && lrw.body != nil && lrw.body.Len() > 0 {
-			completionLogFields["response_body"] = lrw.body.String()
+			completionLogFields["response_body"] = redactBody(lrw.body.String())
 		}
 
 		logrus.WithContext(ctx).WithFields(completionLogFields).Info("HTTP Request Completed")
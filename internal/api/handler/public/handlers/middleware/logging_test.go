@@ -12,6 +12,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestLoggingMiddleware_Middleware(t *testing.T) {
@@ -193,6 +198,41 @@ func TestLoggingMiddleware_WithTracing(t *testing.T) {
 	assert.Contains(t, logOutput, "span_id")
 }
 
+func TestLoggingMiddleware_ContinuesIncomingTrace(t *testing.T) {
+	logrus.SetOutput(io.Discard)
+
+	previousPropagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	defer otel.SetTextMapPropagator(previousPropagator)
+
+	recorder := tracetest.NewSpanRecorder()
+	middleware := &LoggingMiddleware{
+		Config: LoggingConfig{ServiceName: "test-service"},
+		Tracer: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test"),
+	}
+
+	var handlerTraceID string
+
+	wrappedHandler := middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerTraceID = trace.SpanContextFromContext(r.Context()).TraceID().String()
+
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	wrappedHandler.ServeHTTP(httptest.NewRecorder(), req)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", handlerTraceID)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent().SpanID().String())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
 func TestLoggingMiddleware_WithInvalidSpan(t *testing.T) {
 	var logBuffer bytes.Buffer
 	logrus.SetOutput(&logBuffer)
@@ -313,3 +353,103 @@ func TestLoggingMiddleware_Integration(t *testing.T) {
 	assert.Contains(t, logOutput, "201") // status code
 	assert.Contains(t, logOutput, "duration_ms")
 }
+
+func TestLoggingMiddleware_Streaming(t *testing.T) {
+	logrus.SetOutput(io.Discard)
+
+	middleware := NewLoggingMiddleware(LoggingConfig{BodyLoggingEnabled: true})
+
+	t.Run("event stream is flushed and not buffered", func(t *testing.T) {
+		var lrw *loggingResponseWriter
+
+		wrappedHandler := middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			lrw, _ = w.(*loggingResponseWriter)
+
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = io.WriteString(w, ": heartbeat\n\n")
+			assert.NoError(t, http.NewResponseController(w).Flush())
+		}))
+
+		recorder := httptest.NewRecorder()
+		wrappedHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+		assert.True(t, recorder.Flushed)
+		assert.Equal(t, ": heartbeat\n\n", recorder.Body.String())
+		require.NotNil(t, lrw)
+		assert.Zero(t, lrw.body.Len())
+	})
+
+	t.Run("connection can be hijacked", func(t *testing.T) {
+		server := httptest.NewServer(middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			conn, rw, err := http.NewResponseController(w).Hijack()
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			defer conn.Close()
+
+			_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\n\r\n")
+			_ = rw.Flush()
+		})))
+		defer server.Close()
+
+		req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		resp, err := server.Client().Do(req)
+		require.NoError(t, err)
+
+		defer resp.Body.Close()
+
+		assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	})
+}
+
+func TestLoggingMiddleware_RedactsSecrets(t *testing.T) {
+	var logBuffer bytes.Buffer
+	logrus.SetOutput(&logBuffer)
+	logrus.SetFormatter(&logrus.JSONFormatter{})
+	defer func() {
+		logrus.SetOutput(io.Discard)
+	}()
+
+	secret := "whsec_4f1c9a2b7e3d8f6a0b5c1d9e2f7a3b8c"
+
+	middleware := NewLoggingMiddleware(LoggingConfig{BodyLoggingEnabled: true})
+	wrappedHandler := middleware.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"id":"5b4b6a3c","url":"https://partner.example.com/hooks","secret" : "`+secret+`"}`)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/admin/webhooks", strings.NewReader(`{"secret":"`+secret+`"}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	recorder := httptest.NewRecorder()
+	wrappedHandler.ServeHTTP(recorder, req)
+
+	assert.Contains(t, recorder.Body.String(), secret)
+
+	logOutput := logBuffer.String()
+	assert.NotContains(t, logOutput, secret)
+	assert.Contains(t, logOutput, `\"secret\" : \"[REDACTED]\"`)
+	assert.Contains(t, logOutput, `\"url\":\"https://partner.example.com/hooks\"`)
+}
+
+func TestRedactBody(t *testing.T) {
+	tests := []struct {
+		body string
+		want string
+	}{
+		{`{"secret":"whsec_1"}`, `{"secret":"[REDACTED]"}`},
+		{`{"a":1, "secret": "with \"quotes\"", "b":2}`, `{"a":1, "secret": "[REDACTED]", "b":2}`},
+		{`{"secretName":"kept"}`, `{"secretName":"kept"}`},
+		{`not json`, `not json`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.body, func(t *testing.T) {
+			assert.Equal(t, tt.want, redactBody(tt.body))
+		})
+	}
+}
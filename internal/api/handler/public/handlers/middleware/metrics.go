@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
+)
+
+// Metrics counts requests and their latency by route pattern rather than path, so user IDs do not
+// become label values.
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lrw := newLoggingResponseWriter(w)
+		lrw.body = nil
+		start := time.Now()
+
+		next.ServeHTTP(lrw, r)
+
+		var route string
+		if routeCtx := chi.RouteContext(r.Context()); routeCtx != nil {
+			route = routeCtx.RoutePattern()
+		}
+
+		metrics.ObserveHTTPRequest(r.Method, route, lrw.statusCode, time.Since(start))
+	})
+}
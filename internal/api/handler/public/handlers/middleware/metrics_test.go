@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	router := chi.NewRouter()
+	router.Use(Metrics)
+	router.Get("/user/{userID}/balance", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/user/42/balance", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/does-not-exist", nil))
+
+	exposition := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(exposition, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusTeapot, rr.Code)
+	assert.Contains(t, exposition.Body.String(),
+		`home_task_http_requests_total{method="GET",route="/user/{userID}/balance",status="418"} 1`)
+	assert.Contains(t, exposition.Body.String(),
+		`home_task_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.False(t, strings.Contains(exposition.Body.String(), "/user/42/balance"), "paths must not become labels")
+}
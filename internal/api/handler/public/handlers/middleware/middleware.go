@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
 	"github.com/TiPSYDiPSY/home-task/internal/util/response"
 )
 
@@ -22,33 +23,48 @@ func getValidSourceTypes() map[string]bool {
 	}
 }
 
+// SourceTypeValidator trusts the Source-Type header as sent. SignatureVerifier should be used
+// instead wherever the caller has to prove its identity.
 func SourceTypeValidator(next http.Handler) http.Handler {
-	validSourceTypes := getValidSourceTypes()
-
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		sourceType := strings.TrimSpace(r.Header.Get("Source-Type"))
-		if sourceType == "" {
-			response.BadRequest(ctx, w, "Source-Type header is required")
-
-			return
-		}
-
-		sourceType = strings.ToLower(sourceType)
-
-		if !validSourceTypes[sourceType] {
-			response.BadRequest(ctx, w, "Source-Type must be one of: game, server, payment")
+		sourceType, message := parseSourceType(r)
+		if message != "" {
+			response.Error(ctx, w, http.StatusBadRequest, response.CodeInvalidSourceType, message)
 
 			return
 		}
 
 		ctx = context.WithValue(ctx, SourceTypeKey, sourceType)
+		ctx = auth.WithPrincipal(ctx, auth.SourcePrincipal(sourceType))
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
+// parseSourceType returns the normalised Source-Type header, or a message explaining why it is invalid.
+func parseSourceType(r *http.Request) (sourceType, message string) {
+	return ParseSourceType(r.Header.Get("Source-Type"))
+}
+
+// ParseSourceType normalises a Source-Type, or returns a message explaining why it is invalid. The
+// gRPC API reads it from metadata rather than a header.
+func ParseSourceType(value string) (sourceType, message string) {
+	sourceType = strings.TrimSpace(value)
+	if sourceType == "" {
+		return "", "Source-Type header is required"
+	}
+
+	sourceType = strings.ToLower(sourceType)
+
+	if !getValidSourceTypes()[sourceType] {
+		return "", "Source-Type must be one of: game, server, payment"
+	}
+
+	return sourceType, ""
+}
+
 func HTTPVersionValidator(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -8,6 +8,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
 )
 
 func TestSourceTypeValidator(t *testing.T) {
@@ -61,45 +63,81 @@ func TestSourceTypeValidator(t *testing.T) {
 			shouldCallNext: true,
 		},
 		{
-			name:           "invalid source type - invalid",
-			sourceType:     "invalid",
-			wantHTTPCode:   http.StatusBadRequest,
-			wantBody:       `{"error":"Bad Request","message":"Source-Type must be one of: game, server, payment"}`,
+			name:         "invalid source type - invalid",
+			sourceType:   "invalid",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type must be one of: game, server, payment"
+			}`,
 			shouldCallNext: false,
 		},
 		{
-			name:           "invalid source type - empty string",
-			sourceType:     "",
-			wantHTTPCode:   http.StatusBadRequest,
-			wantBody:       `{"error":"Bad Request","message":"Source-Type header is required"}`,
+			name:         "invalid source type - empty string",
+			sourceType:   "",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type header is required"
+			}`,
 			shouldCallNext: false,
 		},
 		{
-			name:           "invalid source type - spaces only",
-			sourceType:     "   ",
-			wantHTTPCode:   http.StatusBadRequest,
-			wantBody:       `{"error":"Bad Request","message":"Source-Type header is required"}`,
+			name:         "invalid source type - spaces only",
+			sourceType:   "   ",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type header is required"
+			}`,
 			shouldCallNext: false,
 		},
 		{
-			name:           "invalid source type - random value",
-			sourceType:     "random",
-			wantHTTPCode:   http.StatusBadRequest,
-			wantBody:       `{"error":"Bad Request","message":"Source-Type must be one of: game, server, payment"}`,
+			name:         "invalid source type - random value",
+			sourceType:   "random",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type must be one of: game, server, payment"
+			}`,
 			shouldCallNext: false,
 		},
 		{
-			name:           "invalid source type - partial match",
-			sourceType:     "gam",
-			wantHTTPCode:   http.StatusBadRequest,
-			wantBody:       `{"error":"Bad Request","message":"Source-Type must be one of: game, server, payment"}`,
+			name:         "invalid source type - partial match",
+			sourceType:   "gam",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type must be one of: game, server, payment"
+			}`,
 			shouldCallNext: false,
 		},
 		{
-			name:           "invalid source type - numbers",
-			sourceType:     "123",
-			wantHTTPCode:   http.StatusBadRequest,
-			wantBody:       `{"error":"Bad Request","message":"Source-Type must be one of: game, server, payment"}`,
+			name:         "invalid source type - numbers",
+			sourceType:   "123",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type must be one of: game, server, payment"
+			}`,
 			shouldCallNext: false,
 		},
 	}
@@ -139,7 +177,7 @@ func TestSourceTypeValidator(t *testing.T) {
 				assert.Equal(t, tt.wantBody, rr.Body.String())
 			} else {
 				assert.JSONEq(t, tt.wantBody, rr.Body.String())
-				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+				assert.Equal(t, response.ProblemContentType, rr.Header().Get("Content-Type"))
 			}
 		})
 	}
@@ -182,7 +220,13 @@ func TestSourceTypeValidator_NoHeader(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 	assert.False(t, nextHandlerCalled)
-	assert.JSONEq(t, `{"error":"Bad Request","message":"Source-Type header is required"}`, rr.Body.String())
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Bad Request",
+		"status": 400,
+		"code": "INVALID_SOURCE_TYPE",
+		"detail": "Source-Type header is required"
+	}`, rr.Body.String())
 }
 
 func TestSourceTypeValidator_EdgeCases(t *testing.T) {
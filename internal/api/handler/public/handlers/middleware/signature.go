@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/signing"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+)
+
+// MaxSignedBodySize bounds how much of a request body is read to verify its signature.
+const MaxSignedBodySize = 64 << 10
+
+// SignatureVerifier authenticates the caller of a request as the source named in its Source-Type
+// header by checking the HMAC signature against that source's secrets. Only a verified source
+// is put into the context, so handlers never see an unauthenticated Source-Type.
+func SignatureVerifier(verifier *signing.Verifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			sourceType, message := parseSourceType(r)
+			if message != "" {
+				response.Error(ctx, w, http.StatusBadRequest, response.CodeInvalidSourceType, message)
+
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxSignedBodySize))
+			if err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					response.Error(ctx, w, http.StatusRequestEntityTooLarge, response.CodeRequestTooLarge,
+						"request body is too large")
+
+					return
+				}
+
+				response.BadRequest(ctx, w, "invalid request body")
+
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			if err := verifier.Verify(sourceType, r.Method, r.URL.EscapedPath(),
+				r.Header.Get(signing.TimestampHeader), body, r.Header.Get(signing.SignatureHeader)); err != nil {
+				logrus.WithContext(ctx).WithError(err).WithField("source_type", sourceType).
+					Warn("Request signature verification failed")
+				response.Error(ctx, w, http.StatusUnauthorized, response.CodeInvalidSignature, SignatureErrorMessage(err))
+
+				return
+			}
+
+			ctx = context.WithValue(ctx, SourceTypeKey, sourceType)
+			ctx = auth.WithPrincipal(ctx, auth.SourcePrincipal(sourceType))
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// SignatureErrorMessage tells the caller what to fix without revealing which sources have secrets.
+func SignatureErrorMessage(err error) string {
+	switch {
+	case errors.Is(err, signing.ErrInvalidTimestamp):
+		return signing.TimestampHeader + " must be a Unix timestamp in seconds"
+	case errors.Is(err, signing.ErrStaleTimestamp):
+		return "request signature has expired"
+	default:
+		return "invalid request signature"
+	}
+}
@@ -0,0 +1,182 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/signing"
+)
+
+func TestSignatureVerifier(t *testing.T) {
+	verifier := signing.NewVerifier(map[string][]string{
+		"game":    {"game-secret"},
+		"payment": {"payment-secret"},
+	}, time.Minute)
+
+	body := `{"state":"win","amount":"10.00","transactionId":"txn-1"}`
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	sign := func(secret, timestamp string) string {
+		return signing.Sign([]byte(secret), http.MethodPost, "/user/1/transaction", timestamp, []byte(body))
+	}
+
+	tests := []struct {
+		name           string
+		sourceType     string
+		timestamp      string
+		signature      string
+		body           string
+		wantHTTPCode   int
+		wantBody       string
+		wantSourceType string
+	}{
+		{
+			name:           "valid signature",
+			sourceType:     "Game",
+			timestamp:      now,
+			signature:      sign("game-secret", now),
+			wantHTTPCode:   http.StatusOK,
+			wantSourceType: "game",
+		},
+		{
+			name:         "missing Source-Type",
+			timestamp:    now,
+			signature:    sign("game-secret", now),
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_SOURCE_TYPE",
+				"detail": "Source-Type header is required"
+			}`,
+		},
+		{
+			name:         "signed by another source",
+			sourceType:   "game",
+			timestamp:    now,
+			signature:    sign("payment-secret", now),
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "INVALID_SIGNATURE",
+				"detail": "invalid request signature"
+			}`,
+		},
+		{
+			name:         "source without secret",
+			sourceType:   "server",
+			timestamp:    now,
+			signature:    sign("game-secret", now),
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "INVALID_SIGNATURE",
+				"detail": "invalid request signature"
+			}`,
+		},
+		{
+			name:         "missing signature",
+			sourceType:   "game",
+			timestamp:    now,
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "INVALID_SIGNATURE",
+				"detail": "invalid request signature"
+			}`,
+		},
+		{
+			name:         "tampered body",
+			sourceType:   "game",
+			timestamp:    now,
+			signature:    sign("game-secret", now),
+			body:         `{"state":"win","amount":"10000.00","transactionId":"txn-1"}`,
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "INVALID_SIGNATURE",
+				"detail": "invalid request signature"
+			}`,
+		},
+		{
+			name:         "missing timestamp",
+			sourceType:   "game",
+			signature:    sign("game-secret", now),
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "INVALID_SIGNATURE",
+				"detail": "X-Signature-Timestamp must be a Unix timestamp in seconds"
+			}`,
+		},
+		{
+			name:         "replayed after the window",
+			sourceType:   "game",
+			timestamp:    stale,
+			signature:    sign("game-secret", stale),
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "INVALID_SIGNATURE",
+				"detail": "request signature has expired"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSourceType, gotBody string
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotSourceType = GetSourceType(r.Context())
+				bodyBytes, _ := io.ReadAll(r.Body)
+				gotBody = string(bodyBytes)
+
+				w.WriteHeader(http.StatusOK)
+			})
+
+			requestBody := body
+			if tt.body != "" {
+				requestBody = tt.body
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/user/1/transaction", strings.NewReader(requestBody))
+			req.Header.Set("Source-Type", tt.sourceType)
+			req.Header.Set(signing.TimestampHeader, tt.timestamp)
+			req.Header.Set(signing.SignatureHeader, tt.signature)
+
+			rr := httptest.NewRecorder()
+			SignatureVerifier(verifier)(next).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.Equal(t, tt.wantSourceType, gotSourceType)
+
+			if tt.wantHTTPCode == http.StatusOK {
+				assert.Equal(t, requestBody, gotBody, "body should still be readable by the handler")
+			} else {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
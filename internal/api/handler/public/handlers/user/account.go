@@ -0,0 +1,162 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+// Codes returned when the account status does not allow an operation.
+
+func CreateUser(accountService service.AccountService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		// The body is optional: a user without an external ID needs no fields.
+		var request api.CreateUserRequest
+		if r.ContentLength != 0 {
+			if err := decodeJSONBody(r, &request); err != nil {
+				logger.WithError(err).Error("Failed to decode request body")
+				response.BadRequest(ctx, w, err.Error())
+
+				return
+			}
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		user, err := accountService.CreateUser(ctx, request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to create user")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusCreated, user)
+	}
+}
+
+func GetUser(accountService service.AccountService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		user, err := accountService.GetUser(ctx, userID)
+		if err != nil {
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, user)
+	}
+}
+
+func FreezeUser(accountService service.AccountService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		var request api.FreezeRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		user, err := accountService.FreezeUser(ctx, request, userID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to freeze user")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, user)
+	}
+}
+
+func UnfreezeUser(accountService service.AccountService) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		user, err := accountService.UnfreezeUser(ctx, userID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to unfreeze user")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, user)
+	}
+}
+
+func CloseUser(accountService service.AccountService) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		user, err := accountService.CloseUser(ctx, userID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to close user")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, user)
+	}
+}
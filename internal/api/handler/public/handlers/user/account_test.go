@@ -0,0 +1,269 @@
+package user
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+func newAccountRequest(t *testing.T, method, userID, body string) *http.Request {
+	t.Helper()
+
+	req := httptest.NewRequest(method, "/user/placeholder", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	if userID != "" {
+		rctx.URLParams.Add("userID", userID)
+	}
+
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestCreateUser(t *testing.T) {
+	createdAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		body         string
+		prepareMocks func(*service.MockAccountService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "empty body",
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().CreateUser(mock.Anything, api.CreateUserRequest{}).
+					Return(api.UserResponse{UserID: 4, Status: "active", CreatedAt: createdAt, UpdatedAt: createdAt}, nil)
+			},
+			wantHTTPCode: http.StatusCreated,
+			wantBody: `{
+				"userId": 4,
+				"status": "active",
+				"createdAt": "2025-08-18T12:00:00Z",
+				"updatedAt": "2025-08-18T12:00:00Z"
+			}`,
+		},
+		{
+			name: "with external ID",
+			body: `{"externalId": "player-42"}`,
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().CreateUser(mock.Anything, api.CreateUserRequest{ExternalID: "player-42"}).
+					Return(api.UserResponse{
+						UserID: 5, ExternalID: "player-42", Status: "active", CreatedAt: createdAt, UpdatedAt: createdAt,
+					}, nil)
+			},
+			wantHTTPCode: http.StatusCreated,
+			wantBody: `{
+				"userId": 5,
+				"externalId": "player-42",
+				"status": "active",
+				"createdAt": "2025-08-18T12:00:00Z",
+				"updatedAt": "2025-08-18T12:00:00Z"
+			}`,
+		},
+		{
+			name:         "external ID too long",
+			body:         `{"externalId": "` + strings.Repeat("x", 65) + `"}`,
+			prepareMocks: func(mockService *service.MockAccountService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: externalId must be at most 64",
+				"errors": [{"field": "externalId", "message": "externalId must be at most 64"}]
+			}`,
+		},
+		{
+			name: "external ID taken",
+			body: `{"externalId": "player-42"}`,
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().CreateUser(mock.Anything, api.CreateUserRequest{ExternalID: "player-42"}).
+					Return(api.UserResponse{}, errs.ErrExternalIDExists)
+			},
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "EXTERNAL_ID_TAKEN",
+				"detail": "external ID is already in use"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockAccountService(t)
+			tt.prepareMocks(mockService)
+
+			rr := httptest.NewRecorder()
+			CreateUser(mockService, validation.NewValidator()).ServeHTTP(rr, newAccountRequest(t, http.MethodPost, "", tt.body))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestAccountStatusHandlers(t *testing.T) {
+	closedAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		handler      func(service.AccountService) http.HandlerFunc
+		userID       string
+		body         string
+		prepareMocks func(*service.MockAccountService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name:    "get user",
+			handler: GetUser,
+			userID:  "1",
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().GetUser(mock.Anything, uint64(1)).
+					Return(api.UserResponse{UserID: 1, Status: "frozen", FreezeScope: "credits"}, nil)
+			},
+			wantHTTPCode: http.StatusOK,
+			wantBody: `{
+				"userId": 1,
+				"status": "frozen",
+				"freezeScope": "credits",
+				"createdAt": "0001-01-01T00:00:00Z",
+				"updatedAt": "0001-01-01T00:00:00Z"
+			}`,
+		},
+		{
+			name:    "get unknown user",
+			handler: GetUser,
+			userID:  "99",
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().GetUser(mock.Anything, uint64(99)).Return(api.UserResponse{}, errs.ErrUserNotFound)
+			},
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "USER_NOT_FOUND",
+				"detail": "user not found"
+			}`,
+		},
+		{
+			name: "freeze with invalid scope",
+			handler: func(s service.AccountService) http.HandlerFunc {
+				return FreezeUser(s, validation.NewValidator())
+			},
+			userID:       "1",
+			body:         `{"scope": "withdrawals"}`,
+			prepareMocks: func(mockService *service.MockAccountService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: scope must be one of [debits credits all]",
+				"errors": [{"field": "scope", "message": "scope must be one of [debits credits all]"}]
+			}`,
+		},
+		{
+			name: "freeze closed account",
+			handler: func(s service.AccountService) http.HandlerFunc {
+				return FreezeUser(s, validation.NewValidator())
+			},
+			userID: "1",
+			body:   `{"scope": "all"}`,
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().FreezeUser(mock.Anything, api.FreezeRequest{Scope: "all"}, uint64(1)).
+					Return(api.UserResponse{}, errs.ErrAccountClosed)
+			},
+			wantHTTPCode: http.StatusForbidden,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Forbidden",
+				"status": 403,
+				"code": "USER_CLOSED",
+				"detail": "account is closed"
+			}`,
+		},
+		{
+			name:    "unfreeze",
+			handler: UnfreezeUser,
+			userID:  "1",
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().UnfreezeUser(mock.Anything, uint64(1)).
+					Return(api.UserResponse{UserID: 1, Status: "active"}, nil)
+			},
+			wantHTTPCode: http.StatusOK,
+			wantBody: `{
+				"userId": 1,
+				"status": "active",
+				"createdAt": "0001-01-01T00:00:00Z",
+				"updatedAt": "0001-01-01T00:00:00Z"
+			}`,
+		},
+		{
+			name:    "close",
+			handler: CloseUser,
+			userID:  "1",
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().CloseUser(mock.Anything, uint64(1)).
+					Return(api.UserResponse{UserID: 1, Status: "closed", ClosedAt: &closedAt}, nil)
+			},
+			wantHTTPCode: http.StatusOK,
+			wantBody: `{
+				"userId": 1,
+				"status": "closed",
+				"createdAt": "0001-01-01T00:00:00Z",
+				"updatedAt": "0001-01-01T00:00:00Z",
+				"closedAt": "2025-08-18T12:00:00Z"
+			}`,
+		},
+		{
+			name:    "close with money left",
+			handler: CloseUser,
+			userID:  "1",
+			prepareMocks: func(mockService *service.MockAccountService) {
+				mockService.EXPECT().CloseUser(mock.Anything, uint64(1)).Return(api.UserResponse{}, errs.ErrNonZeroBalance)
+			},
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "BALANCE_NOT_ZERO",
+				"detail": "account can only be closed with zero balance and no active holds"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockAccountService(t)
+			tt.prepareMocks(mockService)
+
+			rr := httptest.NewRecorder()
+			tt.handler(mockService).ServeHTTP(rr, newAccountRequest(t, http.MethodPost, tt.userID, tt.body))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+func PlaceHold(holdService service.HoldService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		var request api.HoldRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		hold, err := holdService.PlaceHold(ctx, request, userID, middleware.GetSourceType(ctx))
+		if err != nil {
+			logger.WithError(err).Warn("Failed to place hold")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, hold)
+	}
+}
+
+func SettleHold(holdService service.HoldService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, holdID, err := parseHoldPath(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		var request api.HoldSettlementRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		hold, err := holdService.SettleHold(ctx, request, userID, holdID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to settle hold")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, hold)
+	}
+}
+
+func ReleaseHold(holdService service.HoldService) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, holdID, err := parseHoldPath(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		hold, err := holdService.ReleaseHold(ctx, userID, holdID)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to release hold")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, hold)
+	}
+}
+
+func parseHoldPath(r *http.Request) (uint64, string, error) {
+	userID, err := parseUserID(r)
+	if err != nil {
+		return 0, "", err
+	}
+
+	holdID := chi.URLParam(r, "holdID")
+	if holdID == "" {
+		return 0, "", errors.New("hold ID is required")
+	}
+
+	return userID, holdID, nil
+}
@@ -0,0 +1,271 @@
+package user
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
+)
+
+func newHoldRequest(t *testing.T, userID, holdID string, body any) *http.Request {
+	t.Helper()
+
+	bodyBytes, err := json.Marshal(body)
+	assert.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/user/placeholder/hold", bytes.NewReader(bodyBytes))
+	req.Header.Set("Content-Type", "application/json")
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("userID", userID)
+
+	if holdID != "" {
+		rctx.URLParams.Add("holdID", holdID)
+	}
+
+	ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+	ctx = context.WithValue(ctx, middleware.SourceTypeKey, "game")
+
+	return req.WithContext(ctx)
+}
+
+func TestPlaceHold(t *testing.T) {
+	expiresAt := time.Date(2025, 8, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		body         any
+		prepareMocks func(*service.MockHoldService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "successful hold",
+			body: api.HoldRequest{HoldID: "bet-1", Amount: "12.50"},
+			prepareMocks: func(mockService *service.MockHoldService) {
+				mockService.EXPECT().PlaceHold(mock.Anything, api.HoldRequest{HoldID: "bet-1", Amount: "12.50"}, uint64(1), "game").
+					Return(api.HoldResponse{
+						HoldID:     "bet-1",
+						UserID:     1,
+						SourceType: "game",
+						Currency:   "USD",
+						Amount:     "12.50",
+						Status:     "reserved",
+						ExpiresAt:  expiresAt,
+					}, nil)
+			},
+			wantHTTPCode: http.StatusOK,
+			wantBody: `{
+				"holdId": "bet-1",
+				"userId": 1,
+				"sourceType": "game",
+				"currency": "USD",
+				"amount": "12.50",
+				"status": "reserved",
+				"expiresAt": "2025-08-19T12:00:00Z"
+			}`,
+		},
+		{
+			name:         "missing hold ID",
+			body:         api.HoldRequest{Amount: "12.50"},
+			prepareMocks: func(mockService *service.MockHoldService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: holdId is required",
+				"errors": [{"field": "holdId", "message": "holdId is required"}]
+			}`,
+		},
+		{
+			name: "insufficient funds",
+			body: api.HoldRequest{HoldID: "bet-2", Amount: "1000"},
+			prepareMocks: func(mockService *service.MockHoldService) {
+				mockService.EXPECT().PlaceHold(mock.Anything, api.HoldRequest{HoldID: "bet-2", Amount: "1000"}, uint64(1), "game").
+					Return(api.HoldResponse{}, errs.ErrInsufficientFunds)
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INSUFFICIENT_FUNDS",
+				"detail": "insufficient funds for this transaction"
+			}`,
+		},
+		{
+			name: "hold ID reused",
+			body: api.HoldRequest{HoldID: "bet-3", Amount: "1"},
+			prepareMocks: func(mockService *service.MockHoldService) {
+				mockService.EXPECT().PlaceHold(mock.Anything, api.HoldRequest{HoldID: "bet-3", Amount: "1"}, uint64(1), "game").
+					Return(api.HoldResponse{}, errs.ErrHoldExists)
+			},
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "DUPLICATE_HOLD",
+				"detail": "hold ID already used with a different payload"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockHoldService(t)
+			tt.prepareMocks(mockService)
+
+			rr := httptest.NewRecorder()
+			PlaceHold(mockService, validation.NewValidator()).ServeHTTP(rr, newHoldRequest(t, "1", "", tt.body))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestSettleHold(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         any
+		prepareMocks func(*service.MockHoldService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "win with payout",
+			body: api.HoldSettlementRequest{State: "win", Payout: "25.00"},
+			prepareMocks: func(mockService *service.MockHoldService) {
+				mockService.EXPECT().SettleHold(mock.Anything, api.HoldSettlementRequest{State: "win", Payout: "25.00"}, uint64(1), "bet-1").
+					Return(api.HoldResponse{HoldID: "bet-1", UserID: 1, Currency: "USD", Amount: "10.00", Payout: "25.00", Status: "settled"}, nil)
+			},
+			wantHTTPCode: http.StatusOK,
+			wantBody: `{
+				"holdId": "bet-1",
+				"userId": 1,
+				"sourceType": "",
+				"currency": "USD",
+				"amount": "10.00",
+				"payout": "25.00",
+				"status": "settled",
+				"expiresAt": "0001-01-01T00:00:00Z"
+			}`,
+		},
+		{
+			name:         "win without payout",
+			body:         api.HoldSettlementRequest{State: "win"},
+			prepareMocks: func(mockService *service.MockHoldService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: payout is required when State is win",
+				"errors": [{"field": "payout", "message": "payout is required when State is win"}]
+			}`,
+		},
+		{
+			name: "hold not active",
+			body: api.HoldSettlementRequest{State: "lose"},
+			prepareMocks: func(mockService *service.MockHoldService) {
+				mockService.EXPECT().SettleHold(mock.Anything, api.HoldSettlementRequest{State: "lose"}, uint64(1), "bet-1").
+					Return(api.HoldResponse{}, errs.ErrHoldNotActive)
+			},
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "HOLD_NOT_ACTIVE",
+				"detail": "hold is already settled, released or expired"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockHoldService(t)
+			tt.prepareMocks(mockService)
+
+			rr := httptest.NewRecorder()
+			SettleHold(mockService, validation.NewValidator()).ServeHTTP(rr, newHoldRequest(t, "1", "bet-1", tt.body))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	tests := []struct {
+		name         string
+		holdID       string
+		prepareMocks func(*service.MockHoldService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name:   "hold not found",
+			holdID: "missing",
+			prepareMocks: func(mockService *service.MockHoldService) {
+				mockService.EXPECT().ReleaseHold(mock.Anything, uint64(1), "missing").
+					Return(api.HoldResponse{}, errs.ErrHoldNotFound)
+			},
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "HOLD_NOT_FOUND",
+				"detail": "hold not found"
+			}`,
+		},
+		{
+			name:   "internal server error",
+			holdID: "bet-1",
+			prepareMocks: func(mockService *service.MockHoldService) {
+				mockService.EXPECT().ReleaseHold(mock.Anything, uint64(1), "bet-1").
+					Return(api.HoldResponse{}, errors.New("database connection failed"))
+			},
+			wantHTTPCode: http.StatusInternalServerError,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Internal Server Error",
+				"status": 500,
+				"code": "INTERNAL_ERROR",
+				"detail": "internal server error"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockHoldService(t)
+			tt.prepareMocks(mockService)
+
+			rr := httptest.NewRecorder()
+			ReleaseHold(mockService).ServeHTTP(rr, newHoldRequest(t, "1", tt.holdID, nil))
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
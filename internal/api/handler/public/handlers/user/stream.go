@@ -0,0 +1,209 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+)
+
+const (
+	StreamTransportSSE       = "sse"
+	StreamTransportWebSocket = "websocket"
+
+	// LastEventIDHeader is sent by an EventSource that reconnects; other clients may use the
+	// lastEventId query parameter instead.
+	LastEventIDHeader = "Last-Event-ID"
+
+	// streamWriteTimeout bounds each write, so that a client that stopped reading frees its stream.
+	streamWriteTimeout = 10 * time.Second
+	// maxClientMessageSize caps what a WebSocket client may send; its messages are ignored.
+	maxClientMessageSize = 1024
+)
+
+// StreamBalance streams the balance changes of a user as Server-Sent Events, or over a WebSocket
+// when the request asks for an upgrade. Both carry the same messages.
+func StreamBalance(streamService service.BalanceStreamService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		lastEventID, err := parseLastEventID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		balanceStream, err := streamService.OpenBalanceStream(ctx, userID, lastEventID)
+		if err != nil {
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		defer balanceStream.Close()
+
+		if strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+			serveWebSocket(w, r, balanceStream)
+
+			return
+		}
+
+		serveEventStream(ctx, w, balanceStream)
+	}
+}
+
+func serveEventStream(ctx context.Context, w http.ResponseWriter, balanceStream *service.BalanceStream) {
+	defer metrics.TrackBalanceStream(StreamTransportSSE)()
+
+	controller := http.NewResponseController(w)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// Keeps reverse proxies such as nginx from holding the events back.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	if err := controller.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case message, ok := <-balanceStream.Messages():
+			if !ok {
+				return
+			}
+
+			// The server write timeout is meant for whole responses, this one is open-ended.
+			if err := controller.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil &&
+				!errors.Is(err, http.ErrNotSupported) {
+				return
+			}
+
+			if err := writeEvent(w, message); err != nil {
+				return
+			}
+
+			if err := controller.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// writeEvent writes one message in the event stream format. A heartbeat is a comment, which
+// keeps the connection alive without waking up an EventSource.
+func writeEvent(w io.Writer, message api.StreamMessage) error {
+	var event string
+
+	switch {
+	case message.Type == api.StreamMessageHeartbeat:
+		event = ": heartbeat\n\n"
+	case message.ID != "":
+		event = fmt.Sprintf("id: %s\nevent: %s\ndata: %s\n\n", message.ID, message.Type, message.Data)
+	default:
+		event = fmt.Sprintf("event: %s\ndata: %s\n\n", message.Type, message.Data)
+	}
+
+	if _, err := io.WriteString(w, event); err != nil {
+		return fmt.Errorf("failed to write event: %w", err)
+	}
+
+	return nil
+}
+
+func serveWebSocket(w http.ResponseWriter, r *http.Request, balanceStream *service.BalanceStream) {
+	server := websocket.Server{
+		// Clients authenticate with a credential rather than a cookie, so any origin may connect.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			defer metrics.TrackBalanceStream(StreamTransportWebSocket)()
+
+			conn.MaxPayloadBytes = maxClientMessageSize
+
+			// The hijacked connection keeps the deadlines of the HTTP server.
+			if err := conn.SetDeadline(time.Time{}); err != nil {
+				return
+			}
+
+			sendWebSocketMessages(conn, balanceStream)
+		},
+	}
+
+	server.ServeHTTP(w, r)
+}
+
+func sendWebSocketMessages(conn *websocket.Conn, balanceStream *service.BalanceStream) {
+	// The client only ever closes the connection, which its reader notices.
+	disconnected := make(chan struct{})
+
+	go func() {
+		defer close(disconnected)
+
+		var ignored []byte
+
+		for {
+			if err := websocket.Message.Receive(conn, &ignored); err != nil {
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-disconnected:
+			return
+		case message, ok := <-balanceStream.Messages():
+			if !ok {
+				return
+			}
+
+			if err := conn.SetWriteDeadline(time.Now().Add(streamWriteTimeout)); err != nil {
+				return
+			}
+
+			if err := websocket.JSON.Send(conn, message); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func parseLastEventID(r *http.Request) (uint64, error) {
+	lastEventID := r.Header.Get(LastEventIDHeader)
+	if lastEventID == "" {
+		lastEventID = r.URL.Query().Get("lastEventId")
+	}
+
+	if lastEventID == "" {
+		return 0, nil
+	}
+
+	id, err := strconv.ParseUint(lastEventID, DecimalBase, BitSize)
+	if err != nil {
+		return 0, errors.New("invalid last event ID")
+	}
+
+	return id, nil
+}
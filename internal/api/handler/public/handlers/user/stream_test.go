@@ -0,0 +1,247 @@
+package user
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/stream"
+)
+
+func newStreamServer(t *testing.T, streamService service.BalanceStreamService) *httptest.Server {
+	t.Helper()
+
+	router := chi.NewRouter()
+	router.Get("/user/{userID}/balance/stream", StreamBalance(streamService))
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// newTestBalanceStreamService streams the USD wallet of user 1, whose last change was event 7.
+func newTestBalanceStreamService(t *testing.T) service.BalanceStreamService {
+	t.Helper()
+
+	mockRepo := db.NewMockBalanceStreamRepository(t)
+	mockRepo.EXPECT().GetBalanceSnapshot(mock.Anything, uint64(1)).Return(db.BalanceSnapshot{
+		Wallets:      []db.Wallet{{UserID: 1, Currency: "USD", Balance: 1000}},
+		LastEventIDs: map[string]uint64{"USD": 7},
+	}, nil).Once()
+
+	streamService := service.NewBalanceStreamService(
+		mockRepo, currency.DefaultRegistry(), stream.NewHub(stream.NewLocalNotifier(), 0),
+		config.StreamConfig{Heartbeat: time.Hour, Buffer: 1, MaxReplay: 1},
+	)
+	t.Cleanup(streamService.CloseStreams)
+
+	return streamService
+}
+
+func TestStreamBalanceErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		path         string
+		lastEventID  string
+		prepareMocks func(*service.MockBalanceStreamService)
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name:         "invalid user ID",
+			path:         "/user/abc/balance/stream",
+			prepareMocks: func(*service.MockBalanceStreamService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid user ID format"
+			}`,
+		},
+		{
+			name:         "invalid last event ID",
+			path:         "/user/1/balance/stream",
+			lastEventID:  "-1",
+			prepareMocks: func(*service.MockBalanceStreamService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid last event ID"
+			}`,
+		},
+		{
+			name: "user not found",
+			path: "/user/1/balance/stream?lastEventId=5",
+			prepareMocks: func(mockService *service.MockBalanceStreamService) {
+				mockService.EXPECT().OpenBalanceStream(mock.Anything, uint64(1), uint64(5)).
+					Return(nil, errs.ErrUserNotFound)
+			},
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "USER_NOT_FOUND",
+				"detail": "user not found"
+			}`,
+		},
+		{
+			name:        "header takes precedence over the query parameter",
+			path:        "/user/1/balance/stream?lastEventId=5",
+			lastEventID: "6",
+			prepareMocks: func(mockService *service.MockBalanceStreamService) {
+				mockService.EXPECT().OpenBalanceStream(mock.Anything, uint64(1), uint64(6)).
+					Return(nil, errs.ErrStreamsUnavailable)
+			},
+			wantHTTPCode: http.StatusServiceUnavailable,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Service Unavailable",
+				"status": 503,
+				"code": "STREAMS_UNAVAILABLE",
+				"detail": "balance streams are unavailable"
+			}`,
+		},
+		{
+			name: "internal error",
+			path: "/user/1/balance/stream",
+			prepareMocks: func(mockService *service.MockBalanceStreamService) {
+				mockService.EXPECT().OpenBalanceStream(mock.Anything, uint64(1), uint64(0)).
+					Return(nil, errors.New("database down"))
+			},
+			wantHTTPCode: http.StatusInternalServerError,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Internal Server Error",
+				"status": 500,
+				"code": "INTERNAL_ERROR",
+				"detail": "internal server error"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockBalanceStreamService(t)
+			tt.prepareMocks(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set(LastEventIDHeader, tt.lastEventID)
+			}
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("userID", strings.Split(tt.path, "/")[2])
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			StreamBalance(mockService).ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestStreamBalanceEventStream(t *testing.T) {
+	server := newStreamServer(t, newTestBalanceStreamService(t))
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, server.URL+"/user/1/balance/stream", nil)
+	require.NoError(t, err)
+
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+
+	reader := bufio.NewReader(resp.Body)
+
+	event, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "event: balance.snapshot\n", event)
+
+	data, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"userId":1,"wallets":[`+
+		`{"currency":"USD","balance":"10.00","available":"10.00","reserved":"0.00","sequence":7}]}`,
+		strings.TrimPrefix(data, "data: "))
+}
+
+func TestStreamBalanceWebSocket(t *testing.T) {
+	server := newStreamServer(t, newTestBalanceStreamService(t))
+
+	conn, err := websocket.Dial(
+		"ws"+strings.TrimPrefix(server.URL, "http")+"/user/1/balance/stream", "", "http://game.example",
+	)
+	require.NoError(t, err)
+
+	defer conn.Close()
+
+	var message api.StreamMessage
+	require.NoError(t, websocket.JSON.Receive(conn, &message))
+
+	assert.Equal(t, api.StreamMessageSnapshot, message.Type)
+	assert.JSONEq(t, `{"userId":1,"wallets":[`+
+		`{"currency":"USD","balance":"10.00","available":"10.00","reserved":"0.00","sequence":7}]}`,
+		string(message.Data))
+}
+
+func TestWriteEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		message api.StreamMessage
+		want    string
+	}{
+		{
+			name:    "balance change",
+			message: api.StreamMessage{ID: "8", Type: api.StreamMessageBalanceChange, Data: []byte(`{"currency":"USD"}`)},
+			want:    "id: 8\nevent: balance.changed\ndata: {\"currency\":\"USD\"}\n\n",
+		},
+		{
+			name:    "snapshot",
+			message: api.StreamMessage{Type: api.StreamMessageSnapshot, Data: []byte(`{"userId":1}`)},
+			want:    "event: balance.snapshot\ndata: {\"userId\":1}\n\n",
+		},
+		{
+			name:    "heartbeat",
+			message: api.StreamMessage{Type: api.StreamMessageHeartbeat},
+			want:    ": heartbeat\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var builder strings.Builder
+
+			require.NoError(t, writeEvent(&builder, tt.message))
+			assert.Equal(t, tt.want, builder.String())
+		})
+	}
+}
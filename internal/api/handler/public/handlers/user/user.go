@@ -10,7 +10,6 @@ import (
 	"github.com/sirupsen/logrus"
 
 	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
-	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
 	"github.com/TiPSYDiPSY/home-task/internal/model/api"
 
 	"github.com/go-chi/chi/v5"
@@ -42,44 +41,74 @@ func UpdateBalance(userService service.UserService, valid *validation.Validator)
 
 		sourceType := middleware.GetSourceType(ctx)
 
-		body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestBodySize))
+		var request api.TransactionRequest
+		if err := decodeJSONBody(r, &request); err != nil {
+			logger.WithError(err).Error("Failed to decode request body")
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		if err := userService.UpdateBalance(ctx, request, userID, sourceType); err != nil {
+			logger.WithError(err).Warn("Failed to update user balance")
+
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func ReverseTransaction(userService service.UserService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
 		if err != nil {
-			logger.WithError(err).Error("Failed to read request body")
-			response.BadRequest(ctx, w, "invalid request body")
+			response.BadRequest(ctx, w, err.Error())
 
 			return
 		}
 
-		var request api.TransactionRequest
-		if err := json.Unmarshal(body, &request); err != nil {
+		originalTransactionID := chi.URLParam(r, "transactionID")
+		if originalTransactionID == "" {
+			response.BadRequest(ctx, w, "transaction ID is required")
+
+			return
+		}
+
+		sourceType := middleware.GetSourceType(ctx)
+
+		var request api.ReversalRequest
+		if err := decodeJSONBody(r, &request); err != nil {
 			logger.WithError(err).Error("Failed to decode request body")
-			response.BadRequest(ctx, w, "invalid JSON format")
+			response.BadRequest(ctx, w, err.Error())
 
 			return
 		}
 
 		if err := valid.ValidateStruct(&request); err != nil {
 			logger.WithError(err).Warn("Request valid failed")
-			response.BadRequest(ctx, w, err.Error())
+			response.FromError(ctx, w, err)
 
 			return
 		}
 
-		if err := userService.UpdateBalance(ctx, request, userID, sourceType); err != nil {
-			logger.WithError(err).Warn("Failed to update user balance")
+		if err := userService.ReverseTransaction(ctx, request, userID, originalTransactionID, sourceType); err != nil {
+			logger.WithError(err).Warn("Failed to reverse transaction")
 
-			switch {
-			case errors.Is(err, customErrors.ErrUserNotFound):
-				response.Error(ctx, w, http.StatusNotFound, "user not found")
-			case errors.Is(err, customErrors.ErrTransactionExists):
-				response.Error(ctx, w, http.StatusConflict, "transaction with this ID already exists")
-			case errors.Is(err, customErrors.ErrInsufficientFunds):
-				response.Error(ctx, w, http.StatusBadRequest, "insufficient funds for this transaction")
-			case errors.Is(err, customErrors.ErrInvalidAmountFormat):
-				response.Error(ctx, w, http.StatusBadRequest, "invalid amount format")
-			default:
-				response.Error(ctx, w, http.StatusInternalServerError, "failed to process transaction")
-			}
+			response.FromError(ctx, w, err)
 
 			return
 		}
@@ -99,14 +128,9 @@ func GetBalance(userService service.UserService) http.HandlerFunc {
 			return
 		}
 
-		balanceResponse, err := userService.GetBalance(ctx, userID)
+		balanceResponse, err := userService.GetBalance(ctx, userID, r.URL.Query().Get("currency"))
 		if err != nil {
-			switch {
-			case errors.Is(err, customErrors.ErrUserNotFound):
-				response.Error(ctx, w, http.StatusNotFound, "user not found")
-			default:
-				response.Error(ctx, w, http.StatusInternalServerError, "internal server error")
-			}
+			response.FromError(ctx, w, err)
 
 			return
 		}
@@ -115,6 +139,107 @@ func GetBalance(userService service.UserService) http.HandlerFunc {
 	}
 }
 
+func ListTransactions(userService service.UserService, valid *validation.Validator) http.HandlerFunc {
+	logger := logrus.StandardLogger()
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		request, err := parseTransactionListRequest(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		if err := valid.ValidateStruct(&request); err != nil {
+			logger.WithError(err).Warn("Request valid failed")
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		transactions, err := userService.ListTransactions(ctx, userID, request)
+		if err != nil {
+			logger.WithError(err).Warn("Failed to list user transactions")
+
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, transactions)
+	}
+}
+
+func ListWallets(userService service.UserService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		userID, err := parseUserID(r)
+		if err != nil {
+			response.BadRequest(ctx, w, err.Error())
+
+			return
+		}
+
+		wallets, err := userService.ListWallets(ctx, userID)
+		if err != nil {
+			response.FromError(ctx, w, err)
+
+			return
+		}
+
+		response.JSON(ctx, w, http.StatusOK, wallets)
+	}
+}
+
+func parseTransactionListRequest(r *http.Request) (api.TransactionListRequest, error) {
+	query := r.URL.Query()
+
+	request := api.TransactionListRequest{
+		Cursor:     query.Get("cursor"),
+		Currency:   query.Get("currency"),
+		State:      query.Get("state"),
+		SourceType: query.Get("sourceType"),
+		From:       query.Get("from"),
+		To:         query.Get("to"),
+		MinAmount:  query.Get("minAmount"),
+		MaxAmount:  query.Get("maxAmount"),
+	}
+
+	if limit := query.Get("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return api.TransactionListRequest{}, errors.New("invalid limit format")
+		}
+
+		request.Limit = parsed
+	}
+
+	return request, nil
+}
+
+func decodeJSONBody(r *http.Request, dst any) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, MaxRequestBodySize))
+	if err != nil {
+		return errors.New("invalid request body")
+	}
+
+	if err := json.Unmarshal(body, dst); err != nil {
+		return errors.New("invalid JSON format")
+	}
+
+	return nil
+}
+
 func parseUserID(r *http.Request) (uint64, error) {
 	userIDStr := chi.URLParam(r, "userID")
 	if userIDStr == "" {
@@ -5,9 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
 	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
@@ -18,6 +20,7 @@ import (
 	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
 	"github.com/TiPSYDiPSY/home-task/internal/model/api"
 	"github.com/TiPSYDiPSY/home-task/internal/service"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
 )
 
 func TestParseUserID(t *testing.T) {
@@ -111,6 +114,15 @@ func TestParseUserID(t *testing.T) {
 	}
 }
 
+// contentType is the Content-Type of a response with the given status.
+func contentType(statusCode int) string {
+	if statusCode >= http.StatusBadRequest {
+		return response.ProblemContentType
+	}
+
+	return "application/json"
+}
+
 func TestGetBalance(t *testing.T) {
 	type prepareMocks func(*service.MockUserService)
 	type args struct {
@@ -128,7 +140,7 @@ func TestGetBalance(t *testing.T) {
 			name: "successful balance retrieval",
 			args: args{userID: "1"},
 			prepareMocks: func(mockService *service.MockUserService) {
-				mockService.EXPECT().GetBalance(mock.Anything, uint64(1)).Return(
+				mockService.EXPECT().GetBalance(mock.Anything, uint64(1), "").Return(
 					api.BalanceResponse{
 						UserID:  1,
 						Balance: "15.50",
@@ -145,7 +157,7 @@ func TestGetBalance(t *testing.T) {
 			name: "zero balance",
 			args: args{userID: "2"},
 			prepareMocks: func(mockService *service.MockUserService) {
-				mockService.EXPECT().GetBalance(mock.Anything, uint64(2)).Return(
+				mockService.EXPECT().GetBalance(mock.Anything, uint64(2), "").Return(
 					api.BalanceResponse{
 						UserID:  2,
 						Balance: "0.00",
@@ -162,7 +174,7 @@ func TestGetBalance(t *testing.T) {
 			name: "large balance",
 			args: args{userID: "3"},
 			prepareMocks: func(mockService *service.MockUserService) {
-				mockService.EXPECT().GetBalance(mock.Anything, uint64(3)).Return(
+				mockService.EXPECT().GetBalance(mock.Anything, uint64(3), "").Return(
 					api.BalanceResponse{
 						UserID:  3,
 						Balance: "12345.67",
@@ -179,26 +191,32 @@ func TestGetBalance(t *testing.T) {
 			name: "user not found",
 			args: args{userID: "999"},
 			prepareMocks: func(mockService *service.MockUserService) {
-				mockService.EXPECT().GetBalance(mock.Anything, uint64(999)).Return(
+				mockService.EXPECT().GetBalance(mock.Anything, uint64(999), "").Return(
 					api.BalanceResponse{}, errs.ErrUserNotFound)
 			},
 			wantHTTPCode: http.StatusNotFound,
 			wantBody: `{
-				"error": "Not Found",
-				"message": "user not found"
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "USER_NOT_FOUND",
+				"detail": "user not found"
 			}`,
 		},
 		{
 			name: "internal server error",
 			args: args{userID: "5"},
 			prepareMocks: func(mockService *service.MockUserService) {
-				mockService.EXPECT().GetBalance(mock.Anything, uint64(5)).Return(
+				mockService.EXPECT().GetBalance(mock.Anything, uint64(5), "").Return(
 					api.BalanceResponse{}, errors.New("database connection failed"))
 			},
 			wantHTTPCode: http.StatusInternalServerError,
 			wantBody: `{
-				"error": "Internal Server Error",
-				"message": "internal server error"
+				"type": "about:blank",
+				"title": "Internal Server Error",
+				"status": 500,
+				"code": "INTERNAL_ERROR",
+				"detail": "internal server error"
 			}`,
 		},
 		{
@@ -207,8 +225,11 @@ func TestGetBalance(t *testing.T) {
 			prepareMocks: func(mockService *service.MockUserService) {},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "invalid user ID format"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid user ID format"
 			}`,
 		},
 		{
@@ -217,8 +238,11 @@ func TestGetBalance(t *testing.T) {
 			prepareMocks: func(mockService *service.MockUserService) {},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "user ID must be positive"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "user ID must be positive"
 			}`,
 		},
 		{
@@ -227,8 +251,11 @@ func TestGetBalance(t *testing.T) {
 			prepareMocks: func(mockService *service.MockUserService) {},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "user ID is required"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "user ID is required"
 			}`,
 		},
 	}
@@ -251,7 +278,7 @@ func TestGetBalance(t *testing.T) {
 			handler.ServeHTTP(rr, req)
 
 			assert.Equal(t, tt.wantHTTPCode, rr.Code)
-			assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+			assert.Equal(t, contentType(tt.wantHTTPCode), rr.Header().Get("Content-Type"))
 			assert.JSONEq(t, tt.wantBody, rr.Body.String())
 		})
 	}
@@ -332,8 +359,11 @@ func TestUpdateBalance(t *testing.T) {
 			},
 			wantHTTPCode: http.StatusNotFound,
 			wantBody: `{
-				"error": "Not Found",
-				"message": "user not found"
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "USER_NOT_FOUND",
+				"detail": "user not found"
 			}`,
 		},
 		{
@@ -356,8 +386,11 @@ func TestUpdateBalance(t *testing.T) {
 			},
 			wantHTTPCode: http.StatusConflict,
 			wantBody: `{
-				"error": "Conflict",
-				"message": "transaction with this ID already exists"
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "DUPLICATE_TRANSACTION",
+				"detail": "transaction ID already used with a different payload"
 			}`,
 		},
 		{
@@ -380,8 +413,119 @@ func TestUpdateBalance(t *testing.T) {
 			},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "insufficient funds for this transaction"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INSUFFICIENT_FUNDS",
+				"detail": "insufficient funds for this transaction"
+			}`,
+		},
+		{
+			name: "account frozen",
+			args: args{
+				userID:     "4",
+				sourceType: "game",
+				body: api.TransactionRequest{
+					State:         "lose",
+					Amount:        "5.00",
+					TransactionID: "txn-frozen",
+				},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().UpdateBalance(mock.Anything, api.TransactionRequest{
+					State:         "lose",
+					Amount:        "5.00",
+					TransactionID: "txn-frozen",
+				}, uint64(4), "game").Return(errs.ErrAccountFrozen)
+			},
+			wantHTTPCode: http.StatusForbidden,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Forbidden",
+				"status": 403,
+				"code": "USER_FROZEN",
+				"detail": "account is frozen for this operation"
+			}`,
+		},
+		{
+			name: "negative amount",
+			args: args{
+				userID:     "5",
+				sourceType: "game",
+				body: api.TransactionRequest{
+					State:         "lose",
+					Amount:        "-50",
+					TransactionID: "txn-negative",
+				},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().UpdateBalance(mock.Anything, api.TransactionRequest{
+					State:         "lose",
+					Amount:        "-50",
+					TransactionID: "txn-negative",
+				}, uint64(5), "game").Return(errs.ErrAmountNotPositive)
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "AMOUNT_NOT_POSITIVE",
+				"detail": "amount must be greater than zero"
+			}`,
+		},
+		{
+			name: "amount above source maximum",
+			args: args{
+				userID:     "5",
+				sourceType: "game",
+				body: api.TransactionRequest{
+					State:         "win",
+					Amount:        "5000.00",
+					TransactionID: "txn-too-large",
+				},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().UpdateBalance(mock.Anything, api.TransactionRequest{
+					State:         "win",
+					Amount:        "5000.00",
+					TransactionID: "txn-too-large",
+				}, uint64(5), "game").Return(fmt.Errorf("%w of 1000 for game", errs.ErrAmountAboveMaximum))
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "AMOUNT_ABOVE_MAXIMUM",
+				"detail": "amount is above the maximum of 1000 for game"
+			}`,
+		},
+		{
+			name: "amount out of range",
+			args: args{
+				userID:     "5",
+				sourceType: "payment",
+				body: api.TransactionRequest{
+					State:         "win",
+					Amount:        "92233720368547758.08",
+					TransactionID: "txn-overflow",
+				},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().UpdateBalance(mock.Anything, api.TransactionRequest{
+					State:         "win",
+					Amount:        "92233720368547758.08",
+					TransactionID: "txn-overflow",
+				}, uint64(5), "payment").Return(errs.ErrAmountOverflow)
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "AMOUNT_OUT_OF_RANGE",
+				"detail": "amount is too large"
 			}`,
 		},
 		{
@@ -404,8 +548,11 @@ func TestUpdateBalance(t *testing.T) {
 			},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "invalid amount format"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_AMOUNT",
+				"detail": "invalid amount format"
 			}`,
 		},
 		{
@@ -428,8 +575,11 @@ func TestUpdateBalance(t *testing.T) {
 			},
 			wantHTTPCode: http.StatusInternalServerError,
 			wantBody: `{
-				"error": "Internal Server Error",
-				"message": "failed to process transaction"
+				"type": "about:blank",
+				"title": "Internal Server Error",
+				"status": 500,
+				"code": "INTERNAL_ERROR",
+				"detail": "internal server error"
 			}`,
 		},
 		{
@@ -446,8 +596,11 @@ func TestUpdateBalance(t *testing.T) {
 			prepareMocks: func(mockService *service.MockUserService) {},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "invalid user ID format"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid user ID format"
 			}`,
 		},
 		{
@@ -464,8 +617,11 @@ func TestUpdateBalance(t *testing.T) {
 			prepareMocks: func(mockService *service.MockUserService) {},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "user ID must be positive"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "user ID must be positive"
 			}`,
 		},
 		{
@@ -478,8 +634,11 @@ func TestUpdateBalance(t *testing.T) {
 			prepareMocks: func(mockService *service.MockUserService) {},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "invalid JSON format"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid JSON format"
 			}`,
 		},
 		{
@@ -492,8 +651,11 @@ func TestUpdateBalance(t *testing.T) {
 			prepareMocks: func(mockService *service.MockUserService) {},
 			wantHTTPCode: http.StatusBadRequest,
 			wantBody: `{
-				"error": "Bad Request",
-				"message": "invalid JSON format"
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid JSON format"
 			}`,
 		},
 		{
@@ -552,7 +714,380 @@ func TestUpdateBalance(t *testing.T) {
 
 			assert.Equal(t, tt.wantHTTPCode, rr.Code)
 			if tt.wantBody != "" {
-				assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+				assert.Equal(t, contentType(tt.wantHTTPCode), rr.Header().Get("Content-Type"))
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestListTransactions(t *testing.T) {
+	type prepareMocks func(*service.MockUserService)
+	type args struct {
+		userID string
+		query  string
+	}
+
+	processedAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		args         args
+		prepareMocks prepareMocks
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "successful listing with filters",
+			args: args{userID: "1", query: "?limit=10&state=lose&sourceType=game&minAmount=1.50"},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ListTransactions(mock.Anything, uint64(1), api.TransactionListRequest{
+					Limit:      10,
+					State:      "lose",
+					SourceType: "game",
+					MinAmount:  "1.50",
+				}).Return(api.TransactionListResponse{
+					UserID: 1,
+					Transactions: []api.TransactionResponse{
+						{TransactionID: "txn-1", State: "lose", SourceType: "game", Amount: "5.25", Currency: "USD", ProcessedAt: processedAt},
+					},
+					NextCursor: "next",
+				}, nil)
+			},
+			wantHTTPCode: http.StatusOK,
+			wantBody: `{
+				"userId": 1,
+				"transactions": [
+					{
+						"transactionId": "txn-1",
+						"state": "lose",
+						"sourceType": "game",
+						"amount": "5.25",
+						"currency": "USD",
+						"processedAt": "2025-08-18T12:00:00Z"
+					}
+				],
+				"nextCursor": "next"
+			}`,
+		},
+		{
+			name:         "invalid limit format",
+			args:         args{userID: "1", query: "?limit=ten"},
+			prepareMocks: func(mockService *service.MockUserService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid limit format"
+			}`,
+		},
+		{
+			name:         "limit out of range",
+			args:         args{userID: "1", query: "?limit=1000"},
+			prepareMocks: func(mockService *service.MockUserService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: limit must be at most 100",
+				"errors": [{"field": "limit", "message": "limit must be at most 100"}]
+			}`,
+		},
+		{
+			name:         "invalid state filter",
+			args:         args{userID: "1", query: "?state=draw"},
+			prepareMocks: func(mockService *service.MockUserService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: state must be one of [win lose reversal adjustment]",
+				"errors": [{"field": "state", "message": "state must be one of [win lose reversal adjustment]"}]
+			}`,
+		},
+		{
+			name:         "invalid from timestamp",
+			args:         args{userID: "1", query: "?from=2025-08-18"},
+			prepareMocks: func(mockService *service.MockUserService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: from must be an RFC 3339 timestamp",
+				"errors": [{"field": "from", "message": "from must be an RFC 3339 timestamp"}]
+			}`,
+		},
+		{
+			name: "invalid cursor",
+			args: args{userID: "1", query: "?cursor=broken"},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ListTransactions(mock.Anything, uint64(1), api.TransactionListRequest{
+					Cursor: "broken",
+				}).Return(api.TransactionListResponse{}, errs.ErrInvalidCursor)
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_CURSOR",
+				"detail": "invalid cursor"
+			}`,
+		},
+		{
+			name: "user not found",
+			args: args{userID: "999"},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ListTransactions(mock.Anything, uint64(999), api.TransactionListRequest{}).
+					Return(api.TransactionListResponse{}, errs.ErrUserNotFound)
+			},
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "USER_NOT_FOUND",
+				"detail": "user not found"
+			}`,
+		},
+		{
+			name: "internal server error",
+			args: args{userID: "1"},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ListTransactions(mock.Anything, uint64(1), api.TransactionListRequest{}).
+					Return(api.TransactionListResponse{}, errors.New("database connection failed"))
+			},
+			wantHTTPCode: http.StatusInternalServerError,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Internal Server Error",
+				"status": 500,
+				"code": "INTERNAL_ERROR",
+				"detail": "internal server error"
+			}`,
+		},
+		{
+			name:         "invalid user ID format",
+			args:         args{userID: "invalid"},
+			prepareMocks: func(mockService *service.MockUserService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INVALID_REQUEST",
+				"detail": "invalid user ID format"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockUserService(t)
+
+			tt.prepareMocks(mockService)
+
+			req := httptest.NewRequest(http.MethodGet, "/user/placeholder/transactions"+tt.args.query, nil)
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("userID", tt.args.userID)
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			rr := httptest.NewRecorder()
+			handler := ListTransactions(mockService, validation.NewValidator())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.Equal(t, contentType(tt.wantHTTPCode), rr.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestReverseTransaction(t *testing.T) {
+	type prepareMocks func(*service.MockUserService)
+	type args struct {
+		userID        string
+		transactionID string
+		body          any
+	}
+
+	tests := []struct {
+		name         string
+		args         args
+		prepareMocks prepareMocks
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name: "successful reversal",
+			args: args{
+				userID:        "1",
+				transactionID: "txn-123",
+				body:          api.ReversalRequest{TransactionID: "rev-123"},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ReverseTransaction(mock.Anything, api.ReversalRequest{TransactionID: "rev-123"},
+					uint64(1), "txn-123", "game").Return(nil)
+			},
+			wantHTTPCode: http.StatusOK,
+		},
+		{
+			name: "original transaction not found",
+			args: args{
+				userID:        "1",
+				transactionID: "txn-unknown",
+				body:          api.ReversalRequest{TransactionID: "rev-unknown"},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ReverseTransaction(mock.Anything, api.ReversalRequest{TransactionID: "rev-unknown"},
+					uint64(1), "txn-unknown", "game").Return(errs.ErrTransactionNotFound)
+			},
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "TRANSACTION_NOT_FOUND",
+				"detail": "transaction not found"
+			}`,
+		},
+		{
+			name: "already reversed",
+			args: args{
+				userID:        "1",
+				transactionID: "txn-123",
+				body:          api.ReversalRequest{TransactionID: "rev-456"},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ReverseTransaction(mock.Anything, api.ReversalRequest{TransactionID: "rev-456"},
+					uint64(1), "txn-123", "game").Return(errs.ErrAlreadyReversed)
+			},
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "ALREADY_REVERSED",
+				"detail": "transaction has already been reversed"
+			}`,
+		},
+		{
+			name: "reversal of a reversal",
+			args: args{
+				userID:        "1",
+				transactionID: "rev-123",
+				body:          api.ReversalRequest{TransactionID: "rev-rev-123"},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ReverseTransaction(mock.Anything, api.ReversalRequest{TransactionID: "rev-rev-123"},
+					uint64(1), "rev-123", "game").Return(errs.ErrNotReversible)
+			},
+			wantHTTPCode: http.StatusUnprocessableEntity,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unprocessable Entity",
+				"status": 422,
+				"code": "NOT_REVERSIBLE",
+				"detail": "this transaction type cannot be reversed"
+			}`,
+		},
+		{
+			name: "insufficient funds",
+			args: args{
+				userID:        "1",
+				transactionID: "txn-win",
+				body:          api.ReversalRequest{TransactionID: "rev-win"},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ReverseTransaction(mock.Anything, api.ReversalRequest{TransactionID: "rev-win"},
+					uint64(1), "txn-win", "game").Return(errs.ErrInsufficientFunds)
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INSUFFICIENT_FUNDS",
+				"detail": "insufficient funds for this transaction"
+			}`,
+		},
+		{
+			name: "missing reversal transaction ID",
+			args: args{
+				userID:        "1",
+				transactionID: "txn-123",
+				body:          api.ReversalRequest{},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: transactionId is required",
+				"errors": [{"field": "transactionId", "message": "transactionId is required"}]
+			}`,
+		},
+		{
+			name: "internal server error",
+			args: args{
+				userID:        "1",
+				transactionID: "txn-123",
+				body:          api.ReversalRequest{TransactionID: "rev-123"},
+			},
+			prepareMocks: func(mockService *service.MockUserService) {
+				mockService.EXPECT().ReverseTransaction(mock.Anything, api.ReversalRequest{TransactionID: "rev-123"},
+					uint64(1), "txn-123", "game").Return(errors.New("database connection failed"))
+			},
+			wantHTTPCode: http.StatusInternalServerError,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Internal Server Error",
+				"status": 500,
+				"code": "INTERNAL_ERROR",
+				"detail": "internal server error"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := service.NewMockUserService(t)
+
+			tt.prepareMocks(mockService)
+
+			bodyBytes, err := json.Marshal(tt.args.body)
+			assert.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodPost, "/user/placeholder/transaction/placeholder/reversal",
+				bytes.NewReader(bodyBytes))
+			req.Header.Set("Content-Type", "application/json")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("userID", tt.args.userID)
+			rctx.URLParams.Add("transactionID", tt.args.transactionID)
+			ctx := context.WithValue(req.Context(), chi.RouteCtxKey, rctx)
+			ctx = context.WithValue(ctx, middleware.SourceTypeKey, "game")
+			req = req.WithContext(ctx)
+
+			rr := httptest.NewRecorder()
+			handler := ReverseTransaction(mockService, validation.NewValidator())
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			if tt.wantBody != "" {
 				assert.JSONEq(t, tt.wantBody, rr.Body.String())
 			}
 		})
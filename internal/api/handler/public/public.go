@@ -1,36 +1,103 @@
 package public
 
 import (
+	"context"
+
 	"github.com/go-chi/chi/v5"
 	chimiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/sirupsen/logrus"
 
 	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/middleware"
 	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public/handlers/user"
+	"github.com/TiPSYDiPSY/home-task/internal/api/openapi"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
 	"github.com/TiPSYDiPSY/home-task/internal/util/validation"
 
 	"github.com/TiPSYDiPSY/home-task/internal/service"
 )
 
-func Init(container service.Container, mainRouter *chi.Mux) {
+func Init(c config.HTTPConfig, container service.Container, mainRouter *chi.Mux) {
 	subRouter := chi.NewRouter()
 
 	loggingMiddleware := middleware.NewLoggingMiddleware(middleware.LoggingConfig{
-		BodyLoggingEnabled: true,
+		BodyLoggingEnabled: c.BodyLogging,
 		ServiceName:        "home-task",
 	})
 
+	subRouter.Use(middleware.Metrics)
 	subRouter.Use(loggingMiddleware.Middleware)
 
+	if c.ValidateRequests || c.ValidateResponses {
+		subRouter.Use(newOpenAPIValidator(c).Middleware)
+	}
+
+	valid := validation.NewValidator(validation.WithCurrencies(container.Currencies))
+
+	authenticateSource := middleware.SourceTypeValidator
+	if container.SignatureVerifier != nil {
+		authenticateSource = middleware.SignatureVerifier(container.SignatureVerifier)
+	}
+
+	// Every route needs a principal: a back-office credential or an authenticated Source-Type.
+	authenticate := middleware.Authenticate(container.Authenticator, authenticateSource)
+
 	subRouter.Group(func(r chi.Router) {
 		r.Use(chimiddleware.AllowContentType("application/json"))
-		r.Use(middleware.SourceTypeValidator)
+		r.Use(authenticate)
 		r.Use(middleware.HTTPVersionValidator)
-		r.Post("/{userID}/transaction", user.UpdateBalance(container.UserService, validation.NewValidator()))
+		r.Use(middleware.RequireScope(auth.ScopeTransactionWrite))
+		r.Use(middleware.RequireSourceType)
+		r.Post("/{userID}/transaction", user.UpdateBalance(container.UserService, valid))
+		r.Post("/{userID}/transaction/{transactionID}/reversal",
+			user.ReverseTransaction(container.UserService, valid))
+		r.Post("/{userID}/hold", user.PlaceHold(container.HoldService, valid))
+		r.Post("/{userID}/hold/{holdID}/settle", user.SettleHold(container.HoldService, valid))
+		r.Post("/{userID}/hold/{holdID}/release", user.ReleaseHold(container.HoldService))
 	})
 
+	// Account management is not tied to a Source-Type.
 	subRouter.Group(func(r chi.Router) {
-		r.Get("/{userID}/balance", user.GetBalance(container.UserService))
+		r.Use(chimiddleware.AllowContentType("application/json"))
+		r.Use(authenticate)
+		r.Use(middleware.HTTPVersionValidator)
+		r.Use(middleware.RequireScope(auth.ScopeAccountWrite))
+		r.Post("/", user.CreateUser(container.AccountService, valid))
+		r.Post("/{userID}/freeze", user.FreezeUser(container.AccountService, valid))
+		r.Post("/{userID}/unfreeze", user.UnfreezeUser(container.AccountService))
+		r.Post("/{userID}/close", user.CloseUser(container.AccountService))
+	})
+
+	subRouter.Group(func(r chi.Router) {
+		r.Use(authenticate)
+		r.With(middleware.RequireScope(auth.ScopeAccountRead)).
+			Get("/{userID}", user.GetUser(container.AccountService))
+		r.With(middleware.RequireScope(auth.ScopeBalanceRead)).
+			Get("/{userID}/balance", user.GetBalance(container.UserService))
+		r.With(middleware.RequireScope(auth.ScopeBalanceRead)).
+			Get("/{userID}/wallets", user.ListWallets(container.UserService))
+
+		if container.BalanceStreamService != nil {
+			r.With(middleware.RequireScope(auth.ScopeBalanceRead)).
+				Get("/{userID}/balance/stream", user.StreamBalance(container.BalanceStreamService))
+		}
+		r.With(middleware.RequireScope(auth.ScopeTransactionRead)).
+			Get("/{userID}/transactions", user.ListTransactions(container.UserService, valid))
 	})
 
 	mainRouter.Mount("/user", subRouter)
 }
+
+// newOpenAPIValidator builds the validator of the embedded specification, which only fails when
+// the specification itself is broken.
+func newOpenAPIValidator(c config.HTTPConfig) *openapi.Validator {
+	validator, err := openapi.NewValidator(context.Background(), openapi.ValidatorConfig{
+		Requests:  c.ValidateRequests,
+		Responses: c.ValidateResponses,
+	})
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load the OpenAPI specification")
+	}
+
+	return validator
+}
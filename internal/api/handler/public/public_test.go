@@ -0,0 +1,154 @@
+package public
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/api/openapi"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+// TestInit_MatchesOpenAPISpecification fails when a route is added to or removed from Init
+// without updating the OpenAPI specification, or the other way round.
+func TestInit_MatchesOpenAPISpecification(t *testing.T) {
+	router := chi.NewRouter()
+	Init(config.HTTPConfig{}, service.Container{
+		BalanceStreamService: service.NewMockBalanceStreamService(t),
+	}, router)
+
+	var registered []string
+
+	require.NoError(t, chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// Mounted roots are registered as "/user/" but served as "/user" too.
+		registered = append(registered, method+" "+strings.TrimSuffix(route, "/"))
+
+		return nil
+	}))
+
+	doc, err := openapi.Load(t.Context())
+	require.NoError(t, err)
+
+	var specified []string
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			specified = append(specified, method+" "+path)
+		}
+	}
+
+	slices.Sort(registered)
+	slices.Sort(specified)
+
+	assert.Equal(t, specified, registered, "routes of public.Init and openapi.json differ")
+}
+
+func TestInit_ValidateRequests(t *testing.T) {
+	router := chi.NewRouter()
+	Init(config.HTTPConfig{ValidateRequests: true}, service.Container{}, router)
+
+	tests := []struct {
+		name         string
+		method       string
+		target       string
+		body         string
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name:         "invalid state",
+			method:       http.MethodPost,
+			target:       "/user/1/transaction",
+			body:         `{"state":"draw","amount":"10.00","transactionId":"txn-1"}`,
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: invalid request body: state: value is not one of the allowed values [\"win\",\"lose\"]",
+				"errors": [{
+					"field": "state",
+					"message": "invalid request body: state: value is not one of the allowed values [\"win\",\"lose\"]"
+				}]
+			}`,
+		},
+		{
+			name:         "limit out of range",
+			method:       http.MethodGet,
+			target:       "/user/1/transactions?limit=500",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: invalid query parameter limit: number must be at most 100",
+				"errors": [{"field": "limit", "message": "invalid query parameter limit: number must be at most 100"}]
+			}`,
+		},
+		{
+			name:         "valid request reaches authentication",
+			method:       http.MethodGet,
+			target:       "/user/1/transactions?limit=50",
+			wantHTTPCode: http.StatusUnauthorized,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Unauthorized",
+				"status": 401,
+				"code": "AUTHENTICATION_REQUIRED",
+				"detail": "authentication required"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+// TestInit_SourceCannotManageAccounts makes sure a Source-Type header, which is all an unsigned
+// source has to send, does not reach the account lifecycle.
+func TestInit_SourceCannotManageAccounts(t *testing.T) {
+	router := chi.NewRouter()
+	Init(config.HTTPConfig{}, service.Container{}, router)
+
+	for _, route := range []struct {
+		method string
+		target string
+	}{
+		{http.MethodPost, "/user"},
+		{http.MethodPost, "/user/1/freeze"},
+		{http.MethodPost, "/user/1/unfreeze"},
+		{http.MethodPost, "/user/1/close"},
+		{http.MethodGet, "/user/1"},
+	} {
+		t.Run(route.method+" "+route.target, func(t *testing.T) {
+			req := httptest.NewRequest(route.method, route.target, strings.NewReader(`{"scope":"all"}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Source-Type", "game")
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+			assert.Contains(t, rr.Body.String(), `"code":"MISSING_SCOPE"`)
+		})
+	}
+}
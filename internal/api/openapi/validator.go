@@ -0,0 +1,225 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/sirupsen/logrus"
+
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+)
+
+// MaxBodySize bounds how much of a request body is read to validate it.
+const MaxBodySize = 64 << 10
+
+type ValidatorConfig struct {
+	// Requests rejects requests that do not match the specification with 400 Bad Request.
+	Requests bool
+	// Responses logs responses that do not match the specification. They are sent unchanged.
+	Responses bool
+}
+
+// Validator checks requests and responses against the specification. Requests to routes the
+// specification does not know are passed on untouched, so the router answers them.
+type Validator struct {
+	config  ValidatorConfig
+	router  routers.Router
+	options *openapi3filter.Options
+}
+
+func NewValidator(ctx context.Context, config ValidatorConfig) (*Validator, error) {
+	doc, err := Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to route OpenAPI specification: %w", err)
+	}
+
+	// Credentials are checked by the authentication middleware, which knows the scopes.
+	options := &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc}
+	options.WithCustomSchemaErrorFunc(schemaErrorMessage)
+
+	return &Validator{config: config, router: router, options: options}, nil
+}
+
+func (v *Validator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		route, pathParams, err := v.router.FindRoute(r)
+		if err != nil {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    r,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    v.options,
+		}
+
+		if v.config.Requests {
+			r.Body = http.MaxBytesReader(w, r.Body, MaxBodySize)
+
+			if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+				var maxBytesErr *http.MaxBytesError
+				if errors.As(err, &maxBytesErr) {
+					response.Error(ctx, w, http.StatusRequestEntityTooLarge, response.CodeRequestTooLarge, "request body is too large")
+
+					return
+				}
+
+				if fieldErr, ok := requestFieldError(err); ok {
+					response.FromError(ctx, w, customErrors.ValidationErrors{fieldErr})
+
+					return
+				}
+
+				response.BadRequest(ctx, w, requestErrorMessage(err))
+
+				return
+			}
+		}
+
+		// A stream is never complete, so there is no response to validate.
+		if !v.config.Responses || streams(route.Operation) {
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		recorder := &recordingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+
+		if err := openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 recorder.statusCode,
+			Header:                 recorder.Header(),
+			Body:                   io.NopCloser(&recorder.body),
+			Options:                v.options,
+		}); err != nil {
+			logrus.WithContext(ctx).WithError(err).WithFields(logrus.Fields{
+				"method":      r.Method,
+				"route":       route.Path,
+				"status_code": recorder.statusCode,
+			}).Error("Response does not match the OpenAPI specification")
+		}
+	})
+}
+
+// streams tells whether the operation answers with an event stream.
+func streams(operation *openapi3.Operation) bool {
+	ok := operation.Responses.Status(http.StatusOK)
+
+	return ok != nil && ok.Value != nil && ok.Value.Content.Get("text/event-stream") != nil
+}
+
+// requestErrorMessage says what is wrong with a request without the schema references of the
+// specification.
+func requestErrorMessage(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	reason := requestErr.Reason
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		reason = schemaErrorMessage(schemaErr)
+	} else if requestErr.Err != nil {
+		reason = requestErr.Err.Error()
+	}
+
+	switch {
+	case requestErr.Parameter != nil:
+		return fmt.Sprintf("invalid %s parameter %s: %s", requestErr.Parameter.In, requestErr.Parameter.Name, reason)
+	case requestErr.RequestBody != nil:
+		return "invalid request body: " + reason
+	default:
+		return reason
+	}
+}
+
+// requestFieldError tells which parameter or body field a request error is about. It returns
+// false for errors about the request as a whole, such as an unsupported content type.
+func requestFieldError(err error) (customErrors.ValidationError, bool) {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return customErrors.ValidationError{}, false
+	}
+
+	field := ""
+
+	var schemaErr *openapi3.SchemaError
+
+	switch {
+	case requestErr.Parameter != nil:
+		field = requestErr.Parameter.Name
+	case requestErr.RequestBody != nil && errors.As(err, &schemaErr):
+		field = strings.Join(schemaErr.JSONPointer(), ".")
+	}
+
+	if field == "" {
+		return customErrors.ValidationError{}, false
+	}
+
+	return customErrors.ValidationError{Field: field, Message: requestErrorMessage(err)}, true
+}
+
+// schemaErrorMessage names the offending field instead of dumping the schema and the value.
+func schemaErrorMessage(err *openapi3.SchemaError) string {
+	reason := err.Reason
+	if err.Origin != nil {
+		reason = err.Origin.Error()
+	}
+
+	if reason == "" {
+		reason = "does not match schema " + err.SchemaField
+	}
+
+	if pointer := err.JSONPointer(); len(pointer) > 0 {
+		return strings.Join(pointer, ".") + ": " + reason
+	}
+
+	return reason
+}
+
+// recordingResponseWriter keeps a copy of the response it writes, to validate it afterwards.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (rw *recordingResponseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *recordingResponseWriter) Write(b []byte) (int, error) {
+	rw.body.Write(b)
+
+	return rw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the methods of the underlying writer.
+func (rw *recordingResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/util/response"
+)
+
+func TestHandler(t *testing.T) {
+	rr := httptest.NewRecorder()
+	Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.True(t, json.Valid(rr.Body.Bytes()))
+}
+
+func TestValidator_Middleware(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        string
+		target        string
+		body          string
+		handlerStatus int
+		handlerBody   string
+		wantHTTPCode  int
+		wantBody      string
+		wantLogged    bool
+	}{
+		{
+			name:          "valid request and response",
+			method:        http.MethodGet,
+			target:        "/user/1/balance",
+			handlerStatus: http.StatusOK,
+			handlerBody:   `{"userId":1,"currency":"USD","balance":"10.00"}`,
+			wantHTTPCode:  http.StatusOK,
+			wantBody:      `{"userId":1,"currency":"USD","balance":"10.00"}`,
+		},
+		{
+			name:          "response missing a required field",
+			method:        http.MethodGet,
+			target:        "/user/1/balance",
+			handlerStatus: http.StatusOK,
+			handlerBody:   `{"userId":1,"currency":"USD"}`,
+			wantHTTPCode:  http.StatusOK,
+			wantBody:      `{"userId":1,"currency":"USD"}`,
+			wantLogged:    true,
+		},
+		{
+			name:          "error response",
+			method:        http.MethodGet,
+			target:        "/user/1/balance",
+			handlerStatus: http.StatusNotFound,
+			handlerBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "USER_NOT_FOUND",
+				"detail": "user not found"
+			}`,
+			wantHTTPCode: http.StatusNotFound,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Not Found",
+				"status": 404,
+				"code": "USER_NOT_FOUND",
+				"detail": "user not found"
+			}`,
+		},
+		{
+			name:         "invalid user ID",
+			method:       http.MethodGet,
+			target:       "/user/abc/balance",
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: invalid path parameter userID: value abc: an invalid integer: invalid syntax",
+				"errors": [{
+					"field": "userID",
+					"message": "invalid path parameter userID: value abc: an invalid integer: invalid syntax"
+				}]
+			}`,
+		},
+		{
+			name:         "missing required field",
+			method:       http.MethodPost,
+			target:       "/user/1/hold",
+			body:         `{"amount":"10.00"}`,
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: invalid request body: holdId: property \"holdId\" is missing",
+				"errors": [{"field": "holdId", "message": "invalid request body: holdId: property \"holdId\" is missing"}]
+			}`,
+		},
+		{
+			name:         "body too large",
+			method:       http.MethodPost,
+			target:       "/user/1/hold",
+			body:         `{"holdId":"` + strings.Repeat("x", MaxBodySize) + `"}`,
+			wantHTTPCode: http.StatusRequestEntityTooLarge,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Request Entity Too Large",
+				"status": 413,
+				"code": "REQUEST_TOO_LARGE",
+				"detail": "request body is too large"
+			}`,
+		},
+		{
+			name:          "route unknown to the specification",
+			method:        http.MethodGet,
+			target:        "/user/1/unknown",
+			handlerStatus: http.StatusNotFound,
+			handlerBody:   `404 page not found`,
+			wantHTTPCode:  http.StatusNotFound,
+			wantBody:      `404 page not found`,
+		},
+	}
+
+	validator, err := NewValidator(t.Context(), ValidatorConfig{Requests: true, Responses: true})
+	require.NoError(t, err)
+
+	hook := test.NewGlobal()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook.Reset()
+
+			handler := validator.Middleware(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.handlerStatus >= http.StatusBadRequest {
+					w.Header().Set("Content-Type", response.ProblemContentType)
+				} else {
+					w.Header().Set("Content-Type", "application/json")
+				}
+
+				w.WriteHeader(tt.handlerStatus)
+				_, _ = w.Write([]byte(tt.handlerBody))
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			if json.Valid([]byte(tt.wantBody)) {
+				assert.JSONEq(t, tt.wantBody, rr.Body.String())
+			} else {
+				assert.Equal(t, tt.wantBody, strings.TrimSpace(rr.Body.String()))
+			}
+
+			logged := false
+
+			for _, entry := range hook.AllEntries() {
+				if entry.Level == logrus.ErrorLevel && entry.Message == "Response does not match the OpenAPI specification" {
+					logged = true
+				}
+			}
+
+			assert.Equal(t, tt.wantLogged, logged)
+		})
+	}
+}
@@ -4,44 +4,47 @@ import (
 	"context"
 	"crypto/tls"
 	"errors"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
+	"github.com/TiPSYDiPSY/home-task/internal/api/grpcapi"
+	"github.com/TiPSYDiPSY/home-task/internal/api/handler/admin"
 	"github.com/TiPSYDiPSY/home-task/internal/api/handler/operation"
 	"github.com/TiPSYDiPSY/home-task/internal/api/handler/public"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
 
 	"github.com/TiPSYDiPSY/home-task/internal/config"
 	"github.com/TiPSYDiPSY/home-task/internal/service"
 )
 
-const (
-	readTimeoutSec     = 5
-	writeTimeoutSec    = 60
-	idleTimeoutSec     = 120
-	shutdownTimeoutSec = 30
-)
-
 func StartServer(ctx context.Context, c *config.ServerConfig, container service.Container) {
 	log := logrus.WithContext(ctx)
-	log.Info("Starting http server on port: " + c.Port)
+	log.Info("Starting http server on port: " + strconv.Itoa(c.HTTP.Port))
 
-	router := initServerMux(container)
+	router := initServerMux(c.HTTP, container)
 
 	srv := &http.Server{
-		ReadTimeout:  readTimeoutSec * time.Second,
-		WriteTimeout: writeTimeoutSec * time.Second,
-		IdleTimeout:  idleTimeoutSec * time.Second,
-		Addr:         ":" + c.Port,
+		ReadTimeout:  c.HTTP.ReadTimeout,
+		WriteTimeout: c.HTTP.WriteTimeout,
+		IdleTimeout:  c.HTTP.IdleTimeout,
+		Addr:         ":" + strconv.Itoa(c.HTTP.Port),
 		Handler:      router,
 		TLSNextProto: make(map[string]func(*http.Server, *tls.Conn, http.Handler)),
 	}
 
+	// Shutdown waits for requests to finish, which a balance stream never does by itself.
+	if container.BalanceStreamService != nil {
+		srv.RegisterOnShutdown(container.BalanceStreamService.CloseStreams)
+	}
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
@@ -51,14 +54,36 @@ func StartServer(ctx context.Context, c *config.ServerConfig, container service.
 		}
 	}()
 
+	var grpcServer *grpc.Server
+	if c.GRPC.Enabled {
+		grpcServer = startGRPCServer(ctx, c.GRPC, container)
+	}
+
 	log.Info("Server started successfully")
 
 	<-quit
 	log.Info("Shutting down server...")
 
-	shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeoutSec*time.Second)
+	// Fail readiness first and keep serving for a while, so load balancers stop sending new
+	// requests before the listener closes. A second signal skips the wait.
+	container.HealthService.BeginShutdown()
+
+	if c.HTTP.ShutdownDrainDelay > 0 {
+		log.WithField("drain_delay", c.HTTP.ShutdownDrainDelay).Info("Draining traffic before shutdown")
+
+		select {
+		case <-time.After(c.HTTP.ShutdownDrainDelay):
+		case <-quit:
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, c.HTTP.ShutdownTimeout)
 	defer cancel()
 
+	if grpcServer != nil {
+		stopGRPCServer(shutdownCtx, grpcServer)
+	}
+
 	if err := srv.Shutdown(shutdownCtx); err != nil {
 		log.WithError(err).Error("Server forced to shutdown")
 
@@ -68,11 +93,50 @@ func StartServer(ctx context.Context, c *config.ServerConfig, container service.
 	log.Info("Server exited gracefully")
 }
 
-func initServerMux(container service.Container) *chi.Mux {
+// startGRPCServer serves the gRPC API on its own port, next to the REST API.
+func startGRPCServer(ctx context.Context, c config.GRPCConfig, container service.Container) *grpc.Server {
+	log := logrus.WithContext(ctx)
+	log.Info("Starting gRPC server on port: " + strconv.Itoa(c.Port))
+
+	listener, err := new(net.ListenConfig).Listen(ctx, "tcp", ":"+strconv.Itoa(c.Port))
+	if err != nil {
+		log.WithError(err).Fatal("gRPC server failed to start")
+	}
+
+	server := grpcapi.NewServer(container, "home-task")
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			log.WithError(err).Fatal("gRPC server failed")
+		}
+	}()
+
+	return server
+}
+
+// stopGRPCServer lets in-flight calls finish until ctx is done and then closes the rest.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		logrus.WithContext(ctx).Warn("gRPC server forced to stop")
+		server.Stop()
+	}
+}
+
+func initServerMux(c config.HTTPConfig, container service.Container) *chi.Mux {
 	r := chi.NewRouter()
 
-	operation.Init(r)
-	public.Init(container, r)
+	operation.Init(container, r)
+	public.Init(c, container, r)
+	admin.Init(c, container, r)
 
 	return r
 }
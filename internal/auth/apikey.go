@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// APIKey is a static credential. Only the SHA-256 hash of the key is stored: keys are long random
+// strings, so a fast hash is enough to keep a leaked file from revealing them.
+type APIKey struct {
+	ID     string   `json:"id"`
+	Hash   string   `json:"hash"`
+	Scopes []string `json:"scopes"`
+}
+
+// APIKeyStore looks up API keys by their hash.
+type APIKeyStore struct {
+	byHash map[string]APIKey
+}
+
+// HashAPIKey returns the hex-encoded SHA-256 hash under which key is stored.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// ParseAPIKeys reads a JSON array of APIKey.
+func ParseAPIKeys(data []byte) (*APIKeyStore, error) {
+	var keys []APIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("failed to parse API keys: %w", err)
+	}
+
+	store := &APIKeyStore{byHash: make(map[string]APIKey, len(keys))}
+
+	for _, key := range keys {
+		key.Hash = strings.ToLower(key.Hash)
+
+		if key.ID == "" {
+			return nil, fmt.Errorf("%w: API key without id", ErrInvalidConfig)
+		}
+
+		if decoded, err := hex.DecodeString(key.Hash); err != nil || len(decoded) != sha256.Size {
+			return nil, fmt.Errorf("%w: API key %q must have a hex-encoded SHA-256 hash", ErrInvalidConfig, key.ID)
+		}
+
+		for _, scope := range key.Scopes {
+			if !slices.Contains(KnownScopes(), scope) {
+				return nil, fmt.Errorf("%w: API key %q has unknown scope %q", ErrInvalidConfig, key.ID, scope)
+			}
+		}
+
+		if _, ok := store.byHash[key.Hash]; ok {
+			return nil, fmt.Errorf("%w: API key %q duplicates another key", ErrInvalidConfig, key.ID)
+		}
+
+		store.byHash[key.Hash] = key
+	}
+
+	return store, nil
+}
+
+func (s *APIKeyStore) Authenticate(key string) (Principal, error) {
+	apiKey, ok := s.byHash[HashAPIKey(key)]
+	if !ok {
+		return Principal{}, ErrInvalidCredentials
+	}
+
+	return Principal{Kind: KindAPIKey, ID: apiKey.ID, Scopes: apiKey.Scopes}, nil
+}
@@ -0,0 +1,60 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+const (
+	APIKeyHeader        = "X-API-Key"
+	AuthorizationHeader = "Authorization"
+
+	bearerPrefix = "Bearer "
+)
+
+var (
+	ErrNoCredentials      = errors.New("no credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrInvalidConfig      = errors.New("invalid authentication configuration")
+)
+
+// Authenticator resolves the principal of a request from an API key or a JWT bearer token.
+// Either mechanism may be left unconfigured, in which case its credentials are rejected.
+type Authenticator struct {
+	apiKeys *APIKeyStore
+	tokens  *JWTValidator
+}
+
+func NewAuthenticator(apiKeys *APIKeyStore, tokens *JWTValidator) *Authenticator {
+	return &Authenticator{apiKeys: apiKeys, tokens: tokens}
+}
+
+// Authenticate returns ErrNoCredentials when the request carries neither an API key nor a bearer
+// token, so the caller can fall back to another mechanism.
+func (a *Authenticator) Authenticate(r *http.Request) (Principal, error) {
+	return a.AuthenticateCredentials(r.Header.Get(APIKeyHeader), r.Header.Get(AuthorizationHeader))
+}
+
+// AuthenticateCredentials authenticates the values of the APIKeyHeader and AuthorizationHeader,
+// wherever a transport carries them.
+func (a *Authenticator) AuthenticateCredentials(key, header string) (Principal, error) {
+	if key != "" {
+		if a == nil || a.apiKeys == nil {
+			return Principal{}, ErrInvalidCredentials
+		}
+
+		return a.apiKeys.Authenticate(key)
+	}
+
+	if header != "" {
+		token, found := strings.CutPrefix(header, bearerPrefix)
+		if !found || a == nil || a.tokens == nil {
+			return Principal{}, ErrInvalidCredentials
+		}
+
+		return a.tokens.Authenticate(strings.TrimSpace(token))
+	}
+
+	return Principal{}, ErrNoCredentials
+}
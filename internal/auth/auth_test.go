@@ -0,0 +1,257 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrincipalHasScope(t *testing.T) {
+	reader := Principal{Scopes: []string{ScopeBalanceRead}}
+	admin := Principal{Scopes: []string{ScopeAdmin}}
+
+	assert.True(t, reader.HasScope(ScopeBalanceRead))
+	assert.False(t, reader.HasScope(ScopeTransactionWrite))
+	assert.True(t, admin.HasScope(ScopeTransactionWrite))
+	assert.False(t, SourcePrincipal("game").HasScope(ScopeAdmin))
+	assert.True(t, SourcePrincipal("game").HasScope(ScopeTransactionWrite))
+	assert.False(t, SourcePrincipal("game").HasScope(ScopeAccountRead))
+	assert.False(t, SourcePrincipal("game").HasScope(ScopeAccountWrite))
+	assert.Equal(t, "source:game", SourcePrincipal("game").String())
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	hash := HashAPIKey("backoffice-key")
+
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{name: "valid", data: `[{"id": "backoffice", "hash": "` + hash + `", "scopes": ["balance:read"]}]`},
+		{name: "empty list", data: `[]`},
+		{name: "not JSON", data: `backoffice`, wantErr: true},
+		{name: "missing id", data: `[{"hash": "` + hash + `"}]`, wantErr: true},
+		{name: "plain-text key instead of hash", data: `[{"id": "backoffice", "hash": "backoffice-key"}]`, wantErr: true},
+		{name: "unknown scope", data: `[{"id": "backoffice", "hash": "` + hash + `", "scopes": ["balance:write"]}]`, wantErr: true},
+		{
+			name:    "duplicate key",
+			data:    `[{"id": "a", "hash": "` + hash + `"}, {"id": "b", "hash": "` + hash + `"}]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseAPIKeys([]byte(tt.data))
+
+			assert.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+		})
+	}
+}
+
+func TestJWTValidator(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	validator, err := NewJWTValidator(testJWKS(t, rsaKey, ecKey), "https://issuer.example", "home-task")
+	require.NoError(t, err)
+
+	claims := func(modify func(jwt.MapClaims)) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"sub":   "ops@example.com",
+			"iss":   "https://issuer.example",
+			"aud":   "home-task",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "balance:read transaction:read",
+		}
+		if modify != nil {
+			modify(c)
+		}
+
+		return c
+	}
+
+	sign := func(method jwt.SigningMethod, kid string, key any, c jwt.MapClaims) string {
+		token := jwt.NewWithClaims(method, c)
+		token.Header["kid"] = kid
+
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+
+		return signed
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		want    Principal
+		wantErr bool
+	}{
+		{
+			name:  "RSA token",
+			token: sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(nil)),
+			want:  Principal{Kind: KindJWT, ID: "ops@example.com", Scopes: []string{ScopeBalanceRead, ScopeTransactionRead}},
+		},
+		{
+			name:  "EC token",
+			token: sign(jwt.SigningMethodES256, "ec-1", ecKey, claims(nil)),
+			want:  Principal{Kind: KindJWT, ID: "ops@example.com", Scopes: []string{ScopeBalanceRead, ScopeTransactionRead}},
+		},
+		{
+			name:    "signed with a key outside the JWKS",
+			token:   sign(jwt.SigningMethodRS256, "rsa-1", otherKey, claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "unknown key id",
+			token:   sign(jwt.SigningMethodRS256, "rsa-2", rsaKey, claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "expired",
+			token:   sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() })),
+			wantErr: true,
+		},
+		{
+			name:    "without expiry",
+			token:   sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(func(c jwt.MapClaims) { delete(c, "exp") })),
+			wantErr: true,
+		},
+		{
+			name:    "other issuer",
+			token:   sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(func(c jwt.MapClaims) { c["iss"] = "https://evil.example" })),
+			wantErr: true,
+		},
+		{
+			name:    "other audience",
+			token:   sign(jwt.SigningMethodRS256, "rsa-1", rsaKey, claims(func(c jwt.MapClaims) { c["aud"] = "other-service" })),
+			wantErr: true,
+		},
+		{
+			name:    "symmetric algorithm",
+			token:   sign(jwt.SigningMethodHS256, "rsa-1", []byte("secret"), claims(nil)),
+			wantErr: true,
+		},
+		{
+			name:    "not a token",
+			token:   "not-a-token",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := validator.Authenticate(tt.token)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, principal)
+		})
+	}
+}
+
+func TestAuthenticator(t *testing.T) {
+	apiKeys, err := ParseAPIKeys([]byte(`[{"id": "backoffice", "hash": "` + HashAPIKey("backoffice-key") +
+		`", "scopes": ["balance:read"]}]`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		authenticator *Authenticator
+		headers       map[string]string
+		want          Principal
+		wantErr       error
+	}{
+		{
+			name:          "API key",
+			authenticator: NewAuthenticator(apiKeys, nil),
+			headers:       map[string]string{APIKeyHeader: "backoffice-key"},
+			want:          Principal{Kind: KindAPIKey, ID: "backoffice", Scopes: []string{ScopeBalanceRead}},
+		},
+		{
+			name:          "wrong API key",
+			authenticator: NewAuthenticator(apiKeys, nil),
+			headers:       map[string]string{APIKeyHeader: "guess"},
+			wantErr:       ErrInvalidCredentials,
+		},
+		{
+			name:          "bearer token without JWKS",
+			authenticator: NewAuthenticator(apiKeys, nil),
+			headers:       map[string]string{"Authorization": "Bearer token"},
+			wantErr:       ErrInvalidCredentials,
+		},
+		{
+			name:          "basic authorization",
+			authenticator: NewAuthenticator(apiKeys, nil),
+			headers:       map[string]string{"Authorization": "Basic dXNlcjpwYXNz"},
+			wantErr:       ErrInvalidCredentials,
+		},
+		{
+			name:          "no credentials",
+			authenticator: NewAuthenticator(apiKeys, nil),
+			wantErr:       ErrNoCredentials,
+		},
+		{
+			name:    "nil authenticator rejects credentials",
+			headers: map[string]string{APIKeyHeader: "backoffice-key"},
+			wantErr: ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/user/1/balance", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+
+			principal, err := tt.authenticator.Authenticate(req)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, principal)
+		})
+	}
+}
+
+func testJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) []byte {
+	t.Helper()
+
+	encode := func(value *big.Int) string {
+		return base64.RawURLEncoding.EncodeToString(value.Bytes())
+	}
+
+	jwks, err := json.Marshal(map[string]any{
+		"keys": []map[string]string{
+			{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": encode(rsaKey.N), "e": encode(big.NewInt(int64(rsaKey.E)))},
+			{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": encode(ecKey.X), "y": encode(ecKey.Y)},
+			{"kty": "RSA", "kid": "enc-1", "use": "enc", "n": encode(rsaKey.N), "e": "AQAB"},
+		},
+	})
+	require.NoError(t, err)
+
+	return jwks
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+// Scopes grant access to groups of endpoints. ScopeAdmin grants all of them.
+const (
+	ScopeBalanceRead      = "balance:read"
+	ScopeTransactionRead  = "transaction:read"
+	ScopeTransactionWrite = "transaction:write"
+	ScopeAccountRead      = "account:read"
+	ScopeAccountWrite     = "account:write"
+	ScopeAdmin            = "admin"
+)
+
+// Kinds of principal, by how they authenticated.
+const (
+	KindSource = "source"
+	KindAPIKey = "api_key"
+	KindJWT    = "jwt"
+	// KindOperator is a person running the admin CLI, identified by the name they give.
+	KindOperator = "operator"
+)
+
+type contextKey struct{}
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	Kind   string
+	ID     string
+	Scopes []string
+}
+
+// KnownScopes lists every scope a credential may be granted.
+func KnownScopes() []string {
+	return []string{
+		ScopeBalanceRead, ScopeTransactionRead, ScopeTransactionWrite, ScopeAccountRead, ScopeAccountWrite, ScopeAdmin,
+	}
+}
+
+// SourcePrincipal is the principal of a request authenticated by its Source-Type. Sources move
+// money and read balances and transactions; managing accounts is left to credentialed back-office
+// callers, since without a signature verifier a Source-Type header is all a source has to send.
+func SourcePrincipal(sourceType string) Principal {
+	return Principal{
+		Kind:   KindSource,
+		ID:     sourceType,
+		Scopes: []string{ScopeBalanceRead, ScopeTransactionRead, ScopeTransactionWrite},
+	}
+}
+
+// OperatorPrincipal is the principal of an operator using the admin CLI. Access to the CLI is
+// access to the database, so it has every scope.
+func OperatorPrincipal(name string) Principal {
+	return Principal{
+		Kind:   KindOperator,
+		ID:     name,
+		Scopes: []string{ScopeAdmin},
+	}
+}
+
+func (p Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, ScopeAdmin) || slices.Contains(p.Scopes, scope)
+}
+
+// String identifies the principal as "kind:id", which is how it is recorded on transactions.
+func (p Principal) String() string {
+	return p.Kind + ":" + p.ID
+}
+
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	principal, ok := ctx.Value(contextKey{}).(Principal)
+
+	return principal, ok
+}
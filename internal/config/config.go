@@ -1,47 +1,277 @@
 package config
 
 import (
-	"context"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
-	log "github.com/sirupsen/logrus"
-	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/sdk/trace"
-
-	"github.com/TiPSYDiPSY/home-task/internal/util/env"
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/signing"
 )
 
 type PostgresDBConfig struct {
-	Username string
-	Password string
-	Database string
-	Host     string
-	Port     int
-	SSLMode  string
+	Host     string `koanf:"host"     validate:"required"`
+	Port     int    `koanf:"port"     validate:"min=1,max=65535"`
+	Username string `koanf:"username" validate:"required"`
+	Password string `koanf:"password"`
+	Database string `koanf:"name"     validate:"required"`
+	// SSLMode is passed to libpq: disable, allow, prefer, require, verify-ca or verify-full.
+	SSLMode string `koanf:"sslmode" validate:"oneof=disable allow prefer require verify-ca verify-full"`
+
+	MaxOpenConns    int           `koanf:"max_open_conns"     validate:"min=1"`
+	MaxIdleConns    int           `koanf:"max_idle_conns"     validate:"min=0"`
+	ConnMaxLifetime time.Duration `koanf:"conn_max_lifetime"  validate:"min=0"`
+	ConnMaxIdleTime time.Duration `koanf:"conn_max_idle_time" validate:"min=0"`
+	// ReadTimeout and WriteTimeout bound a single repository call, including waits for row locks.
+	ReadTimeout      time.Duration `koanf:"read_timeout"      validate:"gt=0"`
+	WriteTimeout     time.Duration `koanf:"write_timeout"     validate:"gt=0"`
+	MigrationTimeout time.Duration `koanf:"migration_timeout" validate:"gt=0"`
+	// ReconciliationTimeout bounds the ledger scan of a reconciliation run.
+	ReconciliationTimeout time.Duration `koanf:"reconciliation_timeout" validate:"gt=0"`
+	// SlowQueryThreshold is the duration above which queries are logged as slow.
+	SlowQueryThreshold time.Duration `koanf:"slow_query_threshold" validate:"gt=0"`
 }
 
-type ServerConfig struct {
-	Port                      string
-	DatabaseConnectionDetails PostgresDBConfig
+type HTTPConfig struct {
+	Port            int           `koanf:"port"             validate:"min=1,max=65535"`
+	ReadTimeout     time.Duration `koanf:"read_timeout"     validate:"gt=0"`
+	WriteTimeout    time.Duration `koanf:"write_timeout"    validate:"gt=0"`
+	IdleTimeout     time.Duration `koanf:"idle_timeout"     validate:"gt=0"`
+	ShutdownTimeout time.Duration `koanf:"shutdown_timeout" validate:"gt=0"`
+	// ShutdownDrainDelay is how long the server keeps serving with failing readiness after a
+	// shutdown signal, so load balancers can take it out of rotation first.
+	ShutdownDrainDelay time.Duration `koanf:"shutdown_drain_delay" validate:"min=0"`
+	// BodyLogging logs request and response bodies.
+	BodyLogging bool `koanf:"body_logging"`
+	// ValidateRequests rejects requests that do not match the OpenAPI specification.
+	ValidateRequests bool `koanf:"validate_requests"`
+	// ValidateResponses logs responses that do not match the OpenAPI specification.
+	ValidateResponses bool `koanf:"validate_responses"`
 }
 
-const (
-	TracingSampleRate = 0.1
-)
+type GRPCConfig struct {
+	// Enabled serves the gRPC API on its own port next to the HTTP API.
+	Enabled bool `koanf:"enabled"`
+	Port    int  `koanf:"port"    validate:"min=1,max=65535"`
+}
+
+type CurrencyConfig struct {
+	// Default is used when a request does not name a currency.
+	Default string `koanf:"default" validate:"len=3"`
+	// Definitions adds or overrides currencies as "CODE:EXPONENT" pairs, e.g. "XTS:4,JPY:0".
+	Definitions string `koanf:"definitions"`
+}
+
+type AmountConfig struct {
+	// Limits bounds amounts per Source-Type as "SOURCE:MIN:MAX" triples, e.g. "game:0.01:1000,payment::5000".
+	Limits string `koanf:"limits"`
+}
+
+type ReconciliationConfig struct {
+	// Interval between scheduled reconciliation runs. Zero disables the in-process job.
+	Interval time.Duration `koanf:"interval" validate:"min=0"`
+	// Repair writes adjustment transactions for the drift found by scheduled runs.
+	Repair bool `koanf:"repair"`
+}
+
+type HoldExpiryConfig struct {
+	// Interval between runs of the job that releases expired holds.
+	Interval time.Duration `koanf:"interval" validate:"gt=0"`
+}
+
+type AdjustmentConfig struct {
+	// FourEyes holds manual adjustments until a second operator approves them.
+	FourEyes bool `koanf:"four_eyes"`
+}
+
+type OutboxConfig struct {
+	// Sink is where the relay publishes outbox events: none, stdout, file or webhook. With none the
+	// relay does not run and events accumulate in the outbox until a sink is configured.
+	Sink string `koanf:"sink" validate:"oneof=none stdout file webhook"`
+	// File receives the events as JSON lines for the file sink.
+	File string `koanf:"file" validate:"required_if=Sink file"`
+	// WebhookURL receives each event as a JSON POST for the webhook sink.
+	WebhookURL     string        `koanf:"webhook_url"     validate:"required_if=Sink webhook,omitempty,url"`
+	WebhookTimeout time.Duration `koanf:"webhook_timeout" validate:"gt=0"`
+	// Interval between relay runs. A run keeps going while it finds full batches.
+	Interval  time.Duration `koanf:"interval"   validate:"gt=0"`
+	BatchSize int           `koanf:"batch_size" validate:"min=1,max=1000"`
+	// Lease is how long a claimed event is kept from other relays. It should cover publishing a
+	// whole batch, or events are delivered twice.
+	Lease time.Duration `koanf:"lease" validate:"gt=0"`
+	// MinBackoff is the delay before the first retry of a failed event. It doubles with every
+	// further attempt, up to MaxBackoff.
+	MinBackoff time.Duration `koanf:"min_backoff" validate:"gt=0"`
+	MaxBackoff time.Duration `koanf:"max_backoff" validate:"gt=0"`
+	// Retention is how long published events are kept. Zero keeps them forever.
+	Retention time.Duration `koanf:"retention" validate:"min=0"`
+}
 
-func NewServerConfig() *ServerConfig {
-	config := &ServerConfig{
-		Port: env.GetEnv("PORT", "8080"),
+type WebhookConfig struct {
+	// Enabled turns balance change events into deliveries to the webhook subscriptions, through the
+	// outbox relay, and runs the delivery worker.
+	Enabled bool `koanf:"enabled"`
+	// Interval between runs of the delivery worker. A run keeps going while it finds full batches.
+	Interval  time.Duration `koanf:"interval"   validate:"gt=0"`
+	BatchSize int           `koanf:"batch_size" validate:"min=1,max=1000"`
+	// Timeout bounds a single delivery, Lease the time a claimed batch is kept from other workers.
+	Timeout time.Duration `koanf:"timeout" validate:"gt=0"`
+	Lease   time.Duration `koanf:"lease"   validate:"gt=0"`
+	// MaxAttempts is the number of failed attempts after which a delivery is dead-lettered.
+	MaxAttempts int `koanf:"max_attempts" validate:"min=1"`
+	// MinBackoff is the delay before the first retry of a failed delivery. It doubles with every
+	// further attempt, up to MaxBackoff.
+	MinBackoff time.Duration `koanf:"min_backoff" validate:"gt=0"`
+	MaxBackoff time.Duration `koanf:"max_backoff" validate:"gt=0"`
+}
+
+type StreamConfig struct {
+	// Enabled serves the balance streams and feeds them from the outbox relay.
+	Enabled bool `koanf:"enabled"`
+	// Notifier shares the relayed events between replicas: postgres, over LISTEN/NOTIFY, or local,
+	// which only reaches the streams of the replica that relayed an event and so suits a single one.
+	Notifier string `koanf:"notifier" validate:"oneof=local postgres"`
+	// Heartbeat is the interval of the keep-alive messages of an idle stream.
+	Heartbeat time.Duration `koanf:"heartbeat" validate:"gt=0"`
+	// Buffer is how many changes a client may fall behind before its stream is closed.
+	Buffer int `koanf:"buffer" validate:"min=1,max=10000"`
+	// MaxReplay is how many missed changes a resumed stream replays. A client that missed more
+	// starts again from a snapshot.
+	MaxReplay int `koanf:"max_replay" validate:"min=1,max=10000"`
+}
+
+type SigningConfig struct {
+	// Enabled requires mutating requests to be signed by their source. Disabling it trusts the
+	// Source-Type header as sent and is only meant for local development.
+	Enabled bool `koanf:"enabled"`
+	// Secrets lists the HMAC secrets as "SOURCE:SECRET" pairs. Repeat a source to rotate its secret.
+	Secrets string `koanf:"secrets" validate:"required_if=Enabled true"`
+	// MaxSkew is how far a signature timestamp may be from the server clock.
+	MaxSkew time.Duration `koanf:"max_skew" validate:"gt=0"`
+}
+
+type AuthConfig struct {
+	// APIKeysFile is a JSON array of {"id", "hash", "scopes"} objects, hash being the hex SHA-256 of the key.
+	APIKeysFile string `koanf:"api_keys_file" validate:"omitempty,file"`
+	// JWKSFile holds the public keys bearer tokens are verified against.
+	JWKSFile string `koanf:"jwks_file" validate:"omitempty,file"`
+	// JWTIssuer and JWTAudience, when set, must match the iss and aud claims of bearer tokens.
+	JWTIssuer   string `koanf:"jwt_issuer"`
+	JWTAudience string `koanf:"jwt_audience"`
+}
+
+type MigrationConfig struct {
+	// OnStart applies pending migrations when the server starts.
+	OnStart bool `koanf:"on_start"`
+	// Seed creates the predefined demo users after migrating. Meant for local development only.
+	Seed bool `koanf:"seed"`
+}
+
+type ServerConfig struct {
+	HTTP                      HTTPConfig           `koanf:"http"`
+	GRPC                      GRPCConfig           `koanf:"grpc"`
+	DatabaseConnectionDetails PostgresDBConfig     `koanf:"database"`
+	Migration                 MigrationConfig      `koanf:"migration"`
+	Currency                  CurrencyConfig       `koanf:"currency"`
+	Amount                    AmountConfig         `koanf:"amount"`
+	Reconciliation            ReconciliationConfig `koanf:"reconciliation"`
+	HoldExpiry                HoldExpiryConfig     `koanf:"hold_expiry"`
+	Adjustment                AdjustmentConfig     `koanf:"adjustment"`
+	Outbox                    OutboxConfig         `koanf:"outbox"`
+	Webhooks                  WebhookConfig        `koanf:"webhooks"`
+	Stream                    StreamConfig         `koanf:"stream"`
+	Signing                   SigningConfig        `koanf:"signing"`
+	Auth                      AuthConfig           `koanf:"auth"`
+	Tracing                   TracingConfig        `koanf:"tracing"`
+}
+
+// Default returns the configuration used for every setting that no file, environment variable or
+// flag overrides.
+func Default() *ServerConfig {
+	return &ServerConfig{
+		HTTP: HTTPConfig{
+			Port:               8080,
+			ReadTimeout:        5 * time.Second,
+			WriteTimeout:       60 * time.Second,
+			IdleTimeout:        120 * time.Second,
+			ShutdownTimeout:    30 * time.Second,
+			ShutdownDrainDelay: 5 * time.Second,
+			BodyLogging:        true,
+		},
+		GRPC: GRPCConfig{
+			Enabled: true,
+			Port:    9090,
+		},
 		DatabaseConnectionDetails: PostgresDBConfig{
-			Username: env.GetEnv("DB_USER", "myuser"),
-			Password: env.GetEnv("DB_PASSWORD", "mypassword"),
-			Database: env.GetEnv("DB_NAME", "mydb"),
-			Host:     env.GetEnv("DB_HOST", "localhost"),
-			Port:     env.GetEnvInt("DB_PORT", "5432"),
+			Host:                  "localhost",
+			Port:                  5432,
+			Username:              "myuser",
+			Password:              "mypassword",
+			Database:              "mydb",
+			SSLMode:               "disable",
+			MaxOpenConns:          25,
+			MaxIdleConns:          10,
+			ConnMaxLifetime:       30 * time.Minute,
+			ConnMaxIdleTime:       5 * time.Minute,
+			ReadTimeout:           5 * time.Second,
+			WriteTimeout:          10 * time.Second,
+			MigrationTimeout:      5 * time.Minute,
+			ReconciliationTimeout: time.Minute,
+			SlowQueryThreshold:    200 * time.Millisecond,
+		},
+		Migration: MigrationConfig{
+			OnStart: true,
+		},
+		Currency: CurrencyConfig{
+			Default: currency.DefaultCode,
+		},
+		Reconciliation: ReconciliationConfig{
+			Interval: time.Hour,
+		},
+		HoldExpiry: HoldExpiryConfig{
+			Interval: time.Minute,
+		},
+		Outbox: OutboxConfig{
+			Sink:           OutboxSinkNone,
+			WebhookTimeout: 5 * time.Second,
+			Interval:       time.Second,
+			BatchSize:      100,
+			Lease:          time.Minute,
+			MinBackoff:     time.Second,
+			MaxBackoff:     5 * time.Minute,
+			Retention:      7 * 24 * time.Hour,
+		},
+		Webhooks: WebhookConfig{
+			Enabled:     true,
+			Interval:    time.Second,
+			BatchSize:   50,
+			Timeout:     5 * time.Second,
+			Lease:       5 * time.Minute,
+			MaxAttempts: 10,
+			MinBackoff:  10 * time.Second,
+			MaxBackoff:  time.Hour,
+		},
+		Stream: StreamConfig{
+			Enabled:   true,
+			Notifier:  StreamNotifierPostgres,
+			Heartbeat: 15 * time.Second,
+			Buffer:    64,
+			MaxReplay: 100,
+		},
+		Signing: SigningConfig{
+			Enabled: true,
+			MaxSkew: 5 * time.Minute,
+		},
+		Tracing: TracingConfig{
+			Exporter:     TracingExporterNone,
+			OTLPProtocol: OTLPProtocolGRPC,
+			SampleRatio:  0.1,
+			ServiceName:  "home-task",
 		},
 	}
-
-	return config
 }
 
 func (c PostgresDBConfig) DSN() string {
@@ -57,16 +287,76 @@ func (c PostgresDBConfig) getSSLMode() string {
 	return c.SSLMode
 }
 
-func InitTracer() func() {
-	tp := trace.NewTracerProvider(
-		trace.WithSampler(trace.TraceIDRatioBased(TracingSampleRate)),
+func (c CurrencyConfig) Registry() (*currency.Registry, error) {
+	definitions, err := currency.ParseList(c.Definitions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse currencies: %w", err)
+	}
+
+	registry, err := currency.NewRegistry(c.Default, definitions...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build currency registry: %w", err)
+	}
+
+	return registry, nil
+}
+
+func (c AmountConfig) Policy() (amount.Policy, error) {
+	limits, err := amount.ParseLimits(c.Limits)
+	if err != nil {
+		return amount.Policy{}, fmt.Errorf("failed to parse amount limits: %w", err)
+	}
+
+	return amount.NewPolicy(limits), nil
+}
+
+// Verifier builds the request signature verifier. It returns nil when signing is disabled.
+func (c SigningConfig) Verifier() (*signing.Verifier, error) {
+	if !c.Enabled {
+		return nil, nil //nolint: nilnil // Signing is disabled
+	}
+
+	secrets, err := signing.ParseSecrets(c.Secrets)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing secrets: %w", err)
+	}
+
+	if len(secrets) == 0 {
+		return nil, errors.New("request signing is enabled but SIGNING_SECRETS is empty")
+	}
+
+	return signing.NewVerifier(secrets, c.MaxSkew), nil
+}
+
+// Authenticator builds the back-office authenticator from the configured files. A mechanism without
+// a file is disabled.
+func (c AuthConfig) Authenticator() (*auth.Authenticator, error) {
+	var (
+		apiKeys *auth.APIKeyStore
+		tokens  *auth.JWTValidator
 	)
 
-	otel.SetTracerProvider(tp)
+	if c.APIKeysFile != "" {
+		data, err := os.ReadFile(c.APIKeysFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read API keys file: %w", err)
+		}
+
+		if apiKeys, err = auth.ParseAPIKeys(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.JWKSFile != "" {
+		data, err := os.ReadFile(c.JWKSFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %w", err)
+		}
 
-	return func() {
-		if err := tp.Shutdown(context.Background()); err != nil {
-			log.Printf("Error shutting down tracer provider: %v", err)
+		if tokens, err = auth.NewJWTValidator(data, c.JWTIssuer, c.JWTAudience); err != nil {
+			return nil, err
 		}
 	}
+
+	return auth.NewAuthenticator(apiKeys, tokens), nil
 }
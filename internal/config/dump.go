@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/structs"
+	"github.com/knadh/koanf/v2"
+)
+
+const redacted = "[REDACTED]"
+
+// secretKeys are masked by Dump.
+var secretKeys = []string{
+	"database.password",
+	"signing.secrets",
+}
+
+// Dump writes the configuration as YAML in the format Load reads, with secrets masked, so the
+// effective settings of a deployment can be inspected and shared.
+func (c *ServerConfig) Dump(w io.Writer) error {
+	k := koanf.New(keyDelimiter)
+	if err := k.Load(structs.Provider(c, structTag), nil); err != nil {
+		return fmt.Errorf("failed to read config: %w", err)
+	}
+
+	for _, key := range secretKeys {
+		if k.String(key) != "" {
+			if err := k.Set(key, redacted); err != nil {
+				return fmt.Errorf("failed to redact %s: %w", key, err)
+			}
+		}
+	}
+
+	// Durations are written as strings such as 5s rather than nanoseconds.
+	for key, value := range k.All() {
+		if duration, ok := value.(time.Duration); ok {
+			if err := k.Set(key, duration.String()); err != nil {
+				return fmt.Errorf("failed to format %s: %w", key, err)
+			}
+		}
+	}
+
+	out, err := k.Marshal(yaml.Parser())
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	if _, err := w.Write(out); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+
+	return nil
+}
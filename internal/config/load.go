@@ -0,0 +1,272 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/knadh/koanf/parsers/yaml"
+	"github.com/knadh/koanf/providers/env"
+	"github.com/knadh/koanf/providers/file"
+	"github.com/knadh/koanf/providers/posflag"
+	"github.com/knadh/koanf/providers/structs"
+	"github.com/knadh/koanf/v2"
+	"github.com/spf13/pflag"
+)
+
+const (
+	keyDelimiter = "."
+	structTag    = "koanf"
+
+	// ConfigFileEnv names the YAML file to load when --config is not given.
+	ConfigFileEnv = "CONFIG_FILE"
+
+	configFlag = "config"
+	setFlag    = "set"
+)
+
+var ErrInvalidConfig = errors.New("invalid configuration")
+
+// envKeys maps environment variables to config keys. The names predate the config file and are
+// kept so that existing deployments keep working.
+var envKeys = map[string]string{
+	"PORT":                    "http.port",
+	"HTTP_READ_TIMEOUT":       "http.read_timeout",
+	"HTTP_WRITE_TIMEOUT":      "http.write_timeout",
+	"HTTP_IDLE_TIMEOUT":       "http.idle_timeout",
+	"HTTP_SHUTDOWN_TIMEOUT":   "http.shutdown_timeout",
+	"SHUTDOWN_DRAIN_DELAY":    "http.shutdown_drain_delay",
+	"HTTP_BODY_LOGGING":       "http.body_logging",
+	"HTTP_VALIDATE_REQUESTS":  "http.validate_requests",
+	"HTTP_VALIDATE_RESPONSES": "http.validate_responses",
+
+	"GRPC_ENABLED": "grpc.enabled",
+	"GRPC_PORT":    "grpc.port",
+
+	"DB_HOST":                   "database.host",
+	"DB_PORT":                   "database.port",
+	"DB_USER":                   "database.username",
+	"DB_PASSWORD":               "database.password",
+	"DB_NAME":                   "database.name",
+	"DB_SSLMODE":                "database.sslmode",
+	"DB_MAX_OPEN_CONNS":         "database.max_open_conns",
+	"DB_MAX_IDLE_CONNS":         "database.max_idle_conns",
+	"DB_CONN_MAX_LIFETIME":      "database.conn_max_lifetime",
+	"DB_CONN_MAX_IDLE_TIME":     "database.conn_max_idle_time",
+	"DB_READ_TIMEOUT":           "database.read_timeout",
+	"DB_WRITE_TIMEOUT":          "database.write_timeout",
+	"DB_MIGRATION_TIMEOUT":      "database.migration_timeout",
+	"DB_RECONCILIATION_TIMEOUT": "database.reconciliation_timeout",
+	"DB_SLOW_QUERY_THRESHOLD":   "database.slow_query_threshold",
+
+	"DB_MIGRATE_ON_START": "migration.on_start",
+	"DB_SEED":             "migration.seed",
+
+	"DEFAULT_CURRENCY": "currency.default",
+	"CURRENCIES":       "currency.definitions",
+	"AMOUNT_LIMITS":    "amount.limits",
+
+	"RECONCILIATION_INTERVAL": "reconciliation.interval",
+	"RECONCILIATION_REPAIR":   "reconciliation.repair",
+	"HOLD_EXPIRY_INTERVAL":    "hold_expiry.interval",
+	"ADJUSTMENT_FOUR_EYES":    "adjustment.four_eyes",
+
+	"OUTBOX_SINK":            "outbox.sink",
+	"OUTBOX_FILE":            "outbox.file",
+	"OUTBOX_WEBHOOK_URL":     "outbox.webhook_url",
+	"OUTBOX_WEBHOOK_TIMEOUT": "outbox.webhook_timeout",
+	"OUTBOX_INTERVAL":        "outbox.interval",
+	"OUTBOX_BATCH_SIZE":      "outbox.batch_size",
+	"OUTBOX_LEASE":           "outbox.lease",
+	"OUTBOX_MIN_BACKOFF":     "outbox.min_backoff",
+	"OUTBOX_MAX_BACKOFF":     "outbox.max_backoff",
+	"OUTBOX_RETENTION":       "outbox.retention",
+
+	"WEBHOOKS_ENABLED":     "webhooks.enabled",
+	"WEBHOOK_INTERVAL":     "webhooks.interval",
+	"WEBHOOK_BATCH_SIZE":   "webhooks.batch_size",
+	"WEBHOOK_TIMEOUT":      "webhooks.timeout",
+	"WEBHOOK_LEASE":        "webhooks.lease",
+	"WEBHOOK_MAX_ATTEMPTS": "webhooks.max_attempts",
+	"WEBHOOK_MIN_BACKOFF":  "webhooks.min_backoff",
+	"WEBHOOK_MAX_BACKOFF":  "webhooks.max_backoff",
+
+	"STREAM_ENABLED":    "stream.enabled",
+	"STREAM_NOTIFIER":   "stream.notifier",
+	"STREAM_HEARTBEAT":  "stream.heartbeat",
+	"STREAM_BUFFER":     "stream.buffer",
+	"STREAM_MAX_REPLAY": "stream.max_replay",
+
+	"REQUEST_SIGNING_ENABLED": "signing.enabled",
+	"SIGNING_SECRETS":         "signing.secrets",
+	"SIGNATURE_MAX_SKEW":      "signing.max_skew",
+
+	"AUTH_API_KEYS_FILE": "auth.api_keys_file",
+	"AUTH_JWKS_FILE":     "auth.jwks_file",
+	"AUTH_JWT_ISSUER":    "auth.jwt_issuer",
+	"AUTH_JWT_AUDIENCE":  "auth.jwt_audience",
+
+	"TRACING_EXPORTER":       "tracing.exporter",
+	"TRACING_OTLP_PROTOCOL":  "tracing.otlp_protocol",
+	"TRACING_OTLP_ENDPOINT":  "tracing.otlp_endpoint",
+	"TRACING_FILE":           "tracing.file",
+	"TRACING_SAMPLE_RATIO":   "tracing.sample_ratio",
+	"SERVICE_NAME":           "tracing.service_name",
+	"DEPLOYMENT_ENVIRONMENT": "tracing.environment",
+}
+
+// flagKeys maps the shorthand command line flags to config keys. Any other key can be set with
+// --set key=value.
+var flagKeys = map[string]string{
+	"port":                 "http.port",
+	"body-logging":         "http.body_logging",
+	"db-host":              "database.host",
+	"db-port":              "database.port",
+	"db-user":              "database.username",
+	"db-name":              "database.name",
+	"db-sslmode":           "database.sslmode",
+	"tracing-exporter":     "tracing.exporter",
+	"tracing-sample-ratio": "tracing.sample_ratio",
+}
+
+// BindFlags adds the configuration flags to flags. Pass the same set to Load after parsing.
+func BindFlags(flags *pflag.FlagSet) {
+	flags.String(configFlag, "", "YAML config file, defaults to $"+ConfigFileEnv)
+	flags.StringArray(setFlag, nil, "set any config key, e.g. --set database.max_open_conns=50 (repeatable)")
+
+	flags.Int("port", 0, "HTTP listen port (http.port)")
+	flags.Bool("body-logging", false, "log request and response bodies (http.body_logging)")
+	flags.String("db-host", "", "database host (database.host)")
+	flags.Int("db-port", 0, "database port (database.port)")
+	flags.String("db-user", "", "database user (database.username)")
+	flags.String("db-name", "", "database name (database.name)")
+	flags.String("db-sslmode", "", "database SSL mode (database.sslmode)")
+	flags.String("tracing-exporter", "", "trace exporter: none, otlp, stdout or file (tracing.exporter)")
+	flags.Float64("tracing-sample-ratio", 0, "share of new traces that are sampled (tracing.sample_ratio)")
+}
+
+// Load builds the configuration from, in increasing order of precedence, the defaults, the YAML
+// file, the environment and the flags bound with BindFlags. flags may be nil. The result is
+// validated, and every problem found is reported in the returned error.
+func Load(flags *pflag.FlagSet) (*ServerConfig, error) {
+	k := koanf.New(keyDelimiter)
+
+	if err := k.Load(structs.Provider(Default(), structTag), nil); err != nil {
+		return nil, fmt.Errorf("failed to load config defaults: %w", err)
+	}
+
+	known := make(map[string]bool)
+	for _, key := range k.Keys() {
+		known[key] = true
+	}
+
+	if path := configFile(flags); path != "" {
+		if err := loadFile(k, path, known); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := k.Load(env.ProviderWithValue("", keyDelimiter, envValue), nil); err != nil {
+		return nil, fmt.Errorf("failed to load config from environment: %w", err)
+	}
+
+	if flags != nil {
+		if err := loadFlags(k, flags, known); err != nil {
+			return nil, err
+		}
+	}
+
+	var cfg ServerConfig
+	if err := k.UnmarshalWithConf("", &cfg, koanf.UnmarshalConf{Tag: structTag}); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+func configFile(flags *pflag.FlagSet) string {
+	if flags != nil {
+		if path, err := flags.GetString(configFlag); err == nil && path != "" {
+			return path
+		}
+	}
+
+	return os.Getenv(ConfigFileEnv)
+}
+
+// loadFile merges the YAML file at path into k. Unknown keys are rejected so that a typo does not
+// silently leave the default in place.
+func loadFile(k *koanf.Koanf, path string, known map[string]bool) error {
+	fileConfig := koanf.New(keyDelimiter)
+	if err := fileConfig.Load(file.Provider(path), yaml.Parser()); err != nil {
+		return fmt.Errorf("%w: failed to load config file %s: %w", ErrInvalidConfig, path, err)
+	}
+
+	var unknown []string
+
+	for _, key := range fileConfig.Keys() {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: unknown keys in %s: %s", ErrInvalidConfig, path, strings.Join(unknown, ", "))
+	}
+
+	if err := k.Merge(fileConfig); err != nil {
+		return fmt.Errorf("failed to merge config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// envValue maps an environment variable to its config key. Unknown and empty variables are skipped.
+func envValue(name, value string) (string, any) {
+	key, ok := envKeys[name]
+	if !ok || value == "" {
+		return "", nil
+	}
+
+	return key, value
+}
+
+func loadFlags(k *koanf.Koanf, flags *pflag.FlagSet, known map[string]bool) error {
+	provider := posflag.ProviderWithFlag(flags, keyDelimiter, k, func(flag *pflag.Flag) (string, any) {
+		key, ok := flagKeys[flag.Name]
+		if !ok {
+			return "", nil
+		}
+
+		return key, posflag.FlagVal(flags, flag)
+	})
+
+	if err := k.Load(provider, nil); err != nil {
+		return fmt.Errorf("failed to load config from flags: %w", err)
+	}
+
+	// A flag set without --set has no assignments.
+	assignments, _ := flags.GetStringArray(setFlag)
+
+	for _, assignment := range assignments {
+		key, value, found := strings.Cut(assignment, "=")
+		if !found {
+			return fmt.Errorf("%w: --set %q is not in key=value form", ErrInvalidConfig, assignment)
+		}
+
+		if !known[key] {
+			return fmt.Errorf("%w: --set %q names an unknown key", ErrInvalidConfig, assignment)
+		}
+
+		if err := k.Set(key, value); err != nil {
+			return fmt.Errorf("failed to set %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
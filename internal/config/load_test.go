@@ -0,0 +1,235 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSigningSecrets = "game:game-secret,server:server-secret"
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func parseFlags(t *testing.T, args ...string) *pflag.FlagSet {
+	t.Helper()
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	BindFlags(flags)
+	require.NoError(t, flags.Parse(args))
+
+	return flags
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	t.Setenv("SIGNING_SECRETS", testSigningSecrets)
+
+	cfg, err := Load(nil)
+	require.NoError(t, err)
+
+	want := Default()
+	want.Signing.Secrets = testSigningSecrets
+
+	assert.Equal(t, want, cfg)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := writeConfigFile(t, `
+http:
+  port: 9000
+  read_timeout: 7s
+  body_logging: false
+database:
+  host: db.internal
+  port: 6432
+  max_open_conns: 50
+signing:
+  secrets: game:game-secret
+tracing:
+  sample_ratio: 0.5
+`)
+
+	t.Setenv("DB_HOST", "db.env")
+	t.Setenv("DB_PORT", "7432")
+	t.Setenv("DB_MAX_IDLE_CONNS", "")
+	t.Setenv("TRACING_SAMPLE_RATIO", "0.25")
+
+	cfg, err := Load(parseFlags(t,
+		"--config", path,
+		"--db-port", "8432",
+		"--set", "database.max_open_conns=60",
+		"--set", "hold_expiry.interval=30s",
+	))
+	require.NoError(t, err)
+
+	// File only.
+	assert.Equal(t, 9000, cfg.HTTP.Port)
+	assert.Equal(t, 7*time.Second, cfg.HTTP.ReadTimeout)
+	assert.False(t, cfg.HTTP.BodyLogging)
+	assert.Equal(t, "game:game-secret", cfg.Signing.Secrets)
+	// Environment over file.
+	assert.Equal(t, "db.env", cfg.DatabaseConnectionDetails.Host)
+	assert.InDelta(t, 0.25, cfg.Tracing.SampleRatio, 0)
+	// Flags over environment and file.
+	assert.Equal(t, 8432, cfg.DatabaseConnectionDetails.Port)
+	assert.Equal(t, 60, cfg.DatabaseConnectionDetails.MaxOpenConns)
+	assert.Equal(t, 30*time.Second, cfg.HoldExpiry.Interval)
+	// Empty variables and unset flags keep the lower layers.
+	assert.Equal(t, 10, cfg.DatabaseConnectionDetails.MaxIdleConns)
+	assert.Equal(t, 60*time.Second, cfg.HTTP.WriteTimeout)
+}
+
+func TestLoad_ConfigFileFromEnv(t *testing.T) {
+	t.Setenv(ConfigFileEnv, writeConfigFile(t, "signing:\n  enabled: false\n"))
+
+	cfg, err := Load(parseFlags(t))
+	require.NoError(t, err)
+
+	assert.False(t, cfg.Signing.Enabled)
+}
+
+func TestLoad_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		env     map[string]string
+		args    []string
+		wantErr string
+	}{
+		{
+			name:    "malformed number",
+			env:     map[string]string{"DB_PORT": "abc"},
+			wantErr: "'database.port' cannot parse value as 'int'",
+		},
+		{
+			name:    "malformed duration",
+			env:     map[string]string{"HTTP_READ_TIMEOUT": "5 seconds"},
+			wantErr: "'http.read_timeout'",
+		},
+		{
+			name:    "unknown key in file",
+			file:    "database:\n  hots: db.internal\n",
+			wantErr: "unknown keys in",
+		},
+		{
+			name:    "unknown key in --set",
+			args:    []string{"--set", "http.prot=80"},
+			wantErr: `--set "http.prot=80" names an unknown key`,
+		},
+		{
+			name:    "malformed --set",
+			args:    []string{"--set", "http.port"},
+			wantErr: "not in key=value form",
+		},
+		{
+			name:    "gRPC port taken by HTTP",
+			env:     map[string]string{"GRPC_PORT": "8080"},
+			wantErr: "grpc.port must differ from http.port (8080)",
+		},
+		{
+			name:    "missing file",
+			args:    []string{"--config", "/nonexistent/config.yaml"},
+			wantErr: "failed to load config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("SIGNING_SECRETS", testSigningSecrets)
+
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			args := tt.args
+			if tt.file != "" {
+				args = append(args, "--config", writeConfigFile(t, tt.file))
+			}
+
+			_, err := Load(parseFlags(t, args...))
+
+			require.ErrorIs(t, err, ErrInvalidConfig, "got %v", err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestServerConfigValidate(t *testing.T) {
+	cfg := Default()
+	cfg.HTTP.Port = 70000
+	cfg.HTTP.ShutdownTimeout = 0
+	cfg.DatabaseConnectionDetails.SSLMode = "sometimes"
+	cfg.DatabaseConnectionDetails.MaxIdleConns = 50
+	cfg.Currency.Default = "EURO"
+	cfg.Amount.Limits = "game:abc"
+	cfg.Auth.APIKeysFile = "/nonexistent/keys.json"
+	cfg.Tracing.Exporter = TracingExporterFile
+	cfg.Tracing.SampleRatio = 2
+	cfg.Outbox.Sink = OutboxSinkWebhook
+	cfg.Outbox.MinBackoff = time.Hour
+
+	err := cfg.Validate()
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.ErrorIs(t, err, ErrInvalidConfig)
+
+	wantProblems := []string{
+		"http.port must be at most 65535, got 70000",
+		"http.shutdown_timeout must be greater than 0, got 0s",
+		`database.sslmode must be one of [disable allow prefer require verify-ca verify-full], got "sometimes"`,
+		`currency.default must be 3 characters long, got "EURO"`,
+		"outbox.webhook_url is required when outbox.sink is webhook",
+		"signing.secrets is required when signing.enabled is true",
+		`auth.api_keys_file must be an existing file, got "/nonexistent/keys.json"`,
+		"tracing.file is required when tracing.exporter is file",
+		"tracing.sample_ratio must be at most 1, got 2",
+		"database.max_idle_conns must not exceed database.max_open_conns (25), got 50",
+		"outbox.min_backoff must not exceed outbox.max_backoff (5m0s), got 1h0m0s",
+		"currency: failed to build currency registry",
+		"amount.limits: failed to parse amount limits",
+		"auth: failed to read API keys file",
+	}
+
+	require.Len(t, validationErr.Problems, len(wantProblems), "got %v", validationErr.Problems)
+
+	for i, want := range wantProblems {
+		assert.Contains(t, validationErr.Problems[i], want)
+	}
+}
+
+func TestServerConfigDump(t *testing.T) {
+	cfg := Default()
+	cfg.DatabaseConnectionDetails.Password = "db-password"
+	cfg.Signing.Secrets = testSigningSecrets
+
+	var out bytes.Buffer
+	require.NoError(t, cfg.Dump(&out))
+
+	dump := out.String()
+	assert.NotContains(t, dump, "db-password")
+	assert.NotContains(t, dump, "game-secret")
+	assert.Contains(t, dump, "password: '[REDACTED]'")
+	assert.Contains(t, dump, "secrets: '[REDACTED]'")
+	assert.Contains(t, dump, "read_timeout: 5s")
+
+	// The dump is a valid config file that loads back to the same settings, secrets aside.
+	t.Setenv("SIGNING_SECRETS", testSigningSecrets)
+	t.Setenv("DB_PASSWORD", "db-password")
+
+	loaded, err := Load(parseFlags(t, "--config", writeConfigFile(t, dump)))
+	require.NoError(t, err)
+	assert.Equal(t, cfg, loaded)
+}
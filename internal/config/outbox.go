@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+)
+
+// Outbox sinks.
+const (
+	OutboxSinkNone    = "none"
+	OutboxSinkStdout  = "stdout"
+	OutboxSinkFile    = "file"
+	OutboxSinkWebhook = "webhook"
+)
+
+const outboxFileMode = 0o600
+
+var ErrInvalidOutboxConfig = errors.New("invalid outbox configuration")
+
+// Publisher builds the configured event sink. It returns a nil publisher for the none sink, and
+// for the file sink also the file, which must be closed once the relay has stopped.
+func (c OutboxConfig) Publisher() (events.EventPublisher, io.Closer, error) {
+	switch c.Sink {
+	case OutboxSinkNone, "":
+		return nil, nil, nil
+	case OutboxSinkStdout:
+		return events.NewWriterPublisher(os.Stdout), nil, nil
+	case OutboxSinkFile:
+		if c.File == "" {
+			return nil, nil, fmt.Errorf("%w: the file sink needs outbox.file", ErrInvalidOutboxConfig)
+		}
+
+		file, err := os.OpenFile(c.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, outboxFileMode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open outbox file: %w", err)
+		}
+
+		return events.NewWriterPublisher(file), file, nil
+	case OutboxSinkWebhook:
+		if c.WebhookURL == "" {
+			return nil, nil, fmt.Errorf("%w: the webhook sink needs outbox.webhook_url", ErrInvalidOutboxConfig)
+		}
+
+		return events.NewWebhookPublisher(c.WebhookURL, &http.Client{Timeout: c.WebhookTimeout}), nil, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown sink %q, expected none, stdout, file or webhook",
+			ErrInvalidOutboxConfig, c.Sink)
+	}
+}
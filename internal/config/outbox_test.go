@@ -0,0 +1,65 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+)
+
+func TestOutboxConfigPublisher(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    OutboxConfig
+		wantType  events.EventPublisher
+		wantClose bool
+		wantErr   bool
+	}{
+		{name: "no sink", config: OutboxConfig{Sink: OutboxSinkNone}},
+		{name: "stdout", config: OutboxConfig{Sink: OutboxSinkStdout}, wantType: &events.WriterPublisher{}},
+		{
+			name:      "file",
+			config:    OutboxConfig{Sink: OutboxSinkFile, File: filepath.Join(t.TempDir(), "events.jsonl")},
+			wantType:  &events.WriterPublisher{},
+			wantClose: true,
+		},
+		{
+			name:     "webhook",
+			config:   OutboxConfig{Sink: OutboxSinkWebhook, WebhookURL: "http://localhost:9000/events"},
+			wantType: &events.WebhookPublisher{},
+		},
+		{name: "file sink without file", config: OutboxConfig{Sink: OutboxSinkFile}, wantErr: true},
+		{name: "webhook sink without URL", config: OutboxConfig{Sink: OutboxSinkWebhook}, wantErr: true},
+		{name: "unknown sink", config: OutboxConfig{Sink: "kafka"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher, closer, err := tt.config.Publisher()
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidOutboxConfig)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			if tt.wantType == nil {
+				assert.Nil(t, publisher)
+			} else {
+				assert.IsType(t, tt.wantType, publisher)
+			}
+
+			if tt.wantClose {
+				require.NotNil(t, closer)
+				assert.NoError(t, closer.Close())
+			} else {
+				assert.Nil(t, closer)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/TiPSYDiPSY/home-task/internal/stream"
+)
+
+// Balance stream notifiers.
+const (
+	StreamNotifierLocal    = "local"
+	StreamNotifierPostgres = "postgres"
+)
+
+var ErrInvalidStreamConfig = errors.New("invalid stream configuration")
+
+// NewNotifier builds the configured notifier. The postgres notifier connects to database, and must
+// be closed once the outbox relay has stopped publishing to it.
+func (c StreamConfig) NewNotifier(database PostgresDBConfig) (stream.Notifier, io.Closer, error) {
+	switch c.Notifier {
+	case StreamNotifierLocal:
+		return stream.NewLocalNotifier(), nil, nil
+	case StreamNotifierPostgres, "":
+		notifier := stream.NewPostgresNotifier(database.DSN(), stream.DefaultChannel)
+
+		return notifier, notifier, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown notifier %q, expected local or postgres",
+			ErrInvalidStreamConfig, c.Notifier)
+	}
+}
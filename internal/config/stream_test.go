@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/stream"
+)
+
+func TestStreamConfigNewNotifier(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    StreamConfig
+		wantType  stream.Notifier
+		wantClose bool
+		wantErr   bool
+	}{
+		{name: "local", config: StreamConfig{Notifier: StreamNotifierLocal}, wantType: &stream.LocalNotifier{}},
+		{
+			name:      "postgres",
+			config:    StreamConfig{Notifier: StreamNotifierPostgres},
+			wantType:  &stream.PostgresNotifier{},
+			wantClose: true,
+		},
+		{name: "unknown notifier", config: StreamConfig{Notifier: "redis"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier, closer, err := tt.config.NewNotifier(Default().DatabaseConnectionDetails)
+
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidStreamConfig)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantType, notifier)
+
+			if tt.wantClose {
+				require.NotNil(t, closer)
+				// Nothing was published, so there is no connection to close.
+				assert.NoError(t, closer.Close())
+			} else {
+				assert.Nil(t, closer)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+)
+
+// Trace exporters.
+const (
+	TracingExporterNone   = "none"
+	TracingExporterOTLP   = "otlp"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+)
+
+// OTLP transports.
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http/protobuf"
+)
+
+const tracingFileMode = 0o600
+
+var ErrInvalidTracingConfig = errors.New("invalid tracing configuration")
+
+type TracingConfig struct {
+	// Exporter is one of none, otlp, stdout or file. Spans are still created with none, so trace IDs
+	// show up in the logs and are propagated downstream.
+	Exporter string `koanf:"exporter" validate:"oneof=none otlp stdout file"`
+	// OTLPProtocol is grpc or http/protobuf.
+	OTLPProtocol string `koanf:"otlp_protocol" validate:"oneof=grpc http/protobuf"`
+	// OTLPEndpoint is the collector URL, e.g. http://otel-collector:4317. The scheme decides whether
+	// TLS is used. Empty falls back to the standard OTEL_EXPORTER_OTLP_* variables.
+	OTLPEndpoint string `koanf:"otlp_endpoint" validate:"omitempty,url"`
+	// File receives the spans as JSON lines for the file exporter.
+	File string `koanf:"file" validate:"required_if=Exporter file"`
+	// SampleRatio is the share of new traces that are sampled. Requests that arrive with a trace
+	// context follow the caller's sampling decision.
+	SampleRatio float64 `koanf:"sample_ratio" validate:"min=0,max=1"`
+	ServiceName string  `koanf:"service_name" validate:"required"`
+	// Environment is recorded as the deployment.environment.name resource attribute.
+	Environment string `koanf:"environment"`
+}
+
+// InitTracer installs the global tracer provider and the W3C trace context propagator. The
+// returned function flushes pending spans and must be called before the process exits.
+func (c TracingConfig) InitTracer(ctx context.Context, version string) (func(), error) {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return nil, fmt.Errorf("%w: sample ratio %v is not between 0 and 1", ErrInvalidTracingConfig, c.SampleRatio)
+	}
+
+	res, err := c.resource(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter, closeOutput, err := c.exporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []trace.TracerProviderOption{
+		trace.WithSampler(trace.ParentBased(trace.TraceIDRatioBased(c.SampleRatio))),
+		trace.WithResource(res),
+	}
+
+	if exporter != nil {
+		options = append(options, trace.WithBatcher(exporter))
+	}
+
+	tp := trace.NewTracerProvider(options...)
+
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return func() {
+		if err := tp.Shutdown(context.Background()); err != nil {
+			log.Printf("Error shutting down tracer provider: %v", err)
+		}
+
+		if closeOutput != nil {
+			if err := closeOutput.Close(); err != nil {
+				log.Printf("Error closing trace file: %v", err)
+			}
+		}
+	}, nil
+}
+
+func (c TracingConfig) resource(ctx context.Context, version string) (*resource.Resource, error) {
+	attributes := []resource.Option{
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+		resource.WithHost(),
+		resource.WithAttributes(semconv.ServiceName(c.ServiceName), semconv.ServiceVersion(version)),
+	}
+
+	if c.Environment != "" {
+		attributes = append(attributes, resource.WithAttributes(semconv.DeploymentEnvironmentName(c.Environment)))
+	}
+
+	res, err := resource.New(ctx, attributes...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	return res, nil
+}
+
+// exporter builds the configured span exporter. For the file exporter it also returns the file,
+// which is closed after the provider has flushed.
+func (c TracingConfig) exporter(ctx context.Context) (trace.SpanExporter, io.Closer, error) {
+	switch c.Exporter {
+	case TracingExporterNone, "":
+		return nil, nil, nil
+	case TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+
+		return exporter, nil, wrapExporterError(err)
+	case TracingExporterFile:
+		if c.File == "" {
+			return nil, nil, fmt.Errorf("%w: the file exporter needs tracing.file", ErrInvalidTracingConfig)
+		}
+
+		file, err := os.OpenFile(c.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, tracingFileMode)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			_ = file.Close()
+
+			return nil, nil, wrapExporterError(err)
+		}
+
+		return exporter, file, nil
+	case TracingExporterOTLP:
+		exporter, err := c.otlpExporter(ctx)
+
+		return exporter, nil, wrapExporterError(err)
+	default:
+		return nil, nil, fmt.Errorf("%w: unknown exporter %q, expected none, otlp, stdout or file",
+			ErrInvalidTracingConfig, c.Exporter)
+	}
+}
+
+func (c TracingConfig) otlpExporter(ctx context.Context) (trace.SpanExporter, error) {
+	switch c.OTLPProtocol {
+	case OTLPProtocolGRPC, "":
+		var options []otlptracegrpc.Option
+		if c.OTLPEndpoint != "" {
+			options = append(options, otlptracegrpc.WithEndpointURL(c.OTLPEndpoint))
+		}
+
+		return otlptracegrpc.New(ctx, options...)
+	case OTLPProtocolHTTP:
+		var options []otlptracehttp.Option
+		if c.OTLPEndpoint != "" {
+			options = append(options, otlptracehttp.WithEndpointURL(c.OTLPEndpoint))
+		}
+
+		return otlptracehttp.New(ctx, options...)
+	default:
+		return nil, fmt.Errorf("%w: unknown OTLP protocol %q, expected grpc or http/protobuf",
+			ErrInvalidTracingConfig, c.OTLPProtocol)
+	}
+}
+
+func wrapExporterError(err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestTracingConfigInitTracer(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  TracingConfig
+		wantErr bool
+	}{
+		{name: "no exporter", config: TracingConfig{Exporter: TracingExporterNone, SampleRatio: 0.1}},
+		{name: "stdout", config: TracingConfig{Exporter: TracingExporterStdout, SampleRatio: 1}},
+		{name: "OTLP over HTTP", config: TracingConfig{
+			Exporter: TracingExporterOTLP, OTLPProtocol: OTLPProtocolHTTP, OTLPEndpoint: "http://localhost:4318",
+		}},
+		{name: "OTLP over gRPC", config: TracingConfig{
+			Exporter: TracingExporterOTLP, OTLPProtocol: OTLPProtocolGRPC, OTLPEndpoint: "http://localhost:4317",
+		}},
+		{name: "unknown exporter", config: TracingConfig{Exporter: "jaeger"}, wantErr: true},
+		{name: "unknown OTLP protocol", config: TracingConfig{Exporter: TracingExporterOTLP, OTLPProtocol: "thrift"}, wantErr: true},
+		{name: "file exporter without file", config: TracingConfig{Exporter: TracingExporterFile}, wantErr: true},
+		{name: "ratio above one", config: TracingConfig{SampleRatio: 10}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shutdown, err := tt.config.InitTracer(context.Background(), "test")
+
+			if tt.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			shutdown()
+		})
+	}
+}
+
+func TestTracingConfigInitTracer_File(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "spans.json")
+
+	shutdown, err := TracingConfig{
+		Exporter:    TracingExporterFile,
+		File:        file,
+		SampleRatio: 1,
+		ServiceName: "home-task",
+		Environment: "test",
+	}.InitTracer(context.Background(), "1.2.3")
+	require.NoError(t, err)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "test_span")
+	span.End()
+	shutdown()
+
+	spans, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(spans), `"Name":"test_span"`)
+	assert.Contains(t, string(spans), `"Value":"home-task"`)
+	assert.Contains(t, string(spans), `"Value":"1.2.3"`)
+	assert.Contains(t, string(spans), `"Value":"test"`)
+}
@@ -0,0 +1,123 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/TiPSYDiPSY/home-task/internal/signing"
+)
+
+// ValidationError lists every problem found in a configuration, keyed by config path.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s:\n  - %s", ErrInvalidConfig, strings.Join(e.Problems, "\n  - "))
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidConfig
+}
+
+// Validate checks the field constraints and then builds the derived settings (currencies, amount
+// limits, signing secrets, authentication), so that a bad value fails at startup instead of on the
+// first request that needs it. It returns a *ValidationError.
+func (c *ServerConfig) Validate() error {
+	var problems []string
+
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get(structTag)
+	})
+
+	if err := validate.Struct(c); err != nil {
+		var validationErrors validator.ValidationErrors
+		if !errors.As(err, &validationErrors) {
+			return fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+		}
+
+		for _, fieldErr := range validationErrors {
+			problems = append(problems, fieldProblem(fieldErr))
+		}
+	}
+
+	if c.GRPC.Enabled && c.GRPC.Port == c.HTTP.Port {
+		problems = append(problems, fmt.Sprintf("grpc.port must differ from http.port (%d)", c.HTTP.Port))
+	}
+
+	if db := c.DatabaseConnectionDetails; db.MaxIdleConns > db.MaxOpenConns {
+		problems = append(problems, fmt.Sprintf("database.max_idle_conns must not exceed database.max_open_conns (%d), got %d",
+			db.MaxOpenConns, db.MaxIdleConns))
+	}
+
+	if outbox := c.Outbox; outbox.MinBackoff > outbox.MaxBackoff {
+		problems = append(problems, fmt.Sprintf("outbox.min_backoff must not exceed outbox.max_backoff (%s), got %s",
+			outbox.MaxBackoff, outbox.MinBackoff))
+	}
+
+	if webhooks := c.Webhooks; webhooks.MinBackoff > webhooks.MaxBackoff {
+		problems = append(problems, fmt.Sprintf("webhooks.min_backoff must not exceed webhooks.max_backoff (%s), got %s",
+			webhooks.MaxBackoff, webhooks.MinBackoff))
+	}
+
+	if _, err := c.Currency.Registry(); err != nil {
+		problems = append(problems, "currency: "+err.Error())
+	}
+
+	if _, err := c.Amount.Policy(); err != nil {
+		problems = append(problems, "amount.limits: "+err.Error())
+	}
+
+	if c.Signing.Secrets != "" {
+		if _, err := signing.ParseSecrets(c.Signing.Secrets); err != nil {
+			problems = append(problems, "signing.secrets: "+err.Error())
+		}
+	}
+
+	if _, err := c.Auth.Authenticator(); err != nil {
+		problems = append(problems, "auth: "+err.Error())
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+
+	return nil
+}
+
+// fieldProblem describes a failed constraint by config path, e.g. "database.port must be at most 65535".
+func fieldProblem(fe validator.FieldError) string {
+	// The namespace starts with the struct name, which is not part of the path.
+	_, key, _ := strings.Cut(fe.Namespace(), ".")
+
+	switch fe.Tag() {
+	case "required":
+		return key + " is required"
+	case "required_if":
+		field, value, _ := strings.Cut(fe.Param(), " ")
+		parent := key[:strings.LastIndex(key, ".")+1]
+
+		return fmt.Sprintf("%s is required when %s%s is %s", key, parent, strings.ToLower(field), value)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of [%s], got %q", key, fe.Param(), fe.Value())
+	case "min":
+		return fmt.Sprintf("%s must be at least %s, got %v", key, fe.Param(), fe.Value())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s, got %v", key, fe.Param(), fe.Value())
+	case "gt":
+		return fmt.Sprintf("%s must be greater than %s, got %v", key, fe.Param(), fe.Value())
+	case "len":
+		return fmt.Sprintf("%s must be %s characters long, got %q", key, fe.Param(), fe.Value())
+	case "file":
+		return fmt.Sprintf("%s must be an existing file, got %q", key, fe.Value())
+	case "url":
+		return fmt.Sprintf("%s must be a URL, got %q", key, fe.Value())
+	default:
+		return fmt.Sprintf("%s is invalid (%s)", key, fe.Tag())
+	}
+}
@@ -0,0 +1,127 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Currency describes how amounts of an ISO 4217 currency are stored: an amount of 1 unit is kept
+// as 10^Exponent minor units (cents for USD, yen for JPY, fils for KWD).
+type Currency struct {
+	Code     string
+	Exponent int32
+}
+
+const (
+	DefaultCode = "USD"
+
+	maxExponent = 8
+	codeLength  = 3
+)
+
+var ErrInvalidDefinition = errors.New("invalid currency definition")
+
+func builtinCurrencies() []Currency {
+	return []Currency{
+		{Code: "USD", Exponent: 2},
+		{Code: "EUR", Exponent: 2},
+		{Code: "GBP", Exponent: 2},
+		{Code: "JPY", Exponent: 0},
+		{Code: "KRW", Exponent: 0},
+		{Code: "BHD", Exponent: 3},
+		{Code: "KWD", Exponent: 3},
+		{Code: "TND", Exponent: 3},
+	}
+}
+
+// ToMinorUnits converts amount to minor units, truncating digits beyond the exponent.
+func (c Currency) ToMinorUnits(amount decimal.Decimal) int64 {
+	return amount.Shift(c.Exponent).IntPart()
+}
+
+// Format renders minor units as a fixed-point string with exactly Exponent decimal places.
+func (c Currency) Format(minorUnits int64) string {
+	return decimal.New(minorUnits, -c.Exponent).StringFixed(c.Exponent)
+}
+
+type Registry struct {
+	currencies  map[string]Currency
+	defaultCode string
+}
+
+// NewRegistry returns a registry of the built-in currencies extended or overridden by extra.
+func NewRegistry(defaultCode string, extra ...Currency) (*Registry, error) {
+	registry := &Registry{
+		currencies:  make(map[string]Currency),
+		defaultCode: strings.ToUpper(defaultCode),
+	}
+
+	for _, c := range append(builtinCurrencies(), extra...) {
+		if len(c.Code) != codeLength || c.Exponent < 0 || c.Exponent > maxExponent {
+			return nil, fmt.Errorf("%w: %s:%d", ErrInvalidDefinition, c.Code, c.Exponent)
+		}
+
+		c.Code = strings.ToUpper(c.Code)
+		registry.currencies[c.Code] = c
+	}
+
+	if _, ok := registry.currencies[registry.defaultCode]; !ok {
+		return nil, fmt.Errorf("%w: unknown default currency %q", ErrInvalidDefinition, defaultCode)
+	}
+
+	return registry, nil
+}
+
+// DefaultRegistry returns the built-in currencies with USD as the default.
+func DefaultRegistry() *Registry {
+	registry, err := NewRegistry(DefaultCode)
+	if err != nil {
+		panic(fmt.Sprintf("failed to build default currency registry: %v", err))
+	}
+
+	return registry
+}
+
+// Resolve looks up code case-insensitively. An empty code resolves to the default currency.
+func (r *Registry) Resolve(code string) (Currency, bool) {
+	if code == "" {
+		return r.Default(), true
+	}
+
+	c, ok := r.currencies[strings.ToUpper(code)]
+
+	return c, ok
+}
+
+func (r *Registry) Default() Currency {
+	return r.currencies[r.defaultCode]
+}
+
+// ParseList parses definitions in the form "USD:2,JPY:0,KWD:3".
+func ParseList(value string) ([]Currency, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	var currencies []Currency
+
+	for _, item := range strings.Split(value, ",") {
+		code, exponent, found := strings.Cut(strings.TrimSpace(item), ":")
+		if !found {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDefinition, item)
+		}
+
+		parsed, err := strconv.ParseInt(exponent, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidDefinition, item)
+		}
+
+		currencies = append(currencies, Currency{Code: strings.ToUpper(code), Exponent: int32(parsed)})
+	}
+
+	return currencies, nil
+}
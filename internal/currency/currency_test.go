@@ -0,0 +1,98 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrencyMinorUnits(t *testing.T) {
+	tests := []struct {
+		name       string
+		currency   Currency
+		amount     string
+		wantUnits  int64
+		wantString string
+	}{
+		{"two decimals", Currency{Code: "USD", Exponent: 2}, "10.50", 1050, "10.50"},
+		{"zero decimals", Currency{Code: "JPY", Exponent: 0}, "1500", 1500, "1500"},
+		{"three decimals", Currency{Code: "KWD", Exponent: 3}, "1.234", 1234, "1.234"},
+		{"negative", Currency{Code: "USD", Exponent: 2}, "-5.25", -525, "-5.25"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			units := tt.currency.ToMinorUnits(decimal.RequireFromString(tt.amount))
+
+			assert.Equal(t, tt.wantUnits, units)
+			assert.Equal(t, tt.wantString, tt.currency.Format(units))
+		})
+	}
+}
+
+func TestNewRegistry(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultCode string
+		extra       []Currency
+		wantErr     bool
+	}{
+		{name: "builtin default", defaultCode: "usd"},
+		{name: "custom currency as default", defaultCode: "XTS", extra: []Currency{{Code: "xts", Exponent: 4}}},
+		{name: "unknown default", defaultCode: "XXX", wantErr: true},
+		{name: "invalid code", defaultCode: "USD", extra: []Currency{{Code: "DOLLAR", Exponent: 2}}, wantErr: true},
+		{name: "negative exponent", defaultCode: "USD", extra: []Currency{{Code: "XTS", Exponent: -1}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry, err := NewRegistry(tt.defaultCode, tt.extra...)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidDefinition)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, registry.Default().Code)
+		})
+	}
+}
+
+func TestRegistryResolve(t *testing.T) {
+	registry := DefaultRegistry()
+
+	c, ok := registry.Resolve("")
+	assert.True(t, ok)
+	assert.Equal(t, Currency{Code: "USD", Exponent: 2}, c)
+
+	c, ok = registry.Resolve("jpy")
+	assert.True(t, ok)
+	assert.Equal(t, Currency{Code: "JPY", Exponent: 0}, c)
+
+	_, ok = registry.Resolve("XXX")
+	assert.False(t, ok)
+}
+
+func TestParseList(t *testing.T) {
+	currencies, err := ParseList("usd:2, JPY:0,KWD:3")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Currency{
+		{Code: "USD", Exponent: 2},
+		{Code: "JPY", Exponent: 0},
+		{Code: "KWD", Exponent: 3},
+	}, currencies)
+
+	currencies, err = ParseList("")
+	assert.NoError(t, err)
+	assert.Empty(t, currencies)
+
+	_, err = ParseList("USD")
+	assert.ErrorIs(t, err, ErrInvalidDefinition)
+
+	_, err = ParseList("USD:two")
+	assert.ErrorIs(t, err, ErrInvalidDefinition)
+}
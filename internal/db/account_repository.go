@@ -0,0 +1,177 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AccountRepository interface {
+	CreateUser(ctx context.Context, user User) (User, error)
+	GetUser(ctx context.Context, userID uint64) (User, error)
+	FreezeUser(ctx context.Context, userID uint64, scope string) (User, error)
+	UnfreezeUser(ctx context.Context, userID uint64) (User, error)
+	CloseUser(ctx context.Context, userID uint64) (User, error)
+}
+
+// CreateUser stores a new active user. It returns ErrExternalIDExists when user.ExternalID is
+// already taken.
+func (r *PostgresDBDataStore) CreateUser(ctx context.Context, user User) (User, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	user.Status = UserStatusActive
+
+	// Only a conflict on the external ID is skipped; any other one is an error rather than a taken ID.
+	result := r.db.WithContext(ctxWithTimeout).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "external_id"}}, DoNothing: true}).
+		Create(&user)
+	if result.Error != nil {
+		return User{}, fmt.Errorf("failed to create user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return User{}, ErrExternalIDExists
+	}
+
+	return user, nil
+}
+
+func (r *PostgresDBDataStore) GetUser(ctx context.Context, userID uint64) (User, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	var user User
+
+	result := r.db.WithContext(ctxWithTimeout).Where("id = ?", userID).Limit(1).Find(&user)
+	if result.Error != nil {
+		return User{}, fmt.Errorf("failed to find user: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return User{}, ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+// FreezeUser blocks debits, credits or both on an open account. Freezing a frozen account replaces its scope.
+func (r *PostgresDBDataStore) FreezeUser(ctx context.Context, userID uint64, scope string) (User, error) {
+	return r.updateUserStatus(ctx, userID, func(_ *gorm.DB, user *User) error {
+		if user.Status == UserStatusClosed {
+			return ErrAccountClosed
+		}
+
+		user.Status = UserStatusFrozen
+		user.FreezeScope = &scope
+
+		return nil
+	})
+}
+
+// UnfreezeUser reactivates a frozen account. It is a no-op for an active one.
+func (r *PostgresDBDataStore) UnfreezeUser(ctx context.Context, userID uint64) (User, error) {
+	return r.updateUserStatus(ctx, userID, func(_ *gorm.DB, user *User) error {
+		if user.Status == UserStatusClosed {
+			return ErrAccountClosed
+		}
+
+		user.Status = UserStatusActive
+		user.FreezeScope = nil
+
+		return nil
+	})
+}
+
+// CloseUser closes an account whose wallets are all empty, including reserved funds. Closing is
+// final and closing a closed account returns it unchanged.
+func (r *PostgresDBDataStore) CloseUser(ctx context.Context, userID uint64) (User, error) {
+	return r.updateUserStatus(ctx, userID, func(tx *gorm.DB, user *User) error {
+		if user.Status == UserStatusClosed {
+			return nil
+		}
+
+		var nonEmpty int64
+		if err := tx.Model(&Wallet{}).
+			Where("user_id = ? AND (balance <> 0 OR reserved <> 0)", userID).
+			Count(&nonEmpty).Error; err != nil {
+			return fmt.Errorf("failed to check wallet balances: %w", err)
+		}
+
+		if nonEmpty > 0 {
+			return ErrNonZeroBalance
+		}
+
+		closedAt := time.Now()
+		user.Status = UserStatusClosed
+		user.FreezeScope = nil
+		user.ClosedAt = &closedAt
+
+		return nil
+	})
+}
+
+// updateUserStatus locks the user row, lets change modify the user and saves the result. Balance
+// updates hold a share lock on the same row, so a status change waits for those in flight and
+// every later one sees the new status.
+func (r *PostgresDBDataStore) updateUserStatus(
+	ctx context.Context, userID uint64, change func(tx *gorm.DB, user *User) error,
+) (User, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var user User
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("id = ?", userID).
+			Limit(1).
+			Find(&user)
+		if result.Error != nil {
+			return fmt.Errorf("failed to lock user: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+
+		if err := change(tx, &user); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&user).Select("status", "freeze_scope", "closed_at", "updated_at").
+			Updates(&user).Error; err != nil {
+			return fmt.Errorf("failed to update user status: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return User{}, fmt.Errorf("failed to execute user status transaction: %w", err)
+	}
+
+	return user, nil
+}
+
+// checkAccountMovement returns an error when the user's status does not allow amount to be posted.
+// The share lock keeps the status from changing until the posting is committed.
+func (*PostgresDBDataStore) checkAccountMovement(tx *gorm.DB, userID uint64, amount int64) error {
+	var user User
+
+	result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthShare}).
+		Select("id", "status", "freeze_scope").
+		Where("id = ?", userID).
+		Limit(1).
+		Find(&user)
+	if result.Error != nil {
+		return fmt.Errorf("failed to check account status: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrUserNotFound
+	}
+
+	return user.CheckMovement(amount)
+}
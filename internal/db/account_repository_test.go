@@ -0,0 +1,44 @@
+package db
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUserConflict(t *testing.T) {
+	externalID := "player-42"
+
+	tests := []struct {
+		name     string
+		inserted bool
+		wantErr  error
+	}{
+		{name: "inserted", inserted: true},
+		{name: "external ID taken", wantErr: ErrExternalIDExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeConnector{respond: func(query string) ([]string, [][]driver.Value) {
+				// The insert returns no row when it was skipped.
+				if strings.HasPrefix(query, `INSERT INTO "users"`) && tt.inserted {
+					return []string{"id", "created_at"}, [][]driver.Value{{int64(4), time.Now()}}
+				}
+
+				return nil, nil
+			}}
+
+			_, err := newFakeDataStore(t, fake).CreateUser(t.Context(), User{ExternalID: &externalID})
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			inserts := fake.inserts(`INSERT INTO "users"`)
+			require.Len(t, inserts, 1)
+			assert.Contains(t, inserts[0].query, `ON CONFLICT ("external_id") DO NOTHING`)
+		})
+	}
+}
@@ -0,0 +1,329 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+type AdjustmentRepository interface {
+	CreateAdjustment(ctx context.Context, adjustment Adjustment, post bool) (Adjustment, error)
+	ApproveAdjustment(ctx context.Context, id uuid.UUID, approver, comment string) (Adjustment, error)
+	RejectAdjustment(ctx context.Context, id uuid.UUID, rejecter, comment string) (Adjustment, error)
+	GetAdjustment(ctx context.Context, id uuid.UUID) (Adjustment, error)
+	ListAdjustments(ctx context.Context, filter AdjustmentFilter) ([]Adjustment, error)
+}
+
+// AdjustmentFilter narrows down the adjustment list. Zero values mean "no filter".
+type AdjustmentFilter struct {
+	UserID uint64
+	Status string
+	Limit  int
+}
+
+const (
+	// HouseAccountManualAdjustment is the counterparty of adjustments made by operators.
+	HouseAccountManualAdjustment = "manual_adjustment"
+
+	// manualAdjustmentSourceType is recorded on manual adjustments, which do not come from a source.
+	manualAdjustmentSourceType = "server"
+)
+
+var (
+	ErrAdjustmentNotFound   = errors.ErrAdjustmentNotFound
+	ErrAdjustmentExists     = errors.ErrAdjustmentExists
+	ErrAdjustmentNotPending = errors.ErrAdjustmentNotPending
+	ErrSelfApproval         = errors.ErrSelfApproval
+)
+
+// CreateAdjustment records a requested adjustment and, when post is set, posts it at once. Requesting
+// the same TransactionID again with an identical payload returns the stored adjustment; a different
+// payload, or a TransactionID already used by another transaction, is ErrAdjustmentExists.
+func (r *PostgresDBDataStore) CreateAdjustment(ctx context.Context, adjustment Adjustment, post bool) (Adjustment, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	adjustment.Status = AdjustmentStatusPending
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var existing Adjustment
+
+		result := tx.Preload("Audit", orderAuditRecords).
+			Where("transaction_id = ?", adjustment.TransactionID).
+			Limit(1).
+			Find(&existing)
+		if result.Error != nil {
+			return fmt.Errorf("failed to check adjustment existence: %w", result.Error)
+		}
+
+		if result.RowsAffected > 0 {
+			if existing.UserID == adjustment.UserID && existing.Amount == adjustment.Amount &&
+				existing.Currency == adjustment.Currency && existing.ReasonCode == adjustment.ReasonCode &&
+				existing.Comment == adjustment.Comment {
+				adjustment = existing
+
+				return nil
+			}
+
+			return ErrAdjustmentExists
+		}
+
+		var transactions int64
+		if err := tx.Model(&Transaction{}).
+			Where("transaction_id = ?", adjustment.TransactionID).
+			Count(&transactions).Error; err != nil {
+			return fmt.Errorf("failed to check transaction existence: %w", err)
+		}
+
+		if transactions > 0 {
+			return ErrAdjustmentExists
+		}
+
+		if err := r.checkUserExists(tx, adjustment.UserID); err != nil {
+			return err
+		}
+
+		if err := tx.Omit("Audit").Create(&adjustment).Error; err != nil {
+			return fmt.Errorf("failed to create adjustment: %w", err)
+		}
+
+		if err := r.appendAdjustmentAudit(tx, &adjustment, AdjustmentActionRequested, adjustment.RequestedBy,
+			adjustment.Comment); err != nil {
+			return err
+		}
+
+		if !post {
+			return nil
+		}
+
+		return r.postAdjustment(tx, &adjustment, adjustment.RequestedBy)
+	}); err != nil {
+		return Adjustment{}, fmt.Errorf("failed to execute create adjustment transaction: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// ApproveAdjustment posts a pending adjustment on behalf of approver, who must not be the operator
+// that requested it. If the posting fails, for example because the wallet no longer covers a debit,
+// the adjustment stays pending.
+func (r *PostgresDBDataStore) ApproveAdjustment(
+	ctx context.Context, id uuid.UUID, approver, comment string,
+) (Adjustment, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var adjustment Adjustment
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if adjustment, err = r.findPendingAdjustment(tx, id); err != nil {
+			return err
+		}
+
+		if adjustment.RequestedBy == approver {
+			return ErrSelfApproval
+		}
+
+		if err := r.appendAdjustmentAudit(tx, &adjustment, AdjustmentActionApproved, approver, comment); err != nil {
+			return err
+		}
+
+		return r.postAdjustment(tx, &adjustment, approver)
+	}); err != nil {
+		return Adjustment{}, fmt.Errorf("failed to execute approve adjustment transaction: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// RejectAdjustment closes a pending adjustment without posting it. The requester may reject their
+// own adjustment to withdraw it.
+func (r *PostgresDBDataStore) RejectAdjustment(
+	ctx context.Context, id uuid.UUID, rejecter, comment string,
+) (Adjustment, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var adjustment Adjustment
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if adjustment, err = r.findPendingAdjustment(tx, id); err != nil {
+			return err
+		}
+
+		if err := r.decideAdjustment(tx, &adjustment, AdjustmentStatusRejected, rejecter); err != nil {
+			return err
+		}
+
+		return r.appendAdjustmentAudit(tx, &adjustment, AdjustmentActionRejected, rejecter, comment)
+	}); err != nil {
+		return Adjustment{}, fmt.Errorf("failed to execute reject adjustment transaction: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// GetAdjustment returns the adjustment with its audit trail, oldest step first.
+func (r *PostgresDBDataStore) GetAdjustment(ctx context.Context, id uuid.UUID) (Adjustment, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	var adjustment Adjustment
+
+	result := r.db.WithContext(ctxWithTimeout).
+		Preload("Audit", orderAuditRecords).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&adjustment)
+	if result.Error != nil {
+		return Adjustment{}, fmt.Errorf("failed to find adjustment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return Adjustment{}, ErrAdjustmentNotFound
+	}
+
+	return adjustment, nil
+}
+
+// ListAdjustments returns adjustments newest first, without their audit trails.
+func (r *PostgresDBDataStore) ListAdjustments(
+	ctx context.Context, filter AdjustmentFilter,
+) (adjustments []Adjustment, err error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	query := r.db.WithContext(ctxWithTimeout)
+
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	return adjustments, query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&adjustments).Error
+}
+
+func (*PostgresDBDataStore) findPendingAdjustment(tx *gorm.DB, id uuid.UUID) (Adjustment, error) {
+	var adjustment Adjustment
+
+	result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("id = ?", id).
+		Limit(1).
+		Find(&adjustment)
+	if result.Error != nil {
+		return Adjustment{}, fmt.Errorf("failed to find adjustment: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return Adjustment{}, ErrAdjustmentNotFound
+	}
+
+	if adjustment.Status != AdjustmentStatusPending {
+		return Adjustment{}, ErrAdjustmentNotPending
+	}
+
+	if err := tx.Scopes(orderAuditRecords).
+		Where("adjustment_id = ?", id).
+		Find(&adjustment.Audit).Error; err != nil {
+		return Adjustment{}, fmt.Errorf("failed to read adjustment audit: %w", err)
+	}
+
+	return adjustment, nil
+}
+
+// postAdjustment writes the adjustment transaction against HouseAccountManualAdjustment. The
+// transaction is recorded as requested by the operator that asked for the adjustment, the decision
+// by decidedBy.
+func (r *PostgresDBDataStore) postAdjustment(tx *gorm.DB, adjustment *Adjustment, decidedBy string) error {
+	reasonCode := adjustment.ReasonCode
+
+	transaction := Transaction{
+		UserID:        adjustment.UserID,
+		Amount:        adjustment.Amount,
+		Currency:      adjustment.Currency,
+		State:         StateAdjustment,
+		SourceType:    manualAdjustmentSourceType,
+		TransactionID: adjustment.TransactionID,
+		Principal:     adjustment.RequestedBy,
+		Reason:        &reasonCode,
+	}
+	transaction.Fingerprint = transaction.RequestFingerprint()
+
+	if err := r.applyTransaction(tx, transaction, HouseAccountManualAdjustment, journalDescriptionManual); err != nil {
+		return err
+	}
+
+	if err := r.decideAdjustment(tx, adjustment, AdjustmentStatusPosted, decidedBy); err != nil {
+		return err
+	}
+
+	return r.appendAdjustmentAudit(tx, adjustment, AdjustmentActionPosted, decidedBy, "")
+}
+
+func (*PostgresDBDataStore) decideAdjustment(tx *gorm.DB, adjustment *Adjustment, status, decidedBy string) error {
+	now := time.Now()
+
+	adjustment.Status = status
+	adjustment.DecidedBy = &decidedBy
+	adjustment.DecidedAt = &now
+
+	if err := tx.Omit("Audit").Save(adjustment).Error; err != nil {
+		return fmt.Errorf("failed to update adjustment: %w", err)
+	}
+
+	return nil
+}
+
+func (*PostgresDBDataStore) appendAdjustmentAudit(
+	tx *gorm.DB, adjustment *Adjustment, action, actor, comment string,
+) error {
+	record := AdjustmentAuditRecord{
+		AdjustmentID: adjustment.ID,
+		Action:       action,
+		Actor:        actor,
+		Comment:      comment,
+	}
+
+	if err := tx.Create(&record).Error; err != nil {
+		return fmt.Errorf("failed to write adjustment audit record: %w", err)
+	}
+
+	adjustment.Audit = append(adjustment.Audit, record)
+
+	return nil
+}
+
+func (*PostgresDBDataStore) checkUserExists(tx *gorm.DB, userID uint64) error {
+	var count int64
+	if err := tx.Model(&User{}).Where("id = ?", userID).Count(&count).Error; err != nil {
+		return fmt.Errorf("failed to check user existence: %w", err)
+	}
+
+	if count == 0 {
+		return ErrUserNotFound
+	}
+
+	return nil
+}
+
+func orderAuditRecords(db *gorm.DB) *gorm.DB {
+	return db.Order("created_at").Order("id")
+}
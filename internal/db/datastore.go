@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/TiPSYDiPSY/home-task/internal/db/gorm_logger"
+	"github.com/TiPSYDiPSY/home-task/internal/db/gorm_tracing"
 
 	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
 
 	"github.com/sirupsen/logrus"
 	"gorm.io/driver/postgres"
@@ -16,42 +18,57 @@ import (
 
 type PostgresDBDataStore struct {
 	db *gorm.DB
-}
 
-const (
-	MaxOpenConns    = 25
-	MaxIdleConns    = 10
-	ConnMaxLifetime = 30 * time.Minute
-	ConnMaxIdleTime = 5 * time.Minute
-)
+	readTimeout           time.Duration
+	writeTimeout          time.Duration
+	migrationTimeout      time.Duration
+	reconciliationTimeout time.Duration
+}
 
 func NewPostgresDBDataStore(ctx context.Context, c config.PostgresDBConfig) (*PostgresDBDataStore, error) {
 	log := logrus.WithContext(ctx)
 
 	log.Info("Connecting to DB...")
 
+	gormLogger := gorm_logger.New()
+	gormLogger.SlowThreshold = c.SlowQueryThreshold
+
 	db, err := gorm.Open(postgres.Open(c.DSN()), &gorm.Config{
-		Logger: gorm_logger.New(),
+		Logger: gormLogger,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	if err := db.Use(gorm_tracing.New()); err != nil {
+		return nil, fmt.Errorf("failed to register tracing plugin: %w", err)
+	}
+
 	sqlDB, err := db.DB()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get underlying sql.DB: %w", err)
 	}
 
-	sqlDB.SetMaxOpenConns(MaxOpenConns)
-	sqlDB.SetMaxIdleConns(MaxIdleConns)
-	sqlDB.SetConnMaxLifetime(ConnMaxLifetime)
-	sqlDB.SetConnMaxIdleTime(ConnMaxIdleTime)
+	sqlDB.SetMaxOpenConns(c.MaxOpenConns)
+	sqlDB.SetMaxIdleConns(c.MaxIdleConns)
+	sqlDB.SetConnMaxLifetime(c.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(c.ConnMaxIdleTime)
 
 	if err := sqlDB.Ping(); err != nil {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
+	if err := metrics.RegisterDBStats(sqlDB, c.Database); err != nil {
+		return nil, err
+	}
+
 	log.Info("Successfully connected to DB")
 
-	return &PostgresDBDataStore{db: db}, nil
+	return &PostgresDBDataStore{
+		db:                    db,
+		readTimeout:           c.ReadTimeout,
+		writeTimeout:          c.WriteTimeout,
+		migrationTimeout:      c.MigrationTimeout,
+		reconciliationTimeout: c.ReconciliationTimeout,
+	}, nil
 }
@@ -1,26 +1,367 @@
 package db
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 type User struct {
-	ID        uint64 `gorm:"primaryKey"`
-	Balance   int64  `gorm:"not null;default:0;check:balance >= 0"`
+	ID uint64 `gorm:"primaryKey"`
+	// ExternalID is the caller's own identifier for the account, if it has one.
+	ExternalID *string `gorm:"type:varchar(64);uniqueIndex"`
+	Status     string  `gorm:"type:varchar(10);not null;default:'active'"`
+	// FreezeScope is the direction of money movement blocked while the account is frozen.
+	FreezeScope *string `gorm:"type:varchar(10)"`
+	ClosedAt    *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Wallets      []Wallet      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Transactions []Transaction `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+	Holds        []Hold        `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+// CheckMovement reports whether amount may be posted to the user's wallets: a positive amount is
+// a credit, a negative one a debit. It returns ErrAccountClosed or ErrAccountFrozen when it may not.
+func (u User) CheckMovement(amount int64) error {
+	switch u.Status {
+	case UserStatusClosed:
+		return ErrAccountClosed
+	case UserStatusFrozen:
+		scope := FreezeScopeAll
+		if u.FreezeScope != nil {
+			scope = *u.FreezeScope
+		}
+
+		if scope == FreezeScopeAll ||
+			(scope == FreezeScopeDebits && amount < 0) ||
+			(scope == FreezeScopeCredits && amount > 0) {
+			return ErrAccountFrozen
+		}
+	}
+
+	return nil
+}
+
+// Wallet holds a user's balance in one currency, in minor units of that currency.
+type Wallet struct {
+	ID       uint64 `gorm:"primaryKey"`
+	UserID   uint64 `gorm:"not null;uniqueIndex:idx_wallets_user_currency,priority:1"`
+	Currency string `gorm:"type:varchar(3);not null;uniqueIndex:idx_wallets_user_currency,priority:2"`
+	Balance  int64  `gorm:"not null;default:0;check:balance >= 0"`
+	// Reserved is the part of Balance locked by active holds. Debits may only use Balance - Reserved.
+	Reserved  int64 `gorm:"not null;default:0;check:reserved >= 0 AND reserved <= balance"`
 	CreatedAt time.Time
 	UpdatedAt time.Time
+}
 
-	Transactions []Transaction `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+func (w Wallet) Available() int64 {
+	return w.Balance - w.Reserved
+}
+
+// Wallet returns the user's wallet in currencyCode, or an empty one when the user has none yet.
+func (u User) Wallet(currencyCode string) Wallet {
+	for _, wallet := range u.Wallets {
+		if wallet.Currency == currencyCode {
+			return wallet
+		}
+	}
+
+	return Wallet{UserID: u.ID, Currency: currencyCode}
 }
 
 type Transaction struct {
 	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	UserID        uint64    `gorm:"not null"`
+	UserID        uint64    `gorm:"not null;index:idx_transactions_user_processed,priority:1"`
 	Amount        int64     `gorm:"not null"`
-	State         string    `gorm:"type:varchar(4);not null"`
+	Currency      string    `gorm:"type:varchar(3);not null;default:'USD'"`
+	State         string    `gorm:"type:varchar(10);not null"`
 	SourceType    string    `gorm:"type:varchar(10);not null"`
 	TransactionID string    `gorm:"uniqueIndex;not null"`
-	ProcessedAt   time.Time `gorm:"not null;default:now()"`
+	ProcessedAt   time.Time `gorm:"not null;default:now();index:idx_transactions_user_processed,priority:2,sort:desc"`
+	// ReversalOf links a compensating entry to the TransactionID it reverses.
+	// The unique index guarantees a transaction is reversed at most once.
+	ReversalOf *string `gorm:"uniqueIndex"`
+	// Fingerprint identifies the request payload, so a retry with the same TransactionID
+	// can be told apart from a conflicting reuse of the ID.
+	Fingerprint string `gorm:"type:varchar(64)"`
+	// Principal is the authenticated caller that requested the transaction, as "kind:id".
+	// It is not part of the fingerprint, so a retry by another caller still replays.
+	Principal string `gorm:"type:varchar(128)"`
+	// Reason is the reason code of a manual adjustment.
+	Reason *string `gorm:"type:varchar(255)"`
+}
+
+// Adjustment is an operator's request to credit (positive Amount) or debit a user by hand. With
+// four-eyes approval it stays pending until a second operator approves or rejects it; otherwise it
+// is posted as soon as it is requested. The posted transaction uses its TransactionID.
+type Adjustment struct {
+	ID            uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID        uint64    `gorm:"not null;index"`
+	TransactionID string    `gorm:"type:varchar(255);uniqueIndex;not null"`
+	Amount        int64     `gorm:"not null;check:amount <> 0"`
+	Currency      string    `gorm:"type:varchar(3);not null"`
+	ReasonCode    string    `gorm:"type:varchar(32);not null"`
+	Comment       string    `gorm:"type:text;not null"`
+	Status        string    `gorm:"type:varchar(10);not null;index"`
+	// RequestedBy and DecidedBy are principals as "kind:id".
+	RequestedBy string  `gorm:"type:varchar(128);not null"`
+	DecidedBy   *string `gorm:"type:varchar(128)"`
+	DecidedAt   *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+
+	Audit []AdjustmentAuditRecord `gorm:"foreignKey:AdjustmentID"`
+}
+
+// AdjustmentAuditRecord is one step in the life of an Adjustment. Records are only ever inserted:
+// a database trigger rejects updates and deletes.
+type AdjustmentAuditRecord struct {
+	ID           uint64    `gorm:"primaryKey"`
+	AdjustmentID uuid.UUID `gorm:"type:uuid;not null;index"`
+	Action       string    `gorm:"type:varchar(16);not null"`
+	Actor        string    `gorm:"type:varchar(128);not null"`
+	Comment      string    `gorm:"type:text;not null;default:''"`
+	CreatedAt    time.Time
+}
+
+// OutboxEvent is a domain event waiting to be published, or already published, by the outbox relay.
+// It is written in the same database transaction as the change it describes, so an event exists
+// exactly when the change was committed.
+type OutboxEvent struct {
+	ID uint64 `gorm:"primaryKey"`
+	// EventID identifies the event to consumers, which see it again when a delivery is retried.
+	EventID   uuid.UUID `gorm:"type:uuid;uniqueIndex;not null"`
+	EventType string    `gorm:"type:varchar(64);not null"`
+	// AggregateKey names the entity the event is about, e.g. the user, for sinks that partition by key.
+	AggregateKey string `gorm:"type:varchar(64);not null"`
+	Payload      string `gorm:"type:jsonb;not null"`
+	// Attempts counts the claims by the relay. A claimed event is not offered again before NextAttemptAt.
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null"`
+	LastError     *string   `gorm:"type:text"`
+	PublishedAt   *time.Time
+	CreatedAt     time.Time
+}
+
+// BalanceChange is the payload of EventTypeBalanceChanged. Amounts are in minor units of Currency;
+// Amount is signed like the balance change, and Balance and Reserved are the wallet after it. Hold
+// changes have a zero Amount and the hold status as State.
+type BalanceChange struct {
+	UserID        uint64  `json:"user_id"`
+	Currency      string  `json:"currency"`
+	TransactionID string  `json:"transaction_id"`
+	State         string  `json:"state"`
+	SourceType    string  `json:"source_type"`
+	Amount        int64   `json:"amount"`
+	Balance       int64   `json:"balance"`
+	Reserved      int64   `json:"reserved"`
+	ReversalOf    *string `json:"reversal_of,omitempty"`
+	Principal     string  `json:"principal,omitempty"`
+	Reason        *string `json:"reason,omitempty"`
+}
+
+// WebhookSubscription is a partner endpoint that receives the balance change events matching its
+// filters. The filters are comma-separated lists; an empty one matches every value.
+type WebhookSubscription struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	URL         string    `gorm:"type:varchar(2048);not null"`
+	Description string    `gorm:"type:varchar(255);not null;default:''"`
+	// Secret signs the deliveries. It is only shown to the operator that creates the subscription.
+	Secret      string `gorm:"type:varchar(128);not null"`
+	SourceTypes string `gorm:"type:varchar(255);not null;default:''"`
+	States      string `gorm:"type:varchar(255);not null;default:''"`
+	Currencies  string `gorm:"type:varchar(255);not null;default:''"`
+	// MinAmount is the smallest absolute amount that matches, as a decimal in major units of the
+	// event's currency. Empty matches any amount.
+	MinAmount string `gorm:"type:varchar(64);not null;default:''"`
+	// Active subscriptions receive new events; the deliveries of paused ones wait until they resume.
+	Active    bool   `gorm:"not null;default:true"`
+	CreatedBy string `gorm:"type:varchar(128);not null"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// WebhookDelivery is one event on its way to one subscription, and the log of how that went. A
+// delivery that fails too often is dead-lettered and only retried when an operator asks for it.
+type WebhookDelivery struct {
+	ID             uuid.UUID            `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	SubscriptionID uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_subscription_event,priority:1"`
+	Subscription   *WebhookSubscription `gorm:"foreignKey:SubscriptionID"`
+	EventID        uuid.UUID            `gorm:"type:uuid;not null;uniqueIndex:idx_webhook_deliveries_subscription_event,priority:2"`
+	EventType      string               `gorm:"type:varchar(64);not null"`
+	// Payload is the event envelope exactly as it is POSTed and signed.
+	Payload        string    `gorm:"type:jsonb;not null"`
+	Status         string    `gorm:"type:varchar(10);not null"`
+	Attempts       int       `gorm:"not null;default:0"`
+	NextAttemptAt  time.Time `gorm:"not null"`
+	LastStatusCode *int
+	LastError      *string `gorm:"type:text"`
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Hold is a stake reserved from a user's balance until the bet is settled or released.
+type Hold struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	UserID     uint64    `gorm:"not null;index"`
+	HoldID     string    `gorm:"uniqueIndex;not null"`
+	SourceType string    `gorm:"type:varchar(10);not null"`
+	Currency   string    `gorm:"type:varchar(3);not null;default:'USD'"`
+	Amount     int64     `gorm:"not null;check:amount > 0"`
+	Payout     int64     `gorm:"not null;default:0"`
+	Status     string    `gorm:"type:varchar(10);not null;index:idx_holds_status_expires,priority:1"`
+	ExpiresAt  time.Time `gorm:"not null;index:idx_holds_status_expires,priority:2"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// SamePlacement reports whether other places the same stake as h, so that placing it again is a
+// retry. The expiry is derived from the time of the request and left out.
+func (h Hold) SamePlacement(other Hold) bool {
+	return h.UserID == other.UserID && h.Amount == other.Amount &&
+		h.Currency == other.Currency && h.SourceType == other.SourceType
+}
+
+// CheckActive returns ErrHoldNotActive unless the hold is reserved and not yet past ExpiresAt.
+// An expired hold the expirer has not reached yet is already out of play.
+func (h Hold) CheckActive(now time.Time) error {
+	if h.Status != HoldStatusReserved || now.After(h.ExpiresAt) {
+		return ErrHoldNotActive
+	}
+
+	return nil
+}
+
+// HouseAccount is the counterparty of user postings: one per Source-Type and currency, plus system
+// accounts such as HouseAccountOpening. Its balance is derived from postings when needed rather
+// than cached, so that every transaction of a source does not contend on the same row.
+type HouseAccount struct {
+	ID        uint64 `gorm:"primaryKey"`
+	Name      string `gorm:"type:varchar(32);not null;uniqueIndex:idx_house_accounts_name_currency,priority:1"`
+	Currency  string `gorm:"type:varchar(3);not null;uniqueIndex:idx_house_accounts_name_currency,priority:2"`
+	CreatedAt time.Time
+}
+
+// JournalEntry records one movement of money. Its postings always sum to zero per currency.
+type JournalEntry struct {
+	ID uuid.UUID `gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	// TransactionID links the entry to the API transaction that caused it. Entries without one,
+	// such as opening balances, are system entries.
+	TransactionID *uuid.UUID   `gorm:"type:uuid;uniqueIndex"`
+	Transaction   *Transaction `gorm:"foreignKey:TransactionID"`
+	Description   string       `gorm:"type:varchar(64);not null"`
+	CreatedAt     time.Time
+
+	Postings []Posting `gorm:"foreignKey:JournalEntryID;constraint:OnDelete:CASCADE"`
+}
+
+// Posting credits (positive Amount) or debits (negative Amount) exactly one account:
+// either a user Wallet or a HouseAccount.
+type Posting struct {
+	ID             uint64        `gorm:"primaryKey"`
+	JournalEntryID uuid.UUID     `gorm:"type:uuid;not null;index"`
+	WalletID       *uint64       `gorm:"index"`
+	Wallet         *Wallet       `gorm:"foreignKey:WalletID"`
+	HouseAccountID *uint64       `gorm:"index;check:chk_postings_single_account,(wallet_id IS NULL) <> (house_account_id IS NULL)"`
+	HouseAccount   *HouseAccount `gorm:"foreignKey:HouseAccountID"`
+	Amount         int64         `gorm:"not null"`
+	Currency       string        `gorm:"type:varchar(3);not null"`
+	CreatedAt      time.Time
+}
+
+// Balanced reports whether the postings of the entry sum to zero in every currency.
+func (e JournalEntry) Balanced() bool {
+	sums := make(map[string]int64)
+	for _, posting := range e.Postings {
+		sums[posting.Currency] += posting.Amount
+	}
+
+	for _, sum := range sums {
+		if sum != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+const (
+	// HouseAccountOpening balances the wallets that already had money before the ledger existed.
+	HouseAccountOpening = "opening"
+)
+
+const (
+	UserStatusActive = "active"
+	UserStatusFrozen = "frozen"
+	UserStatusClosed = "closed"
+
+	FreezeScopeDebits  = "debits"
+	FreezeScopeCredits = "credits"
+	FreezeScopeAll     = "all"
+)
+
+const (
+	AdjustmentStatusPending  = "pending"
+	AdjustmentStatusPosted   = "posted"
+	AdjustmentStatusRejected = "rejected"
+
+	AdjustmentActionRequested = "requested"
+	AdjustmentActionApproved  = "approved"
+	AdjustmentActionRejected  = "rejected"
+	AdjustmentActionPosted    = "posted"
+)
+
+const (
+	// EventTypeBalanceChanged is written for every transaction that moves money in or out of a wallet,
+	// and for every hold that is placed, released or expires.
+	EventTypeBalanceChanged = "balance.changed"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead"
+)
+
+const (
+	HoldStatusReserved = "reserved"
+	HoldStatusSettled  = "settled"
+	HoldStatusReleased = "released"
+	HoldStatusExpired  = "expired"
+)
+
+// RequestFingerprint hashes the fields a client controls for a given TransactionID.
+func (t Transaction) RequestFingerprint() string {
+	reversalOf := ""
+	if t.ReversalOf != nil {
+		reversalOf = *t.ReversalOf
+	}
+
+	payload := strings.Join([]string{
+		strconv.FormatUint(t.UserID, decimalBase),
+		t.State,
+		t.SourceType,
+		t.Currency,
+		strconv.FormatInt(t.Amount, decimalBase),
+		reversalOf,
+	}, "|")
+
+	sum := sha256.Sum256([]byte(payload))
+
+	return hex.EncodeToString(sum[:])
 }
+
+const (
+	StateReversal = "reversal"
+	// StateAdjustment marks transactions written by balance reconciliation and manual adjustments.
+	StateAdjustment = "adjustment"
+
+	decimalBase = 10
+)
@@ -0,0 +1,164 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionRequestFingerprint(t *testing.T) {
+	originalID := "txn-1"
+	base := Transaction{UserID: 1, State: "win", SourceType: "game", TransactionID: "txn-2", Amount: 1050}
+
+	tests := []struct {
+		name      string
+		other     Transaction
+		wantEqual bool
+	}{
+		{
+			name:      "identical payload",
+			other:     base,
+			wantEqual: true,
+		},
+		{
+			name:      "processing fields are ignored",
+			other:     Transaction{UserID: 1, State: "win", SourceType: "game", TransactionID: "txn-2", Amount: 1050, Fingerprint: "x"},
+			wantEqual: true,
+		},
+		{
+			name:  "different amount",
+			other: Transaction{UserID: 1, State: "win", SourceType: "game", TransactionID: "txn-2", Amount: 1051},
+		},
+		{
+			name:  "different state",
+			other: Transaction{UserID: 1, State: "lose", SourceType: "game", TransactionID: "txn-2", Amount: 1050},
+		},
+		{
+			name:  "different source type",
+			other: Transaction{UserID: 1, State: "win", SourceType: "payment", TransactionID: "txn-2", Amount: 1050},
+		},
+		{
+			name:  "different user",
+			other: Transaction{UserID: 2, State: "win", SourceType: "game", TransactionID: "txn-2", Amount: 1050},
+		},
+		{
+			name:  "different reversal target",
+			other: Transaction{UserID: 1, State: "win", SourceType: "game", TransactionID: "txn-2", Amount: 1050, ReversalOf: &originalID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEqual, base.RequestFingerprint() == tt.other.RequestFingerprint())
+		})
+	}
+}
+
+func TestJournalEntryBalanced(t *testing.T) {
+	tests := []struct {
+		name     string
+		postings []Posting
+		want     bool
+	}{
+		{
+			name:     "no postings",
+			postings: nil,
+			want:     true,
+		},
+		{
+			name: "user credit against house debit",
+			postings: []Posting{
+				{Amount: 1050, Currency: "USD"},
+				{Amount: -1050, Currency: "USD"},
+			},
+			want: true,
+		},
+		{
+			name: "amounts do not sum to zero",
+			postings: []Posting{
+				{Amount: 1050, Currency: "USD"},
+				{Amount: -1000, Currency: "USD"},
+			},
+			want: false,
+		},
+		{
+			name: "sums to zero only across currencies",
+			postings: []Posting{
+				{Amount: 1000, Currency: "USD"},
+				{Amount: -1000, Currency: "EUR"},
+			},
+			want: false,
+		},
+		{
+			name: "balanced in each currency",
+			postings: []Posting{
+				{Amount: 1000, Currency: "USD"},
+				{Amount: -1000, Currency: "USD"},
+				{Amount: -5, Currency: "EUR"},
+				{Amount: 5, Currency: "EUR"},
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, JournalEntry{Postings: tt.postings}.Balanced())
+		})
+	}
+}
+
+func TestUserCheckMovement(t *testing.T) {
+	debits, credits, all := FreezeScopeDebits, FreezeScopeCredits, FreezeScopeAll
+
+	tests := []struct {
+		name    string
+		user    User
+		amount  int64
+		wantErr error
+	}{
+		{name: "active credit", user: User{Status: UserStatusActive}, amount: 100},
+		{name: "active debit", user: User{Status: UserStatusActive}, amount: -100},
+		{name: "debits frozen blocks debit", user: User{Status: UserStatusFrozen, FreezeScope: &debits}, amount: -100, wantErr: ErrAccountFrozen},
+		{name: "debits frozen allows credit", user: User{Status: UserStatusFrozen, FreezeScope: &debits}, amount: 100},
+		{name: "credits frozen blocks credit", user: User{Status: UserStatusFrozen, FreezeScope: &credits}, amount: 100, wantErr: ErrAccountFrozen},
+		{name: "credits frozen allows debit", user: User{Status: UserStatusFrozen, FreezeScope: &credits}, amount: -100},
+		{name: "fully frozen blocks credit", user: User{Status: UserStatusFrozen, FreezeScope: &all}, amount: 100, wantErr: ErrAccountFrozen},
+		{name: "frozen without scope blocks everything", user: User{Status: UserStatusFrozen}, amount: -100, wantErr: ErrAccountFrozen},
+		{name: "closed blocks credit", user: User{Status: UserStatusClosed}, amount: 100, wantErr: ErrAccountClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.user.CheckMovement(tt.amount), tt.wantErr)
+		})
+	}
+}
+
+func TestHoldCheckActive(t *testing.T) {
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		hold    Hold
+		wantErr error
+	}{
+		{name: "reserved before expiry", hold: Hold{Status: HoldStatusReserved, ExpiresAt: now.Add(time.Minute)}},
+		{name: "reserved at expiry", hold: Hold{Status: HoldStatusReserved, ExpiresAt: now}},
+		{
+			name:    "reserved past expiry",
+			hold:    Hold{Status: HoldStatusReserved, ExpiresAt: now.Add(-time.Second)},
+			wantErr: ErrHoldNotActive,
+		},
+		{name: "settled", hold: Hold{Status: HoldStatusSettled, ExpiresAt: now.Add(time.Minute)}, wantErr: ErrHoldNotActive},
+		{name: "released", hold: Hold{Status: HoldStatusReleased, ExpiresAt: now.Add(time.Minute)}, wantErr: ErrHoldNotActive},
+		{name: "expired", hold: Hold{Status: HoldStatusExpired, ExpiresAt: now.Add(-time.Minute)}, wantErr: ErrHoldNotActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.hold.CheckActive(now), tt.wantErr)
+		})
+	}
+}
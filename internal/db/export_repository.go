@@ -0,0 +1,56 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// ExportRepository reads whole tables in primary key order and hands them to fn batch by batch, so
+// memory use does not grow with the table. The methods have no timeout of their own: an export takes
+// as long as the table is big and is stopped by cancelling ctx. An error returned by fn stops it too.
+type ExportRepository interface {
+	ExportUsers(ctx context.Context, fn func([]User) error) error
+	ExportWallets(ctx context.Context, fn func([]Wallet) error) error
+	ExportTransactions(ctx context.Context, userID uint64, fn func([]Transaction) error) error
+}
+
+// ExportBatchSize is the number of rows read per query while exporting.
+const ExportBatchSize = 500
+
+func (r *PostgresDBDataStore) ExportUsers(ctx context.Context, fn func([]User) error) error {
+	var batch []User
+
+	return exportInBatches(r.db.WithContext(ctx), &batch, func() error { return fn(batch) }, "users")
+}
+
+func (r *PostgresDBDataStore) ExportWallets(ctx context.Context, fn func([]Wallet) error) error {
+	var batch []Wallet
+
+	return exportInBatches(r.db.WithContext(ctx), &batch, func() error { return fn(batch) }, "wallets")
+}
+
+// ExportTransactions exports the transactions of userID, or of every user when userID is zero.
+func (r *PostgresDBDataStore) ExportTransactions(
+	ctx context.Context, userID uint64, fn func([]Transaction) error,
+) error {
+	query := r.db.WithContext(ctx)
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	var batch []Transaction
+
+	return exportInBatches(query, &batch, func() error { return fn(batch) }, "transactions")
+}
+
+func exportInBatches(query *gorm.DB, dest any, fn func() error, table string) error {
+	if err := query.FindInBatches(dest, ExportBatchSize, func(*gorm.DB, int) error {
+		return fn()
+	}).Error; err != nil {
+		return fmt.Errorf("failed to export %s: %w", table, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newFakeDataStore returns a data store on top of connector, for the repository code whose
+// outcome shows in the statements it sends.
+func newFakeDataStore(t *testing.T, connector *fakeConnector) *PostgresDBDataStore {
+	t.Helper()
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sql.OpenDB(connector)}), &gorm.Config{
+		Logger:               logger.Discard,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+
+	return &PostgresDBDataStore{db: db, readTimeout: time.Second, writeTimeout: time.Second}
+}
+
+// fakeConnector is a database/sql driver that records the statements it gets and answers queries
+// with the rows respond returns. Statements succeed and affect one row.
+type fakeConnector struct {
+	respond func(query string) ([]string, [][]driver.Value)
+
+	mu         sync.Mutex
+	statements []fakeStatement
+}
+
+type fakeStatement struct {
+	query string
+	args  []driver.Value
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{c}, nil }
+
+func (c *fakeConnector) Driver() driver.Driver { return c }
+
+func (c *fakeConnector) Open(string) (driver.Conn, error) { return fakeConn{c}, nil }
+
+func (c *fakeConnector) record(query string, args []driver.NamedValue) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	values := make([]driver.Value, 0, len(args))
+	for _, arg := range args {
+		values = append(values, arg.Value)
+	}
+
+	c.statements = append(c.statements, fakeStatement{query: query, args: values})
+}
+
+func (c *fakeConnector) inserts(prefix string) []fakeStatement {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var found []fakeStatement
+
+	for _, statement := range c.statements {
+		if strings.HasPrefix(statement.query, prefix) {
+			found = append(found, statement)
+		}
+	}
+
+	return found
+}
+
+type fakeConn struct{ connector *fakeConnector }
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+func (fakeConn) Close() error { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) { return c, nil }
+
+func (fakeConn) Commit() error { return nil }
+
+func (fakeConn) Rollback() error { return nil }
+
+func (fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func (c fakeConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.connector.record(query, args)
+
+	return driver.RowsAffected(1), nil
+}
+
+func (c fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.connector.record(query, args)
+
+	columns, rows := c.connector.respond(query)
+
+	return &fakeRows{columns: columns, rows: rows}, nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+
+func (*fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+
+	return nil
+}
@@ -3,11 +3,15 @@ package gorm_logger
 
 import (
 	"context"
+	"errors"
 	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
 )
 
 type Logger struct {
@@ -70,19 +74,17 @@ func (l *Logger) Error(ctx context.Context, msg string, data ...interface{}) {
 }
 
 func (l *Logger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
-	if l.LogLevel <= logger.Silent {
-		return
-	}
-
 	elapsed := time.Since(begin)
 	sql, rows := fc()
+	queryType := l.getQueryType(sql)
+
+	// Queries are timed even when logging is silenced. A missing record is a result, not a failure.
+	metrics.ObserveDBQuery(queryType, elapsed, err != nil && !errors.Is(err, gorm.ErrRecordNotFound))
 
-	if l.shouldSkipQuery(sql) {
+	if l.LogLevel <= logger.Silent || l.shouldSkipQuery(sql) {
 		return
 	}
 
-	queryType := l.getQueryType(sql)
-
 	fields := logrus.Fields{
 		"elapsed":    elapsed,
 		"query_type": queryType,
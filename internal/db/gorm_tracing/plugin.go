@@ -0,0 +1,151 @@
+//nolint:revive,nosnakecase //package name is fine
+package gorm_tracing
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const (
+	PluginName = "otel_tracing"
+	tracerName = "github.com/TiPSYDiPSY/home-task/internal/db"
+	spanKey    = "otel_tracing:span"
+	dbSystem   = "postgresql"
+
+	// MaxStatementLength keeps huge batch inserts from bloating spans.
+	MaxStatementLength = 2000
+)
+
+var (
+	stringLiteralPattern  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteralPattern = regexp.MustCompile(`([^$\w.])-?\d+(?:\.\d+)?\b`)
+)
+
+// Plugin starts a child span for every statement GORM runs, carrying db.system, db.operation,
+// db.sql.table and the statement with its literals replaced by "?".
+type Plugin struct {
+	tracer trace.Tracer
+}
+
+func New() *Plugin {
+	return &Plugin{tracer: otel.Tracer(tracerName)}
+}
+
+func (*Plugin) Name() string {
+	return PluginName
+}
+
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	callbacks := []struct {
+		operation string
+		before    func(name string, fn func(*gorm.DB)) error
+		after     func(name string, fn func(*gorm.DB)) error
+	}{
+		{"create", db.Callback().Create().Before("gorm:create").Register, db.Callback().Create().After("gorm:create").Register},
+		{"query", db.Callback().Query().Before("gorm:query").Register, db.Callback().Query().After("gorm:query").Register},
+		{"update", db.Callback().Update().Before("gorm:update").Register, db.Callback().Update().After("gorm:update").Register},
+		{"delete", db.Callback().Delete().Before("gorm:delete").Register, db.Callback().Delete().After("gorm:delete").Register},
+		{"row", db.Callback().Row().Before("gorm:row").Register, db.Callback().Row().After("gorm:row").Register},
+		{"raw", db.Callback().Raw().Before("gorm:raw").Register, db.Callback().Raw().After("gorm:raw").Register},
+	}
+
+	for _, callback := range callbacks {
+		if err := callback.before(PluginName+":before_"+callback.operation, p.before); err != nil {
+			return err
+		}
+
+		if err := callback.after(PluginName+":after_"+callback.operation, p.after); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Plugin) before(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	_, span := p.tracer.Start(ctx, "db.query",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", dbSystem)),
+	)
+
+	db.InstanceSet(spanKey, span)
+}
+
+func (*Plugin) after(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+
+	defer span.End()
+
+	statement := db.Statement.SQL.String()
+	operation := Operation(statement)
+
+	span.SetName(spanName(operation, db.Statement.Table))
+	span.SetAttributes(
+		attribute.String("db.operation", operation),
+		attribute.String("db.statement", Sanitize(statement)),
+		attribute.Int64("db.rows_affected", db.RowsAffected),
+	)
+
+	if db.Statement.Table != "" {
+		span.SetAttributes(attribute.String("db.sql.table", db.Statement.Table))
+	}
+
+	// A missing record is a result the caller handles, not a failed query.
+	if err := db.Error; err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// Operation returns the SQL verb of a statement, e.g. SELECT or INSERT.
+func Operation(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return "UNKNOWN"
+	}
+
+	return strings.ToUpper(fields[0])
+}
+
+// Sanitize replaces string and numeric literals with "?", so values written into raw SQL do not end
+// up in traces. Bind parameters such as $1 are kept.
+func Sanitize(statement string) string {
+	sanitized := stringLiteralPattern.ReplaceAllString(statement, "?")
+	sanitized = numericLiteralPattern.ReplaceAllString(sanitized, "$1?")
+	sanitized = strings.Join(strings.Fields(sanitized), " ")
+
+	if len(sanitized) > MaxStatementLength {
+		sanitized = sanitized[:MaxStatementLength] + "..."
+	}
+
+	return sanitized
+}
+
+func spanName(operation, table string) string {
+	if table == "" {
+		return operation
+	}
+
+	return operation + " " + table
+}
@@ -0,0 +1,75 @@
+//nolint:revive,nosnakecase //package name is fine
+package gorm_tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+type account struct {
+	ID      uint64
+	Balance int64
+}
+
+func TestPlugin(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	// Dry run builds the statements without a database.
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, db.Use(&Plugin{tracer: tracer}))
+
+	ctx, parent := tracer.Start(context.Background(), "UserService.UpdateBalance")
+
+	var accounts []account
+	db.WithContext(ctx).Where("balance > ?", 100).Find(&accounts)
+	db.WithContext(ctx).Exec("UPDATE accounts SET balance = balance - 250 WHERE id = ?", 7)
+
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	query := spans[0]
+	assert.Equal(t, "SELECT accounts", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Contains(t, query.Attributes(), attribute.String("db.system", "postgresql"))
+	assert.Contains(t, query.Attributes(), attribute.String("db.operation", "SELECT"))
+	assert.Contains(t, query.Attributes(), attribute.String("db.sql.table", "accounts"))
+	assert.Contains(t, query.Attributes(), attribute.String("db.statement", `SELECT * FROM "accounts" WHERE balance > $1`))
+
+	raw := spans[1]
+	assert.Equal(t, "UPDATE", raw.Name())
+	assert.Contains(t, raw.Attributes(), attribute.String("db.statement", "UPDATE accounts SET balance = balance - ? WHERE id = $1"))
+}
+
+func TestSanitize(t *testing.T) {
+	tests := []struct {
+		statement string
+		want      string
+	}{
+		{`SELECT * FROM "users" WHERE id = $1 LIMIT 1`, `SELECT * FROM "users" WHERE id = $1 LIMIT ?`},
+		{"SELECT * FROM users WHERE external_id = 'player-42' AND status <> 'it''s'", "SELECT * FROM users WHERE external_id = ? AND status <> ?"},
+		{"SELECT pg_advisory_lock(727465826301)", "SELECT pg_advisory_lock(?)"},
+		{"UPDATE wallets SET balance = -10.50,\n\treserved = 0 WHERE user_id = $2", "UPDATE wallets SET balance = ?, reserved = ? WHERE user_id = $2"},
+		{"SELECT t1.id FROM users t1", "SELECT t1.id FROM users t1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.statement, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sanitize(tt.statement))
+		})
+	}
+}
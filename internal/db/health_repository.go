@@ -0,0 +1,63 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db/migrations"
+)
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	PendingMigrations(ctx context.Context) ([]Migration, error)
+}
+
+// Ping checks that a connection to the database can be used.
+func (r *PostgresDBDataStore) Ping(ctx context.Context) error {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get underlying sql.DB: %w", err)
+	}
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return nil
+}
+
+// PendingMigrations returns the known migrations that have not been applied. Unlike MigrationStatus
+// it does not wait for the migration lock, so a probe does not hang while another replica migrates.
+func (r *PostgresDBDataStore) PendingMigrations(ctx context.Context) ([]Migration, error) {
+	known, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	conn := r.db.WithContext(ctx)
+
+	var exists bool
+	if err := conn.Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("failed to look up schema_migrations: %w", err)
+	}
+
+	var applied []SchemaMigration
+
+	if exists {
+		if err := conn.Order("version").Find(&applied).Error; err != nil {
+			return nil, fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+	}
+
+	statuses, _ := migrationStatuses(known, applied)
+
+	pending := make([]Migration, 0)
+
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+		}
+	}
+
+	return pending, nil
+}
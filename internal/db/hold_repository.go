@@ -0,0 +1,287 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type HoldRepository interface {
+	PlaceHold(ctx context.Context, hold Hold) (Hold, error)
+	SettleHold(
+		ctx context.Context, userID uint64, holdID, state string, payout int64, payoutCurrency, principal string,
+	) (Hold, error)
+	ReleaseHold(ctx context.Context, userID uint64, holdID string) (Hold, error)
+	ExpireHolds(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+const holdTransactionPrefix = "hold:"
+
+// PlaceHold reserves hold.Amount from the user's available balance. Placing the same hold again
+// with an identical payload returns the stored hold instead of reserving twice.
+func (r *PostgresDBDataStore) PlaceHold(ctx context.Context, hold Hold) (Hold, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	hold.Status = HoldStatusReserved
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		if placed, err := r.findPlacedHold(tx, &hold); placed || err != nil {
+			return err
+		}
+
+		// A stake is a pending debit, so it is blocked when debits are.
+		if err := r.checkAccountMovement(tx, hold.UserID, -hold.Amount); err != nil {
+			return err
+		}
+
+		// A concurrent request with the same hold ID can pass findPlacedHold before either of them
+		// is committed. The unique index makes this insert wait for the other one and skip the row,
+		// which is then compared like findPlacedHold does, before any funds are reserved.
+		result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "hold_id"}}, DoNothing: true}).
+			Create(&hold)
+		if result.Error != nil {
+			return fmt.Errorf("failed to create hold: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			if placed, err := r.findPlacedHold(tx, &hold); placed || err != nil {
+				return err
+			}
+
+			return ErrHoldExists
+		}
+
+		if err := r.reserveUserFundsAtomic(tx, hold.UserID, hold.Currency, hold.Amount); err != nil {
+			return err
+		}
+
+		return r.enqueueHoldChange(tx, hold)
+	}); err != nil {
+		return Hold{}, fmt.Errorf("failed to execute place hold transaction: %w", err)
+	}
+
+	return hold, nil
+}
+
+// findPlacedHold replaces hold with the stored hold of the same HoldID and reports true when both
+// were placed with the same payload. A stored hold with another payload is ErrHoldExists.
+func (*PostgresDBDataStore) findPlacedHold(tx *gorm.DB, hold *Hold) (bool, error) {
+	var existing Hold
+
+	result := tx.Where("hold_id = ?", hold.HoldID).Limit(1).Find(&existing)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to check hold existence: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return false, nil
+	}
+
+	if !existing.SamePlacement(*hold) {
+		return false, ErrHoldExists
+	}
+
+	*hold = existing
+
+	return true, nil
+}
+
+// SettleHold closes an active hold. A lose debits the stake, a win credits payout minus the stake.
+// Either way a Transaction row is written so the settlement shows up in the user's history.
+// Holds placed before the account was frozen are settled regardless, so accepted bets can finish.
+// A non-empty payoutCurrency must match the currency the stake was reserved in. The settlement
+// transaction is recorded as requested by principal.
+func (r *PostgresDBDataStore) SettleHold(
+	ctx context.Context, userID uint64, holdID, state string, payout int64, payoutCurrency, principal string,
+) (Hold, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var hold Hold
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if hold, err = r.findActiveHold(tx, userID, holdID); err != nil {
+			return err
+		}
+
+		if payoutCurrency != "" && payoutCurrency != hold.Currency {
+			return ErrCurrencyMismatch
+		}
+
+		delta := -hold.Amount
+		if state == "win" {
+			delta = payout - hold.Amount
+			hold.Payout = payout
+		}
+
+		// The stake is freed first, so a lose can debit it through the regular overdraft check.
+		if err := tx.Model(&Wallet{}).
+			Where("user_id = ? AND currency = ?", userID, hold.Currency).
+			Update("reserved", gorm.Expr("reserved - ?", hold.Amount)).Error; err != nil {
+			return fmt.Errorf("failed to settle user funds: %w", err)
+		}
+
+		hold.Status = HoldStatusSettled
+		if err := tx.Save(&hold).Error; err != nil {
+			return fmt.Errorf("failed to update hold: %w", err)
+		}
+
+		transaction := Transaction{
+			UserID:        userID,
+			Amount:        delta,
+			Currency:      hold.Currency,
+			State:         state,
+			SourceType:    hold.SourceType,
+			TransactionID: holdTransactionPrefix + hold.HoldID,
+			Principal:     principal,
+		}
+		transaction.Fingerprint = transaction.RequestFingerprint()
+		newTransactionID(&transaction)
+
+		wallet, err := r.findOrCreateWallet(tx, userID, hold.Currency)
+		if err != nil {
+			return err
+		}
+
+		if err := r.checkTransactionExists(tx, transaction); err != nil {
+			return settlementConflict(err)
+		}
+
+		if err := r.createTransactionRecord(tx, transaction); err != nil {
+			return settlementConflict(err)
+		}
+
+		return r.postTransfer(tx, wallet, transaction, hold.SourceType, journalDescriptionSettlement)
+	}); err != nil {
+		return Hold{}, fmt.Errorf("failed to execute settle hold transaction: %w", err)
+	}
+
+	return hold, nil
+}
+
+func (r *PostgresDBDataStore) ReleaseHold(ctx context.Context, userID uint64, holdID string) (Hold, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var hold Hold
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if hold, err = r.findActiveHold(tx, userID, holdID); err != nil {
+			return err
+		}
+
+		return r.releaseHold(tx, &hold, HoldStatusReleased)
+	}); err != nil {
+		return Hold{}, fmt.Errorf("failed to execute release hold transaction: %w", err)
+	}
+
+	return hold, nil
+}
+
+// ExpireHolds returns the funds of up to limit reserved holds whose ExpiresAt is before now.
+// Rows locked by a concurrent settle or by another replica are skipped.
+func (r *PostgresDBDataStore) ExpireHolds(ctx context.Context, now time.Time, limit int) (int, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var expired int
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var holds []Hold
+		if err := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate, Options: clause.LockingOptionsSkipLocked}).
+			Where("status = ? AND expires_at < ?", HoldStatusReserved, now).
+			Order("expires_at").
+			Limit(limit).
+			Find(&holds).Error; err != nil {
+			return fmt.Errorf("failed to find expired holds: %w", err)
+		}
+
+		for i := range holds {
+			if err := r.releaseHold(tx, &holds[i], HoldStatusExpired); err != nil {
+				return err
+			}
+		}
+
+		expired = len(holds)
+
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("failed to execute expire holds transaction: %w", err)
+	}
+
+	return expired, nil
+}
+
+// settlementConflict reports a stored settlement ID as ErrDuplicateTransaction. The hold is still
+// active, so the row was not written by a settle and is never a replay.
+func settlementConflict(err error) error {
+	if errors.Is(err, ErrIdempotentReplay) {
+		return ErrDuplicateTransaction
+	}
+
+	return err
+}
+
+func (*PostgresDBDataStore) findActiveHold(tx *gorm.DB, userID uint64, holdID string) (Hold, error) {
+	var hold Hold
+
+	result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("user_id = ? AND hold_id = ?", userID, holdID).
+		Limit(1).
+		Find(&hold)
+	if result.Error != nil {
+		return Hold{}, fmt.Errorf("failed to find hold: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return Hold{}, ErrHoldNotFound
+	}
+
+	if err := hold.CheckActive(time.Now()); err != nil {
+		return Hold{}, err
+	}
+
+	return hold, nil
+}
+
+func (r *PostgresDBDataStore) releaseHold(tx *gorm.DB, hold *Hold, status string) error {
+	if err := tx.Model(&Wallet{}).
+		Where("user_id = ? AND currency = ?", hold.UserID, hold.Currency).
+		Update("reserved", gorm.Expr("reserved - ?", hold.Amount)).Error; err != nil {
+		return fmt.Errorf("failed to release user funds: %w", err)
+	}
+
+	hold.Status = status
+	if err := tx.Save(hold).Error; err != nil {
+		return fmt.Errorf("failed to update hold: %w", err)
+	}
+
+	return r.enqueueHoldChange(tx, *hold)
+}
+
+func (r *PostgresDBDataStore) reserveUserFundsAtomic(tx *gorm.DB, userID uint64, currency string, amount int64) error {
+	if err := r.ensureWallet(tx, userID, currency); err != nil {
+		return err
+	}
+
+	result := tx.Model(&Wallet{}).
+		Where("user_id = ? AND currency = ? AND balance - reserved >= ?", userID, currency, amount).
+		Update("reserved", gorm.Expr("reserved + ?", amount))
+
+	if result.Error != nil {
+		return fmt.Errorf("failed to reserve user funds: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return r.determineUpdateFailureReason(tx, userID)
+	}
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package db
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHoldChangesEnqueueBalanceChange(t *testing.T) {
+	now := time.Now()
+	hold := Hold{
+		ID:         uuid.MustParse("0b7d1c8e-5f3a-4c2b-9e61-7a4d2f8c3b10"),
+		UserID:     1,
+		HoldID:     "bet-42",
+		SourceType: "game",
+		Currency:   "USD",
+		Amount:     250,
+		Status:     HoldStatusReserved,
+		ExpiresAt:  now.Add(time.Hour),
+	}
+	expiredHold := hold
+	expiredHold.ExpiresAt = now.Add(-time.Minute)
+
+	tests := []struct {
+		name     string
+		existing []Hold
+		call     func(r *PostgresDBDataStore) error
+		want     BalanceChange
+	}{
+		{
+			name: "place",
+			call: func(r *PostgresDBDataStore) error {
+				_, err := r.PlaceHold(context.Background(), hold)
+
+				return err
+			},
+			want: BalanceChange{
+				UserID: 1, Currency: "USD", TransactionID: "hold:bet-42", State: HoldStatusReserved,
+				SourceType: "game", Balance: 1000, Reserved: 250,
+			},
+		},
+		{
+			name:     "release",
+			existing: []Hold{hold},
+			call: func(r *PostgresDBDataStore) error {
+				_, err := r.ReleaseHold(context.Background(), 1, "bet-42")
+
+				return err
+			},
+			want: BalanceChange{
+				UserID: 1, Currency: "USD", TransactionID: "hold:bet-42", State: HoldStatusReleased,
+				SourceType: "game", Balance: 1000, Reserved: 250,
+			},
+		},
+		{
+			name:     "expire",
+			existing: []Hold{expiredHold},
+			call: func(r *PostgresDBDataStore) error {
+				_, err := r.ExpireHolds(context.Background(), now, 10)
+
+				return err
+			},
+			want: BalanceChange{
+				UserID: 1, Currency: "USD", TransactionID: "hold:bet-42", State: HoldStatusExpired,
+				SourceType: "game", Balance: 1000, Reserved: 250,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeConnector{respond: func(query string) ([]string, [][]driver.Value) {
+				switch {
+				case strings.HasPrefix(query, `SELECT * FROM "holds"`):
+					return holdRows(tt.existing)
+				case strings.HasPrefix(query, `INSERT INTO "holds"`):
+					return []string{"id"}, [][]driver.Value{{hold.ID.String()}}
+				case strings.HasPrefix(query, `SELECT "id","status","freeze_scope" FROM "users"`):
+					return []string{"id", "status", "freeze_scope"}, [][]driver.Value{{int64(1), UserStatusActive, nil}}
+				case strings.HasPrefix(query, `SELECT "balance","reserved" FROM "wallets"`):
+					return []string{"balance", "reserved"}, [][]driver.Value{{int64(1000), int64(250)}}
+				default:
+					return nil, nil
+				}
+			}}
+
+			require.NoError(t, tt.call(newFakeDataStore(t, fake)))
+
+			events := fake.inserts(`INSERT INTO "outbox_events"`)
+			require.Len(t, events, 1)
+
+			var change BalanceChange
+			require.NoError(t, json.Unmarshal([]byte(payloadArg(t, events[0])), &change))
+			assert.Equal(t, tt.want, change)
+		})
+	}
+}
+
+func TestPlaceHoldConcurrently(t *testing.T) {
+	stored := Hold{
+		ID:         uuid.MustParse("0b7d1c8e-5f3a-4c2b-9e61-7a4d2f8c3b10"),
+		UserID:     1,
+		HoldID:     "bet-42",
+		SourceType: "game",
+		Currency:   "USD",
+		Amount:     250,
+		Status:     HoldStatusReserved,
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+
+	tests := []struct {
+		name    string
+		amount  int64
+		wantErr error
+	}{
+		{name: "retry replays the stored hold", amount: 250},
+		{name: "other payload is rejected", amount: 500, wantErr: ErrHoldExists},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Neither request sees the other's hold in its first read, and only the first insert gets
+			// the row, as with the unique index. The loser's second read finds the winner's hold.
+			var (
+				mu       sync.Mutex
+				inserted bool
+				reads    int
+			)
+
+			fake := &fakeConnector{}
+			fake.respond = func(query string) ([]string, [][]driver.Value) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				switch {
+				case strings.HasPrefix(query, `SELECT * FROM "holds"`):
+					reads++
+					if reads <= 2 {
+						return holdRows(nil)
+					}
+
+					winner := stored
+					winner.Amount, _ = insertArg(fake.inserts(`INSERT INTO "holds"`)[0], `"amount"`).(int64)
+
+					return holdRows([]Hold{winner})
+				case strings.HasPrefix(query, `INSERT INTO "holds"`):
+					if inserted {
+						return nil, nil
+					}
+
+					inserted = true
+
+					return []string{"id"}, [][]driver.Value{{stored.ID.String()}}
+				case strings.HasPrefix(query, `SELECT "id","status","freeze_scope" FROM "users"`):
+					return []string{"id", "status", "freeze_scope"}, [][]driver.Value{{int64(1), UserStatusActive, nil}}
+				case strings.HasPrefix(query, `SELECT "balance","reserved" FROM "wallets"`):
+					return []string{"balance", "reserved"}, [][]driver.Value{{int64(1000), int64(250)}}
+				default:
+					return nil, nil
+				}
+			}
+			store := newFakeDataStore(t, fake)
+
+			first := stored
+			first.ID = uuid.Nil
+			second := first
+			second.Amount = tt.amount
+
+			errs := make([]error, 2)
+
+			var wg sync.WaitGroup
+			for i, hold := range []Hold{first, second} {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					_, errs[i] = store.PlaceHold(context.Background(), hold)
+				}()
+			}
+
+			wg.Wait()
+
+			assert.Contains(t, errs, nil)
+
+			if tt.wantErr == nil {
+				assert.Equal(t, []error{nil, nil}, errs)
+			} else {
+				assert.True(t, errors.Is(errs[0], tt.wantErr) || errors.Is(errs[1], tt.wantErr))
+			}
+
+			assert.Len(t, fake.inserts(`INSERT INTO "holds"`), 2)
+			assert.Len(t, fake.inserts(`UPDATE "wallets" SET "reserved"`), 1, "only the inserted hold reserves funds")
+			assert.Len(t, fake.inserts(`INSERT INTO "outbox_events"`), 1)
+		})
+	}
+}
+
+func holdRows(holds []Hold) ([]string, [][]driver.Value) {
+	columns := []string{"id", "user_id", "hold_id", "source_type", "currency", "amount", "status", "expires_at"}
+
+	rows := make([][]driver.Value, 0, len(holds))
+	for _, hold := range holds {
+		rows = append(rows, []driver.Value{
+			hold.ID.String(), int64(hold.UserID), hold.HoldID, hold.SourceType, hold.Currency, hold.Amount,
+			hold.Status, hold.ExpiresAt,
+		})
+	}
+
+	return columns, rows
+}
+
+// payloadArg returns the payload column of an outbox insert.
+func payloadArg(t *testing.T, statement fakeStatement) string {
+	t.Helper()
+
+	payload, ok := insertArg(statement, `"payload"`).(string)
+	require.True(t, ok, "outbox insert without payload: %s", statement.query)
+
+	return payload
+}
+
+// insertArg returns the value an insert sets column to. The arguments follow the column order.
+func insertArg(statement fakeStatement, column string) driver.Value {
+	query := statement.query
+	columns := strings.Split(query[strings.Index(query, "(")+1:strings.Index(query, ")")], ",")
+
+	for i, name := range columns {
+		if name == column {
+			return statement.args[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// postTransfer moves transaction.Amount between wallet and the house account named counterparty.
+// A positive amount credits the user, a negative one debits them, and the house account takes the
+// opposite side. The entry is linked to the transaction, which must already be stored. Every
+// transfer enqueues an EventTypeBalanceChanged outbox event.
+func (r *PostgresDBDataStore) postTransfer(
+	tx *gorm.DB, wallet Wallet, transaction Transaction, counterparty, description string,
+) error {
+	house, err := r.findOrCreateHouseAccount(tx, counterparty, wallet.Currency)
+	if err != nil {
+		return err
+	}
+
+	if err := r.postJournalEntry(tx, JournalEntry{
+		TransactionID: &transaction.ID,
+		Description:   description,
+		Postings: []Posting{
+			{WalletID: &wallet.ID, Amount: transaction.Amount, Currency: wallet.Currency},
+			{HouseAccountID: &house.ID, Amount: -transaction.Amount, Currency: wallet.Currency},
+		},
+	}); err != nil {
+		return err
+	}
+
+	return r.enqueueBalanceChange(tx, wallet.ID, transaction)
+}
+
+// postJournalEntry stores a balanced entry and applies its postings to the cached wallet balances.
+// A wallet posting that would take the wallet below its reserved amount fails with ErrInsufficientFunds.
+func (r *PostgresDBDataStore) postJournalEntry(tx *gorm.DB, entry JournalEntry) error {
+	if !entry.Balanced() {
+		return ErrUnbalancedEntry
+	}
+
+	for _, posting := range entry.Postings {
+		if posting.WalletID == nil {
+			continue
+		}
+
+		result := tx.Model(&Wallet{}).
+			Where("id = ? AND balance - reserved + ? >= 0", *posting.WalletID, posting.Amount).
+			Update("balance", gorm.Expr("balance + ?", posting.Amount))
+		if result.Error != nil {
+			return fmt.Errorf("failed to update wallet balance: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrInsufficientFunds
+		}
+	}
+
+	return r.insertJournalEntry(tx, entry)
+}
+
+// insertJournalEntry stores a balanced entry without touching cached wallet balances. It is meant for
+// entries that record money the wallets already hold, such as opening balances and adjustments.
+func (*PostgresDBDataStore) insertJournalEntry(tx *gorm.DB, entry JournalEntry) error {
+	if !entry.Balanced() {
+		return ErrUnbalancedEntry
+	}
+
+	if err := tx.Create(&entry).Error; err != nil {
+		return fmt.Errorf("failed to create journal entry: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresDBDataStore) findOrCreateWallet(tx *gorm.DB, userID uint64, currency string) (Wallet, error) {
+	if err := r.ensureWallet(tx, userID, currency); err != nil {
+		return Wallet{}, err
+	}
+
+	var wallet Wallet
+
+	result := tx.Where("user_id = ? AND currency = ?", userID, currency).Limit(1).Find(&wallet)
+	if result.Error != nil {
+		return Wallet{}, fmt.Errorf("failed to find wallet: %w", result.Error)
+	}
+
+	// ensureWallet only skips the insert when the user does not exist.
+	if result.RowsAffected == 0 {
+		return Wallet{}, ErrUserNotFound
+	}
+
+	return wallet, nil
+}
+
+func (*PostgresDBDataStore) findOrCreateHouseAccount(tx *gorm.DB, name, currency string) (HouseAccount, error) {
+	if err := tx.Exec(`INSERT INTO house_accounts (name, currency, created_at) VALUES (?, ?, NOW())
+		ON CONFLICT (name, currency) DO NOTHING`, name, currency).Error; err != nil {
+		return HouseAccount{}, fmt.Errorf("failed to create house account: %w", err)
+	}
+
+	var account HouseAccount
+	if err := tx.Where("name = ? AND currency = ?", name, currency).First(&account).Error; err != nil {
+		return HouseAccount{}, fmt.Errorf("failed to find house account: %w", err)
+	}
+
+	return account, nil
+}
+
+// newTransactionID assigns the primary key up front so journal entries can reference the
+// transaction row in the same database transaction.
+func newTransactionID(transaction *Transaction) {
+	if transaction.ID == uuid.Nil {
+		transaction.ID = uuid.New()
+	}
+}
@@ -0,0 +1,260 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db/migrations"
+)
+
+// Migration is one versioned schema change with the SQL that applies and reverts it.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus reports whether a migration has been applied. AppliedAt is nil for pending ones.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+// SchemaMigration is a row of the schema_migrations table.
+type SchemaMigration struct {
+	Version   int    `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"not null"`
+	AppliedAt time.Time
+}
+
+const (
+	// migrationLockKey is the pg_advisory_lock key that serialises migrations across replicas.
+	migrationLockKey = 727_465_826_301
+)
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// LoadMigrations reads NNNN_name.up.sql / NNNN_name.down.sql pairs from fsys, ordered by version.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+
+	for _, entry := range entries {
+		if entry.IsDir() || entry.Name() == "migrations.go" {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected file %q in migrations", entry.Name())
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration version in %q: %w", entry.Name(), err)
+		}
+
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files with different names", version)
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both an up and a down file", migration.Version, migration.Name)
+		}
+
+		result = append(result, *migration)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+
+	for i, migration := range result {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migration versions must be contiguous from 1, found %d at position %d",
+				migration.Version, i+1)
+		}
+	}
+
+	return result, nil
+}
+
+// MigrateUp applies all pending migrations and returns the ones it applied.
+func (r *PostgresDBDataStore) MigrateUp(ctx context.Context) (applied []Migration, err error) {
+	err = r.withMigrationLock(ctx, func(conn *gorm.DB, statuses []MigrationStatus) error {
+		for _, status := range statuses {
+			if status.AppliedAt != nil {
+				continue
+			}
+
+			log.WithContext(ctx).Infof("Applying migration %d_%s", status.Version, status.Name)
+
+			if err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(status.Up).Error; err != nil {
+					return fmt.Errorf("failed to apply migration %d_%s: %w", status.Version, status.Name, err)
+				}
+
+				return tx.Create(&SchemaMigration{Version: status.Version, Name: status.Name, AppliedAt: time.Now()}).Error
+			}); err != nil {
+				return err
+			}
+
+			applied = append(applied, status.Migration)
+		}
+
+		return nil
+	})
+
+	return applied, err
+}
+
+// MigrateDown reverts the last steps applied migrations, newest first, and returns the ones it reverted.
+func (r *PostgresDBDataStore) MigrateDown(ctx context.Context, steps int) (reverted []Migration, err error) {
+	err = r.withMigrationLock(ctx, func(conn *gorm.DB, statuses []MigrationStatus) error {
+		for i := len(statuses) - 1; i >= 0 && len(reverted) < steps; i-- {
+			status := statuses[i]
+			if status.AppliedAt == nil {
+				continue
+			}
+
+			log.WithContext(ctx).Infof("Reverting migration %d_%s", status.Version, status.Name)
+
+			if err := conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(status.Down).Error; err != nil {
+					return fmt.Errorf("failed to revert migration %d_%s: %w", status.Version, status.Name, err)
+				}
+
+				return tx.Delete(&SchemaMigration{}, status.Version).Error
+			}); err != nil {
+				return err
+			}
+
+			reverted = append(reverted, status.Migration)
+		}
+
+		return nil
+	})
+
+	return reverted, err
+}
+
+// MigrationStatus lists every known migration and when it was applied.
+func (r *PostgresDBDataStore) MigrationStatus(ctx context.Context) (statuses []MigrationStatus, err error) {
+	err = r.withMigrationLock(ctx, func(_ *gorm.DB, current []MigrationStatus) error {
+		statuses = current
+
+		return nil
+	})
+
+	return statuses, err
+}
+
+// withMigrationLock runs fn on a single connection holding the migration advisory lock, so that
+// replicas starting at the same time apply each migration exactly once.
+func (r *PostgresDBDataStore) withMigrationLock(
+	ctx context.Context, fn func(conn *gorm.DB, statuses []MigrationStatus) error,
+) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.migrationTimeout)
+	defer cancel()
+
+	known, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	// Session-level advisory locks belong to a connection, so everything runs on the same one.
+	return r.db.WithContext(ctxWithTimeout).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+
+		defer func() {
+			if err := conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey).Error; err != nil {
+				log.WithContext(ctx).WithError(err).Error("Failed to release migration lock")
+			}
+		}()
+
+		if err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    bigint      PRIMARY KEY,
+			name       text        NOT NULL,
+			applied_at timestamptz NOT NULL DEFAULT now()
+		)`).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations table: %w", err)
+		}
+
+		var applied []SchemaMigration
+		if err := conn.Order("version").Find(&applied).Error; err != nil {
+			return fmt.Errorf("failed to read applied migrations: %w", err)
+		}
+
+		statuses, unknown := migrationStatuses(known, applied)
+		if len(unknown) > 0 {
+			log.WithContext(ctx).WithField("versions", unknown).
+				Warn("Database has migrations applied that this build does not know about")
+		}
+
+		return fn(conn, statuses)
+	})
+}
+
+// migrationStatuses merges the known migrations with the applied ones. It also returns the applied
+// versions this build does not know about, which a newer release applied during a rolling deploy.
+func migrationStatuses(known []Migration, applied []SchemaMigration) ([]MigrationStatus, []int) {
+	appliedAt := make(map[int]time.Time, len(applied))
+	for _, migration := range applied {
+		appliedAt[migration.Version] = migration.AppliedAt
+	}
+
+	statuses := make([]MigrationStatus, 0, len(known))
+
+	for _, migration := range known {
+		status := MigrationStatus{Migration: migration}
+
+		if at, ok := appliedAt[migration.Version]; ok {
+			status.AppliedAt = &at
+			delete(appliedAt, migration.Version)
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	unknown := make([]int, 0, len(appliedAt))
+	for version := range appliedAt {
+		unknown = append(unknown, version)
+	}
+
+	sort.Ints(unknown)
+
+	return statuses, unknown
+}
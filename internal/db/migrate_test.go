@@ -0,0 +1,103 @@
+package db
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db/migrations"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(content string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(content)} }
+
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		want    []Migration
+		wantErr string
+	}{
+		{
+			name: "ordered by version",
+			fsys: fstest.MapFS{
+				"0002_second.up.sql":   file("up 2"),
+				"0002_second.down.sql": file("down 2"),
+				"0001_first.up.sql":    file("up 1"),
+				"0001_first.down.sql":  file("down 1"),
+				"migrations.go":        file("package migrations"),
+			},
+			want: []Migration{
+				{Version: 1, Name: "first", Up: "up 1", Down: "down 1"},
+				{Version: 2, Name: "second", Up: "up 2", Down: "down 2"},
+			},
+		},
+		{
+			name:    "missing down file",
+			fsys:    fstest.MapFS{"0001_first.up.sql": file("up 1")},
+			wantErr: "migration 1_first needs both an up and a down file",
+		},
+		{
+			name: "gap in versions",
+			fsys: fstest.MapFS{
+				"0001_first.up.sql":   file("up 1"),
+				"0001_first.down.sql": file("down 1"),
+				"0003_third.up.sql":   file("up 3"),
+				"0003_third.down.sql": file("down 3"),
+			},
+			wantErr: "migration versions must be contiguous from 1, found 3 at position 2",
+		},
+		{
+			name: "mismatched names",
+			fsys: fstest.MapFS{
+				"0001_first.up.sql":   file("up 1"),
+				"0001_other.down.sql": file("down 1"),
+			},
+			wantErr: "migration 1 has files with different names",
+		},
+		{
+			name:    "unexpected file",
+			fsys:    fstest.MapFS{"README.md": file("docs")},
+			wantErr: `unexpected file "README.md" in migrations`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadMigrations(tt.fsys)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	loaded, err := LoadMigrations(migrations.FS)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, loaded)
+}
+
+func TestMigrationStatuses(t *testing.T) {
+	appliedAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	known := []Migration{{Version: 1, Name: "first"}, {Version: 2, Name: "second"}}
+
+	statuses, unknown := migrationStatuses(known, []SchemaMigration{
+		{Version: 1, Name: "first", AppliedAt: appliedAt},
+		{Version: 3, Name: "from_newer_release", AppliedAt: appliedAt},
+	})
+
+	assert.Equal(t, []MigrationStatus{
+		{Migration: known[0], AppliedAt: &appliedAt},
+		{Migration: known[1]},
+	}, statuses)
+	assert.Equal(t, []int{3}, unknown)
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS holds;
+DROP TABLE IF EXISTS transactions;
+DROP TABLE IF EXISTS wallets;
+DROP TABLE IF EXISTS users;
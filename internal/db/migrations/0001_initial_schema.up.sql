@@ -0,0 +1,82 @@
+-- Initial schema. Statements are idempotent so databases previously managed by GORM AutoMigrate,
+-- in any of their earlier layouts, are adopted rather than recreated.
+
+CREATE TABLE IF NOT EXISTS users (
+    id         bigserial PRIMARY KEY,
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS wallets (
+    id         bigserial PRIMARY KEY,
+    user_id    bigint      NOT NULL CONSTRAINT fk_users_wallets REFERENCES users (id) ON DELETE CASCADE,
+    currency   varchar(3)  NOT NULL,
+    balance    bigint      NOT NULL DEFAULT 0 CONSTRAINT chk_wallets_balance CHECK (balance >= 0),
+    reserved   bigint      NOT NULL DEFAULT 0 CONSTRAINT chk_wallets_reserved CHECK (reserved >= 0 AND reserved <= balance),
+    created_at timestamptz,
+    updated_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS transactions (
+    id             uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        bigint      NOT NULL CONSTRAINT fk_users_transactions REFERENCES users (id) ON DELETE CASCADE,
+    amount         bigint      NOT NULL,
+    currency       varchar(3)  NOT NULL DEFAULT 'USD',
+    state          varchar(10) NOT NULL,
+    source_type    varchar(10) NOT NULL,
+    transaction_id text        NOT NULL,
+    processed_at   timestamptz NOT NULL DEFAULT now(),
+    reversal_of    text,
+    fingerprint    varchar(64)
+);
+
+CREATE TABLE IF NOT EXISTS holds (
+    id          uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id     bigint      NOT NULL CONSTRAINT fk_users_holds REFERENCES users (id) ON DELETE CASCADE,
+    hold_id     text        NOT NULL,
+    source_type varchar(10) NOT NULL,
+    currency    varchar(3)  NOT NULL DEFAULT 'USD',
+    amount      bigint      NOT NULL CONSTRAINT chk_holds_amount CHECK (amount > 0),
+    payout      bigint      NOT NULL DEFAULT 0,
+    status      varchar(10) NOT NULL,
+    expires_at  timestamptz NOT NULL,
+    created_at  timestamptz,
+    updated_at  timestamptz
+);
+
+-- Columns added after the first AutoMigrate layout.
+ALTER TABLE transactions ALTER COLUMN state TYPE varchar(10);
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reversal_of text;
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS fingerprint varchar(64);
+ALTER TABLE holds ADD COLUMN IF NOT EXISTS currency varchar(3) NOT NULL DEFAULT 'USD';
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wallets_user_currency ON wallets (user_id, currency);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_transaction_id ON transactions (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_user_processed ON transactions (user_id, processed_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_transactions_reversal_of ON transactions (reversal_of);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_holds_hold_id ON holds (hold_id);
+CREATE INDEX IF NOT EXISTS idx_holds_user_id ON holds (user_id);
+CREATE INDEX IF NOT EXISTS idx_holds_status_expires ON holds (status, expires_at);
+
+-- Balances used to live on users, always in USD. Move them into wallets.
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM information_schema.columns
+        WHERE table_schema = current_schema() AND table_name = 'users' AND column_name = 'balance'
+    ) THEN
+        ALTER TABLE users ADD COLUMN IF NOT EXISTS reserved bigint NOT NULL DEFAULT 0;
+
+        INSERT INTO wallets (user_id, currency, balance, reserved, created_at, updated_at)
+        SELECT id, 'USD', balance, reserved, now(), now() FROM users
+        ON CONFLICT (user_id, currency) DO NOTHING;
+
+        ALTER TABLE users DROP COLUMN reserved, DROP COLUMN balance;
+    END IF;
+END
+$$;
+
+-- AutoMigrate seeded users with explicit IDs, which does not advance the sequence. Move it past
+-- them so new users do not collide with the seeded ones.
+SELECT setval(pg_get_serial_sequence('users', 'id'), GREATEST(MAX(id), 1), MAX(id) IS NOT NULL) FROM users;
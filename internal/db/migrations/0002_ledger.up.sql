@@ -0,0 +1,71 @@
+-- Double-entry ledger: every balance change is a journal entry whose postings sum to zero.
+
+CREATE TABLE IF NOT EXISTS house_accounts (
+    id         bigserial   PRIMARY KEY,
+    name       varchar(32) NOT NULL,
+    currency   varchar(3)  NOT NULL,
+    created_at timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS journal_entries (
+    id             uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    transaction_id uuid        CONSTRAINT fk_journal_entries_transaction REFERENCES transactions (id),
+    description    varchar(64) NOT NULL,
+    created_at     timestamptz
+);
+
+CREATE TABLE IF NOT EXISTS postings (
+    id               bigserial  PRIMARY KEY,
+    journal_entry_id uuid       NOT NULL
+        CONSTRAINT fk_journal_entries_postings REFERENCES journal_entries (id) ON DELETE CASCADE,
+    wallet_id        bigint     CONSTRAINT fk_postings_wallet REFERENCES wallets (id),
+    house_account_id bigint     CONSTRAINT fk_postings_house_account REFERENCES house_accounts (id),
+    amount           bigint     NOT NULL,
+    currency         varchar(3) NOT NULL,
+    created_at       timestamptz,
+    CONSTRAINT chk_postings_single_account CHECK ((wallet_id IS NULL) <> (house_account_id IS NULL))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_house_accounts_name_currency ON house_accounts (name, currency);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_journal_entries_transaction_id ON journal_entries (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_postings_journal_entry_id ON postings (journal_entry_id);
+CREATE INDEX IF NOT EXISTS idx_postings_wallet_id ON postings (wallet_id);
+CREATE INDEX IF NOT EXISTS idx_postings_house_account_id ON postings (house_account_id);
+
+-- Rejects, at commit time, entries whose postings do not sum to zero per currency.
+CREATE OR REPLACE FUNCTION check_journal_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM postings WHERE journal_entry_id = NEW.journal_entry_id
+        GROUP BY currency HAVING SUM(amount) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.journal_entry_id;
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS postings_balanced ON postings;
+CREATE CONSTRAINT TRIGGER postings_balanced AFTER INSERT OR UPDATE ON postings
+    DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Wallets funded before the ledger existed get an opening entry against the "opening" house account.
+CREATE TEMPORARY TABLE opening_entries ON COMMIT DROP AS
+SELECT gen_random_uuid() AS entry_id, w.id AS wallet_id, w.currency, w.balance
+FROM wallets w
+WHERE w.balance <> 0 AND NOT EXISTS (SELECT 1 FROM postings p WHERE p.wallet_id = w.id);
+
+INSERT INTO house_accounts (name, currency, created_at)
+SELECT DISTINCT 'opening', currency, now() FROM opening_entries
+ON CONFLICT (name, currency) DO NOTHING;
+
+INSERT INTO journal_entries (id, description, created_at)
+SELECT entry_id, 'opening balance', now() FROM opening_entries;
+
+INSERT INTO postings (journal_entry_id, wallet_id, amount, currency, created_at)
+SELECT entry_id, wallet_id, balance, currency, now() FROM opening_entries;
+
+INSERT INTO postings (journal_entry_id, house_account_id, amount, currency, created_at)
+SELECT o.entry_id, h.id, -o.balance, o.currency, now()
+FROM opening_entries o
+JOIN house_accounts h ON h.name = 'opening' AND h.currency = o.currency;
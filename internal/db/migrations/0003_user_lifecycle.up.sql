@@ -0,0 +1,15 @@
+-- Account lifecycle: users can be frozen for debits, credits or both, and closed once empty.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS external_id  varchar(64);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS status       varchar(10) NOT NULL DEFAULT 'active';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS freeze_scope varchar(10);
+ALTER TABLE users ADD COLUMN IF NOT EXISTS closed_at    timestamptz;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_external_id ON users (external_id);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS chk_users_status;
+ALTER TABLE users ADD CONSTRAINT chk_users_status CHECK (
+    (status = 'active' AND freeze_scope IS NULL AND closed_at IS NULL)
+    OR (status = 'frozen' AND freeze_scope IN ('debits', 'credits', 'all') AND closed_at IS NULL)
+    OR (status = 'closed' AND freeze_scope IS NULL AND closed_at IS NOT NULL)
+);
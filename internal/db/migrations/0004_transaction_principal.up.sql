@@ -0,0 +1,4 @@
+-- The authenticated caller that requested each transaction, as "kind:id" (e.g. "source:game",
+-- "api_key:backoffice"). Transactions written before authentication existed have none.
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS principal varchar(128);
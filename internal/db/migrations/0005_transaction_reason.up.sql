@@ -0,0 +1,3 @@
+-- Why an operator adjusted a balance by hand. Only manual adjustments have one.
+
+ALTER TABLE transactions ADD COLUMN IF NOT EXISTS reason varchar(255);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS adjustment_audit_records_no_truncate ON adjustment_audit_records;
+DROP TRIGGER IF EXISTS adjustment_audit_records_immutable ON adjustment_audit_records;
+DROP FUNCTION IF EXISTS reject_adjustment_audit_change();
+DROP TABLE IF EXISTS adjustment_audit_records;
+DROP TABLE IF EXISTS adjustments;
@@ -0,0 +1,52 @@
+-- Manual adjustments by operators, optionally held for a second operator's approval, and the
+-- append-only audit trail of every step.
+
+CREATE TABLE IF NOT EXISTS adjustments (
+    id             uuid         PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id        bigint       NOT NULL CONSTRAINT fk_users_adjustments REFERENCES users (id),
+    transaction_id varchar(255) NOT NULL,
+    amount         bigint       NOT NULL CONSTRAINT chk_adjustments_amount CHECK (amount <> 0),
+    currency       varchar(3)   NOT NULL,
+    reason_code    varchar(32)  NOT NULL,
+    comment        text         NOT NULL,
+    status         varchar(10)  NOT NULL,
+    requested_by   varchar(128) NOT NULL,
+    decided_by     varchar(128),
+    decided_at     timestamptz,
+    created_at     timestamptz,
+    updated_at     timestamptz,
+    CONSTRAINT chk_adjustments_status CHECK (
+        (status = 'pending' AND decided_by IS NULL AND decided_at IS NULL)
+        OR (status IN ('posted', 'rejected'))
+    )
+);
+
+CREATE TABLE IF NOT EXISTS adjustment_audit_records (
+    id            bigserial    PRIMARY KEY,
+    adjustment_id uuid         NOT NULL CONSTRAINT fk_adjustments_audit REFERENCES adjustments (id),
+    action        varchar(16)  NOT NULL,
+    actor         varchar(128) NOT NULL,
+    comment       text         NOT NULL DEFAULT '',
+    created_at    timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_adjustments_transaction_id ON adjustments (transaction_id);
+CREATE INDEX IF NOT EXISTS idx_adjustments_user_id ON adjustments (user_id);
+CREATE INDEX IF NOT EXISTS idx_adjustments_status ON adjustments (status);
+CREATE INDEX IF NOT EXISTS idx_adjustment_audit_records_adjustment_id ON adjustment_audit_records (adjustment_id);
+
+-- Audit records are immutable: only inserts are allowed, and the foreign key keeps the audited
+-- adjustment from being deleted.
+CREATE OR REPLACE FUNCTION reject_adjustment_audit_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'adjustment audit records cannot be changed';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS adjustment_audit_records_immutable ON adjustment_audit_records;
+CREATE TRIGGER adjustment_audit_records_immutable BEFORE UPDATE OR DELETE ON adjustment_audit_records
+    FOR EACH ROW EXECUTE FUNCTION reject_adjustment_audit_change();
+
+DROP TRIGGER IF EXISTS adjustment_audit_records_no_truncate ON adjustment_audit_records;
+CREATE TRIGGER adjustment_audit_records_no_truncate BEFORE TRUNCATE ON adjustment_audit_records
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_adjustment_audit_change();
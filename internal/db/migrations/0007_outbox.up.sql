@@ -0,0 +1,23 @@
+-- Transactional outbox: domain events written in the same transaction as the change they describe
+-- and published by the relay afterwards.
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id              bigserial    PRIMARY KEY,
+    event_id        uuid         NOT NULL,
+    event_type      varchar(64)  NOT NULL,
+    aggregate_key   varchar(64)  NOT NULL,
+    payload         jsonb        NOT NULL,
+    attempts        integer      NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz  NOT NULL DEFAULT now(),
+    last_error      text,
+    published_at    timestamptz,
+    created_at      timestamptz  NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_event_id ON outbox_events (event_id);
+-- The relay only ever looks at unpublished events.
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (next_attempt_at, id)
+    WHERE published_at IS NULL;
+-- Published events are purged once they are past their retention.
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published_at ON outbox_events (published_at)
+    WHERE published_at IS NOT NULL;
@@ -0,0 +1,42 @@
+-- Webhook subscriptions of partners and the deliveries of balance change events to them.
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id           uuid          PRIMARY KEY DEFAULT gen_random_uuid(),
+    url          varchar(2048) NOT NULL,
+    description  varchar(255)  NOT NULL DEFAULT '',
+    secret       varchar(128)  NOT NULL,
+    source_types varchar(255)  NOT NULL DEFAULT '',
+    states       varchar(255)  NOT NULL DEFAULT '',
+    currencies   varchar(255)  NOT NULL DEFAULT '',
+    min_amount   varchar(64)   NOT NULL DEFAULT '',
+    active       boolean       NOT NULL DEFAULT true,
+    created_by   varchar(128)  NOT NULL,
+    created_at   timestamptz,
+    updated_at   timestamptz
+);
+
+-- Deleting a subscription deletes its delivery log with it.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id               uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+    subscription_id  uuid        NOT NULL CONSTRAINT fk_webhook_subscriptions_deliveries
+        REFERENCES webhook_subscriptions (id) ON DELETE CASCADE,
+    event_id         uuid        NOT NULL,
+    event_type       varchar(64) NOT NULL,
+    payload          jsonb       NOT NULL,
+    status           varchar(10) NOT NULL CONSTRAINT chk_webhook_deliveries_status
+        CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts         integer     NOT NULL DEFAULT 0,
+    next_attempt_at  timestamptz NOT NULL DEFAULT now(),
+    last_status_code integer,
+    last_error       text,
+    delivered_at     timestamptz,
+    created_at       timestamptz,
+    updated_at       timestamptz
+);
+
+-- An event is delivered to a subscription once, however often the outbox relay hands it over.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_event
+    ON webhook_deliveries (subscription_id, event_id);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_pending ON webhook_deliveries (next_attempt_at)
+    WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created_at ON webhook_deliveries (created_at);
@@ -0,0 +1,2 @@
+-- Balance streams replay a user's missed events and read the last event of each wallet.
+CREATE INDEX IF NOT EXISTS idx_outbox_events_aggregate ON outbox_events (aggregate_key, id);
@@ -0,0 +1,10 @@
+// Package migrations embeds the versioned SQL migrations of the database schema.
+//
+// Each version NNNN has a NNNN_name.up.sql file and a matching NNNN_name.down.sql file that reverts it.
+// Applied migrations must never be edited; schema changes go into a new version.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
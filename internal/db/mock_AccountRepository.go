@@ -0,0 +1,374 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAccountRepository creates a new instance of MockAccountRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountRepository {
+	mock := &MockAccountRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccountRepository is an autogenerated mock type for the AccountRepository type
+type MockAccountRepository struct {
+	mock.Mock
+}
+
+type MockAccountRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountRepository) EXPECT() *MockAccountRepository_Expecter {
+	return &MockAccountRepository_Expecter{mock: &_m.Mock}
+}
+
+// CloseUser provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) CloseUser(ctx context.Context, userID uint64) (User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseUser")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_CloseUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseUser'
+type MockAccountRepository_CloseUser_Call struct {
+	*mock.Call
+}
+
+// CloseUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockAccountRepository_Expecter) CloseUser(ctx interface{}, userID interface{}) *MockAccountRepository_CloseUser_Call {
+	return &MockAccountRepository_CloseUser_Call{Call: _e.mock.On("CloseUser", ctx, userID)}
+}
+
+func (_c *MockAccountRepository_CloseUser_Call) Run(run func(ctx context.Context, userID uint64)) *MockAccountRepository_CloseUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_CloseUser_Call) Return(user User, err error) *MockAccountRepository_CloseUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_CloseUser_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (User, error)) *MockAccountRepository_CloseUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) CreateUser(ctx context.Context, user User) (User, error) {
+	ret := _mock.Called(ctx, user)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, User) (User, error)); ok {
+		return returnFunc(ctx, user)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, User) User); ok {
+		r0 = returnFunc(ctx, user)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, User) error); ok {
+		r1 = returnFunc(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockAccountRepository_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - user User
+func (_e *MockAccountRepository_Expecter) CreateUser(ctx interface{}, user interface{}) *MockAccountRepository_CreateUser_Call {
+	return &MockAccountRepository_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, user)}
+}
+
+func (_c *MockAccountRepository_CreateUser_Call) Run(run func(ctx context.Context, user User)) *MockAccountRepository_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 User
+		if args[1] != nil {
+			arg1 = args[1].(User)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_CreateUser_Call) Return(user1 User, err error) *MockAccountRepository_CreateUser_Call {
+	_c.Call.Return(user1, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_CreateUser_Call) RunAndReturn(run func(ctx context.Context, user User) (User, error)) *MockAccountRepository_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FreezeUser provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) FreezeUser(ctx context.Context, userID uint64, scope string) (User, error) {
+	ret := _mock.Called(ctx, userID, scope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FreezeUser")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (User, error)); ok {
+		return returnFunc(ctx, userID, scope)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) User); ok {
+		r0 = returnFunc(ctx, userID, scope)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, userID, scope)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_FreezeUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FreezeUser'
+type MockAccountRepository_FreezeUser_Call struct {
+	*mock.Call
+}
+
+// FreezeUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - scope string
+func (_e *MockAccountRepository_Expecter) FreezeUser(ctx interface{}, userID interface{}, scope interface{}) *MockAccountRepository_FreezeUser_Call {
+	return &MockAccountRepository_FreezeUser_Call{Call: _e.mock.On("FreezeUser", ctx, userID, scope)}
+}
+
+func (_c *MockAccountRepository_FreezeUser_Call) Run(run func(ctx context.Context, userID uint64, scope string)) *MockAccountRepository_FreezeUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_FreezeUser_Call) Return(user User, err error) *MockAccountRepository_FreezeUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_FreezeUser_Call) RunAndReturn(run func(ctx context.Context, userID uint64, scope string) (User, error)) *MockAccountRepository_FreezeUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) GetUser(ctx context.Context, userID uint64) (User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockAccountRepository_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockAccountRepository_Expecter) GetUser(ctx interface{}, userID interface{}) *MockAccountRepository_GetUser_Call {
+	return &MockAccountRepository_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *MockAccountRepository_GetUser_Call) Run(run func(ctx context.Context, userID uint64)) *MockAccountRepository_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_GetUser_Call) Return(user User, err error) *MockAccountRepository_GetUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_GetUser_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (User, error)) *MockAccountRepository_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnfreezeUser provides a mock function for the type MockAccountRepository
+func (_mock *MockAccountRepository) UnfreezeUser(ctx context.Context, userID uint64) (User, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnfreezeUser")
+	}
+
+	var r0 User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (User, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) User); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(User)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountRepository_UnfreezeUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnfreezeUser'
+type MockAccountRepository_UnfreezeUser_Call struct {
+	*mock.Call
+}
+
+// UnfreezeUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockAccountRepository_Expecter) UnfreezeUser(ctx interface{}, userID interface{}) *MockAccountRepository_UnfreezeUser_Call {
+	return &MockAccountRepository_UnfreezeUser_Call{Call: _e.mock.On("UnfreezeUser", ctx, userID)}
+}
+
+func (_c *MockAccountRepository_UnfreezeUser_Call) Run(run func(ctx context.Context, userID uint64)) *MockAccountRepository_UnfreezeUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountRepository_UnfreezeUser_Call) Return(user User, err error) *MockAccountRepository_UnfreezeUser_Call {
+	_c.Call.Return(user, err)
+	return _c
+}
+
+func (_c *MockAccountRepository_UnfreezeUser_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (User, error)) *MockAccountRepository_UnfreezeUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
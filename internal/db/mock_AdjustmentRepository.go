@@ -0,0 +1,401 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAdjustmentRepository creates a new instance of MockAdjustmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAdjustmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAdjustmentRepository {
+	mock := &MockAdjustmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAdjustmentRepository is an autogenerated mock type for the AdjustmentRepository type
+type MockAdjustmentRepository struct {
+	mock.Mock
+}
+
+type MockAdjustmentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAdjustmentRepository) EXPECT() *MockAdjustmentRepository_Expecter {
+	return &MockAdjustmentRepository_Expecter{mock: &_m.Mock}
+}
+
+// ApproveAdjustment provides a mock function for the type MockAdjustmentRepository
+func (_mock *MockAdjustmentRepository) ApproveAdjustment(ctx context.Context, id uuid.UUID, approver string, comment string) (Adjustment, error) {
+	ret := _mock.Called(ctx, id, approver, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveAdjustment")
+	}
+
+	var r0 Adjustment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) (Adjustment, error)); ok {
+		return returnFunc(ctx, id, approver, comment)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) Adjustment); ok {
+		r0 = returnFunc(ctx, id, approver, comment)
+	} else {
+		r0 = ret.Get(0).(Adjustment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r1 = returnFunc(ctx, id, approver, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentRepository_ApproveAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveAdjustment'
+type MockAdjustmentRepository_ApproveAdjustment_Call struct {
+	*mock.Call
+}
+
+// ApproveAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - approver string
+//   - comment string
+func (_e *MockAdjustmentRepository_Expecter) ApproveAdjustment(ctx interface{}, id interface{}, approver interface{}, comment interface{}) *MockAdjustmentRepository_ApproveAdjustment_Call {
+	return &MockAdjustmentRepository_ApproveAdjustment_Call{Call: _e.mock.On("ApproveAdjustment", ctx, id, approver, comment)}
+}
+
+func (_c *MockAdjustmentRepository_ApproveAdjustment_Call) Run(run func(ctx context.Context, id uuid.UUID, approver string, comment string)) *MockAdjustmentRepository_ApproveAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_ApproveAdjustment_Call) Return(adjustment Adjustment, err error) *MockAdjustmentRepository_ApproveAdjustment_Call {
+	_c.Call.Return(adjustment, err)
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_ApproveAdjustment_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, approver string, comment string) (Adjustment, error)) *MockAdjustmentRepository_ApproveAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateAdjustment provides a mock function for the type MockAdjustmentRepository
+func (_mock *MockAdjustmentRepository) CreateAdjustment(ctx context.Context, adjustment Adjustment, post bool) (Adjustment, error) {
+	ret := _mock.Called(ctx, adjustment, post)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAdjustment")
+	}
+
+	var r0 Adjustment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Adjustment, bool) (Adjustment, error)); ok {
+		return returnFunc(ctx, adjustment, post)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Adjustment, bool) Adjustment); ok {
+		r0 = returnFunc(ctx, adjustment, post)
+	} else {
+		r0 = ret.Get(0).(Adjustment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, Adjustment, bool) error); ok {
+		r1 = returnFunc(ctx, adjustment, post)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentRepository_CreateAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAdjustment'
+type MockAdjustmentRepository_CreateAdjustment_Call struct {
+	*mock.Call
+}
+
+// CreateAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adjustment Adjustment
+//   - post bool
+func (_e *MockAdjustmentRepository_Expecter) CreateAdjustment(ctx interface{}, adjustment interface{}, post interface{}) *MockAdjustmentRepository_CreateAdjustment_Call {
+	return &MockAdjustmentRepository_CreateAdjustment_Call{Call: _e.mock.On("CreateAdjustment", ctx, adjustment, post)}
+}
+
+func (_c *MockAdjustmentRepository_CreateAdjustment_Call) Run(run func(ctx context.Context, adjustment Adjustment, post bool)) *MockAdjustmentRepository_CreateAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 Adjustment
+		if args[1] != nil {
+			arg1 = args[1].(Adjustment)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_CreateAdjustment_Call) Return(adjustment1 Adjustment, err error) *MockAdjustmentRepository_CreateAdjustment_Call {
+	_c.Call.Return(adjustment1, err)
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_CreateAdjustment_Call) RunAndReturn(run func(ctx context.Context, adjustment Adjustment, post bool) (Adjustment, error)) *MockAdjustmentRepository_CreateAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAdjustment provides a mock function for the type MockAdjustmentRepository
+func (_mock *MockAdjustmentRepository) GetAdjustment(ctx context.Context, id uuid.UUID) (Adjustment, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdjustment")
+	}
+
+	var r0 Adjustment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (Adjustment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) Adjustment); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Adjustment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentRepository_GetAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdjustment'
+type MockAdjustmentRepository_GetAdjustment_Call struct {
+	*mock.Call
+}
+
+// GetAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockAdjustmentRepository_Expecter) GetAdjustment(ctx interface{}, id interface{}) *MockAdjustmentRepository_GetAdjustment_Call {
+	return &MockAdjustmentRepository_GetAdjustment_Call{Call: _e.mock.On("GetAdjustment", ctx, id)}
+}
+
+func (_c *MockAdjustmentRepository_GetAdjustment_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockAdjustmentRepository_GetAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_GetAdjustment_Call) Return(adjustment Adjustment, err error) *MockAdjustmentRepository_GetAdjustment_Call {
+	_c.Call.Return(adjustment, err)
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_GetAdjustment_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (Adjustment, error)) *MockAdjustmentRepository_GetAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAdjustments provides a mock function for the type MockAdjustmentRepository
+func (_mock *MockAdjustmentRepository) ListAdjustments(ctx context.Context, filter AdjustmentFilter) ([]Adjustment, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAdjustments")
+	}
+
+	var r0 []Adjustment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, AdjustmentFilter) ([]Adjustment, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, AdjustmentFilter) []Adjustment); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Adjustment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, AdjustmentFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentRepository_ListAdjustments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAdjustments'
+type MockAdjustmentRepository_ListAdjustments_Call struct {
+	*mock.Call
+}
+
+// ListAdjustments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter AdjustmentFilter
+func (_e *MockAdjustmentRepository_Expecter) ListAdjustments(ctx interface{}, filter interface{}) *MockAdjustmentRepository_ListAdjustments_Call {
+	return &MockAdjustmentRepository_ListAdjustments_Call{Call: _e.mock.On("ListAdjustments", ctx, filter)}
+}
+
+func (_c *MockAdjustmentRepository_ListAdjustments_Call) Run(run func(ctx context.Context, filter AdjustmentFilter)) *MockAdjustmentRepository_ListAdjustments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 AdjustmentFilter
+		if args[1] != nil {
+			arg1 = args[1].(AdjustmentFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_ListAdjustments_Call) Return(adjustments []Adjustment, err error) *MockAdjustmentRepository_ListAdjustments_Call {
+	_c.Call.Return(adjustments, err)
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_ListAdjustments_Call) RunAndReturn(run func(ctx context.Context, filter AdjustmentFilter) ([]Adjustment, error)) *MockAdjustmentRepository_ListAdjustments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectAdjustment provides a mock function for the type MockAdjustmentRepository
+func (_mock *MockAdjustmentRepository) RejectAdjustment(ctx context.Context, id uuid.UUID, rejecter string, comment string) (Adjustment, error) {
+	ret := _mock.Called(ctx, id, rejecter, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectAdjustment")
+	}
+
+	var r0 Adjustment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) (Adjustment, error)); ok {
+		return returnFunc(ctx, id, rejecter, comment)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, string, string) Adjustment); ok {
+		r0 = returnFunc(ctx, id, rejecter, comment)
+	} else {
+		r0 = ret.Get(0).(Adjustment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID, string, string) error); ok {
+		r1 = returnFunc(ctx, id, rejecter, comment)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentRepository_RejectAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectAdjustment'
+type MockAdjustmentRepository_RejectAdjustment_Call struct {
+	*mock.Call
+}
+
+// RejectAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - rejecter string
+//   - comment string
+func (_e *MockAdjustmentRepository_Expecter) RejectAdjustment(ctx interface{}, id interface{}, rejecter interface{}, comment interface{}) *MockAdjustmentRepository_RejectAdjustment_Call {
+	return &MockAdjustmentRepository_RejectAdjustment_Call{Call: _e.mock.On("RejectAdjustment", ctx, id, rejecter, comment)}
+}
+
+func (_c *MockAdjustmentRepository_RejectAdjustment_Call) Run(run func(ctx context.Context, id uuid.UUID, rejecter string, comment string)) *MockAdjustmentRepository_RejectAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_RejectAdjustment_Call) Return(adjustment Adjustment, err error) *MockAdjustmentRepository_RejectAdjustment_Call {
+	_c.Call.Return(adjustment, err)
+	return _c
+}
+
+func (_c *MockAdjustmentRepository_RejectAdjustment_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, rejecter string, comment string) (Adjustment, error)) *MockAdjustmentRepository_RejectAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
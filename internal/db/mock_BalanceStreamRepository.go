@@ -0,0 +1,184 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBalanceStreamRepository creates a new instance of MockBalanceStreamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBalanceStreamRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBalanceStreamRepository {
+	mock := &MockBalanceStreamRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBalanceStreamRepository is an autogenerated mock type for the BalanceStreamRepository type
+type MockBalanceStreamRepository struct {
+	mock.Mock
+}
+
+type MockBalanceStreamRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBalanceStreamRepository) EXPECT() *MockBalanceStreamRepository_Expecter {
+	return &MockBalanceStreamRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetBalanceSnapshot provides a mock function for the type MockBalanceStreamRepository
+func (_mock *MockBalanceStreamRepository) GetBalanceSnapshot(ctx context.Context, userID uint64) (BalanceSnapshot, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceSnapshot")
+	}
+
+	var r0 BalanceSnapshot
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (BalanceSnapshot, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) BalanceSnapshot); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(BalanceSnapshot)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBalanceStreamRepository_GetBalanceSnapshot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBalanceSnapshot'
+type MockBalanceStreamRepository_GetBalanceSnapshot_Call struct {
+	*mock.Call
+}
+
+// GetBalanceSnapshot is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockBalanceStreamRepository_Expecter) GetBalanceSnapshot(ctx interface{}, userID interface{}) *MockBalanceStreamRepository_GetBalanceSnapshot_Call {
+	return &MockBalanceStreamRepository_GetBalanceSnapshot_Call{Call: _e.mock.On("GetBalanceSnapshot", ctx, userID)}
+}
+
+func (_c *MockBalanceStreamRepository_GetBalanceSnapshot_Call) Run(run func(ctx context.Context, userID uint64)) *MockBalanceStreamRepository_GetBalanceSnapshot_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBalanceStreamRepository_GetBalanceSnapshot_Call) Return(balanceSnapshot BalanceSnapshot, err error) *MockBalanceStreamRepository_GetBalanceSnapshot_Call {
+	_c.Call.Return(balanceSnapshot, err)
+	return _c
+}
+
+func (_c *MockBalanceStreamRepository_GetBalanceSnapshot_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (BalanceSnapshot, error)) *MockBalanceStreamRepository_GetBalanceSnapshot_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListBalanceChanges provides a mock function for the type MockBalanceStreamRepository
+func (_mock *MockBalanceStreamRepository) ListBalanceChanges(ctx context.Context, userID uint64, afterID uint64, limit int) ([]OutboxEvent, error) {
+	ret := _mock.Called(ctx, userID, afterID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListBalanceChanges")
+	}
+
+	var r0 []OutboxEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64, int) ([]OutboxEvent, error)); ok {
+		return returnFunc(ctx, userID, afterID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64, int) []OutboxEvent); ok {
+		r0 = returnFunc(ctx, userID, afterID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]OutboxEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, uint64, int) error); ok {
+		r1 = returnFunc(ctx, userID, afterID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBalanceStreamRepository_ListBalanceChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListBalanceChanges'
+type MockBalanceStreamRepository_ListBalanceChanges_Call struct {
+	*mock.Call
+}
+
+// ListBalanceChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - afterID uint64
+//   - limit int
+func (_e *MockBalanceStreamRepository_Expecter) ListBalanceChanges(ctx interface{}, userID interface{}, afterID interface{}, limit interface{}) *MockBalanceStreamRepository_ListBalanceChanges_Call {
+	return &MockBalanceStreamRepository_ListBalanceChanges_Call{Call: _e.mock.On("ListBalanceChanges", ctx, userID, afterID, limit)}
+}
+
+func (_c *MockBalanceStreamRepository_ListBalanceChanges_Call) Run(run func(ctx context.Context, userID uint64, afterID uint64, limit int)) *MockBalanceStreamRepository_ListBalanceChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBalanceStreamRepository_ListBalanceChanges_Call) Return(outboxEvents []OutboxEvent, err error) *MockBalanceStreamRepository_ListBalanceChanges_Call {
+	_c.Call.Return(outboxEvents, err)
+	return _c
+}
+
+func (_c *MockBalanceStreamRepository_ListBalanceChanges_Call) RunAndReturn(run func(ctx context.Context, userID uint64, afterID uint64, limit int) ([]OutboxEvent, error)) *MockBalanceStreamRepository_ListBalanceChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,215 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExportRepository creates a new instance of MockExportRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportRepository {
+	mock := &MockExportRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportRepository is an autogenerated mock type for the ExportRepository type
+type MockExportRepository struct {
+	mock.Mock
+}
+
+type MockExportRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportRepository) EXPECT() *MockExportRepository_Expecter {
+	return &MockExportRepository_Expecter{mock: &_m.Mock}
+}
+
+// ExportTransactions provides a mock function for the type MockExportRepository
+func (_mock *MockExportRepository) ExportTransactions(ctx context.Context, userID uint64, fn func([]Transaction) error) error {
+	ret := _mock.Called(ctx, userID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTransactions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, func([]Transaction) error) error); ok {
+		r0 = returnFunc(ctx, userID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportRepository_ExportTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTransactions'
+type MockExportRepository_ExportTransactions_Call struct {
+	*mock.Call
+}
+
+// ExportTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - fn func([]Transaction) error
+func (_e *MockExportRepository_Expecter) ExportTransactions(ctx interface{}, userID interface{}, fn interface{}) *MockExportRepository_ExportTransactions_Call {
+	return &MockExportRepository_ExportTransactions_Call{Call: _e.mock.On("ExportTransactions", ctx, userID, fn)}
+}
+
+func (_c *MockExportRepository_ExportTransactions_Call) Run(run func(ctx context.Context, userID uint64, fn func([]Transaction) error)) *MockExportRepository_ExportTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 func([]Transaction) error
+		if args[2] != nil {
+			arg2 = args[2].(func([]Transaction) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportRepository_ExportTransactions_Call) Return(err error) *MockExportRepository_ExportTransactions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportRepository_ExportTransactions_Call) RunAndReturn(run func(ctx context.Context, userID uint64, fn func([]Transaction) error) error) *MockExportRepository_ExportTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportUsers provides a mock function for the type MockExportRepository
+func (_mock *MockExportRepository) ExportUsers(ctx context.Context, fn func([]User) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func([]User) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportRepository_ExportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUsers'
+type MockExportRepository_ExportUsers_Call struct {
+	*mock.Call
+}
+
+// ExportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func([]User) error
+func (_e *MockExportRepository_Expecter) ExportUsers(ctx interface{}, fn interface{}) *MockExportRepository_ExportUsers_Call {
+	return &MockExportRepository_ExportUsers_Call{Call: _e.mock.On("ExportUsers", ctx, fn)}
+}
+
+func (_c *MockExportRepository_ExportUsers_Call) Run(run func(ctx context.Context, fn func([]User) error)) *MockExportRepository_ExportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func([]User) error
+		if args[1] != nil {
+			arg1 = args[1].(func([]User) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportRepository_ExportUsers_Call) Return(err error) *MockExportRepository_ExportUsers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportRepository_ExportUsers_Call) RunAndReturn(run func(ctx context.Context, fn func([]User) error) error) *MockExportRepository_ExportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportWallets provides a mock function for the type MockExportRepository
+func (_mock *MockExportRepository) ExportWallets(ctx context.Context, fn func([]Wallet) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportWallets")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func([]Wallet) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportRepository_ExportWallets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportWallets'
+type MockExportRepository_ExportWallets_Call struct {
+	*mock.Call
+}
+
+// ExportWallets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func([]Wallet) error
+func (_e *MockExportRepository_Expecter) ExportWallets(ctx interface{}, fn interface{}) *MockExportRepository_ExportWallets_Call {
+	return &MockExportRepository_ExportWallets_Call{Call: _e.mock.On("ExportWallets", ctx, fn)}
+}
+
+func (_c *MockExportRepository_ExportWallets_Call) Run(run func(ctx context.Context, fn func([]Wallet) error)) *MockExportRepository_ExportWallets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func([]Wallet) error
+		if args[1] != nil {
+			arg1 = args[1].(func([]Wallet) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportRepository_ExportWallets_Call) Return(err error) *MockExportRepository_ExportWallets_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportRepository_ExportWallets_Call) RunAndReturn(run func(ctx context.Context, fn func([]Wallet) error) error) *MockExportRepository_ExportWallets_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,345 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHoldRepository creates a new instance of MockHoldRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHoldRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHoldRepository {
+	mock := &MockHoldRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHoldRepository is an autogenerated mock type for the HoldRepository type
+type MockHoldRepository struct {
+	mock.Mock
+}
+
+type MockHoldRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHoldRepository) EXPECT() *MockHoldRepository_Expecter {
+	return &MockHoldRepository_Expecter{mock: &_m.Mock}
+}
+
+// ExpireHolds provides a mock function for the type MockHoldRepository
+func (_mock *MockHoldRepository) ExpireHolds(ctx context.Context, now time.Time, limit int) (int, error) {
+	ret := _mock.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireHolds")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) (int, error)); ok {
+		return returnFunc(ctx, now, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time, int) int); ok {
+		r0 = returnFunc(ctx, now, limit)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = returnFunc(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldRepository_ExpireHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireHolds'
+type MockHoldRepository_ExpireHolds_Call struct {
+	*mock.Call
+}
+
+// ExpireHolds is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - limit int
+func (_e *MockHoldRepository_Expecter) ExpireHolds(ctx interface{}, now interface{}, limit interface{}) *MockHoldRepository_ExpireHolds_Call {
+	return &MockHoldRepository_ExpireHolds_Call{Call: _e.mock.On("ExpireHolds", ctx, now, limit)}
+}
+
+func (_c *MockHoldRepository_ExpireHolds_Call) Run(run func(ctx context.Context, now time.Time, limit int)) *MockHoldRepository_ExpireHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldRepository_ExpireHolds_Call) Return(n int, err error) *MockHoldRepository_ExpireHolds_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockHoldRepository_ExpireHolds_Call) RunAndReturn(run func(ctx context.Context, now time.Time, limit int) (int, error)) *MockHoldRepository_ExpireHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlaceHold provides a mock function for the type MockHoldRepository
+func (_mock *MockHoldRepository) PlaceHold(ctx context.Context, hold Hold) (Hold, error) {
+	ret := _mock.Called(ctx, hold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlaceHold")
+	}
+
+	var r0 Hold
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Hold) (Hold, error)); ok {
+		return returnFunc(ctx, hold)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Hold) Hold); ok {
+		r0 = returnFunc(ctx, hold)
+	} else {
+		r0 = ret.Get(0).(Hold)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, Hold) error); ok {
+		r1 = returnFunc(ctx, hold)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldRepository_PlaceHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlaceHold'
+type MockHoldRepository_PlaceHold_Call struct {
+	*mock.Call
+}
+
+// PlaceHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - hold Hold
+func (_e *MockHoldRepository_Expecter) PlaceHold(ctx interface{}, hold interface{}) *MockHoldRepository_PlaceHold_Call {
+	return &MockHoldRepository_PlaceHold_Call{Call: _e.mock.On("PlaceHold", ctx, hold)}
+}
+
+func (_c *MockHoldRepository_PlaceHold_Call) Run(run func(ctx context.Context, hold Hold)) *MockHoldRepository_PlaceHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 Hold
+		if args[1] != nil {
+			arg1 = args[1].(Hold)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldRepository_PlaceHold_Call) Return(hold1 Hold, err error) *MockHoldRepository_PlaceHold_Call {
+	_c.Call.Return(hold1, err)
+	return _c
+}
+
+func (_c *MockHoldRepository_PlaceHold_Call) RunAndReturn(run func(ctx context.Context, hold Hold) (Hold, error)) *MockHoldRepository_PlaceHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseHold provides a mock function for the type MockHoldRepository
+func (_mock *MockHoldRepository) ReleaseHold(ctx context.Context, userID uint64, holdID string) (Hold, error) {
+	ret := _mock.Called(ctx, userID, holdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseHold")
+	}
+
+	var r0 Hold
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (Hold, error)); ok {
+		return returnFunc(ctx, userID, holdID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) Hold); ok {
+		r0 = returnFunc(ctx, userID, holdID)
+	} else {
+		r0 = ret.Get(0).(Hold)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, userID, holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldRepository_ReleaseHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseHold'
+type MockHoldRepository_ReleaseHold_Call struct {
+	*mock.Call
+}
+
+// ReleaseHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - holdID string
+func (_e *MockHoldRepository_Expecter) ReleaseHold(ctx interface{}, userID interface{}, holdID interface{}) *MockHoldRepository_ReleaseHold_Call {
+	return &MockHoldRepository_ReleaseHold_Call{Call: _e.mock.On("ReleaseHold", ctx, userID, holdID)}
+}
+
+func (_c *MockHoldRepository_ReleaseHold_Call) Run(run func(ctx context.Context, userID uint64, holdID string)) *MockHoldRepository_ReleaseHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldRepository_ReleaseHold_Call) Return(hold Hold, err error) *MockHoldRepository_ReleaseHold_Call {
+	_c.Call.Return(hold, err)
+	return _c
+}
+
+func (_c *MockHoldRepository_ReleaseHold_Call) RunAndReturn(run func(ctx context.Context, userID uint64, holdID string) (Hold, error)) *MockHoldRepository_ReleaseHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SettleHold provides a mock function for the type MockHoldRepository
+func (_mock *MockHoldRepository) SettleHold(ctx context.Context, userID uint64, holdID string, state string, payout int64, payoutCurrency string, principal string) (Hold, error) {
+	ret := _mock.Called(ctx, userID, holdID, state, payout, payoutCurrency, principal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleHold")
+	}
+
+	var r0 Hold
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string, string, int64, string, string) (Hold, error)); ok {
+		return returnFunc(ctx, userID, holdID, state, payout, payoutCurrency, principal)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string, string, int64, string, string) Hold); ok {
+		r0 = returnFunc(ctx, userID, holdID, state, payout, payoutCurrency, principal)
+	} else {
+		r0 = ret.Get(0).(Hold)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string, string, int64, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, holdID, state, payout, payoutCurrency, principal)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldRepository_SettleHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleHold'
+type MockHoldRepository_SettleHold_Call struct {
+	*mock.Call
+}
+
+// SettleHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - holdID string
+//   - state string
+//   - payout int64
+//   - payoutCurrency string
+//   - principal string
+func (_e *MockHoldRepository_Expecter) SettleHold(ctx interface{}, userID interface{}, holdID interface{}, state interface{}, payout interface{}, payoutCurrency interface{}, principal interface{}) *MockHoldRepository_SettleHold_Call {
+	return &MockHoldRepository_SettleHold_Call{Call: _e.mock.On("SettleHold", ctx, userID, holdID, state, payout, payoutCurrency, principal)}
+}
+
+func (_c *MockHoldRepository_SettleHold_Call) Run(run func(ctx context.Context, userID uint64, holdID string, state string, payout int64, payoutCurrency string, principal string)) *MockHoldRepository_SettleHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 int64
+		if args[4] != nil {
+			arg4 = args[4].(int64)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 string
+		if args[6] != nil {
+			arg6 = args[6].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldRepository_SettleHold_Call) Return(hold Hold, err error) *MockHoldRepository_SettleHold_Call {
+	_c.Call.Return(hold, err)
+	return _c
+}
+
+func (_c *MockHoldRepository_SettleHold_Call) RunAndReturn(run func(ctx context.Context, userID uint64, holdID string, state string, payout int64, payoutCurrency string, principal string) (Hold, error)) *MockHoldRepository_SettleHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,365 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOutboxRepository creates a new instance of MockOutboxRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxRepository {
+	mock := &MockOutboxRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxRepository is an autogenerated mock type for the OutboxRepository type
+type MockOutboxRepository struct {
+	mock.Mock
+}
+
+type MockOutboxRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxRepository) EXPECT() *MockOutboxRepository_Expecter {
+	return &MockOutboxRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimOutboxEvents provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error) {
+	ret := _mock.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimOutboxEvents")
+	}
+
+	var r0 []OutboxEvent
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]OutboxEvent, error)); ok {
+		return returnFunc(ctx, limit, lease)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Duration) []OutboxEvent); ok {
+		r0 = returnFunc(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]OutboxEvent)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = returnFunc(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_ClaimOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimOutboxEvents'
+type MockOutboxRepository_ClaimOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// ClaimOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - lease time.Duration
+func (_e *MockOutboxRepository_Expecter) ClaimOutboxEvents(ctx interface{}, limit interface{}, lease interface{}) *MockOutboxRepository_ClaimOutboxEvents_Call {
+	return &MockOutboxRepository_ClaimOutboxEvents_Call{Call: _e.mock.On("ClaimOutboxEvents", ctx, limit, lease)}
+}
+
+func (_c *MockOutboxRepository_ClaimOutboxEvents_Call) Run(run func(ctx context.Context, limit int, lease time.Duration)) *MockOutboxRepository_ClaimOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_ClaimOutboxEvents_Call) Return(outboxEvents []OutboxEvent, err error) *MockOutboxRepository_ClaimOutboxEvents_Call {
+	_c.Call.Return(outboxEvents, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_ClaimOutboxEvents_Call) RunAndReturn(run func(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error)) *MockOutboxRepository_ClaimOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOutboxBacklog provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) GetOutboxBacklog(ctx context.Context) (OutboxBacklog, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOutboxBacklog")
+	}
+
+	var r0 OutboxBacklog
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (OutboxBacklog, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) OutboxBacklog); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(OutboxBacklog)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_GetOutboxBacklog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOutboxBacklog'
+type MockOutboxRepository_GetOutboxBacklog_Call struct {
+	*mock.Call
+}
+
+// GetOutboxBacklog is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOutboxRepository_Expecter) GetOutboxBacklog(ctx interface{}) *MockOutboxRepository_GetOutboxBacklog_Call {
+	return &MockOutboxRepository_GetOutboxBacklog_Call{Call: _e.mock.On("GetOutboxBacklog", ctx)}
+}
+
+func (_c *MockOutboxRepository_GetOutboxBacklog_Call) Run(run func(ctx context.Context)) *MockOutboxRepository_GetOutboxBacklog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_GetOutboxBacklog_Call) Return(outboxBacklog OutboxBacklog, err error) *MockOutboxRepository_GetOutboxBacklog_Call {
+	_c.Call.Return(outboxBacklog, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_GetOutboxBacklog_Call) RunAndReturn(run func(ctx context.Context) (OutboxBacklog, error)) *MockOutboxRepository_GetOutboxBacklog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOutboxEventFailed provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) MarkOutboxEventFailed(ctx context.Context, id uint64, retryAt time.Time, cause string) error {
+	ret := _mock.Called(ctx, id, retryAt, cause)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxEventFailed")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, time.Time, string) error); ok {
+		r0 = returnFunc(ctx, id, retryAt, cause)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepository_MarkOutboxEventFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOutboxEventFailed'
+type MockOutboxRepository_MarkOutboxEventFailed_Call struct {
+	*mock.Call
+}
+
+// MarkOutboxEventFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+//   - retryAt time.Time
+//   - cause string
+func (_e *MockOutboxRepository_Expecter) MarkOutboxEventFailed(ctx interface{}, id interface{}, retryAt interface{}, cause interface{}) *MockOutboxRepository_MarkOutboxEventFailed_Call {
+	return &MockOutboxRepository_MarkOutboxEventFailed_Call{Call: _e.mock.On("MarkOutboxEventFailed", ctx, id, retryAt, cause)}
+}
+
+func (_c *MockOutboxRepository_MarkOutboxEventFailed_Call) Run(run func(ctx context.Context, id uint64, retryAt time.Time, cause string)) *MockOutboxRepository_MarkOutboxEventFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkOutboxEventFailed_Call) Return(err error) *MockOutboxRepository_MarkOutboxEventFailed_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkOutboxEventFailed_Call) RunAndReturn(run func(ctx context.Context, id uint64, retryAt time.Time, cause string) error) *MockOutboxRepository_MarkOutboxEventFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOutboxEventPublished provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) MarkOutboxEventPublished(ctx context.Context, id uint64) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOutboxEventPublished")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockOutboxRepository_MarkOutboxEventPublished_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOutboxEventPublished'
+type MockOutboxRepository_MarkOutboxEventPublished_Call struct {
+	*mock.Call
+}
+
+// MarkOutboxEventPublished is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uint64
+func (_e *MockOutboxRepository_Expecter) MarkOutboxEventPublished(ctx interface{}, id interface{}) *MockOutboxRepository_MarkOutboxEventPublished_Call {
+	return &MockOutboxRepository_MarkOutboxEventPublished_Call{Call: _e.mock.On("MarkOutboxEventPublished", ctx, id)}
+}
+
+func (_c *MockOutboxRepository_MarkOutboxEventPublished_Call) Run(run func(ctx context.Context, id uint64)) *MockOutboxRepository_MarkOutboxEventPublished_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkOutboxEventPublished_Call) Return(err error) *MockOutboxRepository_MarkOutboxEventPublished_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_MarkOutboxEventPublished_Call) RunAndReturn(run func(ctx context.Context, id uint64) error) *MockOutboxRepository_MarkOutboxEventPublished_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeOutboxEvents provides a mock function for the type MockOutboxRepository
+func (_mock *MockOutboxRepository) PurgeOutboxEvents(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	ret := _mock.Called(ctx, publishedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeOutboxEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return returnFunc(ctx, publishedBefore)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = returnFunc(ctx, publishedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = returnFunc(ctx, publishedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxRepository_PurgeOutboxEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeOutboxEvents'
+type MockOutboxRepository_PurgeOutboxEvents_Call struct {
+	*mock.Call
+}
+
+// PurgeOutboxEvents is a helper method to define mock.On call
+//   - ctx context.Context
+//   - publishedBefore time.Time
+func (_e *MockOutboxRepository_Expecter) PurgeOutboxEvents(ctx interface{}, publishedBefore interface{}) *MockOutboxRepository_PurgeOutboxEvents_Call {
+	return &MockOutboxRepository_PurgeOutboxEvents_Call{Call: _e.mock.On("PurgeOutboxEvents", ctx, publishedBefore)}
+}
+
+func (_c *MockOutboxRepository_PurgeOutboxEvents_Call) Run(run func(ctx context.Context, publishedBefore time.Time)) *MockOutboxRepository_PurgeOutboxEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 time.Time
+		if args[1] != nil {
+			arg1 = args[1].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxRepository_PurgeOutboxEvents_Call) Return(n int64, err error) *MockOutboxRepository_PurgeOutboxEvents_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockOutboxRepository_PurgeOutboxEvents_Call) RunAndReturn(run func(ctx context.Context, publishedBefore time.Time) (int64, error)) *MockOutboxRepository_PurgeOutboxEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
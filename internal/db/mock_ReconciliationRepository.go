@@ -0,0 +1,166 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockReconciliationRepository creates a new instance of MockReconciliationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReconciliationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReconciliationRepository {
+	mock := &MockReconciliationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReconciliationRepository is an autogenerated mock type for the ReconciliationRepository type
+type MockReconciliationRepository struct {
+	mock.Mock
+}
+
+type MockReconciliationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReconciliationRepository) EXPECT() *MockReconciliationRepository_Expecter {
+	return &MockReconciliationRepository_Expecter{mock: &_m.Mock}
+}
+
+// FindBalanceDrift provides a mock function for the type MockReconciliationRepository
+func (_mock *MockReconciliationRepository) FindBalanceDrift(ctx context.Context) ([]BalanceDrift, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindBalanceDrift")
+	}
+
+	var r0 []BalanceDrift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]BalanceDrift, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) []BalanceDrift); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]BalanceDrift)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReconciliationRepository_FindBalanceDrift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindBalanceDrift'
+type MockReconciliationRepository_FindBalanceDrift_Call struct {
+	*mock.Call
+}
+
+// FindBalanceDrift is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockReconciliationRepository_Expecter) FindBalanceDrift(ctx interface{}) *MockReconciliationRepository_FindBalanceDrift_Call {
+	return &MockReconciliationRepository_FindBalanceDrift_Call{Call: _e.mock.On("FindBalanceDrift", ctx)}
+}
+
+func (_c *MockReconciliationRepository_FindBalanceDrift_Call) Run(run func(ctx context.Context)) *MockReconciliationRepository_FindBalanceDrift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReconciliationRepository_FindBalanceDrift_Call) Return(balanceDrifts []BalanceDrift, err error) *MockReconciliationRepository_FindBalanceDrift_Call {
+	_c.Call.Return(balanceDrifts, err)
+	return _c
+}
+
+func (_c *MockReconciliationRepository_FindBalanceDrift_Call) RunAndReturn(run func(ctx context.Context) ([]BalanceDrift, error)) *MockReconciliationRepository_FindBalanceDrift_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RepairBalanceDrift provides a mock function for the type MockReconciliationRepository
+func (_mock *MockReconciliationRepository) RepairBalanceDrift(ctx context.Context, walletID uint64) (BalanceDrift, error) {
+	ret := _mock.Called(ctx, walletID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RepairBalanceDrift")
+	}
+
+	var r0 BalanceDrift
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (BalanceDrift, error)); ok {
+		return returnFunc(ctx, walletID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) BalanceDrift); ok {
+		r0 = returnFunc(ctx, walletID)
+	} else {
+		r0 = ret.Get(0).(BalanceDrift)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, walletID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReconciliationRepository_RepairBalanceDrift_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RepairBalanceDrift'
+type MockReconciliationRepository_RepairBalanceDrift_Call struct {
+	*mock.Call
+}
+
+// RepairBalanceDrift is a helper method to define mock.On call
+//   - ctx context.Context
+//   - walletID uint64
+func (_e *MockReconciliationRepository_Expecter) RepairBalanceDrift(ctx interface{}, walletID interface{}) *MockReconciliationRepository_RepairBalanceDrift_Call {
+	return &MockReconciliationRepository_RepairBalanceDrift_Call{Call: _e.mock.On("RepairBalanceDrift", ctx, walletID)}
+}
+
+func (_c *MockReconciliationRepository_RepairBalanceDrift_Call) Run(run func(ctx context.Context, walletID uint64)) *MockReconciliationRepository_RepairBalanceDrift_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReconciliationRepository_RepairBalanceDrift_Call) Return(balanceDrift BalanceDrift, err error) *MockReconciliationRepository_RepairBalanceDrift_Call {
+	_c.Call.Return(balanceDrift, err)
+	return _c
+}
+
+func (_c *MockReconciliationRepository_RepairBalanceDrift_Call) RunAndReturn(run func(ctx context.Context, walletID uint64) (BalanceDrift, error)) *MockReconciliationRepository_RepairBalanceDrift_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -103,6 +103,209 @@ func (_c *MockUserRepository_GetUserData_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// GetUserTransaction provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) GetUserTransaction(ctx context.Context, userID uint64, transactionID string) (Transaction, error) {
+	ret := _mock.Called(ctx, userID, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserTransaction")
+	}
+
+	var r0 Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (Transaction, error)); ok {
+		return returnFunc(ctx, userID, transactionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) Transaction); ok {
+		r0 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r0 = ret.Get(0).(Transaction)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_GetUserTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserTransaction'
+type MockUserRepository_GetUserTransaction_Call struct {
+	*mock.Call
+}
+
+// GetUserTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - transactionID string
+func (_e *MockUserRepository_Expecter) GetUserTransaction(ctx interface{}, userID interface{}, transactionID interface{}) *MockUserRepository_GetUserTransaction_Call {
+	return &MockUserRepository_GetUserTransaction_Call{Call: _e.mock.On("GetUserTransaction", ctx, userID, transactionID)}
+}
+
+func (_c *MockUserRepository_GetUserTransaction_Call) Run(run func(ctx context.Context, userID uint64, transactionID string)) *MockUserRepository_GetUserTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserTransaction_Call) Return(transaction Transaction, err error) *MockUserRepository_GetUserTransaction_Call {
+	_c.Call.Return(transaction, err)
+	return _c
+}
+
+func (_c *MockUserRepository_GetUserTransaction_Call) RunAndReturn(run func(ctx context.Context, userID uint64, transactionID string) (Transaction, error)) *MockUserRepository_GetUserTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListUserTransactions provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ListUserTransactions(ctx context.Context, userID uint64, filter TransactionFilter) ([]Transaction, error) {
+	ret := _mock.Called(ctx, userID, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUserTransactions")
+	}
+
+	var r0 []Transaction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, TransactionFilter) ([]Transaction, error)); ok {
+		return returnFunc(ctx, userID, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, TransactionFilter) []Transaction); ok {
+		r0 = returnFunc(ctx, userID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Transaction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, TransactionFilter) error); ok {
+		r1 = returnFunc(ctx, userID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserRepository_ListUserTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListUserTransactions'
+type MockUserRepository_ListUserTransactions_Call struct {
+	*mock.Call
+}
+
+// ListUserTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - filter TransactionFilter
+func (_e *MockUserRepository_Expecter) ListUserTransactions(ctx interface{}, userID interface{}, filter interface{}) *MockUserRepository_ListUserTransactions_Call {
+	return &MockUserRepository_ListUserTransactions_Call{Call: _e.mock.On("ListUserTransactions", ctx, userID, filter)}
+}
+
+func (_c *MockUserRepository_ListUserTransactions_Call) Run(run func(ctx context.Context, userID uint64, filter TransactionFilter)) *MockUserRepository_ListUserTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 TransactionFilter
+		if args[2] != nil {
+			arg2 = args[2].(TransactionFilter)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ListUserTransactions_Call) Return(transactions []Transaction, err error) *MockUserRepository_ListUserTransactions_Call {
+	_c.Call.Return(transactions, err)
+	return _c
+}
+
+func (_c *MockUserRepository_ListUserTransactions_Call) RunAndReturn(run func(ctx context.Context, userID uint64, filter TransactionFilter) ([]Transaction, error)) *MockUserRepository_ListUserTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReverseTransaction provides a mock function for the type MockUserRepository
+func (_mock *MockUserRepository) ReverseTransaction(ctx context.Context, reversal Transaction) error {
+	ret := _mock.Called(ctx, reversal)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReverseTransaction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, Transaction) error); ok {
+		r0 = returnFunc(ctx, reversal)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserRepository_ReverseTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReverseTransaction'
+type MockUserRepository_ReverseTransaction_Call struct {
+	*mock.Call
+}
+
+// ReverseTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reversal Transaction
+func (_e *MockUserRepository_Expecter) ReverseTransaction(ctx interface{}, reversal interface{}) *MockUserRepository_ReverseTransaction_Call {
+	return &MockUserRepository_ReverseTransaction_Call{Call: _e.mock.On("ReverseTransaction", ctx, reversal)}
+}
+
+func (_c *MockUserRepository_ReverseTransaction_Call) Run(run func(ctx context.Context, reversal Transaction)) *MockUserRepository_ReverseTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 Transaction
+		if args[1] != nil {
+			arg1 = args[1].(Transaction)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserRepository_ReverseTransaction_Call) Return(err error) *MockUserRepository_ReverseTransaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserRepository_ReverseTransaction_Call) RunAndReturn(run func(ctx context.Context, reversal Transaction) error) *MockUserRepository_ReverseTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateUserBalance provides a mock function for the type MockUserRepository
 func (_mock *MockUserRepository) UpdateUserBalance(ctx context.Context, transaction Transaction) error {
 	ret := _mock.Called(ctx, transaction)
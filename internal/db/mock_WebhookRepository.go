@@ -0,0 +1,832 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebhookRepository creates a new instance of MockWebhookRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookRepository {
+	mock := &MockWebhookRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookRepository is an autogenerated mock type for the WebhookRepository type
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+type MockWebhookRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookRepository) EXPECT() *MockWebhookRepository_Expecter {
+	return &MockWebhookRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimWebhookDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error) {
+	ret := _mock.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimWebhookDeliveries")
+	}
+
+	var r0 []WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]WebhookDelivery, error)); ok {
+		return returnFunc(ctx, limit, lease)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, time.Duration) []WebhookDelivery); ok {
+		r0 = returnFunc(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = returnFunc(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_ClaimWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimWebhookDeliveries'
+type MockWebhookRepository_ClaimWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ClaimWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+//   - lease time.Duration
+func (_e *MockWebhookRepository_Expecter) ClaimWebhookDeliveries(ctx interface{}, limit interface{}, lease interface{}) *MockWebhookRepository_ClaimWebhookDeliveries_Call {
+	return &MockWebhookRepository_ClaimWebhookDeliveries_Call{Call: _e.mock.On("ClaimWebhookDeliveries", ctx, limit, lease)}
+}
+
+func (_c *MockWebhookRepository_ClaimWebhookDeliveries_Call) Run(run func(ctx context.Context, limit int, lease time.Duration)) *MockWebhookRepository_ClaimWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 time.Duration
+		if args[2] != nil {
+			arg2 = args[2].(time.Duration)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ClaimWebhookDeliveries_Call) Return(webhookDeliverys []WebhookDelivery, err error) *MockWebhookRepository_ClaimWebhookDeliveries_Call {
+	_c.Call.Return(webhookDeliverys, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ClaimWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)) *MockWebhookRepository_ClaimWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWebhookSubscription provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) CreateWebhookSubscription(ctx context.Context, subscription WebhookSubscription) (WebhookSubscription, error) {
+	ret := _mock.Called(ctx, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebhookSubscription")
+	}
+
+	var r0 WebhookSubscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WebhookSubscription) (WebhookSubscription, error)); ok {
+		return returnFunc(ctx, subscription)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WebhookSubscription) WebhookSubscription); ok {
+		r0 = returnFunc(ctx, subscription)
+	} else {
+		r0 = ret.Get(0).(WebhookSubscription)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, WebhookSubscription) error); ok {
+		r1 = returnFunc(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_CreateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookSubscription'
+type MockWebhookRepository_CreateWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// CreateWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription WebhookSubscription
+func (_e *MockWebhookRepository_Expecter) CreateWebhookSubscription(ctx interface{}, subscription interface{}) *MockWebhookRepository_CreateWebhookSubscription_Call {
+	return &MockWebhookRepository_CreateWebhookSubscription_Call{Call: _e.mock.On("CreateWebhookSubscription", ctx, subscription)}
+}
+
+func (_c *MockWebhookRepository_CreateWebhookSubscription_Call) Run(run func(ctx context.Context, subscription WebhookSubscription)) *MockWebhookRepository_CreateWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 WebhookSubscription
+		if args[1] != nil {
+			arg1 = args[1].(WebhookSubscription)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateWebhookSubscription_Call) Return(webhookSubscription WebhookSubscription, err error) *MockWebhookRepository_CreateWebhookSubscription_Call {
+	_c.Call.Return(webhookSubscription, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateWebhookSubscription_Call) RunAndReturn(run func(ctx context.Context, subscription WebhookSubscription) (WebhookSubscription, error)) *MockWebhookRepository_CreateWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookSubscription provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookSubscription")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_DeleteWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookSubscription'
+type MockWebhookRepository_DeleteWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) DeleteWebhookSubscription(ctx interface{}, id interface{}) *MockWebhookRepository_DeleteWebhookSubscription_Call {
+	return &MockWebhookRepository_DeleteWebhookSubscription_Call{Call: _e.mock.On("DeleteWebhookSubscription", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_DeleteWebhookSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_DeleteWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteWebhookSubscription_Call) Return(err error) *MockWebhookRepository_DeleteWebhookSubscription_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_DeleteWebhookSubscription_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) error) *MockWebhookRepository_DeleteWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EnqueueWebhookDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) EnqueueWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) error {
+	ret := _mock.Called(ctx, deliveries)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueWebhookDeliveries")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []WebhookDelivery) error); ok {
+		r0 = returnFunc(ctx, deliveries)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_EnqueueWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EnqueueWebhookDeliveries'
+type MockWebhookRepository_EnqueueWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// EnqueueWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveries []WebhookDelivery
+func (_e *MockWebhookRepository_Expecter) EnqueueWebhookDeliveries(ctx interface{}, deliveries interface{}) *MockWebhookRepository_EnqueueWebhookDeliveries_Call {
+	return &MockWebhookRepository_EnqueueWebhookDeliveries_Call{Call: _e.mock.On("EnqueueWebhookDeliveries", ctx, deliveries)}
+}
+
+func (_c *MockWebhookRepository_EnqueueWebhookDeliveries_Call) Run(run func(ctx context.Context, deliveries []WebhookDelivery)) *MockWebhookRepository_EnqueueWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []WebhookDelivery
+		if args[1] != nil {
+			arg1 = args[1].([]WebhookDelivery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_EnqueueWebhookDeliveries_Call) Return(err error) *MockWebhookRepository_EnqueueWebhookDeliveries_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_EnqueueWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, deliveries []WebhookDelivery) error) *MockWebhookRepository_EnqueueWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookDelivery provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDelivery")
+	}
+
+	var r0 WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (WebhookDelivery, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) WebhookDelivery); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(WebhookDelivery)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_GetWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDelivery'
+type MockWebhookRepository_GetWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) GetWebhookDelivery(ctx interface{}, id interface{}) *MockWebhookRepository_GetWebhookDelivery_Call {
+	return &MockWebhookRepository_GetWebhookDelivery_Call{Call: _e.mock.On("GetWebhookDelivery", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_GetWebhookDelivery_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_GetWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetWebhookDelivery_Call) Return(webhookDelivery WebhookDelivery, err error) *MockWebhookRepository_GetWebhookDelivery_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetWebhookDelivery_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)) *MockWebhookRepository_GetWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWebhookSubscription provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookSubscription")
+	}
+
+	var r0 WebhookSubscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (WebhookSubscription, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) WebhookSubscription); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(WebhookSubscription)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_GetWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookSubscription'
+type MockWebhookRepository_GetWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// GetWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) GetWebhookSubscription(ctx interface{}, id interface{}) *MockWebhookRepository_GetWebhookSubscription_Call {
+	return &MockWebhookRepository_GetWebhookSubscription_Call{Call: _e.mock.On("GetWebhookSubscription", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_GetWebhookSubscription_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_GetWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetWebhookSubscription_Call) Return(webhookSubscription WebhookSubscription, err error) *MockWebhookRepository_GetWebhookSubscription_Call {
+	_c.Call.Return(webhookSubscription, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetWebhookSubscription_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)) *MockWebhookRepository_GetWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) ListWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]WebhookDelivery, error) {
+	ret := _mock.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WebhookDeliveryFilter) ([]WebhookDelivery, error)); ok {
+		return returnFunc(ctx, filter)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WebhookDeliveryFilter) []WebhookDelivery); ok {
+		r0 = returnFunc(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, WebhookDeliveryFilter) error); ok {
+		r1 = returnFunc(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockWebhookRepository_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - filter WebhookDeliveryFilter
+func (_e *MockWebhookRepository_Expecter) ListWebhookDeliveries(ctx interface{}, filter interface{}) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	return &MockWebhookRepository_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, filter)}
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, filter WebhookDeliveryFilter)) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 WebhookDeliveryFilter
+		if args[1] != nil {
+			arg1 = args[1].(WebhookDeliveryFilter)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeliveries_Call) Return(webhookDeliverys []WebhookDelivery, err error) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	_c.Call.Return(webhookDeliverys, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, filter WebhookDeliveryFilter) ([]WebhookDelivery, error)) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookSubscriptions provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) ListWebhookSubscriptions(ctx context.Context, activeOnly bool) ([]WebhookSubscription, error) {
+	ret := _mock.Called(ctx, activeOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookSubscriptions")
+	}
+
+	var r0 []WebhookSubscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) ([]WebhookSubscription, error)); ok {
+		return returnFunc(ctx, activeOnly)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) []WebhookSubscription); ok {
+		r0 = returnFunc(ctx, activeOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WebhookSubscription)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = returnFunc(ctx, activeOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_ListWebhookSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookSubscriptions'
+type MockWebhookRepository_ListWebhookSubscriptions_Call struct {
+	*mock.Call
+}
+
+// ListWebhookSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - activeOnly bool
+func (_e *MockWebhookRepository_Expecter) ListWebhookSubscriptions(ctx interface{}, activeOnly interface{}) *MockWebhookRepository_ListWebhookSubscriptions_Call {
+	return &MockWebhookRepository_ListWebhookSubscriptions_Call{Call: _e.mock.On("ListWebhookSubscriptions", ctx, activeOnly)}
+}
+
+func (_c *MockWebhookRepository_ListWebhookSubscriptions_Call) Run(run func(ctx context.Context, activeOnly bool)) *MockWebhookRepository_ListWebhookSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookSubscriptions_Call) Return(webhookSubscriptions []WebhookSubscription, err error) *MockWebhookRepository_ListWebhookSubscriptions_Call {
+	_c.Call.Return(webhookSubscriptions, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookSubscriptions_Call) RunAndReturn(run func(ctx context.Context, activeOnly bool) ([]WebhookSubscription, error)) *MockWebhookRepository_ListWebhookSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkWebhookDeliveryDelivered provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) MarkWebhookDeliveryDelivered(ctx context.Context, id uuid.UUID, statusCode int) error {
+	ret := _mock.Called(ctx, id, statusCode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWebhookDeliveryDelivered")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int) error); ok {
+		r0 = returnFunc(ctx, id, statusCode)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_MarkWebhookDeliveryDelivered_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkWebhookDeliveryDelivered'
+type MockWebhookRepository_MarkWebhookDeliveryDelivered_Call struct {
+	*mock.Call
+}
+
+// MarkWebhookDeliveryDelivered is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - statusCode int
+func (_e *MockWebhookRepository_Expecter) MarkWebhookDeliveryDelivered(ctx interface{}, id interface{}, statusCode interface{}) *MockWebhookRepository_MarkWebhookDeliveryDelivered_Call {
+	return &MockWebhookRepository_MarkWebhookDeliveryDelivered_Call{Call: _e.mock.On("MarkWebhookDeliveryDelivered", ctx, id, statusCode)}
+}
+
+func (_c *MockWebhookRepository_MarkWebhookDeliveryDelivered_Call) Run(run func(ctx context.Context, id uuid.UUID, statusCode int)) *MockWebhookRepository_MarkWebhookDeliveryDelivered_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_MarkWebhookDeliveryDelivered_Call) Return(err error) *MockWebhookRepository_MarkWebhookDeliveryDelivered_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_MarkWebhookDeliveryDelivered_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, statusCode int) error) *MockWebhookRepository_MarkWebhookDeliveryDelivered_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkWebhookDeliveryFailed provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) MarkWebhookDeliveryFailed(ctx context.Context, id uuid.UUID, statusCode int, cause string, retryAt *time.Time) error {
+	ret := _mock.Called(ctx, id, statusCode, cause, retryAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkWebhookDeliveryFailed")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, string, *time.Time) error); ok {
+		r0 = returnFunc(ctx, id, statusCode, cause, retryAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_MarkWebhookDeliveryFailed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkWebhookDeliveryFailed'
+type MockWebhookRepository_MarkWebhookDeliveryFailed_Call struct {
+	*mock.Call
+}
+
+// MarkWebhookDeliveryFailed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - statusCode int
+//   - cause string
+//   - retryAt *time.Time
+func (_e *MockWebhookRepository_Expecter) MarkWebhookDeliveryFailed(ctx interface{}, id interface{}, statusCode interface{}, cause interface{}, retryAt interface{}) *MockWebhookRepository_MarkWebhookDeliveryFailed_Call {
+	return &MockWebhookRepository_MarkWebhookDeliveryFailed_Call{Call: _e.mock.On("MarkWebhookDeliveryFailed", ctx, id, statusCode, cause, retryAt)}
+}
+
+func (_c *MockWebhookRepository_MarkWebhookDeliveryFailed_Call) Run(run func(ctx context.Context, id uuid.UUID, statusCode int, cause string, retryAt *time.Time)) *MockWebhookRepository_MarkWebhookDeliveryFailed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 *time.Time
+		if args[4] != nil {
+			arg4 = args[4].(*time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_MarkWebhookDeliveryFailed_Call) Return(err error) *MockWebhookRepository_MarkWebhookDeliveryFailed_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_MarkWebhookDeliveryFailed_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID, statusCode int, cause string, retryAt *time.Time) error) *MockWebhookRepository_MarkWebhookDeliveryFailed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedeliverWebhookDelivery provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) RedeliverWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeliverWebhookDelivery")
+	}
+
+	var r0 WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) (WebhookDelivery, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uuid.UUID) WebhookDelivery); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(WebhookDelivery)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_RedeliverWebhookDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverWebhookDelivery'
+type MockWebhookRepository_RedeliverWebhookDelivery_Call struct {
+	*mock.Call
+}
+
+// RedeliverWebhookDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *MockWebhookRepository_Expecter) RedeliverWebhookDelivery(ctx interface{}, id interface{}) *MockWebhookRepository_RedeliverWebhookDelivery_Call {
+	return &MockWebhookRepository_RedeliverWebhookDelivery_Call{Call: _e.mock.On("RedeliverWebhookDelivery", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_RedeliverWebhookDelivery_Call) Run(run func(ctx context.Context, id uuid.UUID)) *MockWebhookRepository_RedeliverWebhookDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uuid.UUID
+		if args[1] != nil {
+			arg1 = args[1].(uuid.UUID)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_RedeliverWebhookDelivery_Call) Return(webhookDelivery WebhookDelivery, err error) *MockWebhookRepository_RedeliverWebhookDelivery_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_RedeliverWebhookDelivery_Call) RunAndReturn(run func(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)) *MockWebhookRepository_RedeliverWebhookDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateWebhookSubscription provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) UpdateWebhookSubscription(ctx context.Context, subscription WebhookSubscription) (WebhookSubscription, error) {
+	ret := _mock.Called(ctx, subscription)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateWebhookSubscription")
+	}
+
+	var r0 WebhookSubscription
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WebhookSubscription) (WebhookSubscription, error)); ok {
+		return returnFunc(ctx, subscription)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, WebhookSubscription) WebhookSubscription); ok {
+		r0 = returnFunc(ctx, subscription)
+	} else {
+		r0 = ret.Get(0).(WebhookSubscription)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, WebhookSubscription) error); ok {
+		r1 = returnFunc(ctx, subscription)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_UpdateWebhookSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateWebhookSubscription'
+type MockWebhookRepository_UpdateWebhookSubscription_Call struct {
+	*mock.Call
+}
+
+// UpdateWebhookSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscription WebhookSubscription
+func (_e *MockWebhookRepository_Expecter) UpdateWebhookSubscription(ctx interface{}, subscription interface{}) *MockWebhookRepository_UpdateWebhookSubscription_Call {
+	return &MockWebhookRepository_UpdateWebhookSubscription_Call{Call: _e.mock.On("UpdateWebhookSubscription", ctx, subscription)}
+}
+
+func (_c *MockWebhookRepository_UpdateWebhookSubscription_Call) Run(run func(ctx context.Context, subscription WebhookSubscription)) *MockWebhookRepository_UpdateWebhookSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 WebhookSubscription
+		if args[1] != nil {
+			arg1 = args[1].(WebhookSubscription)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_UpdateWebhookSubscription_Call) Return(webhookSubscription WebhookSubscription, err error) *MockWebhookRepository_UpdateWebhookSubscription_Call {
+	_c.Call.Return(webhookSubscription, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_UpdateWebhookSubscription_Call) RunAndReturn(run func(ctx context.Context, subscription WebhookSubscription) (WebhookSubscription, error)) *MockWebhookRepository_UpdateWebhookSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
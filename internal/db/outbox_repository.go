@@ -0,0 +1,188 @@
+package db
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxRepository hands outbox events to the relay. An event is claimed for a lease before it is
+// published, so concurrent relays do not pick it up twice; a relay that dies mid-batch leaves its
+// events to be claimed again when the lease runs out. Delivery is therefore at least once.
+type OutboxRepository interface {
+	// ClaimOutboxEvents returns up to limit due events, oldest first, and pushes their next attempt
+	// lease into the future.
+	ClaimOutboxEvents(ctx context.Context, limit int, lease time.Duration) ([]OutboxEvent, error)
+	MarkOutboxEventPublished(ctx context.Context, id uint64) error
+	// MarkOutboxEventFailed records cause and schedules the next attempt at retryAt.
+	MarkOutboxEventFailed(ctx context.Context, id uint64, retryAt time.Time, cause string) error
+	GetOutboxBacklog(ctx context.Context) (OutboxBacklog, error)
+	// PurgeOutboxEvents deletes the events published before publishedBefore and returns how many it deleted.
+	PurgeOutboxEvents(ctx context.Context, publishedBefore time.Time) (int64, error)
+}
+
+// OutboxBacklog describes the events that are still to be published.
+type OutboxBacklog struct {
+	Pending int64
+	// OldestCreatedAt is when the oldest unpublished event was written, nil when there is none.
+	OldestCreatedAt *time.Time
+}
+
+// maxOutboxErrorLength keeps a verbose sink error, such as an HTML error page, out of the table.
+const maxOutboxErrorLength = 1024
+
+func (r *PostgresDBDataStore) ClaimOutboxEvents(
+	ctx context.Context, limit int, lease time.Duration,
+) (events []OutboxEvent, err error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	// SKIP LOCKED lets concurrent relays claim disjoint batches instead of waiting on each other.
+	if err := r.db.WithContext(ctxWithTimeout).Raw(`UPDATE outbox_events
+		SET attempts = attempts + 1, next_attempt_at = now() + make_interval(secs => ?)
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE published_at IS NULL AND next_attempt_at <= now()
+			ORDER BY id
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`, lease.Seconds(), limit).Scan(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	return events, nil
+}
+
+func (r *PostgresDBDataStore) MarkOutboxEventPublished(ctx context.Context, id uint64) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	if err := r.db.WithContext(ctxWithTimeout).Model(&OutboxEvent{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"published_at": gorm.Expr("now()"), "last_error": nil}).Error; err != nil {
+		return fmt.Errorf("failed to mark outbox event published: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresDBDataStore) MarkOutboxEventFailed(
+	ctx context.Context, id uint64, retryAt time.Time, cause string,
+) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	if len(cause) > maxOutboxErrorLength {
+		cause = cause[:maxOutboxErrorLength]
+	}
+
+	if err := r.db.WithContext(ctxWithTimeout).Model(&OutboxEvent{}).
+		Where("id = ? AND published_at IS NULL", id).
+		Updates(map[string]any{"next_attempt_at": retryAt, "last_error": cause}).Error; err != nil {
+		return fmt.Errorf("failed to mark outbox event failed: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresDBDataStore) GetOutboxBacklog(ctx context.Context) (OutboxBacklog, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	var backlog OutboxBacklog
+
+	if err := r.db.WithContext(ctxWithTimeout).Raw(`SELECT count(*) AS pending, min(created_at) AS oldest_created_at
+		FROM outbox_events WHERE published_at IS NULL`).Scan(&backlog).Error; err != nil {
+		return OutboxBacklog{}, fmt.Errorf("failed to read outbox backlog: %w", err)
+	}
+
+	return backlog, nil
+}
+
+func (r *PostgresDBDataStore) PurgeOutboxEvents(ctx context.Context, publishedBefore time.Time) (int64, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctxWithTimeout).
+		Where("published_at < ?", publishedBefore).
+		Delete(&OutboxEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
+
+// enqueueBalanceChange writes the EventTypeBalanceChanged event of a transaction that was just
+// posted to the wallet with walletID. The wallet row is locked by the posting, so the balance read
+// here is the one the transaction left behind.
+func (*PostgresDBDataStore) enqueueBalanceChange(tx *gorm.DB, walletID uint64, transaction Transaction) error {
+	var wallet Wallet
+	if err := tx.Select("balance", "reserved").Where("id = ?", walletID).First(&wallet).Error; err != nil {
+		return fmt.Errorf("failed to read wallet balance: %w", err)
+	}
+
+	return writeBalanceChange(tx, BalanceChange{
+		UserID:        transaction.UserID,
+		Currency:      transaction.Currency,
+		TransactionID: transaction.TransactionID,
+		State:         transaction.State,
+		SourceType:    transaction.SourceType,
+		Amount:        transaction.Amount,
+		Balance:       wallet.Balance,
+		Reserved:      wallet.Reserved,
+		ReversalOf:    transaction.ReversalOf,
+		Principal:     transaction.Principal,
+		Reason:        transaction.Reason,
+	})
+}
+
+// enqueueHoldChange writes the EventTypeBalanceChanged event of a hold that was just placed,
+// released or expired. The balance is unchanged, so Amount is zero and State is the hold status;
+// the event tells consumers about the new reserved and available amounts.
+func (*PostgresDBDataStore) enqueueHoldChange(tx *gorm.DB, hold Hold) error {
+	var wallet Wallet
+	if err := tx.Select("balance", "reserved").
+		Where("user_id = ? AND currency = ?", hold.UserID, hold.Currency).
+		First(&wallet).Error; err != nil {
+		return fmt.Errorf("failed to read wallet balance: %w", err)
+	}
+
+	return writeBalanceChange(tx, BalanceChange{
+		UserID:        hold.UserID,
+		Currency:      hold.Currency,
+		TransactionID: holdTransactionPrefix + hold.HoldID,
+		State:         hold.Status,
+		SourceType:    hold.SourceType,
+		Balance:       wallet.Balance,
+		Reserved:      wallet.Reserved,
+	})
+}
+
+func writeBalanceChange(tx *gorm.DB, change BalanceChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("failed to encode balance change: %w", err)
+	}
+
+	now := time.Now()
+
+	if err := tx.Create(&OutboxEvent{
+		EventID:       uuid.New(),
+		EventType:     EventTypeBalanceChanged,
+		AggregateKey:  strconv.FormatUint(change.UserID, decimalBase),
+		Payload:       string(payload),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}).Error; err != nil {
+		return fmt.Errorf("failed to write outbox event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ReconciliationRepository interface {
+	FindBalanceDrift(ctx context.Context) ([]BalanceDrift, error)
+	RepairBalanceDrift(ctx context.Context, walletID uint64) (BalanceDrift, error)
+}
+
+// BalanceDrift compares the cached balance of a wallet with the balance recomputed from its postings.
+// Every transaction posts its amount to the wallet, so the recomputed balance is the sum of the
+// user's transactions in that currency plus system entries such as opening balances.
+type BalanceDrift struct {
+	WalletID        uint64
+	UserID          uint64
+	Currency        string
+	CachedBalance   int64
+	ComputedBalance int64
+}
+
+// Drift is the amount the cached balance is ahead of the ledger.
+func (d BalanceDrift) Drift() int64 {
+	return d.CachedBalance - d.ComputedBalance
+}
+
+const (
+	// HouseAccountReconciliation is the counterparty of adjustments written by reconciliation.
+	HouseAccountReconciliation = "reconciliation"
+
+	reconciliationSourceType        = "server"
+	reconciliationPrincipal         = "system:reconciliation"
+	reconciliationTransactionPrefix = "reconciliation:"
+	journalDescriptionAdjustment    = "reconciliation adjustment"
+)
+
+const walletBalancesQuery = `SELECT w.id AS wallet_id, w.user_id, w.currency, w.balance AS cached_balance,
+		COALESCE(SUM(p.amount), 0) AS computed_balance
+	FROM wallets w
+	LEFT JOIN postings p ON p.wallet_id = w.id`
+
+// FindBalanceDrift returns every wallet whose cached balance differs from its ledger balance.
+// The comparison is a single statement, so balance updates committed together with their postings
+// are never reported as drift.
+func (r *PostgresDBDataStore) FindBalanceDrift(ctx context.Context) (drifts []BalanceDrift, err error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.reconciliationTimeout)
+	defer cancel()
+
+	return drifts, r.db.WithContext(ctxWithTimeout).
+		Raw(walletBalancesQuery + `
+			GROUP BY w.id
+			HAVING w.balance <> COALESCE(SUM(p.amount), 0)
+			ORDER BY w.user_id, w.currency`).
+		Scan(&drifts).Error
+}
+
+// RepairBalanceDrift recomputes the drift of a wallet under lock and, if there still is one, writes
+// an adjustment transaction that brings the ledger in line with the cached balance. The cached
+// balance is what the user has seen and spent against, so it is the ledger that is corrected.
+// It returns the drift that was repaired, which is zero when there was nothing left to do.
+func (r *PostgresDBDataStore) RepairBalanceDrift(ctx context.Context, walletID uint64) (BalanceDrift, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var drift BalanceDrift
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var wallet Wallet
+
+		result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("id = ?", walletID).
+			Limit(1).
+			Find(&wallet)
+		if result.Error != nil {
+			return fmt.Errorf("failed to lock wallet: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrWalletNotFound
+		}
+
+		if err := tx.Raw(walletBalancesQuery+` WHERE w.id = ? GROUP BY w.id`, walletID).
+			Scan(&drift).Error; err != nil {
+			return fmt.Errorf("failed to compute wallet balance: %w", err)
+		}
+
+		if drift.Drift() == 0 {
+			return nil
+		}
+
+		return r.writeAdjustment(tx, wallet, drift.Drift())
+	}); err != nil {
+		return BalanceDrift{}, fmt.Errorf("failed to execute balance repair transaction: %w", err)
+	}
+
+	return drift, nil
+}
+
+// writeAdjustment records amount as an adjustment transaction and posts it to the ledger only:
+// the cached wallet balance already includes it.
+func (r *PostgresDBDataStore) writeAdjustment(tx *gorm.DB, wallet Wallet, amount int64) error {
+	house, err := r.findOrCreateHouseAccount(tx, HouseAccountReconciliation, wallet.Currency)
+	if err != nil {
+		return err
+	}
+
+	transaction := Transaction{
+		ID:            uuid.New(),
+		UserID:        wallet.UserID,
+		Amount:        amount,
+		Currency:      wallet.Currency,
+		State:         StateAdjustment,
+		SourceType:    reconciliationSourceType,
+		TransactionID: reconciliationTransactionPrefix + uuid.NewString(),
+		Principal:     reconciliationPrincipal,
+	}
+	transaction.Fingerprint = transaction.RequestFingerprint()
+
+	if err := r.createTransactionRecord(tx, transaction); err != nil {
+		return err
+	}
+
+	return r.insertJournalEntry(tx, JournalEntry{
+		TransactionID: &transaction.ID,
+		Description:   journalDescriptionAdjustment,
+		Postings: []Posting{
+			{WalletID: &wallet.ID, Amount: amount, Currency: wallet.Currency},
+			{HouseAccountID: &house.ID, Amount: -amount, Currency: wallet.Currency},
+		},
+	})
+}
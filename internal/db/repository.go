@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/TiPSYDiPSY/home-task/internal/errors"
 )
@@ -13,73 +15,301 @@ import (
 type UserRepository interface {
 	GetUserData(ctx context.Context, userID uint64) (User, error)
 	UpdateUserBalance(ctx context.Context, transaction Transaction) error
+	ListUserTransactions(ctx context.Context, userID uint64, filter TransactionFilter) ([]Transaction, error)
+	ReverseTransaction(ctx context.Context, reversal Transaction) error
+	GetUserTransaction(ctx context.Context, userID uint64, transactionID string) (Transaction, error)
+}
+
+// TransactionFilter narrows down a user's transaction history. Zero values mean "no filter".
+// MinAmount and MaxAmount are compared against the absolute amount in minor units of the currency.
+type TransactionFilter struct {
+	Currency   string
+	State      string
+	SourceType string
+	From       *time.Time
+	To         *time.Time
+	MinAmount  *int64
+	MaxAmount  *int64
+	After      *TransactionCursor
+	Limit      int
+}
+
+// TransactionCursor points at the last row of the previous page in (processed_at, id) order.
+type TransactionCursor struct {
+	ProcessedAt time.Time
+	ID          uuid.UUID
 }
 
 const (
-	ReadTimeoutSeconds  = 5
-	WriteTimeoutSeconds = 10
+	journalDescriptionTransaction = "transaction"
+	journalDescriptionReversal    = "reversal"
+	journalDescriptionSettlement  = "hold settlement"
+	journalDescriptionManual      = "manual adjustment"
 )
 
 var (
 	ErrUserNotFound         = errors.ErrUserNotFound
 	ErrDuplicateTransaction = errors.ErrDuplicateTransaction
+	ErrIdempotentReplay     = errors.ErrIdempotentReplay
 	ErrInsufficientFunds    = errors.ErrInsufficientFunds
+	ErrUnbalancedEntry      = errors.ErrUnbalancedEntry
+	ErrTransactionNotFound  = errors.ErrTransactionNotFound
+	ErrAlreadyReversed      = errors.ErrAlreadyReversed
+	ErrNotReversible        = errors.ErrNotReversible
+	ErrHoldNotFound         = errors.ErrHoldNotFound
+	ErrHoldExists           = errors.ErrHoldExists
+	ErrHoldNotActive        = errors.ErrHoldNotActive
+	ErrCurrencyMismatch     = errors.ErrCurrencyMismatch
+	ErrWalletNotFound       = errors.ErrWalletNotFound
+	ErrExternalIDExists     = errors.ErrExternalIDExists
+	ErrAccountFrozen        = errors.ErrAccountFrozen
+	ErrAccountClosed        = errors.ErrAccountClosed
+	ErrNonZeroBalance       = errors.ErrNonZeroBalance
 )
 
 func (r *PostgresDBDataStore) GetUserData(ctx context.Context, userID uint64) (user User, err error) {
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, ReadTimeoutSeconds*time.Second)
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
 	defer cancel()
 
-	return user, r.db.WithContext(ctxWithTimeout).First(&user, userID).Error
+	return user, r.db.WithContext(ctxWithTimeout).Preload("Wallets").First(&user, userID).Error
+}
+
+func (r *PostgresDBDataStore) ListUserTransactions(
+	ctx context.Context, userID uint64, filter TransactionFilter,
+) (transactions []Transaction, err error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	query := r.db.WithContext(ctxWithTimeout).
+		Where("user_id = ?", userID)
+
+	if filter.Currency != "" {
+		query = query.Where("currency = ?", filter.Currency)
+	}
+
+	if filter.State != "" {
+		query = query.Where("state = ?", filter.State)
+	}
+
+	if filter.SourceType != "" {
+		query = query.Where("source_type = ?", filter.SourceType)
+	}
+
+	if filter.From != nil {
+		query = query.Where("processed_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("processed_at <= ?", *filter.To)
+	}
+
+	if filter.MinAmount != nil {
+		query = query.Where("ABS(amount) >= ?", *filter.MinAmount)
+	}
+
+	if filter.MaxAmount != nil {
+		query = query.Where("ABS(amount) <= ?", *filter.MaxAmount)
+	}
+
+	if filter.After != nil {
+		query = query.Where("(processed_at, id) < (?, ?)", filter.After.ProcessedAt, filter.After.ID)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	return transactions, query.
+		Order("processed_at DESC").
+		Order("id DESC").
+		Find(&transactions).Error
 }
 
 func (r *PostgresDBDataStore) UpdateUserBalance(ctx context.Context, transaction Transaction) error {
-	ctxWithTimeout, cancel := context.WithTimeout(ctx, WriteTimeoutSeconds*time.Second)
+	return r.postTransaction(ctx, transaction, transaction.SourceType, journalDescriptionTransaction)
+}
+
+// postTransaction stores transaction and moves its amount between the user's wallet and the house
+// account named counterparty.
+func (r *PostgresDBDataStore) postTransaction(
+	ctx context.Context, transaction Transaction, counterparty, description string,
+) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
 	defer cancel()
 
+	transaction.Fingerprint = transaction.RequestFingerprint()
+
 	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
-		if err := r.checkTransactionExists(tx, transaction.TransactionID); err != nil {
+		if err := r.checkTransactionExists(tx, transaction); err != nil {
 			return err
 		}
 
-		if err := r.updateUserBalanceAtomic(tx, transaction.UserID, transaction.Amount); err != nil {
+		return r.applyTransaction(tx, transaction, counterparty, description)
+	}); err != nil {
+		return fmt.Errorf("failed to execute balance update transaction: %w", err)
+	}
+
+	return nil
+}
+
+// applyTransaction writes transaction and its journal entry within tx, after checking that the
+// account status allows the movement.
+func (r *PostgresDBDataStore) applyTransaction(tx *gorm.DB, transaction Transaction, counterparty, description string) error {
+	if err := r.checkAccountMovement(tx, transaction.UserID, transaction.Amount); err != nil {
+		return err
+	}
+
+	wallet, err := r.findOrCreateWallet(tx, transaction.UserID, transaction.Currency)
+	if err != nil {
+		return err
+	}
+
+	newTransactionID(&transaction)
+
+	if err := r.createTransactionRecord(tx, transaction); err != nil {
+		return err
+	}
+
+	return r.postTransfer(tx, wallet, transaction, counterparty, description)
+}
+
+// GetUserTransaction returns the user's transaction with the caller-assigned transactionID, or
+// ErrTransactionNotFound.
+func (r *PostgresDBDataStore) GetUserTransaction(
+	ctx context.Context, userID uint64, transactionID string,
+) (Transaction, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	var transaction Transaction
+
+	result := r.db.WithContext(ctxWithTimeout).
+		Where("user_id = ? AND transaction_id = ?", userID, transactionID).
+		Limit(1).
+		Find(&transaction)
+	if result.Error != nil {
+		return Transaction{}, fmt.Errorf("failed to find transaction: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return Transaction{}, ErrTransactionNotFound
+	}
+
+	return transaction, nil
+}
+
+// ReverseTransaction posts a compensating entry for reversal.ReversalOf. The amount is taken
+// from the original transaction with the opposite sign, so a reversed win debits the user
+// under the same overdraft rules as a regular lose.
+func (r *PostgresDBDataStore) ReverseTransaction(ctx context.Context, reversal Transaction) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	// The fingerprint is taken before the amount is resolved from the original transaction,
+	// so it only covers what the caller sent.
+	reversal.Fingerprint = reversal.RequestFingerprint()
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		if err := r.checkTransactionExists(tx, reversal); err != nil {
+			return err
+		}
+
+		original, err := r.findReversibleTransaction(tx, reversal.UserID, *reversal.ReversalOf)
+		if err != nil {
+			return err
+		}
+
+		reversal.Amount = -original.Amount
+		reversal.Currency = original.Currency
+
+		if err := r.checkAccountMovement(tx, reversal.UserID, reversal.Amount); err != nil {
+			return err
+		}
+
+		wallet, err := r.findOrCreateWallet(tx, reversal.UserID, reversal.Currency)
+		if err != nil {
+			return err
+		}
+
+		newTransactionID(&reversal)
+
+		if err := r.createTransactionRecord(tx, reversal); err != nil {
 			return err
 		}
 
-		return r.createTransactionRecord(tx, transaction)
+		// The reversal settles against the counterparty of the original, whoever requests it.
+		return r.postTransfer(tx, wallet, reversal, original.SourceType, journalDescriptionReversal)
 	}); err != nil {
-		return fmt.Errorf("failed to execute balance update transaction: %w", err)
+		return fmt.Errorf("failed to execute reversal transaction: %w", err)
 	}
 
 	return nil
 }
 
-func (*PostgresDBDataStore) checkTransactionExists(tx *gorm.DB, transactionID string) error {
+func (*PostgresDBDataStore) findReversibleTransaction(tx *gorm.DB, userID uint64, transactionID string) (Transaction, error) {
+	var original Transaction
+
+	result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("user_id = ? AND transaction_id = ?", userID, transactionID).
+		Limit(1).
+		Find(&original)
+	if result.Error != nil {
+		return Transaction{}, fmt.Errorf("failed to find original transaction: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return Transaction{}, ErrTransactionNotFound
+	}
+
+	if original.State == StateReversal || original.State == StateAdjustment {
+		return Transaction{}, ErrNotReversible
+	}
+
 	var count int64
 	if err := tx.Model(&Transaction{}).
-		Where("transaction_id = ?", transactionID).
+		Where("reversal_of = ?", transactionID).
 		Count(&count).Error; err != nil {
-		return fmt.Errorf("failed to check transaction existence: %w", err)
+		return Transaction{}, fmt.Errorf("failed to check existing reversal: %w", err)
 	}
 
 	if count > 0 {
-		return ErrDuplicateTransaction
+		return Transaction{}, ErrAlreadyReversed
 	}
 
-	return nil
+	return original, nil
 }
 
-func (r *PostgresDBDataStore) updateUserBalanceAtomic(tx *gorm.DB, userID uint64, amount int64) error {
-	result := tx.Model(&User{}).
-		Where("id = ? AND balance + ? >= 0", userID, amount).
-		Update("balance", gorm.Expr("balance + ?", amount))
+// checkTransactionExists returns ErrIdempotentReplay when the TransactionID was already applied
+// with the same request fingerprint and ErrDuplicateTransaction when it was used for a different payload.
+func (*PostgresDBDataStore) checkTransactionExists(tx *gorm.DB, transaction Transaction) error {
+	var existing Transaction
 
+	result := tx.Select("fingerprint").
+		Where("transaction_id = ?", transaction.TransactionID).
+		Limit(1).
+		Find(&existing)
 	if result.Error != nil {
-		return fmt.Errorf("failed to update user balance: %w", result.Error)
+		return fmt.Errorf("failed to check transaction existence: %w", result.Error)
 	}
 
 	if result.RowsAffected == 0 {
-		return r.determineUpdateFailureReason(tx, userID)
+		return nil
+	}
+
+	if existing.Fingerprint != "" && existing.Fingerprint == transaction.Fingerprint {
+		return ErrIdempotentReplay
+	}
+
+	return ErrDuplicateTransaction
+}
+
+// ensureWallet creates an empty wallet in currency for an existing user. It is a no-op when the
+// wallet already exists or the user does not, so the caller decides the outcome from the following query.
+func (*PostgresDBDataStore) ensureWallet(tx *gorm.DB, userID uint64, currency string) error {
+	if err := tx.Exec(`INSERT INTO wallets (user_id, currency, balance, reserved, created_at, updated_at)
+		SELECT id, ?, 0, 0, NOW(), NOW() FROM users WHERE id = ?
+		ON CONFLICT (user_id, currency) DO NOTHING`, currency, userID).Error; err != nil {
+		return fmt.Errorf("failed to create wallet: %w", err)
 	}
 
 	return nil
@@ -102,10 +332,25 @@ func (*PostgresDBDataStore) determineUpdateFailureReason(tx *gorm.DB, userID uin
 	return ErrInsufficientFunds
 }
 
-func (*PostgresDBDataStore) createTransactionRecord(tx *gorm.DB, transaction Transaction) error {
-	if err := tx.Create(&transaction).Error; err != nil {
-		return fmt.Errorf("failed to create transaction record: %w", err)
+// createTransactionRecord inserts transaction. A concurrent request with the same TransactionID can
+// pass checkTransactionExists before either of them is committed; the unique index then makes this
+// insert wait for the other one and skip the row, and the stored fingerprint decides between
+// ErrIdempotentReplay and ErrDuplicateTransaction.
+func (r *PostgresDBDataStore) createTransactionRecord(tx *gorm.DB, transaction Transaction) error {
+	result := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "transaction_id"}}, DoNothing: true}).
+		Create(&transaction)
+	if result.Error != nil {
+		return fmt.Errorf("failed to create transaction record: %w", result.Error)
 	}
 
-	return nil
+	if result.RowsAffected > 0 {
+		return nil
+	}
+
+	if err := r.checkTransactionExists(tx, transaction); err != nil {
+		return err
+	}
+
+	// The conflicting row is gone again, which only a concurrent delete could do.
+	return ErrDuplicateTransaction
 }
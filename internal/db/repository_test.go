@@ -0,0 +1,52 @@
+package db
+
+import (
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestCreateTransactionRecordConflict(t *testing.T) {
+	transaction := Transaction{UserID: 1, State: "win", SourceType: "game", TransactionID: "txn-1", Amount: 1050}
+	transaction.Fingerprint = transaction.RequestFingerprint()
+	newTransactionID(&transaction)
+
+	tests := []struct {
+		name        string
+		inserted    bool
+		fingerprint string
+		wantErr     error
+	}{
+		{name: "inserted", inserted: true},
+		{name: "concurrent retry", fingerprint: transaction.Fingerprint, wantErr: ErrIdempotentReplay},
+		{name: "concurrent reuse of the ID", fingerprint: "other", wantErr: ErrDuplicateTransaction},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeConnector{respond: func(query string) ([]string, [][]driver.Value) {
+				switch {
+				// The insert returns no row when it conflicted with a committed transaction.
+				case strings.HasPrefix(query, `INSERT INTO "transactions"`) && tt.inserted:
+					return []string{"id", "processed_at"}, [][]driver.Value{{transaction.ID.String(), time.Now()}}
+				case strings.HasPrefix(query, `SELECT "fingerprint" FROM "transactions"`):
+					return []string{"fingerprint"}, [][]driver.Value{{tt.fingerprint}}
+				default:
+					return nil, nil
+				}
+			}}
+			store := newFakeDataStore(t, fake)
+
+			err := store.db.Transaction(func(tx *gorm.DB) error {
+				return store.createTransactionRecord(tx, transaction)
+			})
+
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Len(t, fake.inserts(`INSERT INTO "transactions"`), 1)
+		})
+	}
+}
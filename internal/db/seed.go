@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// SeedPredefinedUsers creates the demo users 1-3 if they do not exist yet. It is opt-in and meant
+// for local development; production users are created through the API.
+func (r *PostgresDBDataStore) SeedPredefinedUsers(ctx context.Context) error {
+	log.WithContext(ctx).Info("Setting up predefined users...")
+
+	//nolint: revive,mnd // This is stub data
+	predefinedUsers := []*User{
+		{ID: 1},
+		{ID: 2},
+		{ID: 3},
+	}
+
+	for _, user := range predefinedUsers {
+		if err := r.db.WithContext(ctx).FirstOrCreate(user, User{ID: user.ID}).Error; err != nil {
+			return fmt.Errorf("failed to create predefined user with ID %d: %w", user.ID, err)
+		}
+	}
+
+	// Explicit IDs do not advance the sequence, so move it past them for users created later.
+	if err := r.db.WithContext(ctx).
+		Exec("SELECT setval(pg_get_serial_sequence('users', 'id'), GREATEST((SELECT MAX(id) FROM users), 1))").
+		Error; err != nil {
+		return fmt.Errorf("failed to advance users id sequence: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// BalanceStreamRepository reads what a balance stream starts from: the balance change events a
+// reconnecting client missed, or a snapshot of the wallets that tells which events it already covers.
+type BalanceStreamRepository interface {
+	GetBalanceSnapshot(ctx context.Context, userID uint64) (BalanceSnapshot, error)
+	// ListBalanceChanges returns up to limit balance change events of the user written after the
+	// event with ID afterID, oldest first.
+	ListBalanceChanges(ctx context.Context, userID, afterID uint64, limit int) ([]OutboxEvent, error)
+}
+
+// BalanceSnapshot is a user's wallets and, by currency, the ID of the last balance change event
+// written for each, read from the same database snapshot. Wallets whose events were all purged
+// have no entry in LastEventIDs.
+type BalanceSnapshot struct {
+	Wallets      []Wallet
+	LastEventIDs map[string]uint64
+}
+
+func (r *PostgresDBDataStore) GetBalanceSnapshot(ctx context.Context, userID uint64) (BalanceSnapshot, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	snapshot := BalanceSnapshot{LastEventIDs: make(map[string]uint64)}
+
+	// The event of a change is written in the transaction of the change, so a repeatable read sees
+	// either both or neither.
+	err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		var user User
+
+		result := tx.Preload("Wallets").Where("id = ?", userID).Limit(1).Find(&user)
+		if result.Error != nil {
+			return fmt.Errorf("failed to read user: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+
+		var lastEvents []struct {
+			Currency string
+			ID       uint64
+		}
+
+		if err := tx.Raw(`SELECT payload->>'currency' AS currency, max(id) AS id FROM outbox_events
+			WHERE aggregate_key = ? AND event_type = ?
+			GROUP BY payload->>'currency'`,
+			strconv.FormatUint(userID, decimalBase), EventTypeBalanceChanged).Scan(&lastEvents).Error; err != nil {
+			return fmt.Errorf("failed to read last balance change events: %w", err)
+		}
+
+		snapshot.Wallets = user.Wallets
+		for _, lastEvent := range lastEvents {
+			snapshot.LastEventIDs[lastEvent.Currency] = lastEvent.ID
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return BalanceSnapshot{}, fmt.Errorf("failed to execute balance snapshot transaction: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func (r *PostgresDBDataStore) ListBalanceChanges(
+	ctx context.Context, userID, afterID uint64, limit int,
+) (events []OutboxEvent, err error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	if err := r.db.WithContext(ctxWithTimeout).
+		Where("aggregate_key = ? AND event_type = ? AND id > ?",
+			strconv.FormatUint(userID, decimalBase), EventTypeBalanceChanged, afterID).
+		Order("id").
+		Limit(limit).
+		Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list balance changes: %w", err)
+	}
+
+	return events, nil
+}
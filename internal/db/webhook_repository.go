@@ -0,0 +1,370 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+// WebhookRepository stores webhook subscriptions and their deliveries. Deliveries are claimed for a
+// lease like outbox events, so concurrent workers do not send the same one twice.
+type WebhookRepository interface {
+	CreateWebhookSubscription(ctx context.Context, subscription WebhookSubscription) (WebhookSubscription, error)
+	GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error)
+	// ListWebhookSubscriptions returns subscriptions oldest first, only the active ones if activeOnly is set.
+	ListWebhookSubscriptions(ctx context.Context, activeOnly bool) ([]WebhookSubscription, error)
+	// UpdateWebhookSubscription replaces the URL, description, filters and active flag. The secret is kept.
+	UpdateWebhookSubscription(ctx context.Context, subscription WebhookSubscription) (WebhookSubscription, error)
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error
+
+	// EnqueueWebhookDeliveries stores pending deliveries. A delivery of an event the subscription
+	// already has is skipped, so handing over the same event twice is harmless.
+	EnqueueWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) error
+	// ClaimWebhookDeliveries returns up to limit due deliveries of active subscriptions, oldest first,
+	// with their subscription, and pushes their next attempt lease into the future.
+	ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]WebhookDelivery, error)
+	MarkWebhookDeliveryDelivered(ctx context.Context, id uuid.UUID, statusCode int) error
+	// MarkWebhookDeliveryFailed records a failed attempt. statusCode is zero when no response was
+	// received. A nil retryAt dead-letters the delivery.
+	MarkWebhookDeliveryFailed(
+		ctx context.Context, id uuid.UUID, statusCode int, cause string, retryAt *time.Time,
+	) error
+	GetWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	ListWebhookDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]WebhookDelivery, error)
+	// RedeliverWebhookDelivery puts a dead-lettered delivery back in the queue with a fresh attempt count.
+	RedeliverWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+}
+
+// WebhookDeliveryFilter narrows down the delivery log. Zero values mean "no filter".
+type WebhookDeliveryFilter struct {
+	SubscriptionID uuid.UUID
+	Status         string
+	Limit          int
+}
+
+var (
+	ErrWebhookSubscriptionNotFound = errors.ErrWebhookSubscriptionNotFound
+	ErrWebhookDeliveryNotFound     = errors.ErrWebhookDeliveryNotFound
+	ErrWebhookDeliveryNotDead      = errors.ErrWebhookDeliveryNotDead
+)
+
+func (r *PostgresDBDataStore) CreateWebhookSubscription(
+	ctx context.Context, subscription WebhookSubscription,
+) (WebhookSubscription, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	// Select keeps GORM from skipping a false Active in favour of the column default.
+	if err := r.db.WithContext(ctxWithTimeout).Select("*").Create(&subscription).Error; err != nil {
+		return WebhookSubscription{}, fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func (r *PostgresDBDataStore) GetWebhookSubscription(ctx context.Context, id uuid.UUID) (WebhookSubscription, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	var subscription WebhookSubscription
+
+	result := r.db.WithContext(ctxWithTimeout).Where("id = ?", id).Limit(1).Find(&subscription)
+	if result.Error != nil {
+		return WebhookSubscription{}, fmt.Errorf("failed to find webhook subscription: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return WebhookSubscription{}, ErrWebhookSubscriptionNotFound
+	}
+
+	return subscription, nil
+}
+
+func (r *PostgresDBDataStore) ListWebhookSubscriptions(
+	ctx context.Context, activeOnly bool,
+) (subscriptions []WebhookSubscription, err error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	query := r.db.WithContext(ctxWithTimeout)
+	if activeOnly {
+		query = query.Where("active")
+	}
+
+	return subscriptions, query.Order("created_at").Order("id").Find(&subscriptions).Error
+}
+
+func (r *PostgresDBDataStore) UpdateWebhookSubscription(
+	ctx context.Context, subscription WebhookSubscription,
+) (WebhookSubscription, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var updated WebhookSubscription
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("id = ?", subscription.ID).
+			Limit(1).
+			Find(&updated)
+		if result.Error != nil {
+			return fmt.Errorf("failed to find webhook subscription: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrWebhookSubscriptionNotFound
+		}
+
+		updated.URL = subscription.URL
+		updated.Description = subscription.Description
+		updated.SourceTypes = subscription.SourceTypes
+		updated.States = subscription.States
+		updated.Currencies = subscription.Currencies
+		updated.MinAmount = subscription.MinAmount
+		updated.Active = subscription.Active
+
+		if err := tx.Save(&updated).Error; err != nil {
+			return fmt.Errorf("failed to update webhook subscription: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return WebhookSubscription{}, fmt.Errorf("failed to execute update webhook subscription transaction: %w", err)
+	}
+
+	return updated, nil
+}
+
+func (r *PostgresDBDataStore) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	result := r.db.WithContext(ctxWithTimeout).Where("id = ?", id).Delete(&WebhookSubscription{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresDBDataStore) EnqueueWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	if err := r.db.WithContext(ctxWithTimeout).
+		Omit("Subscription").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).
+		Create(&deliveries).Error; err != nil {
+		return fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresDBDataStore) ClaimWebhookDeliveries(
+	ctx context.Context, limit int, lease time.Duration,
+) ([]WebhookDelivery, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var deliveries []WebhookDelivery
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw(`UPDATE webhook_deliveries
+			SET attempts = attempts + 1, next_attempt_at = now() + make_interval(secs => ?), updated_at = now()
+			WHERE id IN (
+				SELECT d.id FROM webhook_deliveries d
+				JOIN webhook_subscriptions s ON s.id = d.subscription_id
+				WHERE d.status = ? AND d.next_attempt_at <= now() AND s.active
+				ORDER BY d.next_attempt_at
+				LIMIT ?
+				FOR UPDATE OF d SKIP LOCKED
+			)
+			RETURNING *`, lease.Seconds(), WebhookDeliveryStatusPending, limit).Scan(&deliveries).Error; err != nil {
+			return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+		}
+
+		return attachWebhookSubscriptions(tx, deliveries)
+	}); err != nil {
+		return nil, fmt.Errorf("failed to execute claim webhook deliveries transaction: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (r *PostgresDBDataStore) MarkWebhookDeliveryDelivered(ctx context.Context, id uuid.UUID, statusCode int) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	if err := r.db.WithContext(ctxWithTimeout).Model(&WebhookDelivery{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":           WebhookDeliveryStatusDelivered,
+			"last_status_code": statusCode,
+			"last_error":       nil,
+			"delivered_at":     gorm.Expr("now()"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to mark webhook delivery delivered: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PostgresDBDataStore) MarkWebhookDeliveryFailed(
+	ctx context.Context, id uuid.UUID, statusCode int, cause string, retryAt *time.Time,
+) error {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	if len(cause) > maxOutboxErrorLength {
+		cause = cause[:maxOutboxErrorLength]
+	}
+
+	updates := map[string]any{"last_error": cause, "last_status_code": nil}
+	if statusCode != 0 {
+		updates["last_status_code"] = statusCode
+	}
+
+	if retryAt != nil {
+		updates["next_attempt_at"] = *retryAt
+	} else {
+		updates["status"] = WebhookDeliveryStatusDead
+	}
+
+	if err := r.db.WithContext(ctxWithTimeout).Model(&WebhookDelivery{}).
+		Where("id = ? AND status = ?", id, WebhookDeliveryStatusPending).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to mark webhook delivery failed: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookDelivery returns the delivery with its payload.
+func (r *PostgresDBDataStore) GetWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	var delivery WebhookDelivery
+
+	result := r.db.WithContext(ctxWithTimeout).Where("id = ?", id).Limit(1).Find(&delivery)
+	if result.Error != nil {
+		return WebhookDelivery{}, fmt.Errorf("failed to find webhook delivery: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return WebhookDelivery{}, ErrWebhookDeliveryNotFound
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries returns deliveries newest first, without their payloads.
+func (r *PostgresDBDataStore) ListWebhookDeliveries(
+	ctx context.Context, filter WebhookDeliveryFilter,
+) (deliveries []WebhookDelivery, err error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.readTimeout)
+	defer cancel()
+
+	query := r.db.WithContext(ctxWithTimeout).Omit("payload")
+
+	if filter.SubscriptionID != uuid.Nil {
+		query = query.Where("subscription_id = ?", filter.SubscriptionID)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	return deliveries, query.
+		Order("created_at DESC").
+		Order("id DESC").
+		Find(&deliveries).Error
+}
+
+func (r *PostgresDBDataStore) RedeliverWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, r.writeTimeout)
+	defer cancel()
+
+	var delivery WebhookDelivery
+
+	if err := r.db.WithContext(ctxWithTimeout).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+			Where("id = ?", id).
+			Limit(1).
+			Find(&delivery)
+		if result.Error != nil {
+			return fmt.Errorf("failed to find webhook delivery: %w", result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return ErrWebhookDeliveryNotFound
+		}
+
+		if delivery.Status != WebhookDeliveryStatusDead {
+			return ErrWebhookDeliveryNotDead
+		}
+
+		delivery.Status = WebhookDeliveryStatusPending
+		delivery.Attempts = 0
+		delivery.NextAttemptAt = time.Now()
+
+		if err := tx.Omit("Subscription").Save(&delivery).Error; err != nil {
+			return fmt.Errorf("failed to requeue webhook delivery: %w", err)
+		}
+
+		return nil
+	}); err != nil {
+		return WebhookDelivery{}, fmt.Errorf("failed to execute redeliver webhook transaction: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func attachWebhookSubscriptions(tx *gorm.DB, deliveries []WebhookDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		ids = append(ids, delivery.SubscriptionID)
+	}
+
+	var subscriptions []WebhookSubscription
+	if err := tx.Where("id IN ?", ids).Find(&subscriptions).Error; err != nil {
+		return fmt.Errorf("failed to read webhook subscriptions: %w", err)
+	}
+
+	byID := make(map[uuid.UUID]*WebhookSubscription, len(subscriptions))
+	for i := range subscriptions {
+		byID[subscriptions[i].ID] = &subscriptions[i]
+	}
+
+	for i := range deliveries {
+		deliveries[i].Subscription = byID[deliveries[i].SubscriptionID]
+	}
+
+	return nil
+}
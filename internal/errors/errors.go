@@ -1,12 +1,19 @@
 package errors
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
 
+// ValidationError is a problem with one field of a request, named as the client sent it.
 type ValidationError struct {
-	Field   string
-	Message string
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
+// ValidationErrors lists every invalid field of a request.
+type ValidationErrors []ValidationError
+
 type DatabaseError struct {
 	Operation string
 	Err       error
@@ -14,16 +21,58 @@ type DatabaseError struct {
 
 var (
 	ErrUserNotFound         = errors.New("user not found")
+	ErrWalletNotFound       = errors.New("wallet not found")
+	ErrExternalIDExists     = errors.New("external ID already in use")
+	ErrAccountFrozen        = errors.New("account is frozen")
+	ErrAccountClosed        = errors.New("account is closed")
+	ErrNonZeroBalance       = errors.New("account balance is not zero")
 	ErrDuplicateTransaction = errors.New("duplicate transaction")
+	ErrIdempotentReplay     = errors.New("transaction already applied")
 	ErrInsufficientFunds    = errors.New("insufficient funds")
+	ErrUnbalancedEntry      = errors.New("journal entry postings do not sum to zero")
 	ErrInvalidAmountFormat  = errors.New("invalid amount format")
+	ErrAmountNotPositive    = errors.New("amount must be positive")
+	ErrAmountZero           = errors.New("amount must not be zero")
+	ErrAmountBelowMinimum   = errors.New("amount is below the minimum")
+	ErrAmountAboveMaximum   = errors.New("amount is above the maximum")
+	ErrAmountOverflow       = errors.New("amount is out of range")
+	ErrUnsupportedCurrency  = errors.New("unsupported currency")
+	ErrCurrencyMismatch     = errors.New("currency does not match")
 	ErrTransactionExists    = errors.New("transaction already exists")
+	ErrInvalidCursor        = errors.New("invalid cursor")
+	ErrInvalidTimeFormat    = errors.New("invalid time format")
+	ErrTransactionNotFound  = errors.New("transaction not found")
+	ErrAlreadyReversed      = errors.New("transaction already reversed")
+	ErrNotReversible        = errors.New("transaction cannot be reversed")
+	ErrHoldNotFound         = errors.New("hold not found")
+	ErrHoldExists           = errors.New("hold already exists")
+	ErrHoldNotActive        = errors.New("hold is not active")
+	ErrAdjustmentNotFound   = errors.New("adjustment not found")
+	ErrAdjustmentExists     = errors.New("adjustment already exists")
+	ErrAdjustmentNotPending = errors.New("adjustment is not pending")
+	ErrSelfApproval         = errors.New("adjustment cannot be approved by its requester")
+	ErrOperatorRequired     = errors.New("operator identity is required")
+
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryNotDead      = errors.New("webhook delivery is not dead-lettered")
+
+	ErrStreamsUnavailable = errors.New("balance streams are unavailable")
 )
 
 func (e ValidationError) Error() string {
 	return e.Message
 }
 
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, validationErr := range e {
+		messages = append(messages, validationErr.Message)
+	}
+
+	return "validation failed: " + strings.Join(messages, ", ")
+}
+
 func (e DatabaseError) Error() string {
 	return "database operation failed: " + e.Operation
 }
@@ -0,0 +1,30 @@
+// Package events holds the sinks the outbox relay publishes domain events to.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Event is the envelope every sink delivers. Delivery is at least once: consumers must be ready to
+// see the same ID again and should deduplicate on it.
+type Event struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+	// Key names the entity the event is about, e.g. the user ID, for sinks that partition by key.
+	Key string `json:"key"`
+	// Sequence increases with every event of the same wallet, so a consumer can ignore an event
+	// older than the last one it applied.
+	Sequence   uint64          `json:"sequence"`
+	OccurredAt time.Time       `json:"occurredAt"` //nolint: tagliatelle // Per API spec
+	Data       json.RawMessage `json:"data"`
+}
+
+// EventPublisher delivers events to a downstream system. Publish returns once the sink has accepted
+// the event; an error makes the relay retry it later. Adapters for message brokers implement this
+// interface and are tested against a local fake of the broker, as the webhook sink is against an
+// HTTP test server.
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
@@ -0,0 +1,152 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testEvent = Event{
+	ID:         "4b7f3c2e-8d0a-4c55-9f1e-2a6b9c0d1e2f",
+	Type:       "balance.changed",
+	Key:        "1",
+	Sequence:   42,
+	OccurredAt: time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC),
+	Data:       json.RawMessage(`{"userId":1,"balance":"10.00"}`),
+}
+
+func TestWriterPublisher(t *testing.T) {
+	var out bytes.Buffer
+
+	publisher := NewWriterPublisher(&out)
+
+	require.NoError(t, publisher.Publish(context.Background(), testEvent))
+	require.NoError(t, publisher.Publish(context.Background(), testEvent))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+
+	var decoded Event
+	require.NoError(t, json.Unmarshal(lines[0], &decoded))
+	assert.Equal(t, testEvent, decoded)
+}
+
+func TestWebhookPublisher(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		expectedError string
+	}{
+		{name: "accepted", status: http.StatusNoContent},
+		{
+			name:          "rejected",
+			status:        http.StatusServiceUnavailable,
+			body:          "try later\n",
+			expectedError: "webhook rejected the delivery: status 503: try later",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				received Event
+				headers  http.Header
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				headers = r.Header
+
+				body, err := io.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.NoError(t, json.Unmarshal(body, &received))
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := NewWebhookPublisher(server.URL, server.Client()).Publish(context.Background(), testEvent)
+
+			if tt.expectedError != "" {
+				require.ErrorIs(t, err, ErrDeliveryRejected)
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, testEvent, received)
+			assert.Equal(t, testEvent.ID, headers.Get(EventIDHeader))
+			assert.Equal(t, testEvent.Type, headers.Get(EventTypeHeader))
+			assert.Equal(t, "application/json", headers.Get("Content-Type"))
+		})
+	}
+}
+
+func TestWebhookPublisher_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	err := NewWebhookPublisher(url, &http.Client{Timeout: time.Second}).Publish(context.Background(), testEvent)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to deliver webhook")
+}
+
+func TestSignature(t *testing.T) {
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	body := []byte(`{"id":"evt-1"}`)
+	header := Sign("whsec_test", now, body)
+
+	tests := []struct {
+		name    string
+		secret  string
+		header  string
+		body    []byte
+		now     time.Time
+		wantErr error
+	}{
+		{name: "valid", secret: "whsec_test", header: header, body: body, now: now.Add(time.Minute)},
+		{name: "other secret", secret: "whsec_other", header: header, body: body, now: now, wantErr: ErrSignatureMismatch},
+		{name: "tampered body", secret: "whsec_test", header: header, body: []byte(`{"id":"evt-2"}`), now: now,
+			wantErr: ErrSignatureMismatch},
+		{name: "replayed", secret: "whsec_test", header: header, body: body, now: now.Add(time.Hour),
+			wantErr: ErrSignatureExpired},
+		{name: "malformed", secret: "whsec_test", header: "sha256=abc", body: body, now: now,
+			wantErr: ErrMalformedSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifySignature(tt.secret, tt.header, tt.body, tt.now, 5*time.Minute)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestMultiPublisher(t *testing.T) {
+	errSink := errors.New("sink unavailable")
+
+	first := NewMockEventPublisher(t)
+	first.EXPECT().Publish(mock.Anything, testEvent).Return(nil).Once()
+
+	second := NewMockEventPublisher(t)
+	second.EXPECT().Publish(mock.Anything, testEvent).Return(errSink).Once()
+
+	err := NewMultiPublisher(first, second).Publish(context.Background(), testEvent)
+
+	require.ErrorIs(t, err, errSink)
+	assert.Same(t, first, NewMultiPublisher(first))
+}
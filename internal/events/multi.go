@@ -0,0 +1,30 @@
+package events
+
+import (
+	"context"
+	"errors"
+)
+
+type multiPublisher []EventPublisher
+
+// NewMultiPublisher publishes every event to all of publishers. It fails if any of them fails, so
+// the event is retried on all of them and those that already accepted it see it again.
+func NewMultiPublisher(publishers ...EventPublisher) EventPublisher {
+	if len(publishers) == 1 {
+		return publishers[0]
+	}
+
+	return multiPublisher(publishers)
+}
+
+func (m multiPublisher) Publish(ctx context.Context, event Event) error {
+	var errs []error
+
+	for _, publisher := range m {
+		if err := publisher.Publish(ctx, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
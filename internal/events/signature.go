@@ -0,0 +1,73 @@
+package events
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A signed delivery carries the SignatureHeader "t=<unix timestamp>,v1=<signature>", the signature
+// being the hex-encoded HMAC-SHA256 of "<timestamp>.<body>" under the subscription secret. Receivers
+// recompute it over the raw body and reject timestamps too far from their clock to stop replays.
+const SignatureHeader = "X-Webhook-Signature"
+
+const signatureVersion = "v1"
+
+var (
+	ErrMalformedSignature = errors.New("malformed webhook signature")
+	ErrSignatureMismatch  = errors.New("webhook signature mismatch")
+	ErrSignatureExpired   = errors.New("webhook signature timestamp out of tolerance")
+)
+
+// Sign returns the SignatureHeader value of body sent at timestamp.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	return "t=" + unix + "," + signatureVersion + "=" + computeSignature(secret, unix, body)
+}
+
+// VerifySignature checks a SignatureHeader value against body. The timestamp must be within
+// tolerance of now.
+func VerifySignature(secret, header string, body []byte, now time.Time, tolerance time.Duration) error {
+	var timestamp, signature string
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+
+		switch key {
+		case "t":
+			timestamp = value
+		case signatureVersion:
+			signature = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || signature == "" {
+		return ErrMalformedSignature
+	}
+
+	if skew := now.Sub(time.Unix(unix, 0)); skew > tolerance || skew < -tolerance {
+		return fmt.Errorf("%w: %s", ErrSignatureExpired, skew)
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(computeSignature(secret, timestamp, body))) {
+		return ErrSignatureMismatch
+	}
+
+	return nil
+}
+
+func computeSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Headers set on webhook deliveries, so receivers can deduplicate and route without parsing the body.
+const (
+	EventIDHeader   = "X-Event-Id"
+	EventTypeHeader = "X-Event-Type"
+)
+
+// maxErrorBodySize bounds how much of a rejected delivery's response ends up in the error.
+const maxErrorBodySize = 256
+
+var ErrDeliveryRejected = errors.New("webhook rejected the delivery")
+
+// WebhookPublisher POSTs each event as JSON to a fixed URL. Any 2xx response accepts the event.
+type WebhookPublisher struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookPublisher posts to url with client, whose Timeout bounds a delivery.
+func NewWebhookPublisher(url string, client *http.Client) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:    url,
+		client: client,
+	}
+}
+
+func (p *WebhookPublisher) Publish(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	header := make(http.Header)
+	header.Set(EventIDHeader, event.ID)
+	header.Set(EventTypeHeader, event.Type)
+
+	_, err = PostJSON(ctx, p.client, p.url, body, header)
+
+	return err
+}
+
+// PostJSON POSTs body to url with the given extra headers. It returns the response status code, or
+// zero when no response was received, and fails with ErrDeliveryRejected on a non-2xx status.
+func PostJSON(ctx context.Context, client *http.Client, url string, body []byte, header http.Header) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to deliver webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+
+		return resp.StatusCode, fmt.Errorf("%w: status %d: %s",
+			ErrDeliveryRejected, resp.StatusCode, bytes.TrimSpace(detail))
+	}
+
+	// Drain the body so the connection can be reused.
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
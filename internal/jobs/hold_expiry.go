@@ -0,0 +1,59 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+const (
+	DefaultHoldExpiryInterval = time.Minute
+)
+
+// HoldExpiryJob periodically returns the funds of holds that were never settled or released.
+type HoldExpiryJob struct {
+	holdService service.HoldService
+	interval    time.Duration
+}
+
+func NewHoldExpiryJob(holdService service.HoldService, interval time.Duration) *HoldExpiryJob {
+	if interval <= 0 {
+		interval = DefaultHoldExpiryInterval
+	}
+
+	return &HoldExpiryJob{
+		holdService: holdService,
+		interval:    interval,
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (j *HoldExpiryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.runOnce(ctx)
+		}
+	}
+}
+
+func (j *HoldExpiryJob) runOnce(ctx context.Context) {
+	log := logrus.WithContext(ctx)
+
+	expired, err := j.holdService.ExpireHolds(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to expire holds")
+	}
+
+	if expired > 0 {
+		log.WithField("expired", expired).Info("Expired abandoned holds")
+	}
+}
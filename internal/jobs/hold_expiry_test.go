@@ -0,0 +1,42 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+func TestHoldExpiryJobRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockService := service.NewMockHoldService(t)
+	mockService.EXPECT().ExpireHolds(mock.Anything).RunAndReturn(func(context.Context) (int, error) {
+		cancel()
+
+		return 2, nil
+	}).Once()
+
+	done := make(chan struct{})
+
+	go func() {
+		NewHoldExpiryJob(mockService, time.Millisecond).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job did not stop after context cancellation")
+	}
+}
+
+func TestNewHoldExpiryJobDefaultInterval(t *testing.T) {
+	job := NewHoldExpiryJob(service.NewMockHoldService(t), 0)
+
+	assert.Equal(t, DefaultHoldExpiryInterval, job.interval)
+}
@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+const (
+	DefaultOutboxRelayInterval = time.Second
+	// OutboxPurgeInterval is how often published events past their retention are deleted.
+	OutboxPurgeInterval = time.Hour
+)
+
+// OutboxRelayJob periodically publishes the events of the transactional outbox and exports the
+// relay lag as metrics.
+type OutboxRelayJob struct {
+	outboxService service.OutboxService
+	interval      time.Duration
+	lastPurge     time.Time
+}
+
+func NewOutboxRelayJob(outboxService service.OutboxService, interval time.Duration) *OutboxRelayJob {
+	if interval <= 0 {
+		interval = DefaultOutboxRelayInterval
+	}
+
+	return &OutboxRelayJob{
+		outboxService: outboxService,
+		interval:      interval,
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (j *OutboxRelayJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.runOnce(ctx)
+		}
+	}
+}
+
+func (j *OutboxRelayJob) runOnce(ctx context.Context) {
+	log := logrus.WithContext(ctx)
+
+	published, failed, err := j.outboxService.RelayEvents(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to relay outbox events")
+	}
+
+	metrics.ObserveOutboxRelay(published, failed)
+
+	if failed > 0 {
+		log.WithFields(logrus.Fields{
+			"published": published,
+			"failed":    failed,
+		}).Warn("Some outbox events could not be published")
+	}
+
+	pending, lag, err := j.outboxService.Backlog(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to read outbox backlog")
+	} else {
+		metrics.SetOutboxBacklog(pending, lag)
+	}
+
+	if time.Since(j.lastPurge) >= OutboxPurgeInterval {
+		j.lastPurge = time.Now()
+
+		purged, err := j.outboxService.PurgePublishedEvents(ctx)
+		if err != nil {
+			log.WithError(err).Error("Failed to purge published outbox events")
+		}
+
+		if purged > 0 {
+			log.WithField("purged", purged).Info("Purged published outbox events")
+		}
+	}
+}
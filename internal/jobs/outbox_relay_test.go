@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+func TestOutboxRelayJobRunOnce(t *testing.T) {
+	mockService := service.NewMockOutboxService(t)
+	mockService.EXPECT().RelayEvents(mock.Anything).Return(3, 1, nil).Twice()
+	mockService.EXPECT().Backlog(mock.Anything).Return(int64(1), 2*time.Second, nil).Once()
+	mockService.EXPECT().Backlog(mock.Anything).Return(0, 0, errors.New("connection reset")).Once()
+	mockService.EXPECT().PurgePublishedEvents(mock.Anything).Return(10, nil).Once()
+
+	job := NewOutboxRelayJob(mockService, time.Millisecond)
+
+	// The second run is within the purge interval and does not purge again.
+	job.runOnce(context.Background())
+	job.runOnce(context.Background())
+}
+
+func TestNewOutboxRelayJobDefaultInterval(t *testing.T) {
+	job := NewOutboxRelayJob(service.NewMockOutboxService(t), 0)
+
+	assert.Equal(t, DefaultOutboxRelayInterval, job.interval)
+}
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+const (
+	DefaultReconciliationInterval = time.Hour
+)
+
+// ReconciliationJob periodically checks wallet balances against the ledger and, if configured to,
+// repairs the drift it finds.
+type ReconciliationJob struct {
+	reconciliationService service.ReconciliationService
+	interval              time.Duration
+	repair                bool
+}
+
+func NewReconciliationJob(
+	reconciliationService service.ReconciliationService, interval time.Duration, repair bool,
+) *ReconciliationJob {
+	if interval <= 0 {
+		interval = DefaultReconciliationInterval
+	}
+
+	return &ReconciliationJob{
+		reconciliationService: reconciliationService,
+		interval:              interval,
+		repair:                repair,
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (j *ReconciliationJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.runOnce(ctx)
+		}
+	}
+}
+
+func (j *ReconciliationJob) runOnce(ctx context.Context) {
+	log := logrus.WithContext(ctx)
+
+	report, err := j.reconciliationService.Reconcile(ctx, j.repair)
+	if err != nil {
+		log.WithError(err).Error("Failed to reconcile balances")
+	}
+
+	if len(report.Drifts) > 0 {
+		log.WithFields(logrus.Fields{
+			"drifts": len(report.Drifts),
+			"repair": j.repair,
+		}).Warn("Balance reconciliation found drift")
+	}
+}
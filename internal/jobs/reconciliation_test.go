@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+func TestReconciliationJobRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	mockService := service.NewMockReconciliationService(t)
+	mockService.EXPECT().Reconcile(mock.Anything, true).
+		RunAndReturn(func(context.Context, bool) (api.ReconciliationReport, error) {
+			cancel()
+
+			return api.ReconciliationReport{Drifts: []api.BalanceDrift{{UserID: 1, Repaired: true}}}, nil
+		}).Once()
+
+	done := make(chan struct{})
+
+	go func() {
+		NewReconciliationJob(mockService, time.Millisecond, true).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("job did not stop after context cancellation")
+	}
+}
+
+func TestNewReconciliationJobDefaultInterval(t *testing.T) {
+	job := NewReconciliationJob(service.NewMockReconciliationService(t), 0, false)
+
+	assert.Equal(t, DefaultReconciliationInterval, job.interval)
+}
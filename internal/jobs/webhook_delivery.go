@@ -0,0 +1,64 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+const DefaultWebhookDeliveryInterval = time.Second
+
+// WebhookDeliveryJob periodically sends the due webhook deliveries.
+type WebhookDeliveryJob struct {
+	webhookService service.WebhookService
+	interval       time.Duration
+}
+
+func NewWebhookDeliveryJob(webhookService service.WebhookService, interval time.Duration) *WebhookDeliveryJob {
+	if interval <= 0 {
+		interval = DefaultWebhookDeliveryInterval
+	}
+
+	return &WebhookDeliveryJob{
+		webhookService: webhookService,
+		interval:       interval,
+	}
+}
+
+// Run blocks until ctx is cancelled.
+func (j *WebhookDeliveryJob) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.runOnce(ctx)
+		}
+	}
+}
+
+func (j *WebhookDeliveryJob) runOnce(ctx context.Context) {
+	log := logrus.WithContext(ctx)
+
+	delivered, failed, deadLettered, err := j.webhookService.DeliverWebhooks(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to deliver webhooks")
+	}
+
+	metrics.ObserveWebhookDeliveries(delivered, failed, deadLettered)
+
+	if failed > 0 || deadLettered > 0 {
+		log.WithFields(logrus.Fields{
+			"delivered":     delivered,
+			"failed":        failed,
+			"dead_lettered": deadLettered,
+		}).Warn("Some webhook deliveries failed")
+	}
+}
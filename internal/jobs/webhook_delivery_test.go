@@ -0,0 +1,30 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TiPSYDiPSY/home-task/internal/service"
+)
+
+func TestWebhookDeliveryJobRunOnce(t *testing.T) {
+	mockService := service.NewMockWebhookService(t)
+	mockService.EXPECT().DeliverWebhooks(mock.Anything).Return(2, 1, 1, nil).Once()
+	mockService.EXPECT().DeliverWebhooks(mock.Anything).Return(0, 0, 0, errors.New("connection reset")).Once()
+
+	job := NewWebhookDeliveryJob(mockService, time.Millisecond)
+
+	job.runOnce(context.Background())
+	job.runOnce(context.Background())
+}
+
+func TestNewWebhookDeliveryJobDefaultInterval(t *testing.T) {
+	job := NewWebhookDeliveryJob(service.NewMockWebhookService(t), 0)
+
+	assert.Equal(t, DefaultWebhookDeliveryInterval, job.interval)
+}
@@ -0,0 +1,233 @@
+// Package metrics holds the Prometheus collectors of the service and the /metrics handler.
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "home_task"
+
+// Balance update outcomes.
+const (
+	OutcomeSuccess           = "success"
+	OutcomeDuplicate         = "duplicate"
+	OutcomeInsufficientFunds = "insufficient_funds"
+	OutcomeNotFound          = "not_found"
+	OutcomeAccountBlocked    = "account_blocked"
+	OutcomeRejected          = "rejected"
+	OutcomeError             = "error"
+)
+
+// UnmatchedRoute labels requests that did not match a route, so that scanners cannot create a series per path.
+const UnmatchedRoute = "unmatched"
+
+// Registry is the registry served on /metrics. A dedicated registry keeps collectors registered by
+// libraries out of the exposition.
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route pattern and status code.",
+	}, []string{"method", "route", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route pattern and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	grpcRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC requests by method and status code.",
+	}, []string{"method", "code"})
+
+	grpcRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC request latency by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Database query latency by query type and result.",
+		Buckets:   []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.2, 0.5, 1, 2.5},
+	}, []string{"query_type", "result"})
+
+	balanceUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "balance_updates_total",
+		Help:      "Balance update requests by Source-Type and outcome.",
+	}, []string{"source_type", "outcome"})
+
+	wageredAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "wagered_amount_total",
+		Help:      "Amount debited by lose transactions, in major units, by Source-Type and currency.",
+	}, []string{"source_type", "currency"})
+
+	wonAmount = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "won_amount_total",
+		Help:      "Amount credited by win transactions, in major units, by Source-Type and currency.",
+	}, []string{"source_type", "currency"})
+
+	outboxPublished = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_events_published_total",
+		Help:      "Outbox events accepted by the event sink.",
+	})
+
+	outboxFailures = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "outbox_publish_failures_total",
+		Help:      "Outbox event deliveries that failed and were rescheduled.",
+	})
+
+	outboxPending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_pending_events",
+		Help:      "Outbox events not published yet.",
+	})
+
+	outboxLag = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "outbox_lag_seconds",
+		Help:      "Age of the oldest outbox event not published yet, zero when there is none.",
+	})
+
+	webhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "webhook_deliveries_total",
+		Help:      "Webhook delivery attempts by outcome: delivered, failed (retried) or dead.",
+	}, []string{"outcome"})
+
+	balanceStreams = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "balance_streams",
+		Help:      "Open balance streams by transport: sse or websocket.",
+	}, []string{"transport"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests,
+		httpRequestDuration,
+		grpcRequests,
+		grpcRequestDuration,
+		dbQueryDuration,
+		balanceUpdates,
+		wageredAmount,
+		wonAmount,
+		outboxPublished,
+		outboxFailures,
+		outboxPending,
+		outboxLag,
+		webhookDeliveries,
+		balanceStreams,
+	)
+}
+
+// Handler serves the metrics of Registry in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+func ObserveHTTPRequest(method, route string, status int, elapsed time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+
+	code := strconv.Itoa(status)
+
+	httpRequests.WithLabelValues(method, route, code).Inc()
+	httpRequestDuration.WithLabelValues(method, route, code).Observe(elapsed.Seconds())
+}
+
+// ObserveGRPCRequest records a gRPC request by its full method name, e.g.
+// /hometask.wallet.v1.WalletService/GetBalance, and status code name, e.g. NotFound.
+func ObserveGRPCRequest(method, code string, elapsed time.Duration) {
+	grpcRequests.WithLabelValues(method, code).Inc()
+	grpcRequestDuration.WithLabelValues(method, code).Observe(elapsed.Seconds())
+}
+
+func ObserveDBQuery(queryType string, elapsed time.Duration, failed bool) {
+	result := "ok"
+	if failed {
+		result = "error"
+	}
+
+	dbQueryDuration.WithLabelValues(queryType, result).Observe(elapsed.Seconds())
+}
+
+func ObserveBalanceUpdate(sourceType, outcome string) {
+	balanceUpdates.WithLabelValues(sourceType, outcome).Inc()
+}
+
+// ObserveTransactionAmount adds an applied transaction to the wagered or won total. amount is in
+// major units and signed like the balance change: negative for lose, positive for win.
+func ObserveTransactionAmount(sourceType, currency string, amount float64) {
+	if amount < 0 {
+		wageredAmount.WithLabelValues(sourceType, currency).Add(-amount)
+
+		return
+	}
+
+	wonAmount.WithLabelValues(sourceType, currency).Add(amount)
+}
+
+// ObserveOutboxRelay records the outcome of an outbox relay run.
+func ObserveOutboxRelay(published, failed int) {
+	outboxPublished.Add(float64(published))
+	outboxFailures.Add(float64(failed))
+}
+
+// SetOutboxBacklog records the events still waiting to be published and the age of the oldest one.
+func SetOutboxBacklog(pending int64, lag time.Duration) {
+	outboxPending.Set(float64(pending))
+	outboxLag.Set(lag.Seconds())
+}
+
+// ObserveWebhookDeliveries records the outcome of a webhook delivery run.
+func ObserveWebhookDeliveries(delivered, failed, deadLettered int) {
+	webhookDeliveries.WithLabelValues("delivered").Add(float64(delivered))
+	webhookDeliveries.WithLabelValues("failed").Add(float64(failed))
+	webhookDeliveries.WithLabelValues("dead").Add(float64(deadLettered))
+}
+
+// TrackBalanceStream counts a balance stream as open until the returned function is called.
+func TrackBalanceStream(transport string) func() {
+	gauge := balanceStreams.WithLabelValues(transport)
+	gauge.Inc()
+
+	return gauge.Dec
+}
+
+// RegisterDBStats exports the connection pool statistics of db. Registering the same database
+// twice is not an error.
+func RegisterDBStats(db *sql.DB, dbName string) error {
+	err := Registry.Register(collectors.NewDBStatsCollector(db, dbName))
+
+	var alreadyRegistered prometheus.AlreadyRegisteredError
+	if err != nil && !errors.As(err, &alreadyRegistered) {
+		return fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	return nil
+}
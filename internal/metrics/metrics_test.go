@@ -0,0 +1,82 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserveHTTPRequest(t *testing.T) {
+	ObserveHTTPRequest(http.MethodGet, "/user/{userID}/balance", http.StatusOK, 10*time.Millisecond)
+	ObserveHTTPRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(httpRequests.WithLabelValues("GET", "/user/{userID}/balance", "200")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(httpRequests.WithLabelValues("GET", UnmatchedRoute, "404")), 0)
+}
+
+func TestObserveGRPCRequest(t *testing.T) {
+	ObserveGRPCRequest("/hometask.wallet.v1.WalletService/GetBalance", "OK", time.Millisecond)
+	ObserveGRPCRequest("/hometask.wallet.v1.WalletService/GetBalance", "NotFound", time.Millisecond)
+	ObserveGRPCRequest("/hometask.wallet.v1.WalletService/GetBalance", "OK", time.Millisecond)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(
+		grpcRequests.WithLabelValues("/hometask.wallet.v1.WalletService/GetBalance", "OK")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(
+		grpcRequests.WithLabelValues("/hometask.wallet.v1.WalletService/GetBalance", "NotFound")), 0)
+}
+
+func TestObserveTransactionAmount(t *testing.T) {
+	ObserveTransactionAmount("game", "EUR", -2.5)
+	ObserveTransactionAmount("game", "EUR", -1)
+	ObserveTransactionAmount("game", "EUR", 10.25)
+
+	assert.InDelta(t, 3.5, testutil.ToFloat64(wageredAmount.WithLabelValues("game", "EUR")), 1e-9)
+	assert.InDelta(t, 10.25, testutil.ToFloat64(wonAmount.WithLabelValues("game", "EUR")), 1e-9)
+}
+
+func TestObserveOutbox(t *testing.T) {
+	ObserveOutboxRelay(3, 1)
+	ObserveOutboxRelay(2, 0)
+	SetOutboxBacklog(7, 1500*time.Millisecond)
+
+	assert.InDelta(t, 5, testutil.ToFloat64(outboxPublished), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(outboxFailures), 0)
+	assert.InDelta(t, 7, testutil.ToFloat64(outboxPending), 0)
+	assert.InDelta(t, 1.5, testutil.ToFloat64(outboxLag), 1e-9)
+}
+
+func TestObserveWebhookDeliveries(t *testing.T) {
+	ObserveWebhookDeliveries(4, 2, 1)
+	ObserveWebhookDeliveries(1, 0, 0)
+
+	assert.InDelta(t, 5, testutil.ToFloat64(webhookDeliveries.WithLabelValues("delivered")), 0)
+	assert.InDelta(t, 2, testutil.ToFloat64(webhookDeliveries.WithLabelValues("failed")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(webhookDeliveries.WithLabelValues("dead")), 0)
+}
+
+func TestTrackBalanceStream(t *testing.T) {
+	closeFirst := TrackBalanceStream("sse")
+	TrackBalanceStream("sse")
+	TrackBalanceStream("websocket")
+	closeFirst()
+
+	assert.InDelta(t, 1, testutil.ToFloat64(balanceStreams.WithLabelValues("sse")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(balanceStreams.WithLabelValues("websocket")), 0)
+}
+
+func TestHandler(t *testing.T) {
+	ObserveBalanceUpdate("payment", OutcomeInsufficientFunds)
+	ObserveDBQuery("SELECT", time.Millisecond, false)
+
+	rr := httptest.NewRecorder()
+	Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `home_task_balance_updates_total{outcome="insufficient_funds",source_type="payment"} 1`)
+	assert.Contains(t, rr.Body.String(), `home_task_db_query_duration_seconds_count{query_type="SELECT",result="ok"} 1`)
+	assert.Contains(t, rr.Body.String(), "go_goroutines")
+}
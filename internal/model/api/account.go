@@ -0,0 +1,21 @@
+package api
+
+import "time"
+
+type CreateUserRequest struct {
+	ExternalID string `json:"externalId" validate:"omitempty,max=64"` //nolint: tagliatelle // Per API spec
+}
+
+type FreezeRequest struct {
+	Scope string `json:"scope" validate:"required,oneof=debits credits all"`
+}
+
+type UserResponse struct {
+	UserID      uint64     `json:"userId"`               //nolint: tagliatelle // Per API spec
+	ExternalID  string     `json:"externalId,omitempty"` //nolint: tagliatelle // Per API spec
+	Status      string     `json:"status"`
+	FreezeScope string     `json:"freezeScope,omitempty"` //nolint: tagliatelle // Per API spec
+	CreatedAt   time.Time  `json:"createdAt"`             //nolint: tagliatelle // Per API spec
+	UpdatedAt   time.Time  `json:"updatedAt"`             //nolint: tagliatelle // Per API spec
+	ClosedAt    *time.Time `json:"closedAt,omitempty"`    //nolint: tagliatelle // Per API spec
+}
@@ -0,0 +1,74 @@
+package api
+
+import "time"
+
+// Reason codes of manual adjustments.
+const (
+	AdjustmentReasonGoodwill     = "goodwill"
+	AdjustmentReasonCompensation = "compensation"
+	AdjustmentReasonCorrection   = "correction"
+	AdjustmentReasonChargeback   = "chargeback"
+	AdjustmentReasonPromotion    = "promotion"
+)
+
+// Adjustment statuses.
+const (
+	AdjustmentStatusPending  = "pending"
+	AdjustmentStatusPosted   = "posted"
+	AdjustmentStatusRejected = "rejected"
+)
+
+// AdjustmentRequest is a manual balance correction by an operator. A negative amount debits the user.
+type AdjustmentRequest struct {
+	Amount        string `json:"amount"        validate:"required,numeric,amount"`
+	Currency      string `json:"currency"      validate:"omitempty,currency"`
+	TransactionID string `json:"transactionId" validate:"required,max=255,transaction_id"` //nolint: tagliatelle // Per API spec
+	// ReasonCode classifies the adjustment for reporting; Comment explains it.
+	ReasonCode string `json:"reasonCode" validate:"required,oneof=goodwill compensation correction chargeback promotion"` //nolint: tagliatelle,lll // Per API spec
+	Comment    string `json:"comment"    validate:"required,max=500"`
+}
+
+// AdjustmentApprovalRequest approves a pending adjustment. The comment is optional.
+type AdjustmentApprovalRequest struct {
+	Comment string `json:"comment" validate:"max=500"`
+}
+
+// AdjustmentRejectionRequest rejects a pending adjustment, saying why.
+type AdjustmentRejectionRequest struct {
+	Comment string `json:"comment" validate:"required,max=500"`
+}
+
+type AdjustmentListRequest struct {
+	UserID uint64 `query:"userId"`
+	Status string `query:"status" validate:"omitempty,oneof=pending posted rejected"`
+	Limit  int    `query:"limit"  validate:"omitempty,min=1,max=100"`
+}
+
+// AdjustmentResponse is an adjustment with its audit trail. Amount is signed like the balance change.
+type AdjustmentResponse struct {
+	AdjustmentID  string                  `json:"adjustmentId"`  //nolint: tagliatelle // Per API spec
+	UserID        uint64                  `json:"userId"`        //nolint: tagliatelle // Per API spec
+	TransactionID string                  `json:"transactionId"` //nolint: tagliatelle // Per API spec
+	Amount        string                  `json:"amount"`
+	Currency      string                  `json:"currency"`
+	ReasonCode    string                  `json:"reasonCode"` //nolint: tagliatelle // Per API spec
+	Comment       string                  `json:"comment"`
+	Status        string                  `json:"status"`
+	RequestedBy   string                  `json:"requestedBy"`         //nolint: tagliatelle // Per API spec
+	RequestedAt   time.Time               `json:"requestedAt"`         //nolint: tagliatelle // Per API spec
+	DecidedBy     string                  `json:"decidedBy,omitempty"` //nolint: tagliatelle // Per API spec
+	DecidedAt     *time.Time              `json:"decidedAt,omitempty"` //nolint: tagliatelle // Per API spec
+	Audit         []AdjustmentAuditRecord `json:"audit,omitempty"`
+}
+
+// AdjustmentAuditRecord is one step of an adjustment: requested, approved, rejected or posted.
+type AdjustmentAuditRecord struct {
+	Action  string    `json:"action"`
+	Actor   string    `json:"actor"`
+	Comment string    `json:"comment,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+type AdjustmentListResponse struct {
+	Adjustments []AdjustmentResponse `json:"adjustments"`
+}
@@ -0,0 +1,19 @@
+package api
+
+// BalanceChangedEvent is the data of a balance.changed event. Amount is signed like the balance
+// change, and zero for a placed, released or expired hold; Balance, Available and Reserved describe
+// the wallet after it.
+type BalanceChangedEvent struct {
+	UserID        uint64 `json:"userId"`        //nolint: tagliatelle // Per API spec
+	TransactionID string `json:"transactionId"` //nolint: tagliatelle // Per API spec
+	State         string `json:"state"`
+	SourceType    string `json:"sourceType"` //nolint: tagliatelle // Per API spec
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	Balance       string `json:"balance"`
+	Available     string `json:"available"`
+	Reserved      string `json:"reserved"`
+	ReversalOf    string `json:"reversalOf,omitempty"` //nolint: tagliatelle // Per API spec
+	Principal     string `json:"principal,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
@@ -0,0 +1,27 @@
+package api
+
+import "time"
+
+type HoldRequest struct {
+	HoldID     string `json:"holdId"     validate:"required"` //nolint: tagliatelle // Per API spec
+	Amount     string `json:"amount"     validate:"required,amount"`
+	Currency   string `json:"currency"   validate:"omitempty,currency"`
+	TTLSeconds int    `json:"ttlSeconds" validate:"omitempty,min=1,max=604800"` //nolint: tagliatelle // Per API spec
+}
+
+type HoldSettlementRequest struct {
+	State    string `json:"state"  validate:"required,oneof=win lose"`
+	Payout   string `json:"payout"   validate:"required_if=State win,amount"`
+	Currency string `json:"currency" validate:"omitempty,currency"`
+}
+
+type HoldResponse struct {
+	HoldID     string    `json:"holdId"`     //nolint: tagliatelle // Per API spec
+	UserID     uint64    `json:"userId"`     //nolint: tagliatelle // Per API spec
+	SourceType string    `json:"sourceType"` //nolint: tagliatelle // Per API spec
+	Currency   string    `json:"currency"`
+	Amount     string    `json:"amount"`
+	Payout     string    `json:"payout,omitempty"`
+	Status     string    `json:"status"`
+	ExpiresAt  time.Time `json:"expiresAt"` //nolint: tagliatelle // Per API spec
+}
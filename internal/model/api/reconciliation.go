@@ -0,0 +1,19 @@
+package api
+
+import "time"
+
+type ReconciliationReport struct {
+	CheckedAt time.Time      `json:"checkedAt"` //nolint: tagliatelle // Per API spec
+	Repair    bool           `json:"repair"`
+	Drifts    []BalanceDrift `json:"drifts"`
+}
+
+// BalanceDrift reports a wallet whose cached balance does not match the balance recomputed from the ledger.
+type BalanceDrift struct {
+	UserID          uint64 `json:"userId"` //nolint: tagliatelle // Per API spec
+	Currency        string `json:"currency"`
+	Balance         string `json:"balance"`
+	ComputedBalance string `json:"computedBalance"` //nolint: tagliatelle // Per API spec
+	Drift           string `json:"drift"`
+	Repaired        bool   `json:"repaired"`
+}
@@ -0,0 +1,35 @@
+package api
+
+import "encoding/json"
+
+// Message types of a balance stream.
+const (
+	StreamMessageSnapshot      = "balance.snapshot"
+	StreamMessageBalanceChange = "balance.changed"
+	StreamMessageHeartbeat     = "heartbeat"
+)
+
+// StreamMessage is one message of a balance stream. Over SSE, ID, Type and Data become the id,
+// event and data fields; over WebSocket the message is sent as JSON.
+type StreamMessage struct {
+	// ID is the sequence of a balance.changed message, which a reconnecting client passes back to
+	// resume after it.
+	ID   string          `json:"id,omitempty"`
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// BalanceSnapshot starts a stream that does not resume. Each wallet's Sequence is that of the last
+// balance.changed event it reflects, so clients ignore changes with a lower or equal sequence.
+type BalanceSnapshot struct {
+	UserID  uint64           `json:"userId"` //nolint: tagliatelle // Per API spec
+	Wallets []WalletSnapshot `json:"wallets"`
+}
+
+type WalletSnapshot struct {
+	Currency  string `json:"currency"`
+	Balance   string `json:"balance"`
+	Available string `json:"available"`
+	Reserved  string `json:"reserved"`
+	Sequence  uint64 `json:"sequence,omitempty"`
+}
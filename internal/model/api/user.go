@@ -1,12 +1,70 @@
 package api
 
+import "time"
+
 type BalanceResponse struct {
-	UserID  uint64 `json:"userId"` //nolint: tagliatelle // Per API spec
-	Balance string `json:"balance"`
+	UserID    uint64 `json:"userId"` //nolint: tagliatelle // Per API spec
+	Currency  string `json:"currency,omitempty"`
+	Balance   string `json:"balance"`
+	Available string `json:"available,omitempty"`
+	Reserved  string `json:"reserved,omitempty"`
+}
+
+type WalletListResponse struct {
+	UserID  uint64            `json:"userId"` //nolint: tagliatelle // Per API spec
+	Wallets []BalanceResponse `json:"wallets"`
 }
 
 type TransactionRequest struct {
 	State         string `json:"state"         validate:"required,oneof=win lose"`
-	Amount        string `json:"amount"        validate:"required,decimal2"`
-	TransactionID string `json:"transactionId" validate:"required"` //nolint: tagliatelle // Per API spec
+	Amount        string `json:"amount"        validate:"required,amount"`
+	Currency      string `json:"currency"      validate:"omitempty,currency"`
+	TransactionID string `json:"transactionId" validate:"required,transaction_id"` //nolint: tagliatelle // Per API spec
+}
+
+type ReversalRequest struct {
+	TransactionID string `json:"transactionId" validate:"required,transaction_id"` //nolint: tagliatelle // Per API spec
+}
+
+type TransactionListRequest struct {
+	Cursor     string `query:"cursor"`
+	Limit      int    `query:"limit"      validate:"omitempty,min=1,max=100"`
+	Currency   string `query:"currency"   validate:"omitempty,currency"`
+	State      string `query:"state"      validate:"omitempty,oneof=win lose reversal adjustment"`
+	SourceType string `query:"sourceType" validate:"omitempty,oneof=game server payment"`
+	From       string `query:"from"       validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	To         string `query:"to"         validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+	MinAmount  string `query:"minAmount"  validate:"omitempty,numeric,amount"`
+	MaxAmount  string `query:"maxAmount"  validate:"omitempty,numeric,amount"`
+}
+
+type TransactionResponse struct {
+	TransactionID string    `json:"transactionId"` //nolint: tagliatelle // Per API spec
+	State         string    `json:"state"`
+	SourceType    string    `json:"sourceType"` //nolint: tagliatelle // Per API spec
+	Amount        string    `json:"amount"`
+	Currency      string    `json:"currency"`
+	ProcessedAt   time.Time `json:"processedAt"`          //nolint: tagliatelle // Per API spec
+	ReversalOf    string    `json:"reversalOf,omitempty"` //nolint: tagliatelle // Per API spec
+}
+
+type TransactionListResponse struct {
+	UserID       uint64                `json:"userId"` //nolint: tagliatelle // Per API spec
+	Transactions []TransactionResponse `json:"transactions"`
+	NextCursor   string                `json:"nextCursor,omitempty"` //nolint: tagliatelle // Per API spec
+}
+
+// TransactionDetails is a transaction with the audit fields the public API does not expose. Unlike
+// TransactionResponse, Amount is signed like the balance change, since adjustments can go either way.
+type TransactionDetails struct {
+	UserID        uint64    `json:"userId"`        //nolint: tagliatelle // Per API spec
+	TransactionID string    `json:"transactionId"` //nolint: tagliatelle // Per API spec
+	State         string    `json:"state"`
+	SourceType    string    `json:"sourceType"` //nolint: tagliatelle // Per API spec
+	Amount        string    `json:"amount"`
+	Currency      string    `json:"currency"`
+	ProcessedAt   time.Time `json:"processedAt"`          //nolint: tagliatelle // Per API spec
+	ReversalOf    string    `json:"reversalOf,omitempty"` //nolint: tagliatelle // Per API spec
+	Principal     string    `json:"principal,omitempty"`
+	Reason        string    `json:"reason,omitempty"`
 }
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook delivery statuses.
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead"
+)
+
+// WebhookSubscriptionRequest creates or replaces a webhook subscription. Every filter that is set
+// must match for an event to be delivered; an empty filter matches everything.
+type WebhookSubscriptionRequest struct {
+	URL         string   `json:"url"         validate:"required,http_url,max=2048"`
+	Description string   `json:"description" validate:"max=255"`
+	SourceTypes []string `json:"sourceTypes" validate:"omitempty,dive,oneof=game server payment"` //nolint: tagliatelle // Per API spec
+	// States filters on the transaction state; hold settlements are win or lose, and placed, released
+	// and expired holds are reserved, released and expired.
+	States     []string `json:"states"     validate:"omitempty,dive,oneof=win lose reversal adjustment reserved released expired"` //nolint: lll // Per API spec
+	Currencies []string `json:"currencies" validate:"omitempty,dive,currency"`
+	// MinAmount only matches balance changes of at least this absolute amount, in major units of the
+	// event's currency.
+	MinAmount string `json:"minAmount" validate:"omitempty,numeric"` //nolint: tagliatelle // Per API spec
+	// Active defaults to true. A paused subscription matches no events, and the deliveries it already
+	// has wait until it is resumed.
+	Active *bool `json:"active"`
+}
+
+// WebhookSubscriptionResponse describes a subscription. The secret deliveries are signed with is
+// only returned when the subscription is created.
+type WebhookSubscriptionResponse struct {
+	SubscriptionID string    `json:"subscriptionId"` //nolint: tagliatelle // Per API spec
+	URL            string    `json:"url"`
+	Description    string    `json:"description,omitempty"`
+	SourceTypes    []string  `json:"sourceTypes"` //nolint: tagliatelle // Per API spec
+	States         []string  `json:"states"`
+	Currencies     []string  `json:"currencies"`
+	MinAmount      string    `json:"minAmount,omitempty"` //nolint: tagliatelle // Per API spec
+	Active         bool      `json:"active"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedBy      string    `json:"createdBy"` //nolint: tagliatelle // Per API spec
+	CreatedAt      time.Time `json:"createdAt"` //nolint: tagliatelle // Per API spec
+	UpdatedAt      time.Time `json:"updatedAt"` //nolint: tagliatelle // Per API spec
+}
+
+type WebhookSubscriptionListResponse struct {
+	Subscriptions []WebhookSubscriptionResponse `json:"subscriptions"`
+}
+
+type WebhookDeliveryListRequest struct {
+	SubscriptionID string `query:"subscriptionId" validate:"omitempty,uuid"`
+	Status         string `query:"status"         validate:"omitempty,oneof=pending delivered dead"`
+	Limit          int    `query:"limit"          validate:"omitempty,min=1,max=100"`
+}
+
+// WebhookDeliveryResponse is one entry of the delivery log. The payload is only included when a
+// single delivery is requested.
+type WebhookDeliveryResponse struct {
+	DeliveryID     string          `json:"deliveryId"`     //nolint: tagliatelle // Per API spec
+	SubscriptionID string          `json:"subscriptionId"` //nolint: tagliatelle // Per API spec
+	EventID        string          `json:"eventId"`        //nolint: tagliatelle // Per API spec
+	EventType      string          `json:"eventType"`      //nolint: tagliatelle // Per API spec
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode int             `json:"lastStatusCode,omitempty"` //nolint: tagliatelle // Per API spec
+	LastError      string          `json:"lastError,omitempty"`      //nolint: tagliatelle // Per API spec
+	NextAttemptAt  *time.Time      `json:"nextAttemptAt,omitempty"`  //nolint: tagliatelle // Per API spec
+	DeliveredAt    *time.Time      `json:"deliveredAt,omitempty"`    //nolint: tagliatelle // Per API spec
+	CreatedAt      time.Time       `json:"createdAt"`                //nolint: tagliatelle // Per API spec
+	Payload        json.RawMessage `json:"payload,omitempty"`
+}
+
+type WebhookDeliveryListResponse struct {
+	Deliveries []WebhookDeliveryResponse `json:"deliveries"`
+}
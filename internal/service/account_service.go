@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+type AccountService interface {
+	CreateUser(ctx context.Context, req api.CreateUserRequest) (api.UserResponse, error)
+	GetUser(ctx context.Context, userID uint64) (api.UserResponse, error)
+	FreezeUser(ctx context.Context, req api.FreezeRequest, userID uint64) (api.UserResponse, error)
+	UnfreezeUser(ctx context.Context, userID uint64) (api.UserResponse, error)
+	CloseUser(ctx context.Context, userID uint64) (api.UserResponse, error)
+}
+
+type accountService struct {
+	repo db.AccountRepository
+}
+
+func newAccountService(repo db.AccountRepository) AccountService {
+	return &accountService{
+		repo: repo,
+	}
+}
+
+func (s *accountService) CreateUser(ctx context.Context, req api.CreateUserRequest) (api.UserResponse, error) {
+	var user db.User
+	if req.ExternalID != "" {
+		user.ExternalID = &req.ExternalID
+	}
+
+	user, err := s.repo.CreateUser(ctx, user)
+	if err != nil {
+		return api.UserResponse{}, mapAccountError("CreateUser", err)
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (s *accountService) GetUser(ctx context.Context, userID uint64) (api.UserResponse, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return api.UserResponse{}, mapAccountError("GetUser", err)
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (s *accountService) FreezeUser(ctx context.Context, req api.FreezeRequest, userID uint64) (api.UserResponse, error) {
+	user, err := s.repo.FreezeUser(ctx, userID, req.Scope)
+	if err != nil {
+		return api.UserResponse{}, mapAccountError("FreezeUser", err)
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (s *accountService) UnfreezeUser(ctx context.Context, userID uint64) (api.UserResponse, error) {
+	user, err := s.repo.UnfreezeUser(ctx, userID)
+	if err != nil {
+		return api.UserResponse{}, mapAccountError("UnfreezeUser", err)
+	}
+
+	return toUserResponse(user), nil
+}
+
+func (s *accountService) CloseUser(ctx context.Context, userID uint64) (api.UserResponse, error) {
+	user, err := s.repo.CloseUser(ctx, userID)
+	if err != nil {
+		return api.UserResponse{}, mapAccountError("CloseUser", err)
+	}
+
+	return toUserResponse(user), nil
+}
+
+func toUserResponse(user db.User) api.UserResponse {
+	userResponse := api.UserResponse{
+		UserID:    user.ID,
+		Status:    user.Status,
+		CreatedAt: user.CreatedAt,
+		UpdatedAt: user.UpdatedAt,
+		ClosedAt:  user.ClosedAt,
+	}
+
+	if user.ExternalID != nil {
+		userResponse.ExternalID = *user.ExternalID
+	}
+
+	if user.FreezeScope != nil {
+		userResponse.FreezeScope = *user.FreezeScope
+	}
+
+	return userResponse
+}
+
+func mapAccountError(operation string, err error) error {
+	switch {
+	case errors.Is(err, db.ErrUserNotFound):
+		return errs.ErrUserNotFound
+	case errors.Is(err, db.ErrExternalIDExists):
+		return errs.ErrExternalIDExists
+	case errors.Is(err, db.ErrAccountClosed):
+		return errs.ErrAccountClosed
+	case errors.Is(err, db.ErrNonZeroBalance):
+		return errs.ErrNonZeroBalance
+	default:
+		return fmt.Errorf("%s error: %w", operation, err)
+	}
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func TestCreateUser(t *testing.T) {
+	ctx := context.Background()
+	createdAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	externalID := "player-42"
+
+	tests := []struct {
+		name           string
+		request        api.CreateUserRequest
+		mockSetup      func(*db.MockAccountRepository)
+		expectedResult api.UserResponse
+		expectedError  error
+	}{
+		{
+			name:    "without external ID",
+			request: api.CreateUserRequest{},
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().CreateUser(ctx, db.User{}).
+					Return(db.User{ID: 4, Status: db.UserStatusActive, CreatedAt: createdAt, UpdatedAt: createdAt}, nil)
+			},
+			expectedResult: api.UserResponse{UserID: 4, Status: db.UserStatusActive, CreatedAt: createdAt, UpdatedAt: createdAt},
+		},
+		{
+			name:    "with external ID",
+			request: api.CreateUserRequest{ExternalID: externalID},
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().CreateUser(ctx, db.User{ExternalID: &externalID}).
+					Return(db.User{ID: 5, ExternalID: &externalID, Status: db.UserStatusActive, CreatedAt: createdAt}, nil)
+			},
+			expectedResult: api.UserResponse{UserID: 5, ExternalID: externalID, Status: db.UserStatusActive, CreatedAt: createdAt},
+		},
+		{
+			name:    "external ID taken",
+			request: api.CreateUserRequest{ExternalID: externalID},
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().CreateUser(ctx, db.User{ExternalID: &externalID}).Return(db.User{}, db.ErrExternalIDExists)
+			},
+			expectedError: errs.ErrExternalIDExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockAccountRepository(t)
+			tt.mockSetup(mockRepo)
+
+			result, err := newAccountService(mockRepo).CreateUser(ctx, tt.request)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
+
+func TestAccountStatusChanges(t *testing.T) {
+	ctx := context.Background()
+	closedAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	debits := db.FreezeScopeDebits
+	dbError := errors.New("connection refused")
+
+	tests := []struct {
+		name           string
+		call           func(AccountService) (api.UserResponse, error)
+		mockSetup      func(*db.MockAccountRepository)
+		expectedResult api.UserResponse
+		expectedError  error
+	}{
+		{
+			name: "get user not found",
+			call: func(s AccountService) (api.UserResponse, error) { return s.GetUser(ctx, 99) },
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().GetUser(ctx, uint64(99)).Return(db.User{}, db.ErrUserNotFound)
+			},
+			expectedError: errs.ErrUserNotFound,
+		},
+		{
+			name: "freeze debits",
+			call: func(s AccountService) (api.UserResponse, error) {
+				return s.FreezeUser(ctx, api.FreezeRequest{Scope: db.FreezeScopeDebits}, 1)
+			},
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().FreezeUser(ctx, uint64(1), db.FreezeScopeDebits).
+					Return(db.User{ID: 1, Status: db.UserStatusFrozen, FreezeScope: &debits}, nil)
+			},
+			expectedResult: api.UserResponse{UserID: 1, Status: db.UserStatusFrozen, FreezeScope: db.FreezeScopeDebits},
+		},
+		{
+			name: "freeze closed account",
+			call: func(s AccountService) (api.UserResponse, error) {
+				return s.FreezeUser(ctx, api.FreezeRequest{Scope: db.FreezeScopeAll}, 1)
+			},
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().FreezeUser(ctx, uint64(1), db.FreezeScopeAll).Return(db.User{}, db.ErrAccountClosed)
+			},
+			expectedError: errs.ErrAccountClosed,
+		},
+		{
+			name: "unfreeze",
+			call: func(s AccountService) (api.UserResponse, error) { return s.UnfreezeUser(ctx, 1) },
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().UnfreezeUser(ctx, uint64(1)).Return(db.User{ID: 1, Status: db.UserStatusActive}, nil)
+			},
+			expectedResult: api.UserResponse{UserID: 1, Status: db.UserStatusActive},
+		},
+		{
+			name: "close",
+			call: func(s AccountService) (api.UserResponse, error) { return s.CloseUser(ctx, 1) },
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().CloseUser(ctx, uint64(1)).
+					Return(db.User{ID: 1, Status: db.UserStatusClosed, ClosedAt: &closedAt}, nil)
+			},
+			expectedResult: api.UserResponse{UserID: 1, Status: db.UserStatusClosed, ClosedAt: &closedAt},
+		},
+		{
+			name: "close with money left",
+			call: func(s AccountService) (api.UserResponse, error) { return s.CloseUser(ctx, 1) },
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().CloseUser(ctx, uint64(1)).Return(db.User{}, db.ErrNonZeroBalance)
+			},
+			expectedError: errs.ErrNonZeroBalance,
+		},
+		{
+			name: "database error is wrapped",
+			call: func(s AccountService) (api.UserResponse, error) { return s.CloseUser(ctx, 1) },
+			mockSetup: func(mockRepo *db.MockAccountRepository) {
+				mockRepo.EXPECT().CloseUser(ctx, uint64(1)).Return(db.User{}, dbError)
+			},
+			expectedError: dbError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockAccountRepository(t)
+			tt.mockSetup(mockRepo)
+
+			result, err := tt.call(newAccountService(mockRepo))
+
+			assert.ErrorIs(t, err, tt.expectedError)
+			assert.Equal(t, tt.expectedResult, result)
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+// AdjustmentService lets operators credit or debit users by hand. Every step is recorded in the
+// adjustment's audit trail under the principal in ctx, which is required.
+type AdjustmentService interface {
+	// RequestAdjustment posts the adjustment, or leaves it pending when four-eyes approval is on.
+	RequestAdjustment(ctx context.Context, req api.AdjustmentRequest, userID uint64) (api.AdjustmentResponse, error)
+	// ApproveAdjustment posts a pending adjustment. The approver must not be its requester.
+	ApproveAdjustment(
+		ctx context.Context, adjustmentID string, req api.AdjustmentApprovalRequest,
+	) (api.AdjustmentResponse, error)
+	RejectAdjustment(
+		ctx context.Context, adjustmentID string, req api.AdjustmentRejectionRequest,
+	) (api.AdjustmentResponse, error)
+	GetAdjustment(ctx context.Context, adjustmentID string) (api.AdjustmentResponse, error)
+	ListAdjustments(ctx context.Context, req api.AdjustmentListRequest) (api.AdjustmentListResponse, error)
+}
+
+type adjustmentService struct {
+	moneyConverter
+
+	repo     db.AdjustmentRepository
+	fourEyes bool
+}
+
+const DefaultAdjustmentsLimit = 20
+
+func newAdjustmentService(
+	repo db.AdjustmentRepository, currencies *currency.Registry, policy amount.Policy, fourEyes bool,
+) AdjustmentService {
+	return &adjustmentService{
+		moneyConverter: newMoneyConverter(currencies, policy),
+		repo:           repo,
+		fourEyes:       fourEyes,
+	}
+}
+
+func (s *adjustmentService) RequestAdjustment(
+	ctx context.Context, req api.AdjustmentRequest, userID uint64,
+) (_ api.AdjustmentResponse, err error) {
+	ctx, span := startSpan(ctx, "AdjustmentService.RequestAdjustment",
+		attribute.Int64("user.id", int64(userID)),
+		attribute.String("transaction.id", req.TransactionID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	operator := principalOf(ctx)
+	if operator == "" {
+		return api.AdjustmentResponse{}, errs.ErrOperatorRequired
+	}
+
+	cur, err := s.resolveCurrency(req.Currency)
+	if err != nil {
+		return api.AdjustmentResponse{}, err
+	}
+
+	minorUnits, err := s.toMinorUnits(req.Amount, cur)
+	if err != nil {
+		return api.AdjustmentResponse{}, err
+	}
+
+	if minorUnits == 0 {
+		return api.AdjustmentResponse{}, errs.ErrAmountZero
+	}
+
+	adjustment, err := s.repo.CreateAdjustment(ctx, db.Adjustment{
+		UserID:        userID,
+		TransactionID: req.TransactionID,
+		Amount:        minorUnits,
+		Currency:      cur.Code,
+		ReasonCode:    req.ReasonCode,
+		Comment:       req.Comment,
+		RequestedBy:   operator,
+	}, !s.fourEyes)
+	if err != nil {
+		return api.AdjustmentResponse{}, mapAdjustmentError("CreateAdjustment", err)
+	}
+
+	return s.toAdjustmentResponse(adjustment), nil
+}
+
+func (s *adjustmentService) ApproveAdjustment(
+	ctx context.Context, adjustmentID string, req api.AdjustmentApprovalRequest,
+) (_ api.AdjustmentResponse, err error) {
+	ctx, span := startSpan(ctx, "AdjustmentService.ApproveAdjustment", attribute.String("adjustment.id", adjustmentID))
+	defer func() { endSpan(span, err) }()
+
+	operator := principalOf(ctx)
+	if operator == "" {
+		return api.AdjustmentResponse{}, errs.ErrOperatorRequired
+	}
+
+	id, err := parseAdjustmentID(adjustmentID)
+	if err != nil {
+		return api.AdjustmentResponse{}, err
+	}
+
+	adjustment, err := s.repo.ApproveAdjustment(ctx, id, operator, req.Comment)
+	if err != nil {
+		return api.AdjustmentResponse{}, mapAdjustmentError("ApproveAdjustment", err)
+	}
+
+	return s.toAdjustmentResponse(adjustment), nil
+}
+
+func (s *adjustmentService) RejectAdjustment(
+	ctx context.Context, adjustmentID string, req api.AdjustmentRejectionRequest,
+) (_ api.AdjustmentResponse, err error) {
+	ctx, span := startSpan(ctx, "AdjustmentService.RejectAdjustment", attribute.String("adjustment.id", adjustmentID))
+	defer func() { endSpan(span, err) }()
+
+	operator := principalOf(ctx)
+	if operator == "" {
+		return api.AdjustmentResponse{}, errs.ErrOperatorRequired
+	}
+
+	id, err := parseAdjustmentID(adjustmentID)
+	if err != nil {
+		return api.AdjustmentResponse{}, err
+	}
+
+	adjustment, err := s.repo.RejectAdjustment(ctx, id, operator, req.Comment)
+	if err != nil {
+		return api.AdjustmentResponse{}, mapAdjustmentError("RejectAdjustment", err)
+	}
+
+	return s.toAdjustmentResponse(adjustment), nil
+}
+
+func (s *adjustmentService) GetAdjustment(ctx context.Context, adjustmentID string) (api.AdjustmentResponse, error) {
+	id, err := parseAdjustmentID(adjustmentID)
+	if err != nil {
+		return api.AdjustmentResponse{}, err
+	}
+
+	adjustment, err := s.repo.GetAdjustment(ctx, id)
+	if err != nil {
+		return api.AdjustmentResponse{}, mapAdjustmentError("GetAdjustment", err)
+	}
+
+	return s.toAdjustmentResponse(adjustment), nil
+}
+
+func (s *adjustmentService) ListAdjustments(
+	ctx context.Context, req api.AdjustmentListRequest,
+) (api.AdjustmentListResponse, error) {
+	filter := db.AdjustmentFilter{
+		UserID: req.UserID,
+		Status: req.Status,
+		Limit:  req.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultAdjustmentsLimit
+	}
+
+	adjustments, err := s.repo.ListAdjustments(ctx, filter)
+	if err != nil {
+		return api.AdjustmentListResponse{}, fmt.Errorf("ListAdjustments error: %w", err)
+	}
+
+	result := api.AdjustmentListResponse{
+		Adjustments: make([]api.AdjustmentResponse, 0, len(adjustments)),
+	}
+
+	for _, adjustment := range adjustments {
+		result.Adjustments = append(result.Adjustments, s.toAdjustmentResponse(adjustment))
+	}
+
+	return result, nil
+}
+
+func (s *adjustmentService) toAdjustmentResponse(adjustment db.Adjustment) api.AdjustmentResponse {
+	adjustmentResponse := api.AdjustmentResponse{
+		AdjustmentID:  adjustment.ID.String(),
+		UserID:        adjustment.UserID,
+		TransactionID: adjustment.TransactionID,
+		Amount:        s.formatMinorUnits(adjustment.Amount, adjustment.Currency),
+		Currency:      adjustment.Currency,
+		ReasonCode:    adjustment.ReasonCode,
+		Comment:       adjustment.Comment,
+		Status:        adjustment.Status,
+		RequestedBy:   adjustment.RequestedBy,
+		RequestedAt:   adjustment.CreatedAt,
+		DecidedAt:     adjustment.DecidedAt,
+	}
+
+	if adjustment.DecidedBy != nil {
+		adjustmentResponse.DecidedBy = *adjustment.DecidedBy
+	}
+
+	for _, record := range adjustment.Audit {
+		adjustmentResponse.Audit = append(adjustmentResponse.Audit, api.AdjustmentAuditRecord{
+			Action:  record.Action,
+			Actor:   record.Actor,
+			Comment: record.Comment,
+			At:      record.CreatedAt,
+		})
+	}
+
+	return adjustmentResponse
+}
+
+// parseAdjustmentID treats a malformed ID as unknown, since no adjustment can have it.
+func parseAdjustmentID(adjustmentID string) (uuid.UUID, error) {
+	id, err := uuid.Parse(adjustmentID)
+	if err != nil {
+		return uuid.Nil, errs.ErrAdjustmentNotFound
+	}
+
+	return id, nil
+}
+
+func mapAdjustmentError(operation string, err error) error {
+	switch {
+	case errors.Is(err, db.ErrUserNotFound):
+		return errs.ErrUserNotFound
+	case errors.Is(err, db.ErrAdjustmentNotFound):
+		return errs.ErrAdjustmentNotFound
+	case errors.Is(err, db.ErrAdjustmentExists):
+		return errs.ErrAdjustmentExists
+	case errors.Is(err, db.ErrAdjustmentNotPending):
+		return errs.ErrAdjustmentNotPending
+	case errors.Is(err, db.ErrSelfApproval):
+		return errs.ErrSelfApproval
+	case errors.Is(err, db.ErrInsufficientFunds):
+		return errs.ErrInsufficientFunds
+	case errors.Is(err, db.ErrAccountFrozen):
+		return errs.ErrAccountFrozen
+	case errors.Is(err, db.ErrAccountClosed):
+		return errs.ErrAccountClosed
+	default:
+		return fmt.Errorf("%s error: %w", operation, err)
+	}
+}
@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func TestRequestAdjustment(t *testing.T) {
+	operatorCtx := auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindJWT, ID: "alice"})
+	requestedAt := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	adjustmentID := uuid.MustParse("9b2f4c1e-7d7a-4a39-9a8e-0f3f4a4f3b11")
+
+	request := api.AdjustmentRequest{
+		Amount:        "-3.25",
+		Currency:      "EUR",
+		TransactionID: "manual-1",
+		ReasonCode:    api.AdjustmentReasonCorrection,
+		Comment:       "Duplicate payout",
+	}
+	expectedAdjustment := db.Adjustment{
+		UserID:        1,
+		TransactionID: "manual-1",
+		Amount:        -325,
+		Currency:      "EUR",
+		ReasonCode:    api.AdjustmentReasonCorrection,
+		Comment:       "Duplicate payout",
+		RequestedBy:   "jwt:alice",
+	}
+
+	stored := func(status string) db.Adjustment {
+		adjustment := expectedAdjustment
+		adjustment.ID = adjustmentID
+		adjustment.Status = status
+		adjustment.CreatedAt = requestedAt
+		adjustment.Audit = []db.AdjustmentAuditRecord{
+			{Action: db.AdjustmentActionRequested, Actor: "jwt:alice", Comment: "Duplicate payout", CreatedAt: requestedAt},
+		}
+
+		return adjustment
+	}
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		request       api.AdjustmentRequest
+		fourEyes      bool
+		mockSetup     func(*db.MockAdjustmentRepository)
+		expected      api.AdjustmentResponse
+		expectedError error
+	}{
+		{
+			name:    "posted at once",
+			ctx:     operatorCtx,
+			request: request,
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().CreateAdjustment(mock.Anything, expectedAdjustment, true).
+					Return(stored(db.AdjustmentStatusPosted), nil)
+			},
+			expected: api.AdjustmentResponse{
+				AdjustmentID:  adjustmentID.String(),
+				UserID:        1,
+				TransactionID: "manual-1",
+				Amount:        "-3.25",
+				Currency:      "EUR",
+				ReasonCode:    api.AdjustmentReasonCorrection,
+				Comment:       "Duplicate payout",
+				Status:        db.AdjustmentStatusPosted,
+				RequestedBy:   "jwt:alice",
+				RequestedAt:   requestedAt,
+				Audit: []api.AdjustmentAuditRecord{
+					{Action: "requested", Actor: "jwt:alice", Comment: "Duplicate payout", At: requestedAt},
+				},
+			},
+		},
+		{
+			name:     "held for approval",
+			ctx:      operatorCtx,
+			request:  request,
+			fourEyes: true,
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().CreateAdjustment(mock.Anything, expectedAdjustment, false).
+					Return(stored(db.AdjustmentStatusPending), nil)
+			},
+			expected: api.AdjustmentResponse{
+				AdjustmentID:  adjustmentID.String(),
+				UserID:        1,
+				TransactionID: "manual-1",
+				Amount:        "-3.25",
+				Currency:      "EUR",
+				ReasonCode:    api.AdjustmentReasonCorrection,
+				Comment:       "Duplicate payout",
+				Status:        db.AdjustmentStatusPending,
+				RequestedBy:   "jwt:alice",
+				RequestedAt:   requestedAt,
+				Audit: []api.AdjustmentAuditRecord{
+					{Action: "requested", Actor: "jwt:alice", Comment: "Duplicate payout", At: requestedAt},
+				},
+			},
+		},
+		{
+			name:          "no operator",
+			ctx:           context.Background(),
+			request:       request,
+			mockSetup:     func(*db.MockAdjustmentRepository) {},
+			expectedError: errs.ErrOperatorRequired,
+		},
+		{
+			name:          "zero amount",
+			ctx:           operatorCtx,
+			request:       api.AdjustmentRequest{Amount: "0.00", TransactionID: "manual-1"},
+			mockSetup:     func(*db.MockAdjustmentRepository) {},
+			expectedError: errs.ErrAmountZero,
+		},
+		{
+			name:          "unknown currency",
+			ctx:           operatorCtx,
+			request:       api.AdjustmentRequest{Amount: "1.00", Currency: "XXX", TransactionID: "manual-1"},
+			mockSetup:     func(*db.MockAdjustmentRepository) {},
+			expectedError: errs.ErrUnsupportedCurrency,
+		},
+		{
+			name:    "transaction ID reused",
+			ctx:     operatorCtx,
+			request: request,
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().CreateAdjustment(mock.Anything, expectedAdjustment, true).
+					Return(db.Adjustment{}, db.ErrAdjustmentExists)
+			},
+			expectedError: errs.ErrAdjustmentExists,
+		},
+		{
+			name:    "insufficient funds",
+			ctx:     operatorCtx,
+			request: request,
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().CreateAdjustment(mock.Anything, expectedAdjustment, true).
+					Return(db.Adjustment{}, db.ErrInsufficientFunds)
+			},
+			expectedError: errs.ErrInsufficientFunds,
+		},
+		{
+			name:    "database error",
+			ctx:     operatorCtx,
+			request: request,
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().CreateAdjustment(mock.Anything, expectedAdjustment, true).
+					Return(db.Adjustment{}, errors.New("connection reset"))
+			},
+			expectedError: errors.New("CreateAdjustment error: connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockAdjustmentRepository(t)
+			tt.mockSetup(mockRepo)
+
+			service := newAdjustmentService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil), tt.fourEyes)
+			result, err := service.RequestAdjustment(tt.ctx, tt.request, 1)
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestApproveAdjustment(t *testing.T) {
+	approverCtx := auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindJWT, ID: "bob"})
+	adjustmentID := uuid.MustParse("9b2f4c1e-7d7a-4a39-9a8e-0f3f4a4f3b11")
+	decidedBy := "jwt:bob"
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		adjustmentID  string
+		mockSetup     func(*db.MockAdjustmentRepository)
+		expectedError error
+	}{
+		{
+			name:         "approved",
+			ctx:          approverCtx,
+			adjustmentID: adjustmentID.String(),
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().ApproveAdjustment(mock.Anything, adjustmentID, "jwt:bob", "checked").
+					Return(db.Adjustment{
+						ID: adjustmentID, Amount: 500, Currency: "USD", Status: db.AdjustmentStatusPosted,
+						RequestedBy: "jwt:alice", DecidedBy: &decidedBy,
+					}, nil)
+			},
+		},
+		{
+			name:          "malformed ID",
+			ctx:           approverCtx,
+			adjustmentID:  "not-a-uuid",
+			mockSetup:     func(*db.MockAdjustmentRepository) {},
+			expectedError: errs.ErrAdjustmentNotFound,
+		},
+		{
+			name:          "no operator",
+			ctx:           context.Background(),
+			adjustmentID:  adjustmentID.String(),
+			mockSetup:     func(*db.MockAdjustmentRepository) {},
+			expectedError: errs.ErrOperatorRequired,
+		},
+		{
+			name:         "own adjustment",
+			ctx:          approverCtx,
+			adjustmentID: adjustmentID.String(),
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().ApproveAdjustment(mock.Anything, adjustmentID, "jwt:bob", "checked").
+					Return(db.Adjustment{}, db.ErrSelfApproval)
+			},
+			expectedError: errs.ErrSelfApproval,
+		},
+		{
+			name:         "already decided",
+			ctx:          approverCtx,
+			adjustmentID: adjustmentID.String(),
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().ApproveAdjustment(mock.Anything, adjustmentID, "jwt:bob", "checked").
+					Return(db.Adjustment{}, db.ErrAdjustmentNotPending)
+			},
+			expectedError: errs.ErrAdjustmentNotPending,
+		},
+		{
+			name:         "account frozen since the request",
+			ctx:          approverCtx,
+			adjustmentID: adjustmentID.String(),
+			mockSetup: func(mockRepo *db.MockAdjustmentRepository) {
+				mockRepo.EXPECT().ApproveAdjustment(mock.Anything, adjustmentID, "jwt:bob", "checked").
+					Return(db.Adjustment{}, db.ErrAccountFrozen)
+			},
+			expectedError: errs.ErrAccountFrozen,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockAdjustmentRepository(t)
+			tt.mockSetup(mockRepo)
+
+			service := newAdjustmentService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil), true)
+			result, err := service.ApproveAdjustment(tt.ctx, tt.adjustmentID, api.AdjustmentApprovalRequest{Comment: "checked"})
+
+			if tt.expectedError != nil {
+				assert.EqualError(t, err, tt.expectedError.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "posted", result.Status)
+			assert.Equal(t, "5.00", result.Amount)
+			assert.Equal(t, "jwt:bob", result.DecidedBy)
+		})
+	}
+}
+
+func TestListAdjustments_DefaultLimit(t *testing.T) {
+	mockRepo := db.NewMockAdjustmentRepository(t)
+	mockRepo.EXPECT().ListAdjustments(mock.Anything, db.AdjustmentFilter{
+		Status: db.AdjustmentStatusPending,
+		Limit:  DefaultAdjustmentsLimit,
+	}).Return([]db.Adjustment{{TransactionID: "manual-1", Amount: 100, Currency: "USD"}}, nil)
+
+	service := newAdjustmentService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil), true)
+	result, err := service.ListAdjustments(context.Background(), api.AdjustmentListRequest{Status: "pending"})
+
+	require.NoError(t, err)
+	require.Len(t, result.Adjustments, 1)
+	assert.Equal(t, "1.00", result.Adjustments[0].Amount)
+}
@@ -0,0 +1,282 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/stream"
+)
+
+const decimalBase = 10
+
+// BalanceStreamService opens the balance streams of game clients. A stream starts with what the
+// client is missing, either the changes after the event it resumes from or a snapshot of its
+// wallets, and goes on with every balance change the outbox relay publishes.
+type BalanceStreamService interface {
+	// OpenBalanceStream resumes after the event lastEventID, or starts with a snapshot when it is
+	// zero or more changes than the replay limit were missed.
+	OpenBalanceStream(ctx context.Context, userID, lastEventID uint64) (*BalanceStream, error)
+	// CloseStreams ends every open stream and refuses new ones, for a server that shuts down.
+	CloseStreams()
+}
+
+// BalanceStream delivers the messages of one client, with a heartbeat whenever it has been idle
+// for the heartbeat interval. Its channel is closed when the client falls too far behind or the
+// server shuts down; the client then reconnects and resumes from the last change it received.
+type BalanceStream struct {
+	messages     chan api.StreamMessage
+	subscription *stream.Subscription
+	heartbeat    time.Duration
+	done         chan struct{}
+	closeOnce    sync.Once
+}
+
+type balanceStreamService struct {
+	moneyConverter
+
+	repo   db.BalanceStreamRepository
+	hub    *stream.Hub
+	config config.StreamConfig
+}
+
+// NewBalanceStreamService is built by the server only, next to the hub it subscribes to.
+func NewBalanceStreamService(
+	repo db.BalanceStreamRepository, currencies *currency.Registry, hub *stream.Hub, cfg config.StreamConfig,
+) BalanceStreamService {
+	return &balanceStreamService{
+		moneyConverter: newMoneyConverter(currencies, amount.NewPolicy(nil)),
+		repo:           repo,
+		hub:            hub,
+		config:         cfg,
+	}
+}
+
+func (s *balanceStreamService) OpenBalanceStream(
+	ctx context.Context, userID, lastEventID uint64,
+) (_ *BalanceStream, err error) {
+	ctx, span := startSpan(ctx, "BalanceStreamService.OpenBalanceStream",
+		attribute.Int64("user.id", int64(userID)), attribute.Int64("stream.last_event_id", int64(lastEventID)))
+	defer func() { endSpan(span, err) }()
+
+	// Subscribe before reading the database, so that no change falls between the two.
+	subscription, err := s.hub.Subscribe(strconv.FormatUint(userID, decimalBase))
+	if err != nil {
+		return nil, errs.ErrStreamsUnavailable
+	}
+
+	initial, sent, err := s.initialMessages(ctx, userID, lastEventID)
+	if err != nil {
+		subscription.Close()
+
+		return nil, err
+	}
+
+	balanceStream := &BalanceStream{
+		messages:     make(chan api.StreamMessage),
+		subscription: subscription,
+		heartbeat:    s.config.Heartbeat,
+		done:         make(chan struct{}),
+	}
+
+	go balanceStream.run(initial, func(event events.Event) (api.StreamMessage, bool) {
+		return s.liveMessage(event, sent, lastEventID)
+	})
+
+	return balanceStream, nil
+}
+
+func (s *balanceStreamService) CloseStreams() {
+	s.hub.Shutdown()
+}
+
+// initialMessages returns the replayed changes or the snapshot the stream starts with, and the
+// sequence of the last change each currency's messages cover.
+func (s *balanceStreamService) initialMessages(
+	ctx context.Context, userID, lastEventID uint64,
+) ([]api.StreamMessage, map[string]uint64, error) {
+	snapshot, err := s.repo.GetBalanceSnapshot(ctx, userID)
+	if err != nil {
+		if errors.Is(err, db.ErrUserNotFound) {
+			return nil, nil, errs.ErrUserNotFound
+		}
+
+		return nil, nil, fmt.Errorf("GetBalanceSnapshot error: %w", err)
+	}
+
+	if lastEventID > 0 {
+		changes, err := s.repo.ListBalanceChanges(ctx, userID, lastEventID, s.config.MaxReplay+1)
+		if err != nil {
+			return nil, nil, fmt.Errorf("ListBalanceChanges error: %w", err)
+		}
+
+		if len(changes) <= s.config.MaxReplay {
+			return s.replay(changes)
+		}
+	}
+
+	message, err := s.snapshotMessage(userID, snapshot)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []api.StreamMessage{message}, snapshot.LastEventIDs, nil
+}
+
+func (s *balanceStreamService) replay(changes []db.OutboxEvent) ([]api.StreamMessage, map[string]uint64, error) {
+	messages := make([]api.StreamMessage, 0, len(changes))
+	sent := make(map[string]uint64)
+
+	for _, change := range changes {
+		var payload db.BalanceChange
+		if err := json.Unmarshal([]byte(change.Payload), &payload); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode %s payload: %w", change.EventType, err)
+		}
+
+		data, err := json.Marshal(s.toBalanceChangedEvent(payload))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to encode %s payload: %w", change.EventType, err)
+		}
+
+		messages = append(messages, api.StreamMessage{
+			ID:   strconv.FormatUint(change.ID, decimalBase),
+			Type: api.StreamMessageBalanceChange,
+			Data: data,
+		})
+		sent[payload.Currency] = change.ID
+	}
+
+	return messages, sent, nil
+}
+
+func (s *balanceStreamService) snapshotMessage(userID uint64, snapshot db.BalanceSnapshot) (api.StreamMessage, error) {
+	data := api.BalanceSnapshot{
+		UserID:  userID,
+		Wallets: make([]api.WalletSnapshot, 0, len(snapshot.Wallets)),
+	}
+
+	for _, wallet := range snapshot.Wallets {
+		data.Wallets = append(data.Wallets, api.WalletSnapshot{
+			Currency:  wallet.Currency,
+			Balance:   s.formatMinorUnits(wallet.Balance, wallet.Currency),
+			Available: s.formatMinorUnits(wallet.Available(), wallet.Currency),
+			Reserved:  s.formatMinorUnits(wallet.Reserved, wallet.Currency),
+			Sequence:  snapshot.LastEventIDs[wallet.Currency],
+		})
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return api.StreamMessage{}, fmt.Errorf("failed to encode balance snapshot: %w", err)
+	}
+
+	return api.StreamMessage{Type: api.StreamMessageSnapshot, Data: encoded}, nil
+}
+
+// liveMessage turns a relayed event into a message, skipping the events the client already has:
+// those up to lastEventID, and those covered by the snapshot or replay of their currency. An event
+// that arrives after a later one of its wallet, as a relay retry can cause, is skipped too, since
+// the balance it carries is stale.
+func (*balanceStreamService) liveMessage(
+	event events.Event, sent map[string]uint64, lastEventID uint64,
+) (api.StreamMessage, bool) {
+	if event.Type != db.EventTypeBalanceChanged || event.Sequence <= lastEventID {
+		return api.StreamMessage{}, false
+	}
+
+	var change api.BalanceChangedEvent
+	if err := json.Unmarshal(event.Data, &change); err != nil {
+		logrus.WithError(err).WithField("event_id", event.ID).Warn("Ignoring malformed balance change event")
+
+		return api.StreamMessage{}, false
+	}
+
+	if event.Sequence <= sent[change.Currency] {
+		return api.StreamMessage{}, false
+	}
+
+	sent[change.Currency] = event.Sequence
+
+	return api.StreamMessage{
+		ID:   strconv.FormatUint(event.Sequence, decimalBase),
+		Type: api.StreamMessageBalanceChange,
+		Data: event.Data,
+	}, true
+}
+
+// Messages is closed when the stream ends.
+func (s *BalanceStream) Messages() <-chan api.StreamMessage {
+	return s.messages
+}
+
+// Close ends the stream. It is safe to call more than once.
+func (s *BalanceStream) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.subscription.Close()
+	})
+}
+
+func (s *BalanceStream) run(
+	initial []api.StreamMessage, toMessage func(events.Event) (api.StreamMessage, bool),
+) {
+	defer close(s.messages)
+
+	for _, message := range initial {
+		if !s.send(message) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(s.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-s.subscription.Events():
+			if !ok {
+				return
+			}
+
+			message, ok := toMessage(event)
+			if !ok {
+				continue
+			}
+
+			if !s.send(message) {
+				return
+			}
+
+			heartbeat.Reset(s.heartbeat)
+		case <-heartbeat.C:
+			if !s.send(api.StreamMessage{Type: api.StreamMessageHeartbeat}) {
+				return
+			}
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *BalanceStream) send(message api.StreamMessage) bool {
+	select {
+	case s.messages <- message:
+		return true
+	case <-s.done:
+		return false
+	}
+}
@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	"github.com/TiPSYDiPSY/home-task/internal/stream"
+)
+
+var testStreamConfig = config.StreamConfig{
+	Heartbeat: time.Hour,
+	Buffer:    8,
+	MaxReplay: 2,
+}
+
+func newTestBalanceSnapshot() db.BalanceSnapshot {
+	return db.BalanceSnapshot{
+		Wallets: []db.Wallet{
+			{UserID: 1, Currency: "USD", Balance: 5000, Reserved: 1000},
+			{UserID: 1, Currency: "EUR", Balance: 250},
+		},
+		LastEventIDs: map[string]uint64{"USD": 7},
+	}
+}
+
+func TestOpenBalanceStream(t *testing.T) {
+	tests := []struct {
+		name             string
+		lastEventID      uint64
+		mockSetup        func(*db.MockBalanceStreamRepository)
+		expectedMessages []api.StreamMessage
+		expectedError    error
+	}{
+		{
+			name: "starts with a snapshot",
+			mockSetup: func(mockRepo *db.MockBalanceStreamRepository) {
+				mockRepo.EXPECT().GetBalanceSnapshot(mock.Anything, uint64(1)).Return(newTestBalanceSnapshot(), nil).Once()
+			},
+			expectedMessages: []api.StreamMessage{{
+				Type: api.StreamMessageSnapshot,
+				Data: json.RawMessage(`{"userId":1,"wallets":[` +
+					`{"currency":"USD","balance":"50.00","available":"40.00","reserved":"10.00","sequence":7},` +
+					`{"currency":"EUR","balance":"2.50","available":"2.50","reserved":"0.00"}]}`),
+			}},
+		},
+		{
+			name:        "resumes with the missed changes",
+			lastEventID: 5,
+			mockSetup: func(mockRepo *db.MockBalanceStreamRepository) {
+				mockRepo.EXPECT().GetBalanceSnapshot(mock.Anything, uint64(1)).Return(newTestBalanceSnapshot(), nil).Once()
+				mockRepo.EXPECT().ListBalanceChanges(mock.Anything, uint64(1), uint64(5), 3).
+					Return([]db.OutboxEvent{newTestOutboxEvent(6, 0), newTestOutboxEvent(7, 0)}, nil).Once()
+			},
+			expectedMessages: []api.StreamMessage{
+				{ID: "6", Type: api.StreamMessageBalanceChange},
+				{ID: "7", Type: api.StreamMessageBalanceChange},
+			},
+		},
+		{
+			name:        "falls back to a snapshot when too many changes were missed",
+			lastEventID: 1,
+			mockSetup: func(mockRepo *db.MockBalanceStreamRepository) {
+				mockRepo.EXPECT().GetBalanceSnapshot(mock.Anything, uint64(1)).Return(newTestBalanceSnapshot(), nil).Once()
+				mockRepo.EXPECT().ListBalanceChanges(mock.Anything, uint64(1), uint64(1), 3).
+					Return([]db.OutboxEvent{
+						newTestOutboxEvent(2, 0), newTestOutboxEvent(3, 0), newTestOutboxEvent(4, 0),
+					}, nil).Once()
+			},
+			expectedMessages: []api.StreamMessage{{Type: api.StreamMessageSnapshot}},
+		},
+		{
+			name: "user not found",
+			mockSetup: func(mockRepo *db.MockBalanceStreamRepository) {
+				mockRepo.EXPECT().GetBalanceSnapshot(mock.Anything, uint64(1)).
+					Return(db.BalanceSnapshot{}, db.ErrUserNotFound).Once()
+			},
+			expectedError: errs.ErrUserNotFound,
+		},
+		{
+			name:        "replay fails",
+			lastEventID: 5,
+			mockSetup: func(mockRepo *db.MockBalanceStreamRepository) {
+				mockRepo.EXPECT().GetBalanceSnapshot(mock.Anything, uint64(1)).Return(newTestBalanceSnapshot(), nil).Once()
+				mockRepo.EXPECT().ListBalanceChanges(mock.Anything, uint64(1), uint64(5), 3).
+					Return(nil, errors.New("connection refused")).Once()
+			},
+			expectedError: errors.New("ListBalanceChanges error: connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockBalanceStreamRepository(t)
+			tt.mockSetup(mockRepo)
+
+			streamService := NewBalanceStreamService(
+				mockRepo, currency.DefaultRegistry(), stream.NewHub(stream.NewLocalNotifier(), 0), testStreamConfig,
+			)
+
+			balanceStream, err := streamService.OpenBalanceStream(t.Context(), 1, tt.lastEventID)
+			if tt.expectedError != nil {
+				require.EqualError(t, err, tt.expectedError.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			defer balanceStream.Close()
+
+			for _, expected := range tt.expectedMessages {
+				message := <-balanceStream.Messages()
+
+				assert.Equal(t, expected.ID, message.ID)
+				assert.Equal(t, expected.Type, message.Type)
+
+				if expected.Data != nil {
+					assert.JSONEq(t, string(expected.Data), string(message.Data))
+				}
+			}
+		})
+	}
+}
+
+func TestOpenBalanceStreamAfterShutdown(t *testing.T) {
+	streamService := NewBalanceStreamService(
+		db.NewMockBalanceStreamRepository(t), currency.DefaultRegistry(),
+		stream.NewHub(stream.NewLocalNotifier(), 0), testStreamConfig,
+	)
+
+	streamService.CloseStreams()
+
+	_, err := streamService.OpenBalanceStream(t.Context(), 1, 0)
+	require.ErrorIs(t, err, errs.ErrStreamsUnavailable)
+}
+
+func TestBalanceStreamLiveMessages(t *testing.T) {
+	notifier := stream.NewLocalNotifier()
+	hub := stream.NewHub(notifier, 0)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	go hub.Run(ctx)
+
+	mockRepo := db.NewMockBalanceStreamRepository(t)
+	mockRepo.EXPECT().GetBalanceSnapshot(mock.Anything, uint64(1)).Return(newTestBalanceSnapshot(), nil).Once()
+
+	cfg := testStreamConfig
+	cfg.Heartbeat = 50 * time.Millisecond
+
+	balanceStream, err := NewBalanceStreamService(mockRepo, currency.DefaultRegistry(), hub, cfg).
+		OpenBalanceStream(t.Context(), 1, 0)
+	require.NoError(t, err)
+
+	defer balanceStream.Close()
+
+	assert.Equal(t, api.StreamMessageSnapshot, (<-balanceStream.Messages()).Type)
+
+	change := func(sequence uint64, currencyCode string) events.Event {
+		return events.Event{
+			Type:     db.EventTypeBalanceChanged,
+			Key:      "1",
+			Sequence: sequence,
+			Data:     json.RawMessage(`{"userId":1,"currency":"` + currencyCode + `","balance":"1.00"}`),
+		}
+	}
+
+	// The hub listens asynchronously, so publish until the stream forwards a change. The USD change
+	// is covered by the snapshot and the second EUR one is a duplicate, so only the first EUR one
+	// comes through.
+	var message api.StreamMessage
+
+	require.Eventually(t, func() bool {
+		for _, event := range []events.Event{change(7, "USD"), change(8, "EUR"), change(8, "EUR")} {
+			require.NoError(t, notifier.Publish(ctx, event))
+		}
+
+		select {
+		case message = <-balanceStream.Messages():
+			return message.Type != api.StreamMessageHeartbeat
+		case <-time.After(time.Millisecond):
+			return false
+		}
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, "8", message.ID)
+	assert.Equal(t, api.StreamMessageBalanceChange, message.Type)
+
+	// Nothing but heartbeats follows.
+	assert.Equal(t, api.StreamMessageHeartbeat, (<-balanceStream.Messages()).Type)
+
+	// The channel is closed once the stream is, with at most a heartbeat in flight.
+	balanceStream.Close()
+
+	for message := range balanceStream.Messages() {
+		assert.Equal(t, api.StreamMessageHeartbeat, message.Type)
+	}
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+// ExportService streams whole tables in their API representation. fn is called once per row; an
+// error it returns stops the export and is wrapped in the returned error.
+type ExportService interface {
+	ExportUsers(ctx context.Context, fn func(api.UserResponse) error) error
+	ExportWallets(ctx context.Context, fn func(api.BalanceResponse) error) error
+	// ExportTransactions exports the transactions of userID, or of every user when userID is zero.
+	ExportTransactions(ctx context.Context, userID uint64, fn func(api.TransactionDetails) error) error
+}
+
+type exportService struct {
+	moneyConverter
+
+	repo db.ExportRepository
+}
+
+func newExportService(repo db.ExportRepository, currencies *currency.Registry, policy amount.Policy) ExportService {
+	return &exportService{
+		moneyConverter: newMoneyConverter(currencies, policy),
+		repo:           repo,
+	}
+}
+
+func (s *exportService) ExportUsers(ctx context.Context, fn func(api.UserResponse) error) error {
+	return wrapExportError("ExportUsers", s.repo.ExportUsers(ctx, func(users []db.User) error {
+		for _, user := range users {
+			if err := fn(toUserResponse(user)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+func (s *exportService) ExportWallets(ctx context.Context, fn func(api.BalanceResponse) error) error {
+	return wrapExportError("ExportWallets", s.repo.ExportWallets(ctx, func(wallets []db.Wallet) error {
+		for _, wallet := range wallets {
+			if err := fn(s.toBalanceResponse(wallet)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+func (s *exportService) ExportTransactions(
+	ctx context.Context, userID uint64, fn func(api.TransactionDetails) error,
+) error {
+	return wrapExportError("ExportTransactions", s.repo.ExportTransactions(ctx, userID, func(transactions []db.Transaction) error {
+		for _, transaction := range transactions {
+			if err := fn(s.toTransactionDetails(transaction)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}))
+}
+
+func wrapExportError(operation string, err error) error {
+	if err != nil {
+		return fmt.Errorf("%s error: %w", operation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func TestExportWallets(t *testing.T) {
+	mockRepo := db.NewMockExportRepository(t)
+	mockRepo.EXPECT().ExportWallets(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, fn func([]db.Wallet) error) error {
+			if err := fn([]db.Wallet{{UserID: 1, Currency: "USD", Balance: 1050, Reserved: 50}}); err != nil {
+				return err
+			}
+
+			return fn([]db.Wallet{{UserID: 2, Currency: "EUR", Balance: 200}})
+		})
+
+	service := newExportService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+
+	var exported []api.BalanceResponse
+
+	err := service.ExportWallets(context.Background(), func(wallet api.BalanceResponse) error {
+		exported = append(exported, wallet)
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []api.BalanceResponse{
+		{UserID: 1, Currency: "USD", Balance: "10.50", Available: "10.00", Reserved: "0.50"},
+		{UserID: 2, Currency: "EUR", Balance: "2.00", Available: "2.00", Reserved: "0.00"},
+	}, exported)
+}
+
+func TestExportTransactions(t *testing.T) {
+	errWrite := errors.New("disk full")
+
+	tests := []struct {
+		name          string
+		writeErr      error
+		repoErr       error
+		expectedCount int
+		expectedError error
+	}{
+		{name: "all rows", expectedCount: 3},
+		{name: "writer error stops the export", writeErr: errWrite, expectedCount: 1, expectedError: errWrite},
+		{
+			name:          "database error",
+			repoErr:       errors.New("connection reset"),
+			expectedError: errors.New("ExportTransactions error: connection reset"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockExportRepository(t)
+			mockRepo.EXPECT().ExportTransactions(mock.Anything, uint64(7), mock.Anything).RunAndReturn(
+				func(_ context.Context, _ uint64, fn func([]db.Transaction) error) error {
+					if tt.repoErr != nil {
+						return tt.repoErr
+					}
+
+					return fn([]db.Transaction{
+						{UserID: 7, TransactionID: "t1", Amount: 100, Currency: "USD"},
+						{UserID: 7, TransactionID: "t2", Amount: -50, Currency: "USD"},
+						{UserID: 7, TransactionID: "t3", Amount: 25, Currency: "USD"},
+					})
+				})
+
+			service := newExportService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+
+			count := 0
+			err := service.ExportTransactions(context.Background(), 7, func(api.TransactionDetails) error {
+				count++
+
+				return tt.writeErr
+			})
+
+			assert.Equal(t, tt.expectedCount, count)
+
+			switch {
+			case errors.Is(tt.expectedError, errWrite):
+				assert.ErrorIs(t, err, errWrite)
+			case tt.expectedError != nil:
+				assert.EqualError(t, err, tt.expectedError.Error())
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
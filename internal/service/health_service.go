@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+type HealthService interface {
+	// Live reports whether the process is able to serve requests at all. It does not look at
+	// dependencies, so an outage of the database does not get the pod restarted.
+	Live(ctx context.Context) api.HealthResponse
+	// Ready reports whether the instance should receive traffic.
+	Ready(ctx context.Context) api.HealthResponse
+	// BeginShutdown makes every following readiness check fail, so load balancers drain the instance.
+	BeginShutdown()
+}
+
+const (
+	HealthCheckTimeout = 2 * time.Second
+
+	checkDatabase   = "database"
+	checkMigrations = "migrations"
+	checkShutdown   = "shutdown"
+)
+
+type healthService struct {
+	repo         db.HealthRepository
+	shuttingDown atomic.Bool
+}
+
+func newHealthService(repo db.HealthRepository) HealthService {
+	return &healthService{
+		repo: repo,
+	}
+}
+
+func (*healthService) Live(_ context.Context) api.HealthResponse {
+	return api.HealthResponse{Status: api.HealthStatusOK}
+}
+
+func (s *healthService) Ready(ctx context.Context) api.HealthResponse {
+	if s.shuttingDown.Load() {
+		return api.HealthResponse{
+			Status: api.HealthStatusFail,
+			Checks: map[string]api.HealthCheck{
+				checkShutdown: {Status: api.HealthStatusFail, Error: "server is shutting down"},
+			},
+		}
+	}
+
+	checks := map[string]api.HealthCheck{
+		checkDatabase: runHealthCheck(ctx, s.repo.Ping),
+	}
+
+	// Without a database there is nothing to learn about its schema.
+	if checks[checkDatabase].Status == api.HealthStatusOK {
+		checks[checkMigrations] = runHealthCheck(ctx, s.checkMigrations)
+	} else {
+		checks[checkMigrations] = api.HealthCheck{Status: api.HealthStatusFail, Error: "skipped, database is unavailable"}
+	}
+
+	response := api.HealthResponse{Status: api.HealthStatusOK, Checks: checks}
+
+	for _, check := range checks {
+		if check.Status != api.HealthStatusOK {
+			response.Status = api.HealthStatusFail
+		}
+	}
+
+	return response
+}
+
+func (s *healthService) BeginShutdown() {
+	s.shuttingDown.Store(true)
+}
+
+// checkMigrations fails while the schema is behind this build. Migrations a newer release applied
+// during a rolling deploy are fine, as they are backwards compatible.
+func (s *healthService) checkMigrations(ctx context.Context) error {
+	pending, err := s.repo.PendingMigrations(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(pending))
+	for _, migration := range pending {
+		names = append(names, fmt.Sprintf("%04d_%s", migration.Version, migration.Name))
+	}
+
+	return fmt.Errorf("%d pending migrations: %s", len(pending), strings.Join(names, ", "))
+}
+
+func runHealthCheck(ctx context.Context, check func(ctx context.Context) error) api.HealthCheck {
+	ctx, cancel := context.WithTimeout(ctx, HealthCheckTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+
+	result := api.HealthCheck{
+		Status:     api.HealthStatusOK,
+		DurationMs: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		result.Status = api.HealthStatusFail
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		name           string
+		mockSetup      func(*db.MockHealthRepository)
+		expectedStatus string
+		expectedChecks map[string]api.HealthCheck
+	}{
+		{
+			name: "ready",
+			mockSetup: func(mockRepo *db.MockHealthRepository) {
+				mockRepo.EXPECT().Ping(mock.Anything).Return(nil)
+				mockRepo.EXPECT().PendingMigrations(mock.Anything).Return([]db.Migration{}, nil)
+			},
+			expectedStatus: api.HealthStatusOK,
+			expectedChecks: map[string]api.HealthCheck{
+				checkDatabase:   {Status: api.HealthStatusOK},
+				checkMigrations: {Status: api.HealthStatusOK},
+			},
+		},
+		{
+			name: "database unavailable",
+			mockSetup: func(mockRepo *db.MockHealthRepository) {
+				mockRepo.EXPECT().Ping(mock.Anything).Return(errors.New("failed to ping database: connection refused"))
+			},
+			expectedStatus: api.HealthStatusFail,
+			expectedChecks: map[string]api.HealthCheck{
+				checkDatabase:   {Status: api.HealthStatusFail, Error: "failed to ping database: connection refused"},
+				checkMigrations: {Status: api.HealthStatusFail, Error: "skipped, database is unavailable"},
+			},
+		},
+		{
+			name: "pending migrations",
+			mockSetup: func(mockRepo *db.MockHealthRepository) {
+				mockRepo.EXPECT().Ping(mock.Anything).Return(nil)
+				mockRepo.EXPECT().PendingMigrations(mock.Anything).Return([]db.Migration{
+					{Version: 3, Name: "user_lifecycle"},
+					{Version: 4, Name: "transaction_principal"},
+				}, nil)
+			},
+			expectedStatus: api.HealthStatusFail,
+			expectedChecks: map[string]api.HealthCheck{
+				checkDatabase: {Status: api.HealthStatusOK},
+				checkMigrations: {
+					Status: api.HealthStatusFail,
+					Error:  "2 pending migrations: 0003_user_lifecycle, 0004_transaction_principal",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockHealthRepository(t)
+			tt.mockSetup(mockRepo)
+
+			result := newHealthService(mockRepo).Ready(context.Background())
+
+			// Durations vary between runs.
+			for name, check := range result.Checks {
+				check.DurationMs = 0
+				result.Checks[name] = check
+			}
+
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedChecks, result.Checks)
+		})
+	}
+}
+
+func TestReady_ShuttingDown(t *testing.T) {
+	mockRepo := db.NewMockHealthRepository(t)
+	service := newHealthService(mockRepo)
+
+	service.BeginShutdown()
+
+	result := service.Ready(context.Background())
+
+	assert.Equal(t, api.HealthStatusFail, result.Status)
+	assert.Equal(t, api.HealthStatusFail, result.Checks[checkShutdown].Status)
+	assert.Equal(t, api.HealthStatusOK, service.Live(context.Background()).Status, "a draining instance is still alive")
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+type HoldService interface {
+	PlaceHold(ctx context.Context, req api.HoldRequest, userID uint64, sourceType string) (api.HoldResponse, error)
+	SettleHold(ctx context.Context, req api.HoldSettlementRequest, userID uint64, holdID string) (api.HoldResponse, error)
+	ReleaseHold(ctx context.Context, userID uint64, holdID string) (api.HoldResponse, error)
+	ExpireHolds(ctx context.Context) (int, error)
+}
+
+type holdService struct {
+	moneyConverter
+
+	repo db.HoldRepository
+	now  func() time.Time
+}
+
+const (
+	DefaultHoldTTL      = 24 * time.Hour
+	HoldExpiryBatchSize = 100
+)
+
+func newHoldService(repo db.HoldRepository, currencies *currency.Registry, policy amount.Policy) HoldService {
+	return &holdService{
+		moneyConverter: newMoneyConverter(currencies, policy),
+		repo:           repo,
+		now:            time.Now,
+	}
+}
+
+func (s *holdService) PlaceHold(
+	ctx context.Context, req api.HoldRequest, userID uint64, sourceType string,
+) (api.HoldResponse, error) {
+	cur, err := s.resolveCurrency(req.Currency)
+	if err != nil {
+		return api.HoldResponse{}, err
+	}
+
+	stake, err := s.toTransactionMinorUnits(req.Amount, cur, sourceType)
+	if err != nil {
+		return api.HoldResponse{}, err
+	}
+
+	ttl := DefaultHoldTTL
+	if req.TTLSeconds > 0 {
+		ttl = time.Duration(req.TTLSeconds) * time.Second
+	}
+
+	hold, err := s.repo.PlaceHold(ctx, db.Hold{
+		UserID:     userID,
+		HoldID:     req.HoldID,
+		SourceType: sourceType,
+		Currency:   cur.Code,
+		Amount:     stake,
+		ExpiresAt:  s.now().Add(ttl),
+	})
+	if err != nil {
+		return api.HoldResponse{}, mapHoldError("PlaceHold", err)
+	}
+
+	return s.toHoldResponse(hold), nil
+}
+
+func (s *holdService) SettleHold(
+	ctx context.Context, req api.HoldSettlementRequest, userID uint64, holdID string,
+) (api.HoldResponse, error) {
+	var (
+		payout         int64
+		payoutCurrency string
+	)
+
+	// The payout must be in the currency of the hold, which the repository verifies.
+	if req.State == "win" {
+		cur, err := s.resolveCurrency(req.Currency)
+		if err != nil {
+			return api.HoldResponse{}, err
+		}
+
+		if payout, err = s.toMinorUnits(req.Payout, cur); err != nil {
+			return api.HoldResponse{}, err
+		}
+
+		payoutCurrency = cur.Code
+
+		// Source-Type limits apply to the stake when the hold is placed, not to the payout.
+		if payout <= 0 {
+			return api.HoldResponse{}, errs.ErrAmountNotPositive
+		}
+	}
+
+	hold, err := s.repo.SettleHold(ctx, userID, holdID, req.State, payout, payoutCurrency, principalOf(ctx))
+	if err != nil {
+		return api.HoldResponse{}, mapHoldError("SettleHold", err)
+	}
+
+	return s.toHoldResponse(hold), nil
+}
+
+func (s *holdService) ReleaseHold(ctx context.Context, userID uint64, holdID string) (api.HoldResponse, error) {
+	hold, err := s.repo.ReleaseHold(ctx, userID, holdID)
+	if err != nil {
+		return api.HoldResponse{}, mapHoldError("ReleaseHold", err)
+	}
+
+	return s.toHoldResponse(hold), nil
+}
+
+// ExpireHolds releases abandoned holds in batches until none are left and returns how many were expired.
+func (s *holdService) ExpireHolds(ctx context.Context) (int, error) {
+	var total int
+
+	for {
+		expired, err := s.repo.ExpireHolds(ctx, s.now(), HoldExpiryBatchSize)
+		if err != nil {
+			return total, fmt.Errorf("ExpireHolds error: %w", err)
+		}
+
+		total += expired
+
+		if expired < HoldExpiryBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (s *holdService) toHoldResponse(hold db.Hold) api.HoldResponse {
+	holdResponse := api.HoldResponse{
+		HoldID:     hold.HoldID,
+		UserID:     hold.UserID,
+		SourceType: hold.SourceType,
+		Currency:   hold.Currency,
+		Amount:     s.formatMinorUnits(hold.Amount, hold.Currency),
+		Status:     hold.Status,
+		ExpiresAt:  hold.ExpiresAt,
+	}
+
+	if hold.Status == db.HoldStatusSettled && hold.Payout > 0 {
+		holdResponse.Payout = s.formatMinorUnits(hold.Payout, hold.Currency)
+	}
+
+	return holdResponse
+}
+
+func mapHoldError(operation string, err error) error {
+	switch {
+	case errors.Is(err, db.ErrUserNotFound):
+		return errs.ErrUserNotFound
+	case errors.Is(err, db.ErrInsufficientFunds):
+		return errs.ErrInsufficientFunds
+	case errors.Is(err, db.ErrAccountFrozen):
+		return errs.ErrAccountFrozen
+	case errors.Is(err, db.ErrAccountClosed):
+		return errs.ErrAccountClosed
+	case errors.Is(err, db.ErrHoldNotFound):
+		return errs.ErrHoldNotFound
+	case errors.Is(err, db.ErrHoldExists):
+		return errs.ErrHoldExists
+	case errors.Is(err, db.ErrHoldNotActive):
+		return errs.ErrHoldNotActive
+	case errors.Is(err, db.ErrCurrencyMismatch):
+		return errs.ErrCurrencyMismatch
+	case errors.Is(err, db.ErrDuplicateTransaction):
+		return errs.ErrTransactionExists
+	default:
+		return fmt.Errorf("%s error: %w", operation, err)
+	}
+}
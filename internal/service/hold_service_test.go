@@ -0,0 +1,325 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func newTestHoldService(repo db.HoldRepository, now time.Time) *holdService {
+	service := newHoldService(repo, currency.DefaultRegistry(), amount.NewPolicy(nil)).(*holdService) //nolint: forcetypeassert // Test helper
+
+	service.now = func() time.Time { return now }
+
+	return service
+}
+
+func TestPlaceHold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		request        api.HoldRequest
+		mockSetup      func(*db.MockHoldRepository)
+		expectedResult api.HoldResponse
+		expectedError  error
+	}{
+		{
+			name:    "successful hold with default TTL",
+			request: api.HoldRequest{HoldID: "bet-1", Amount: "12.50"},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().PlaceHold(ctx, db.Hold{
+					UserID:     1,
+					HoldID:     "bet-1",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     1250,
+					ExpiresAt:  now.Add(DefaultHoldTTL),
+				}).Return(db.Hold{
+					UserID:     1,
+					HoldID:     "bet-1",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     1250,
+					Status:     db.HoldStatusReserved,
+					ExpiresAt:  now.Add(DefaultHoldTTL),
+				}, nil)
+			},
+			expectedResult: api.HoldResponse{
+				HoldID:     "bet-1",
+				UserID:     1,
+				SourceType: "game",
+				Currency:   "USD",
+				Amount:     "12.50",
+				Status:     db.HoldStatusReserved,
+				ExpiresAt:  now.Add(DefaultHoldTTL),
+			},
+		},
+		{
+			name:    "custom TTL",
+			request: api.HoldRequest{HoldID: "bet-2", Amount: "1", TTLSeconds: 60},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().PlaceHold(ctx, db.Hold{
+					UserID:     1,
+					HoldID:     "bet-2",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     100,
+					ExpiresAt:  now.Add(time.Minute),
+				}).Return(db.Hold{
+					UserID:     1,
+					HoldID:     "bet-2",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     100,
+					Status:     db.HoldStatusReserved,
+					ExpiresAt:  now.Add(time.Minute),
+				}, nil)
+			},
+			expectedResult: api.HoldResponse{
+				HoldID:     "bet-2",
+				UserID:     1,
+				SourceType: "game",
+				Currency:   "USD",
+				Amount:     "1.00",
+				Status:     db.HoldStatusReserved,
+				ExpiresAt:  now.Add(time.Minute),
+			},
+		},
+		{
+			name:          "non-positive stake",
+			request:       api.HoldRequest{HoldID: "bet-3", Amount: "0"},
+			mockSetup:     func(mockRepo *db.MockHoldRepository) {},
+			expectedError: errs.ErrAmountNotPositive,
+		},
+		{
+			name:    "insufficient funds",
+			request: api.HoldRequest{HoldID: "bet-4", Amount: "100"},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().PlaceHold(ctx, db.Hold{
+					UserID:     1,
+					HoldID:     "bet-4",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     10000,
+					ExpiresAt:  now.Add(DefaultHoldTTL),
+				}).Return(db.Hold{}, db.ErrInsufficientFunds)
+			},
+			expectedError: errs.ErrInsufficientFunds,
+		},
+		{
+			name:    "hold ID reused with different payload",
+			request: api.HoldRequest{HoldID: "bet-5", Amount: "5"},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().PlaceHold(ctx, db.Hold{
+					UserID:     1,
+					HoldID:     "bet-5",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     500,
+					ExpiresAt:  now.Add(DefaultHoldTTL),
+				}).Return(db.Hold{}, db.ErrHoldExists)
+			},
+			expectedError: errs.ErrHoldExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockHoldRepository(t)
+			tt.mockSetup(mockRepo)
+
+			service := newTestHoldService(mockRepo, now)
+			result, err := service.PlaceHold(ctx, tt.request, 1, "game")
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestSettleHold(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		request        api.HoldSettlementRequest
+		mockSetup      func(*db.MockHoldRepository)
+		expectedResult api.HoldResponse
+		expectedError  error
+	}{
+		{
+			name:    "win with payout",
+			request: api.HoldSettlementRequest{State: "win", Payout: "25.00"},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().SettleHold(ctx, uint64(1), "bet-1", "win", int64(2500), "USD", "").Return(db.Hold{
+					UserID:     1,
+					HoldID:     "bet-1",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     1000,
+					Payout:     2500,
+					Status:     db.HoldStatusSettled,
+					ExpiresAt:  now,
+				}, nil)
+			},
+			expectedResult: api.HoldResponse{
+				HoldID:     "bet-1",
+				UserID:     1,
+				SourceType: "game",
+				Currency:   "USD",
+				Amount:     "10.00",
+				Payout:     "25.00",
+				Status:     db.HoldStatusSettled,
+				ExpiresAt:  now,
+			},
+		},
+		{
+			name:    "lose",
+			request: api.HoldSettlementRequest{State: "lose"},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().SettleHold(ctx, uint64(1), "bet-1", "lose", int64(0), "", "").Return(db.Hold{
+					UserID:     1,
+					HoldID:     "bet-1",
+					SourceType: "game",
+					Currency:   "USD",
+					Amount:     1000,
+					Status:     db.HoldStatusSettled,
+					ExpiresAt:  now,
+				}, nil)
+			},
+			expectedResult: api.HoldResponse{
+				HoldID:     "bet-1",
+				UserID:     1,
+				SourceType: "game",
+				Currency:   "USD",
+				Amount:     "10.00",
+				Status:     db.HoldStatusSettled,
+				ExpiresAt:  now,
+			},
+		},
+		{
+			name:          "negative payout",
+			request:       api.HoldSettlementRequest{State: "win", Payout: "-1"},
+			mockSetup:     func(mockRepo *db.MockHoldRepository) {},
+			expectedError: errs.ErrAmountNotPositive,
+		},
+		{
+			name:    "hold already closed",
+			request: api.HoldSettlementRequest{State: "lose"},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().SettleHold(ctx, uint64(1), "bet-1", "lose", int64(0), "", "").
+					Return(db.Hold{}, db.ErrHoldNotActive)
+			},
+			expectedError: errs.ErrHoldNotActive,
+		},
+		{
+			name:    "database error",
+			request: api.HoldSettlementRequest{State: "lose"},
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().SettleHold(ctx, uint64(1), "bet-1", "lose", int64(0), "", "").
+					Return(db.Hold{}, errors.New("database connection error"))
+			},
+			expectedError: errors.New("SettleHold error: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockHoldRepository(t)
+			tt.mockSetup(mockRepo)
+
+			service := newTestHoldService(mockRepo, now)
+			result, err := service.SettleHold(ctx, tt.request, 1, "bet-1")
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestReleaseHold(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := db.NewMockHoldRepository(t)
+	mockRepo.EXPECT().ReleaseHold(ctx, uint64(1), "missing").Return(db.Hold{}, db.ErrHoldNotFound)
+
+	service := newHoldService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+	_, err := service.ReleaseHold(ctx, 1, "missing")
+
+	assert.ErrorIs(t, err, errs.ErrHoldNotFound)
+}
+
+func TestExpireHolds(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		mockSetup     func(*db.MockHoldRepository)
+		expectedCount int
+		expectedError error
+	}{
+		{
+			name: "single partial batch",
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().ExpireHolds(ctx, now, HoldExpiryBatchSize).Return(3, nil).Once()
+			},
+			expectedCount: 3,
+		},
+		{
+			name: "full batch is followed by another one",
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().ExpireHolds(ctx, now, HoldExpiryBatchSize).Return(HoldExpiryBatchSize, nil).Once()
+				mockRepo.EXPECT().ExpireHolds(ctx, now, HoldExpiryBatchSize).Return(0, nil).Once()
+			},
+			expectedCount: HoldExpiryBatchSize,
+		},
+		{
+			name: "database error",
+			mockSetup: func(mockRepo *db.MockHoldRepository) {
+				mockRepo.EXPECT().ExpireHolds(ctx, now, HoldExpiryBatchSize).Return(0, errors.New("database connection error"))
+			},
+			expectedError: errors.New("ExpireHolds error: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockHoldRepository(t)
+			tt.mockSetup(mockRepo)
+
+			service := newTestHoldService(mockRepo, now)
+			count, err := service.ExpireHolds(ctx)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, count)
+			}
+		})
+	}
+}
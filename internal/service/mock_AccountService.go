@@ -0,0 +1,375 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAccountService creates a new instance of MockAccountService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccountService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccountService {
+	mock := &MockAccountService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAccountService is an autogenerated mock type for the AccountService type
+type MockAccountService struct {
+	mock.Mock
+}
+
+type MockAccountService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAccountService) EXPECT() *MockAccountService_Expecter {
+	return &MockAccountService_Expecter{mock: &_m.Mock}
+}
+
+// CloseUser provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) CloseUser(ctx context.Context, userID uint64) (api.UserResponse, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CloseUser")
+	}
+
+	var r0 api.UserResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (api.UserResponse, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) api.UserResponse); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(api.UserResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_CloseUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseUser'
+type MockAccountService_CloseUser_Call struct {
+	*mock.Call
+}
+
+// CloseUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockAccountService_Expecter) CloseUser(ctx interface{}, userID interface{}) *MockAccountService_CloseUser_Call {
+	return &MockAccountService_CloseUser_Call{Call: _e.mock.On("CloseUser", ctx, userID)}
+}
+
+func (_c *MockAccountService_CloseUser_Call) Run(run func(ctx context.Context, userID uint64)) *MockAccountService_CloseUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_CloseUser_Call) Return(userResponse api.UserResponse, err error) *MockAccountService_CloseUser_Call {
+	_c.Call.Return(userResponse, err)
+	return _c
+}
+
+func (_c *MockAccountService_CloseUser_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (api.UserResponse, error)) *MockAccountService_CloseUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateUser provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) CreateUser(ctx context.Context, req api.CreateUserRequest) (api.UserResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 api.UserResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.CreateUserRequest) (api.UserResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.CreateUserRequest) api.UserResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(api.UserResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.CreateUserRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_CreateUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateUser'
+type MockAccountService_CreateUser_Call struct {
+	*mock.Call
+}
+
+// CreateUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.CreateUserRequest
+func (_e *MockAccountService_Expecter) CreateUser(ctx interface{}, req interface{}) *MockAccountService_CreateUser_Call {
+	return &MockAccountService_CreateUser_Call{Call: _e.mock.On("CreateUser", ctx, req)}
+}
+
+func (_c *MockAccountService_CreateUser_Call) Run(run func(ctx context.Context, req api.CreateUserRequest)) *MockAccountService_CreateUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.CreateUserRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.CreateUserRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_CreateUser_Call) Return(userResponse api.UserResponse, err error) *MockAccountService_CreateUser_Call {
+	_c.Call.Return(userResponse, err)
+	return _c
+}
+
+func (_c *MockAccountService_CreateUser_Call) RunAndReturn(run func(ctx context.Context, req api.CreateUserRequest) (api.UserResponse, error)) *MockAccountService_CreateUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FreezeUser provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) FreezeUser(ctx context.Context, req api.FreezeRequest, userID uint64) (api.UserResponse, error) {
+	ret := _mock.Called(ctx, req, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FreezeUser")
+	}
+
+	var r0 api.UserResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.FreezeRequest, uint64) (api.UserResponse, error)); ok {
+		return returnFunc(ctx, req, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.FreezeRequest, uint64) api.UserResponse); ok {
+		r0 = returnFunc(ctx, req, userID)
+	} else {
+		r0 = ret.Get(0).(api.UserResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.FreezeRequest, uint64) error); ok {
+		r1 = returnFunc(ctx, req, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_FreezeUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FreezeUser'
+type MockAccountService_FreezeUser_Call struct {
+	*mock.Call
+}
+
+// FreezeUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.FreezeRequest
+//   - userID uint64
+func (_e *MockAccountService_Expecter) FreezeUser(ctx interface{}, req interface{}, userID interface{}) *MockAccountService_FreezeUser_Call {
+	return &MockAccountService_FreezeUser_Call{Call: _e.mock.On("FreezeUser", ctx, req, userID)}
+}
+
+func (_c *MockAccountService_FreezeUser_Call) Run(run func(ctx context.Context, req api.FreezeRequest, userID uint64)) *MockAccountService_FreezeUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.FreezeRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.FreezeRequest)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_FreezeUser_Call) Return(userResponse api.UserResponse, err error) *MockAccountService_FreezeUser_Call {
+	_c.Call.Return(userResponse, err)
+	return _c
+}
+
+func (_c *MockAccountService_FreezeUser_Call) RunAndReturn(run func(ctx context.Context, req api.FreezeRequest, userID uint64) (api.UserResponse, error)) *MockAccountService_FreezeUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUser provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) GetUser(ctx context.Context, userID uint64) (api.UserResponse, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 api.UserResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (api.UserResponse, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) api.UserResponse); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(api.UserResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_GetUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUser'
+type MockAccountService_GetUser_Call struct {
+	*mock.Call
+}
+
+// GetUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockAccountService_Expecter) GetUser(ctx interface{}, userID interface{}) *MockAccountService_GetUser_Call {
+	return &MockAccountService_GetUser_Call{Call: _e.mock.On("GetUser", ctx, userID)}
+}
+
+func (_c *MockAccountService_GetUser_Call) Run(run func(ctx context.Context, userID uint64)) *MockAccountService_GetUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_GetUser_Call) Return(userResponse api.UserResponse, err error) *MockAccountService_GetUser_Call {
+	_c.Call.Return(userResponse, err)
+	return _c
+}
+
+func (_c *MockAccountService_GetUser_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (api.UserResponse, error)) *MockAccountService_GetUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UnfreezeUser provides a mock function for the type MockAccountService
+func (_mock *MockAccountService) UnfreezeUser(ctx context.Context, userID uint64) (api.UserResponse, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnfreezeUser")
+	}
+
+	var r0 api.UserResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (api.UserResponse, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) api.UserResponse); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(api.UserResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAccountService_UnfreezeUser_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UnfreezeUser'
+type MockAccountService_UnfreezeUser_Call struct {
+	*mock.Call
+}
+
+// UnfreezeUser is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockAccountService_Expecter) UnfreezeUser(ctx interface{}, userID interface{}) *MockAccountService_UnfreezeUser_Call {
+	return &MockAccountService_UnfreezeUser_Call{Call: _e.mock.On("UnfreezeUser", ctx, userID)}
+}
+
+func (_c *MockAccountService_UnfreezeUser_Call) Run(run func(ctx context.Context, userID uint64)) *MockAccountService_UnfreezeUser_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAccountService_UnfreezeUser_Call) Return(userResponse api.UserResponse, err error) *MockAccountService_UnfreezeUser_Call {
+	_c.Call.Return(userResponse, err)
+	return _c
+}
+
+func (_c *MockAccountService_UnfreezeUser_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (api.UserResponse, error)) *MockAccountService_UnfreezeUser_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,387 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockAdjustmentService creates a new instance of MockAdjustmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAdjustmentService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAdjustmentService {
+	mock := &MockAdjustmentService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockAdjustmentService is an autogenerated mock type for the AdjustmentService type
+type MockAdjustmentService struct {
+	mock.Mock
+}
+
+type MockAdjustmentService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockAdjustmentService) EXPECT() *MockAdjustmentService_Expecter {
+	return &MockAdjustmentService_Expecter{mock: &_m.Mock}
+}
+
+// ApproveAdjustment provides a mock function for the type MockAdjustmentService
+func (_mock *MockAdjustmentService) ApproveAdjustment(ctx context.Context, adjustmentID string, req api.AdjustmentApprovalRequest) (api.AdjustmentResponse, error) {
+	ret := _mock.Called(ctx, adjustmentID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApproveAdjustment")
+	}
+
+	var r0 api.AdjustmentResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.AdjustmentApprovalRequest) (api.AdjustmentResponse, error)); ok {
+		return returnFunc(ctx, adjustmentID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.AdjustmentApprovalRequest) api.AdjustmentResponse); ok {
+		r0 = returnFunc(ctx, adjustmentID, req)
+	} else {
+		r0 = ret.Get(0).(api.AdjustmentResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, api.AdjustmentApprovalRequest) error); ok {
+		r1 = returnFunc(ctx, adjustmentID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentService_ApproveAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApproveAdjustment'
+type MockAdjustmentService_ApproveAdjustment_Call struct {
+	*mock.Call
+}
+
+// ApproveAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adjustmentID string
+//   - req api.AdjustmentApprovalRequest
+func (_e *MockAdjustmentService_Expecter) ApproveAdjustment(ctx interface{}, adjustmentID interface{}, req interface{}) *MockAdjustmentService_ApproveAdjustment_Call {
+	return &MockAdjustmentService_ApproveAdjustment_Call{Call: _e.mock.On("ApproveAdjustment", ctx, adjustmentID, req)}
+}
+
+func (_c *MockAdjustmentService_ApproveAdjustment_Call) Run(run func(ctx context.Context, adjustmentID string, req api.AdjustmentApprovalRequest)) *MockAdjustmentService_ApproveAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 api.AdjustmentApprovalRequest
+		if args[2] != nil {
+			arg2 = args[2].(api.AdjustmentApprovalRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentService_ApproveAdjustment_Call) Return(adjustmentResponse api.AdjustmentResponse, err error) *MockAdjustmentService_ApproveAdjustment_Call {
+	_c.Call.Return(adjustmentResponse, err)
+	return _c
+}
+
+func (_c *MockAdjustmentService_ApproveAdjustment_Call) RunAndReturn(run func(ctx context.Context, adjustmentID string, req api.AdjustmentApprovalRequest) (api.AdjustmentResponse, error)) *MockAdjustmentService_ApproveAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAdjustment provides a mock function for the type MockAdjustmentService
+func (_mock *MockAdjustmentService) GetAdjustment(ctx context.Context, adjustmentID string) (api.AdjustmentResponse, error) {
+	ret := _mock.Called(ctx, adjustmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAdjustment")
+	}
+
+	var r0 api.AdjustmentResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (api.AdjustmentResponse, error)); ok {
+		return returnFunc(ctx, adjustmentID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) api.AdjustmentResponse); ok {
+		r0 = returnFunc(ctx, adjustmentID)
+	} else {
+		r0 = ret.Get(0).(api.AdjustmentResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, adjustmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentService_GetAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAdjustment'
+type MockAdjustmentService_GetAdjustment_Call struct {
+	*mock.Call
+}
+
+// GetAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adjustmentID string
+func (_e *MockAdjustmentService_Expecter) GetAdjustment(ctx interface{}, adjustmentID interface{}) *MockAdjustmentService_GetAdjustment_Call {
+	return &MockAdjustmentService_GetAdjustment_Call{Call: _e.mock.On("GetAdjustment", ctx, adjustmentID)}
+}
+
+func (_c *MockAdjustmentService_GetAdjustment_Call) Run(run func(ctx context.Context, adjustmentID string)) *MockAdjustmentService_GetAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentService_GetAdjustment_Call) Return(adjustmentResponse api.AdjustmentResponse, err error) *MockAdjustmentService_GetAdjustment_Call {
+	_c.Call.Return(adjustmentResponse, err)
+	return _c
+}
+
+func (_c *MockAdjustmentService_GetAdjustment_Call) RunAndReturn(run func(ctx context.Context, adjustmentID string) (api.AdjustmentResponse, error)) *MockAdjustmentService_GetAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListAdjustments provides a mock function for the type MockAdjustmentService
+func (_mock *MockAdjustmentService) ListAdjustments(ctx context.Context, req api.AdjustmentListRequest) (api.AdjustmentListResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAdjustments")
+	}
+
+	var r0 api.AdjustmentListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdjustmentListRequest) (api.AdjustmentListResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdjustmentListRequest) api.AdjustmentListResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(api.AdjustmentListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.AdjustmentListRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentService_ListAdjustments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListAdjustments'
+type MockAdjustmentService_ListAdjustments_Call struct {
+	*mock.Call
+}
+
+// ListAdjustments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.AdjustmentListRequest
+func (_e *MockAdjustmentService_Expecter) ListAdjustments(ctx interface{}, req interface{}) *MockAdjustmentService_ListAdjustments_Call {
+	return &MockAdjustmentService_ListAdjustments_Call{Call: _e.mock.On("ListAdjustments", ctx, req)}
+}
+
+func (_c *MockAdjustmentService_ListAdjustments_Call) Run(run func(ctx context.Context, req api.AdjustmentListRequest)) *MockAdjustmentService_ListAdjustments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.AdjustmentListRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.AdjustmentListRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentService_ListAdjustments_Call) Return(adjustmentListResponse api.AdjustmentListResponse, err error) *MockAdjustmentService_ListAdjustments_Call {
+	_c.Call.Return(adjustmentListResponse, err)
+	return _c
+}
+
+func (_c *MockAdjustmentService_ListAdjustments_Call) RunAndReturn(run func(ctx context.Context, req api.AdjustmentListRequest) (api.AdjustmentListResponse, error)) *MockAdjustmentService_ListAdjustments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RejectAdjustment provides a mock function for the type MockAdjustmentService
+func (_mock *MockAdjustmentService) RejectAdjustment(ctx context.Context, adjustmentID string, req api.AdjustmentRejectionRequest) (api.AdjustmentResponse, error) {
+	ret := _mock.Called(ctx, adjustmentID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RejectAdjustment")
+	}
+
+	var r0 api.AdjustmentResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.AdjustmentRejectionRequest) (api.AdjustmentResponse, error)); ok {
+		return returnFunc(ctx, adjustmentID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.AdjustmentRejectionRequest) api.AdjustmentResponse); ok {
+		r0 = returnFunc(ctx, adjustmentID, req)
+	} else {
+		r0 = ret.Get(0).(api.AdjustmentResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, api.AdjustmentRejectionRequest) error); ok {
+		r1 = returnFunc(ctx, adjustmentID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentService_RejectAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RejectAdjustment'
+type MockAdjustmentService_RejectAdjustment_Call struct {
+	*mock.Call
+}
+
+// RejectAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - adjustmentID string
+//   - req api.AdjustmentRejectionRequest
+func (_e *MockAdjustmentService_Expecter) RejectAdjustment(ctx interface{}, adjustmentID interface{}, req interface{}) *MockAdjustmentService_RejectAdjustment_Call {
+	return &MockAdjustmentService_RejectAdjustment_Call{Call: _e.mock.On("RejectAdjustment", ctx, adjustmentID, req)}
+}
+
+func (_c *MockAdjustmentService_RejectAdjustment_Call) Run(run func(ctx context.Context, adjustmentID string, req api.AdjustmentRejectionRequest)) *MockAdjustmentService_RejectAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 api.AdjustmentRejectionRequest
+		if args[2] != nil {
+			arg2 = args[2].(api.AdjustmentRejectionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentService_RejectAdjustment_Call) Return(adjustmentResponse api.AdjustmentResponse, err error) *MockAdjustmentService_RejectAdjustment_Call {
+	_c.Call.Return(adjustmentResponse, err)
+	return _c
+}
+
+func (_c *MockAdjustmentService_RejectAdjustment_Call) RunAndReturn(run func(ctx context.Context, adjustmentID string, req api.AdjustmentRejectionRequest) (api.AdjustmentResponse, error)) *MockAdjustmentService_RejectAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequestAdjustment provides a mock function for the type MockAdjustmentService
+func (_mock *MockAdjustmentService) RequestAdjustment(ctx context.Context, req api.AdjustmentRequest, userID uint64) (api.AdjustmentResponse, error) {
+	ret := _mock.Called(ctx, req, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequestAdjustment")
+	}
+
+	var r0 api.AdjustmentResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdjustmentRequest, uint64) (api.AdjustmentResponse, error)); ok {
+		return returnFunc(ctx, req, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.AdjustmentRequest, uint64) api.AdjustmentResponse); ok {
+		r0 = returnFunc(ctx, req, userID)
+	} else {
+		r0 = ret.Get(0).(api.AdjustmentResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.AdjustmentRequest, uint64) error); ok {
+		r1 = returnFunc(ctx, req, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockAdjustmentService_RequestAdjustment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequestAdjustment'
+type MockAdjustmentService_RequestAdjustment_Call struct {
+	*mock.Call
+}
+
+// RequestAdjustment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.AdjustmentRequest
+//   - userID uint64
+func (_e *MockAdjustmentService_Expecter) RequestAdjustment(ctx interface{}, req interface{}, userID interface{}) *MockAdjustmentService_RequestAdjustment_Call {
+	return &MockAdjustmentService_RequestAdjustment_Call{Call: _e.mock.On("RequestAdjustment", ctx, req, userID)}
+}
+
+func (_c *MockAdjustmentService_RequestAdjustment_Call) Run(run func(ctx context.Context, req api.AdjustmentRequest, userID uint64)) *MockAdjustmentService_RequestAdjustment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.AdjustmentRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.AdjustmentRequest)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockAdjustmentService_RequestAdjustment_Call) Return(adjustmentResponse api.AdjustmentResponse, err error) *MockAdjustmentService_RequestAdjustment_Call {
+	_c.Call.Return(adjustmentResponse, err)
+	return _c
+}
+
+func (_c *MockAdjustmentService_RequestAdjustment_Call) RunAndReturn(run func(ctx context.Context, req api.AdjustmentRequest, userID uint64) (api.AdjustmentResponse, error)) *MockAdjustmentService_RequestAdjustment_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,145 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockBalanceStreamService creates a new instance of MockBalanceStreamService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockBalanceStreamService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockBalanceStreamService {
+	mock := &MockBalanceStreamService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockBalanceStreamService is an autogenerated mock type for the BalanceStreamService type
+type MockBalanceStreamService struct {
+	mock.Mock
+}
+
+type MockBalanceStreamService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockBalanceStreamService) EXPECT() *MockBalanceStreamService_Expecter {
+	return &MockBalanceStreamService_Expecter{mock: &_m.Mock}
+}
+
+// CloseStreams provides a mock function for the type MockBalanceStreamService
+func (_mock *MockBalanceStreamService) CloseStreams() {
+	_mock.Called()
+	return
+}
+
+// MockBalanceStreamService_CloseStreams_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CloseStreams'
+type MockBalanceStreamService_CloseStreams_Call struct {
+	*mock.Call
+}
+
+// CloseStreams is a helper method to define mock.On call
+func (_e *MockBalanceStreamService_Expecter) CloseStreams() *MockBalanceStreamService_CloseStreams_Call {
+	return &MockBalanceStreamService_CloseStreams_Call{Call: _e.mock.On("CloseStreams")}
+}
+
+func (_c *MockBalanceStreamService_CloseStreams_Call) Run(run func()) *MockBalanceStreamService_CloseStreams_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockBalanceStreamService_CloseStreams_Call) Return() *MockBalanceStreamService_CloseStreams_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockBalanceStreamService_CloseStreams_Call) RunAndReturn(run func()) *MockBalanceStreamService_CloseStreams_Call {
+	_c.Run(run)
+	return _c
+}
+
+// OpenBalanceStream provides a mock function for the type MockBalanceStreamService
+func (_mock *MockBalanceStreamService) OpenBalanceStream(ctx context.Context, userID uint64, lastEventID uint64) (*BalanceStream, error) {
+	ret := _mock.Called(ctx, userID, lastEventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenBalanceStream")
+	}
+
+	var r0 *BalanceStream
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) (*BalanceStream, error)); ok {
+		return returnFunc(ctx, userID, lastEventID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, uint64) *BalanceStream); ok {
+		r0 = returnFunc(ctx, userID, lastEventID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*BalanceStream)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, uint64) error); ok {
+		r1 = returnFunc(ctx, userID, lastEventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockBalanceStreamService_OpenBalanceStream_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenBalanceStream'
+type MockBalanceStreamService_OpenBalanceStream_Call struct {
+	*mock.Call
+}
+
+// OpenBalanceStream is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - lastEventID uint64
+func (_e *MockBalanceStreamService_Expecter) OpenBalanceStream(ctx interface{}, userID interface{}, lastEventID interface{}) *MockBalanceStreamService_OpenBalanceStream_Call {
+	return &MockBalanceStreamService_OpenBalanceStream_Call{Call: _e.mock.On("OpenBalanceStream", ctx, userID, lastEventID)}
+}
+
+func (_c *MockBalanceStreamService_OpenBalanceStream_Call) Run(run func(ctx context.Context, userID uint64, lastEventID uint64)) *MockBalanceStreamService_OpenBalanceStream_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockBalanceStreamService_OpenBalanceStream_Call) Return(balanceStream *BalanceStream, err error) *MockBalanceStreamService_OpenBalanceStream_Call {
+	_c.Call.Return(balanceStream, err)
+	return _c
+}
+
+func (_c *MockBalanceStreamService_OpenBalanceStream_Call) RunAndReturn(run func(ctx context.Context, userID uint64, lastEventID uint64) (*BalanceStream, error)) *MockBalanceStreamService_OpenBalanceStream_Call {
+	_c.Call.Return(run)
+	return _c
+}
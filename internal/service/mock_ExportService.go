@@ -0,0 +1,216 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockExportService creates a new instance of MockExportService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockExportService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockExportService {
+	mock := &MockExportService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockExportService is an autogenerated mock type for the ExportService type
+type MockExportService struct {
+	mock.Mock
+}
+
+type MockExportService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockExportService) EXPECT() *MockExportService_Expecter {
+	return &MockExportService_Expecter{mock: &_m.Mock}
+}
+
+// ExportTransactions provides a mock function for the type MockExportService
+func (_mock *MockExportService) ExportTransactions(ctx context.Context, userID uint64, fn func(api.TransactionDetails) error) error {
+	ret := _mock.Called(ctx, userID, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportTransactions")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, func(api.TransactionDetails) error) error); ok {
+		r0 = returnFunc(ctx, userID, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportService_ExportTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportTransactions'
+type MockExportService_ExportTransactions_Call struct {
+	*mock.Call
+}
+
+// ExportTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - fn func(api.TransactionDetails) error
+func (_e *MockExportService_Expecter) ExportTransactions(ctx interface{}, userID interface{}, fn interface{}) *MockExportService_ExportTransactions_Call {
+	return &MockExportService_ExportTransactions_Call{Call: _e.mock.On("ExportTransactions", ctx, userID, fn)}
+}
+
+func (_c *MockExportService_ExportTransactions_Call) Run(run func(ctx context.Context, userID uint64, fn func(api.TransactionDetails) error)) *MockExportService_ExportTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 func(api.TransactionDetails) error
+		if args[2] != nil {
+			arg2 = args[2].(func(api.TransactionDetails) error)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportService_ExportTransactions_Call) Return(err error) *MockExportService_ExportTransactions_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportService_ExportTransactions_Call) RunAndReturn(run func(ctx context.Context, userID uint64, fn func(api.TransactionDetails) error) error) *MockExportService_ExportTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportUsers provides a mock function for the type MockExportService
+func (_mock *MockExportService) ExportUsers(ctx context.Context, fn func(api.UserResponse) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportUsers")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(api.UserResponse) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportService_ExportUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportUsers'
+type MockExportService_ExportUsers_Call struct {
+	*mock.Call
+}
+
+// ExportUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(api.UserResponse) error
+func (_e *MockExportService_Expecter) ExportUsers(ctx interface{}, fn interface{}) *MockExportService_ExportUsers_Call {
+	return &MockExportService_ExportUsers_Call{Call: _e.mock.On("ExportUsers", ctx, fn)}
+}
+
+func (_c *MockExportService_ExportUsers_Call) Run(run func(ctx context.Context, fn func(api.UserResponse) error)) *MockExportService_ExportUsers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(api.UserResponse) error
+		if args[1] != nil {
+			arg1 = args[1].(func(api.UserResponse) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportService_ExportUsers_Call) Return(err error) *MockExportService_ExportUsers_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportService_ExportUsers_Call) RunAndReturn(run func(ctx context.Context, fn func(api.UserResponse) error) error) *MockExportService_ExportUsers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExportWallets provides a mock function for the type MockExportService
+func (_mock *MockExportService) ExportWallets(ctx context.Context, fn func(api.BalanceResponse) error) error {
+	ret := _mock.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportWallets")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(api.BalanceResponse) error) error); ok {
+		r0 = returnFunc(ctx, fn)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockExportService_ExportWallets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportWallets'
+type MockExportService_ExportWallets_Call struct {
+	*mock.Call
+}
+
+// ExportWallets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fn func(api.BalanceResponse) error
+func (_e *MockExportService_Expecter) ExportWallets(ctx interface{}, fn interface{}) *MockExportService_ExportWallets_Call {
+	return &MockExportService_ExportWallets_Call{Call: _e.mock.On("ExportWallets", ctx, fn)}
+}
+
+func (_c *MockExportService_ExportWallets_Call) Run(run func(ctx context.Context, fn func(api.BalanceResponse) error)) *MockExportService_ExportWallets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(api.BalanceResponse) error
+		if args[1] != nil {
+			arg1 = args[1].(func(api.BalanceResponse) error)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockExportService_ExportWallets_Call) Return(err error) *MockExportService_ExportWallets_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockExportService_ExportWallets_Call) RunAndReturn(run func(ctx context.Context, fn func(api.BalanceResponse) error) error) *MockExportService_ExportWallets_Call {
+	_c.Call.Return(run)
+	return _c
+}
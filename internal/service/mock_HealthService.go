@@ -0,0 +1,174 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHealthService creates a new instance of MockHealthService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHealthService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHealthService {
+	mock := &MockHealthService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHealthService is an autogenerated mock type for the HealthService type
+type MockHealthService struct {
+	mock.Mock
+}
+
+type MockHealthService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHealthService) EXPECT() *MockHealthService_Expecter {
+	return &MockHealthService_Expecter{mock: &_m.Mock}
+}
+
+// BeginShutdown provides a mock function for the type MockHealthService
+func (_mock *MockHealthService) BeginShutdown() {
+	_mock.Called()
+	return
+}
+
+// MockHealthService_BeginShutdown_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BeginShutdown'
+type MockHealthService_BeginShutdown_Call struct {
+	*mock.Call
+}
+
+// BeginShutdown is a helper method to define mock.On call
+func (_e *MockHealthService_Expecter) BeginShutdown() *MockHealthService_BeginShutdown_Call {
+	return &MockHealthService_BeginShutdown_Call{Call: _e.mock.On("BeginShutdown")}
+}
+
+func (_c *MockHealthService_BeginShutdown_Call) Run(run func()) *MockHealthService_BeginShutdown_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockHealthService_BeginShutdown_Call) Return() *MockHealthService_BeginShutdown_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockHealthService_BeginShutdown_Call) RunAndReturn(run func()) *MockHealthService_BeginShutdown_Call {
+	_c.Run(run)
+	return _c
+}
+
+// Live provides a mock function for the type MockHealthService
+func (_mock *MockHealthService) Live(ctx context.Context) api.HealthResponse {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Live")
+	}
+
+	var r0 api.HealthResponse
+	if returnFunc, ok := ret.Get(0).(func(context.Context) api.HealthResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(api.HealthResponse)
+	}
+	return r0
+}
+
+// MockHealthService_Live_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Live'
+type MockHealthService_Live_Call struct {
+	*mock.Call
+}
+
+// Live is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHealthService_Expecter) Live(ctx interface{}) *MockHealthService_Live_Call {
+	return &MockHealthService_Live_Call{Call: _e.mock.On("Live", ctx)}
+}
+
+func (_c *MockHealthService_Live_Call) Run(run func(ctx context.Context)) *MockHealthService_Live_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthService_Live_Call) Return(healthResponse api.HealthResponse) *MockHealthService_Live_Call {
+	_c.Call.Return(healthResponse)
+	return _c
+}
+
+func (_c *MockHealthService_Live_Call) RunAndReturn(run func(ctx context.Context) api.HealthResponse) *MockHealthService_Live_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Ready provides a mock function for the type MockHealthService
+func (_mock *MockHealthService) Ready(ctx context.Context) api.HealthResponse {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ready")
+	}
+
+	var r0 api.HealthResponse
+	if returnFunc, ok := ret.Get(0).(func(context.Context) api.HealthResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(api.HealthResponse)
+	}
+	return r0
+}
+
+// MockHealthService_Ready_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ready'
+type MockHealthService_Ready_Call struct {
+	*mock.Call
+}
+
+// Ready is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHealthService_Expecter) Ready(ctx interface{}) *MockHealthService_Ready_Call {
+	return &MockHealthService_Ready_Call{Call: _e.mock.On("Ready", ctx)}
+}
+
+func (_c *MockHealthService_Ready_Call) Run(run func(ctx context.Context)) *MockHealthService_Ready_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHealthService_Ready_Call) Return(healthResponse api.HealthResponse) *MockHealthService_Ready_Call {
+	_c.Call.Return(healthResponse)
+	return _c
+}
+
+func (_c *MockHealthService_Ready_Call) RunAndReturn(run func(ctx context.Context) api.HealthResponse) *MockHealthService_Ready_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,327 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockHoldService creates a new instance of MockHoldService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockHoldService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockHoldService {
+	mock := &MockHoldService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockHoldService is an autogenerated mock type for the HoldService type
+type MockHoldService struct {
+	mock.Mock
+}
+
+type MockHoldService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockHoldService) EXPECT() *MockHoldService_Expecter {
+	return &MockHoldService_Expecter{mock: &_m.Mock}
+}
+
+// ExpireHolds provides a mock function for the type MockHoldService
+func (_mock *MockHoldService) ExpireHolds(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExpireHolds")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldService_ExpireHolds_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExpireHolds'
+type MockHoldService_ExpireHolds_Call struct {
+	*mock.Call
+}
+
+// ExpireHolds is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockHoldService_Expecter) ExpireHolds(ctx interface{}) *MockHoldService_ExpireHolds_Call {
+	return &MockHoldService_ExpireHolds_Call{Call: _e.mock.On("ExpireHolds", ctx)}
+}
+
+func (_c *MockHoldService_ExpireHolds_Call) Run(run func(ctx context.Context)) *MockHoldService_ExpireHolds_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldService_ExpireHolds_Call) Return(n int, err error) *MockHoldService_ExpireHolds_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockHoldService_ExpireHolds_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockHoldService_ExpireHolds_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PlaceHold provides a mock function for the type MockHoldService
+func (_mock *MockHoldService) PlaceHold(ctx context.Context, req api.HoldRequest, userID uint64, sourceType string) (api.HoldResponse, error) {
+	ret := _mock.Called(ctx, req, userID, sourceType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PlaceHold")
+	}
+
+	var r0 api.HoldResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.HoldRequest, uint64, string) (api.HoldResponse, error)); ok {
+		return returnFunc(ctx, req, userID, sourceType)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.HoldRequest, uint64, string) api.HoldResponse); ok {
+		r0 = returnFunc(ctx, req, userID, sourceType)
+	} else {
+		r0 = ret.Get(0).(api.HoldResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.HoldRequest, uint64, string) error); ok {
+		r1 = returnFunc(ctx, req, userID, sourceType)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldService_PlaceHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PlaceHold'
+type MockHoldService_PlaceHold_Call struct {
+	*mock.Call
+}
+
+// PlaceHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.HoldRequest
+//   - userID uint64
+//   - sourceType string
+func (_e *MockHoldService_Expecter) PlaceHold(ctx interface{}, req interface{}, userID interface{}, sourceType interface{}) *MockHoldService_PlaceHold_Call {
+	return &MockHoldService_PlaceHold_Call{Call: _e.mock.On("PlaceHold", ctx, req, userID, sourceType)}
+}
+
+func (_c *MockHoldService_PlaceHold_Call) Run(run func(ctx context.Context, req api.HoldRequest, userID uint64, sourceType string)) *MockHoldService_PlaceHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.HoldRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.HoldRequest)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldService_PlaceHold_Call) Return(holdResponse api.HoldResponse, err error) *MockHoldService_PlaceHold_Call {
+	_c.Call.Return(holdResponse, err)
+	return _c
+}
+
+func (_c *MockHoldService_PlaceHold_Call) RunAndReturn(run func(ctx context.Context, req api.HoldRequest, userID uint64, sourceType string) (api.HoldResponse, error)) *MockHoldService_PlaceHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseHold provides a mock function for the type MockHoldService
+func (_mock *MockHoldService) ReleaseHold(ctx context.Context, userID uint64, holdID string) (api.HoldResponse, error) {
+	ret := _mock.Called(ctx, userID, holdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseHold")
+	}
+
+	var r0 api.HoldResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (api.HoldResponse, error)); ok {
+		return returnFunc(ctx, userID, holdID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) api.HoldResponse); ok {
+		r0 = returnFunc(ctx, userID, holdID)
+	} else {
+		r0 = ret.Get(0).(api.HoldResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, userID, holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldService_ReleaseHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseHold'
+type MockHoldService_ReleaseHold_Call struct {
+	*mock.Call
+}
+
+// ReleaseHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - holdID string
+func (_e *MockHoldService_Expecter) ReleaseHold(ctx interface{}, userID interface{}, holdID interface{}) *MockHoldService_ReleaseHold_Call {
+	return &MockHoldService_ReleaseHold_Call{Call: _e.mock.On("ReleaseHold", ctx, userID, holdID)}
+}
+
+func (_c *MockHoldService_ReleaseHold_Call) Run(run func(ctx context.Context, userID uint64, holdID string)) *MockHoldService_ReleaseHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldService_ReleaseHold_Call) Return(holdResponse api.HoldResponse, err error) *MockHoldService_ReleaseHold_Call {
+	_c.Call.Return(holdResponse, err)
+	return _c
+}
+
+func (_c *MockHoldService_ReleaseHold_Call) RunAndReturn(run func(ctx context.Context, userID uint64, holdID string) (api.HoldResponse, error)) *MockHoldService_ReleaseHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SettleHold provides a mock function for the type MockHoldService
+func (_mock *MockHoldService) SettleHold(ctx context.Context, req api.HoldSettlementRequest, userID uint64, holdID string) (api.HoldResponse, error) {
+	ret := _mock.Called(ctx, req, userID, holdID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SettleHold")
+	}
+
+	var r0 api.HoldResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.HoldSettlementRequest, uint64, string) (api.HoldResponse, error)); ok {
+		return returnFunc(ctx, req, userID, holdID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.HoldSettlementRequest, uint64, string) api.HoldResponse); ok {
+		r0 = returnFunc(ctx, req, userID, holdID)
+	} else {
+		r0 = ret.Get(0).(api.HoldResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.HoldSettlementRequest, uint64, string) error); ok {
+		r1 = returnFunc(ctx, req, userID, holdID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockHoldService_SettleHold_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SettleHold'
+type MockHoldService_SettleHold_Call struct {
+	*mock.Call
+}
+
+// SettleHold is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.HoldSettlementRequest
+//   - userID uint64
+//   - holdID string
+func (_e *MockHoldService_Expecter) SettleHold(ctx interface{}, req interface{}, userID interface{}, holdID interface{}) *MockHoldService_SettleHold_Call {
+	return &MockHoldService_SettleHold_Call{Call: _e.mock.On("SettleHold", ctx, req, userID, holdID)}
+}
+
+func (_c *MockHoldService_SettleHold_Call) Run(run func(ctx context.Context, req api.HoldSettlementRequest, userID uint64, holdID string)) *MockHoldService_SettleHold_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.HoldSettlementRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.HoldSettlementRequest)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockHoldService_SettleHold_Call) Return(holdResponse api.HoldResponse, err error) *MockHoldService_SettleHold_Call {
+	_c.Call.Return(holdResponse, err)
+	return _c
+}
+
+func (_c *MockHoldService_SettleHold_Call) RunAndReturn(run func(ctx context.Context, req api.HoldSettlementRequest, userID uint64, holdID string) (api.HoldResponse, error)) *MockHoldService_SettleHold_Call {
+	_c.Call.Return(run)
+	return _c
+}
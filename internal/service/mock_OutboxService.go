@@ -0,0 +1,231 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+	"time"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockOutboxService creates a new instance of MockOutboxService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockOutboxService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockOutboxService {
+	mock := &MockOutboxService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockOutboxService is an autogenerated mock type for the OutboxService type
+type MockOutboxService struct {
+	mock.Mock
+}
+
+type MockOutboxService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockOutboxService) EXPECT() *MockOutboxService_Expecter {
+	return &MockOutboxService_Expecter{mock: &_m.Mock}
+}
+
+// Backlog provides a mock function for the type MockOutboxService
+func (_mock *MockOutboxService) Backlog(ctx context.Context) (int64, time.Duration, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Backlog")
+	}
+
+	var r0 int64
+	var r1 time.Duration
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, time.Duration, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) time.Duration); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Get(1).(time.Duration)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = returnFunc(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockOutboxService_Backlog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Backlog'
+type MockOutboxService_Backlog_Call struct {
+	*mock.Call
+}
+
+// Backlog is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOutboxService_Expecter) Backlog(ctx interface{}) *MockOutboxService_Backlog_Call {
+	return &MockOutboxService_Backlog_Call{Call: _e.mock.On("Backlog", ctx)}
+}
+
+func (_c *MockOutboxService_Backlog_Call) Run(run func(ctx context.Context)) *MockOutboxService_Backlog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxService_Backlog_Call) Return(pending int64, lag time.Duration, err error) *MockOutboxService_Backlog_Call {
+	_c.Call.Return(pending, lag, err)
+	return _c
+}
+
+func (_c *MockOutboxService_Backlog_Call) RunAndReturn(run func(ctx context.Context) (int64, time.Duration, error)) *MockOutboxService_Backlog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgePublishedEvents provides a mock function for the type MockOutboxService
+func (_mock *MockOutboxService) PurgePublishedEvents(ctx context.Context) (int64, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgePublishedEvents")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockOutboxService_PurgePublishedEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgePublishedEvents'
+type MockOutboxService_PurgePublishedEvents_Call struct {
+	*mock.Call
+}
+
+// PurgePublishedEvents is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOutboxService_Expecter) PurgePublishedEvents(ctx interface{}) *MockOutboxService_PurgePublishedEvents_Call {
+	return &MockOutboxService_PurgePublishedEvents_Call{Call: _e.mock.On("PurgePublishedEvents", ctx)}
+}
+
+func (_c *MockOutboxService_PurgePublishedEvents_Call) Run(run func(ctx context.Context)) *MockOutboxService_PurgePublishedEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxService_PurgePublishedEvents_Call) Return(n int64, err error) *MockOutboxService_PurgePublishedEvents_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockOutboxService_PurgePublishedEvents_Call) RunAndReturn(run func(ctx context.Context) (int64, error)) *MockOutboxService_PurgePublishedEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RelayEvents provides a mock function for the type MockOutboxService
+func (_mock *MockOutboxService) RelayEvents(ctx context.Context) (int, int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RelayEvents")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) int); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = returnFunc(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockOutboxService_RelayEvents_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RelayEvents'
+type MockOutboxService_RelayEvents_Call struct {
+	*mock.Call
+}
+
+// RelayEvents is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockOutboxService_Expecter) RelayEvents(ctx interface{}) *MockOutboxService_RelayEvents_Call {
+	return &MockOutboxService_RelayEvents_Call{Call: _e.mock.On("RelayEvents", ctx)}
+}
+
+func (_c *MockOutboxService_RelayEvents_Call) Run(run func(ctx context.Context)) *MockOutboxService_RelayEvents_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockOutboxService_RelayEvents_Call) Return(published int, failed int, err error) *MockOutboxService_RelayEvents_Call {
+	_c.Call.Return(published, failed, err)
+	return _c
+}
+
+func (_c *MockOutboxService_RelayEvents_Call) RunAndReturn(run func(ctx context.Context) (int, int, error)) *MockOutboxService_RelayEvents_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -0,0 +1,105 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockReconciliationService creates a new instance of MockReconciliationService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockReconciliationService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockReconciliationService {
+	mock := &MockReconciliationService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockReconciliationService is an autogenerated mock type for the ReconciliationService type
+type MockReconciliationService struct {
+	mock.Mock
+}
+
+type MockReconciliationService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockReconciliationService) EXPECT() *MockReconciliationService_Expecter {
+	return &MockReconciliationService_Expecter{mock: &_m.Mock}
+}
+
+// Reconcile provides a mock function for the type MockReconciliationService
+func (_mock *MockReconciliationService) Reconcile(ctx context.Context, repair bool) (api.ReconciliationReport, error) {
+	ret := _mock.Called(ctx, repair)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Reconcile")
+	}
+
+	var r0 api.ReconciliationReport
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) (api.ReconciliationReport, error)); ok {
+		return returnFunc(ctx, repair)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, bool) api.ReconciliationReport); ok {
+		r0 = returnFunc(ctx, repair)
+	} else {
+		r0 = ret.Get(0).(api.ReconciliationReport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, bool) error); ok {
+		r1 = returnFunc(ctx, repair)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockReconciliationService_Reconcile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Reconcile'
+type MockReconciliationService_Reconcile_Call struct {
+	*mock.Call
+}
+
+// Reconcile is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repair bool
+func (_e *MockReconciliationService_Expecter) Reconcile(ctx interface{}, repair interface{}) *MockReconciliationService_Reconcile_Call {
+	return &MockReconciliationService_Reconcile_Call{Call: _e.mock.On("Reconcile", ctx, repair)}
+}
+
+func (_c *MockReconciliationService_Reconcile_Call) Run(run func(ctx context.Context, repair bool)) *MockReconciliationService_Reconcile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockReconciliationService_Reconcile_Call) Return(reconciliationReport api.ReconciliationReport, err error) *MockReconciliationService_Reconcile_Call {
+	_c.Call.Return(reconciliationReport, err)
+	return _c
+}
+
+func (_c *MockReconciliationService_Reconcile_Call) RunAndReturn(run func(ctx context.Context, repair bool) (api.ReconciliationReport, error)) *MockReconciliationService_Reconcile_Call {
+	_c.Call.Return(run)
+	return _c
+}
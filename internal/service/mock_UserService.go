@@ -39,8 +39,8 @@ func (_m *MockUserService) EXPECT() *MockUserService_Expecter {
 }
 
 // GetBalance provides a mock function for the type MockUserService
-func (_mock *MockUserService) GetBalance(ctx context.Context, userID uint64) (api.BalanceResponse, error) {
-	ret := _mock.Called(ctx, userID)
+func (_mock *MockUserService) GetBalance(ctx context.Context, userID uint64, currencyCode string) (api.BalanceResponse, error) {
+	ret := _mock.Called(ctx, userID, currencyCode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetBalance")
@@ -48,16 +48,16 @@ func (_mock *MockUserService) GetBalance(ctx context.Context, userID uint64) (ap
 
 	var r0 api.BalanceResponse
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (api.BalanceResponse, error)); ok {
-		return returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (api.BalanceResponse, error)); ok {
+		return returnFunc(ctx, userID, currencyCode)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) api.BalanceResponse); ok {
-		r0 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) api.BalanceResponse); ok {
+		r0 = returnFunc(ctx, userID, currencyCode)
 	} else {
 		r0 = ret.Get(0).(api.BalanceResponse)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
-		r1 = returnFunc(ctx, userID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, userID, currencyCode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -72,11 +72,12 @@ type MockUserService_GetBalance_Call struct {
 // GetBalance is a helper method to define mock.On call
 //   - ctx context.Context
 //   - userID uint64
-func (_e *MockUserService_Expecter) GetBalance(ctx interface{}, userID interface{}) *MockUserService_GetBalance_Call {
-	return &MockUserService_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, userID)}
+//   - currencyCode string
+func (_e *MockUserService_Expecter) GetBalance(ctx interface{}, userID interface{}, currencyCode interface{}) *MockUserService_GetBalance_Call {
+	return &MockUserService_GetBalance_Call{Call: _e.mock.On("GetBalance", ctx, userID, currencyCode)}
 }
 
-func (_c *MockUserService_GetBalance_Call) Run(run func(ctx context.Context, userID uint64)) *MockUserService_GetBalance_Call {
+func (_c *MockUserService_GetBalance_Call) Run(run func(ctx context.Context, userID uint64, currencyCode string)) *MockUserService_GetBalance_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -86,9 +87,14 @@ func (_c *MockUserService_GetBalance_Call) Run(run func(ctx context.Context, use
 		if args[1] != nil {
 			arg1 = args[1].(uint64)
 		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
@@ -99,7 +105,292 @@ func (_c *MockUserService_GetBalance_Call) Return(balanceResponse api.BalanceRes
 	return _c
 }
 
-func (_c *MockUserService_GetBalance_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (api.BalanceResponse, error)) *MockUserService_GetBalance_Call {
+func (_c *MockUserService_GetBalance_Call) RunAndReturn(run func(ctx context.Context, userID uint64, currencyCode string) (api.BalanceResponse, error)) *MockUserService_GetBalance_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTransaction provides a mock function for the type MockUserService
+func (_mock *MockUserService) GetTransaction(ctx context.Context, userID uint64, transactionID string) (api.TransactionDetails, error) {
+	ret := _mock.Called(ctx, userID, transactionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransaction")
+	}
+
+	var r0 api.TransactionDetails
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) (api.TransactionDetails, error)); ok {
+		return returnFunc(ctx, userID, transactionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, string) api.TransactionDetails); ok {
+		r0 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r0 = ret.Get(0).(api.TransactionDetails)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, string) error); ok {
+		r1 = returnFunc(ctx, userID, transactionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_GetTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTransaction'
+type MockUserService_GetTransaction_Call struct {
+	*mock.Call
+}
+
+// GetTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - transactionID string
+func (_e *MockUserService_Expecter) GetTransaction(ctx interface{}, userID interface{}, transactionID interface{}) *MockUserService_GetTransaction_Call {
+	return &MockUserService_GetTransaction_Call{Call: _e.mock.On("GetTransaction", ctx, userID, transactionID)}
+}
+
+func (_c *MockUserService_GetTransaction_Call) Run(run func(ctx context.Context, userID uint64, transactionID string)) *MockUserService_GetTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_GetTransaction_Call) Return(transactionDetails api.TransactionDetails, err error) *MockUserService_GetTransaction_Call {
+	_c.Call.Return(transactionDetails, err)
+	return _c
+}
+
+func (_c *MockUserService_GetTransaction_Call) RunAndReturn(run func(ctx context.Context, userID uint64, transactionID string) (api.TransactionDetails, error)) *MockUserService_GetTransaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListTransactions provides a mock function for the type MockUserService
+func (_mock *MockUserService) ListTransactions(ctx context.Context, userID uint64, req api.TransactionListRequest) (api.TransactionListResponse, error) {
+	ret := _mock.Called(ctx, userID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTransactions")
+	}
+
+	var r0 api.TransactionListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, api.TransactionListRequest) (api.TransactionListResponse, error)); ok {
+		return returnFunc(ctx, userID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64, api.TransactionListRequest) api.TransactionListResponse); ok {
+		r0 = returnFunc(ctx, userID, req)
+	} else {
+		r0 = ret.Get(0).(api.TransactionListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64, api.TransactionListRequest) error); ok {
+		r1 = returnFunc(ctx, userID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_ListTransactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTransactions'
+type MockUserService_ListTransactions_Call struct {
+	*mock.Call
+}
+
+// ListTransactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+//   - req api.TransactionListRequest
+func (_e *MockUserService_Expecter) ListTransactions(ctx interface{}, userID interface{}, req interface{}) *MockUserService_ListTransactions_Call {
+	return &MockUserService_ListTransactions_Call{Call: _e.mock.On("ListTransactions", ctx, userID, req)}
+}
+
+func (_c *MockUserService_ListTransactions_Call) Run(run func(ctx context.Context, userID uint64, req api.TransactionListRequest)) *MockUserService_ListTransactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		var arg2 api.TransactionListRequest
+		if args[2] != nil {
+			arg2 = args[2].(api.TransactionListRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ListTransactions_Call) Return(transactionListResponse api.TransactionListResponse, err error) *MockUserService_ListTransactions_Call {
+	_c.Call.Return(transactionListResponse, err)
+	return _c
+}
+
+func (_c *MockUserService_ListTransactions_Call) RunAndReturn(run func(ctx context.Context, userID uint64, req api.TransactionListRequest) (api.TransactionListResponse, error)) *MockUserService_ListTransactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWallets provides a mock function for the type MockUserService
+func (_mock *MockUserService) ListWallets(ctx context.Context, userID uint64) (api.WalletListResponse, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWallets")
+	}
+
+	var r0 api.WalletListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) (api.WalletListResponse, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, uint64) api.WalletListResponse); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(api.WalletListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockUserService_ListWallets_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWallets'
+type MockUserService_ListWallets_Call struct {
+	*mock.Call
+}
+
+// ListWallets is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID uint64
+func (_e *MockUserService_Expecter) ListWallets(ctx interface{}, userID interface{}) *MockUserService_ListWallets_Call {
+	return &MockUserService_ListWallets_Call{Call: _e.mock.On("ListWallets", ctx, userID)}
+}
+
+func (_c *MockUserService_ListWallets_Call) Run(run func(ctx context.Context, userID uint64)) *MockUserService_ListWallets_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 uint64
+		if args[1] != nil {
+			arg1 = args[1].(uint64)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ListWallets_Call) Return(walletListResponse api.WalletListResponse, err error) *MockUserService_ListWallets_Call {
+	_c.Call.Return(walletListResponse, err)
+	return _c
+}
+
+func (_c *MockUserService_ListWallets_Call) RunAndReturn(run func(ctx context.Context, userID uint64) (api.WalletListResponse, error)) *MockUserService_ListWallets_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReverseTransaction provides a mock function for the type MockUserService
+func (_mock *MockUserService) ReverseTransaction(ctx context.Context, req api.ReversalRequest, userID uint64, originalTransactionID string, sourceType string) error {
+	ret := _mock.Called(ctx, req, userID, originalTransactionID, sourceType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReverseTransaction")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.ReversalRequest, uint64, string, string) error); ok {
+		r0 = returnFunc(ctx, req, userID, originalTransactionID, sourceType)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockUserService_ReverseTransaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReverseTransaction'
+type MockUserService_ReverseTransaction_Call struct {
+	*mock.Call
+}
+
+// ReverseTransaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.ReversalRequest
+//   - userID uint64
+//   - originalTransactionID string
+//   - sourceType string
+func (_e *MockUserService_Expecter) ReverseTransaction(ctx interface{}, req interface{}, userID interface{}, originalTransactionID interface{}, sourceType interface{}) *MockUserService_ReverseTransaction_Call {
+	return &MockUserService_ReverseTransaction_Call{Call: _e.mock.On("ReverseTransaction", ctx, req, userID, originalTransactionID, sourceType)}
+}
+
+func (_c *MockUserService_ReverseTransaction_Call) Run(run func(ctx context.Context, req api.ReversalRequest, userID uint64, originalTransactionID string, sourceType string)) *MockUserService_ReverseTransaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.ReversalRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.ReversalRequest)
+		}
+		var arg2 uint64
+		if args[2] != nil {
+			arg2 = args[2].(uint64)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockUserService_ReverseTransaction_Call) Return(err error) *MockUserService_ReverseTransaction_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockUserService_ReverseTransaction_Call) RunAndReturn(run func(ctx context.Context, req api.ReversalRequest, userID uint64, originalTransactionID string, sourceType string) error) *MockUserService_ReverseTransaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,688 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package service
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockWebhookService creates a new instance of MockWebhookService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockWebhookService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockWebhookService {
+	mock := &MockWebhookService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockWebhookService is an autogenerated mock type for the WebhookService type
+type MockWebhookService struct {
+	mock.Mock
+}
+
+type MockWebhookService_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockWebhookService) EXPECT() *MockWebhookService_Expecter {
+	return &MockWebhookService_Expecter{mock: &_m.Mock}
+}
+
+// CreateSubscription provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) CreateSubscription(ctx context.Context, req api.WebhookSubscriptionRequest) (api.WebhookSubscriptionResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 api.WebhookSubscriptionResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.WebhookSubscriptionRequest) (api.WebhookSubscriptionResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.WebhookSubscriptionRequest) api.WebhookSubscriptionResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(api.WebhookSubscriptionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.WebhookSubscriptionRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_CreateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSubscription'
+type MockWebhookService_CreateSubscription_Call struct {
+	*mock.Call
+}
+
+// CreateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.WebhookSubscriptionRequest
+func (_e *MockWebhookService_Expecter) CreateSubscription(ctx interface{}, req interface{}) *MockWebhookService_CreateSubscription_Call {
+	return &MockWebhookService_CreateSubscription_Call{Call: _e.mock.On("CreateSubscription", ctx, req)}
+}
+
+func (_c *MockWebhookService_CreateSubscription_Call) Run(run func(ctx context.Context, req api.WebhookSubscriptionRequest)) *MockWebhookService_CreateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.WebhookSubscriptionRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.WebhookSubscriptionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_CreateSubscription_Call) Return(webhookSubscriptionResponse api.WebhookSubscriptionResponse, err error) *MockWebhookService_CreateSubscription_Call {
+	_c.Call.Return(webhookSubscriptionResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_CreateSubscription_Call) RunAndReturn(run func(ctx context.Context, req api.WebhookSubscriptionRequest) (api.WebhookSubscriptionResponse, error)) *MockWebhookService_CreateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSubscription provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	ret := _mock.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, subscriptionID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookService_DeleteSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSubscription'
+type MockWebhookService_DeleteSubscription_Call struct {
+	*mock.Call
+}
+
+// DeleteSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+func (_e *MockWebhookService_Expecter) DeleteSubscription(ctx interface{}, subscriptionID interface{}) *MockWebhookService_DeleteSubscription_Call {
+	return &MockWebhookService_DeleteSubscription_Call{Call: _e.mock.On("DeleteSubscription", ctx, subscriptionID)}
+}
+
+func (_c *MockWebhookService_DeleteSubscription_Call) Run(run func(ctx context.Context, subscriptionID string)) *MockWebhookService_DeleteSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteSubscription_Call) Return(err error) *MockWebhookService_DeleteSubscription_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookService_DeleteSubscription_Call) RunAndReturn(run func(ctx context.Context, subscriptionID string) error) *MockWebhookService_DeleteSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeliverWebhooks provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) DeliverWebhooks(ctx context.Context) (int, int, int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeliverWebhooks")
+	}
+
+	var r0 int
+	var r1 int
+	var r2 int
+	var r3 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, int, int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) int); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context) int); ok {
+		r2 = returnFunc(ctx)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+	if returnFunc, ok := ret.Get(3).(func(context.Context) error); ok {
+		r3 = returnFunc(ctx)
+	} else {
+		r3 = ret.Error(3)
+	}
+	return r0, r1, r2, r3
+}
+
+// MockWebhookService_DeliverWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeliverWebhooks'
+type MockWebhookService_DeliverWebhooks_Call struct {
+	*mock.Call
+}
+
+// DeliverWebhooks is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) DeliverWebhooks(ctx interface{}) *MockWebhookService_DeliverWebhooks_Call {
+	return &MockWebhookService_DeliverWebhooks_Call{Call: _e.mock.On("DeliverWebhooks", ctx)}
+}
+
+func (_c *MockWebhookService_DeliverWebhooks_Call) Run(run func(ctx context.Context)) *MockWebhookService_DeliverWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_DeliverWebhooks_Call) Return(delivered int, failed int, deadLettered int, err error) *MockWebhookService_DeliverWebhooks_Call {
+	_c.Call.Return(delivered, failed, deadLettered, err)
+	return _c
+}
+
+func (_c *MockWebhookService_DeliverWebhooks_Call) RunAndReturn(run func(ctx context.Context) (int, int, int, error)) *MockWebhookService_DeliverWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDelivery provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) GetDelivery(ctx context.Context, deliveryID string) (api.WebhookDeliveryResponse, error) {
+	ret := _mock.Called(ctx, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDelivery")
+	}
+
+	var r0 api.WebhookDeliveryResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (api.WebhookDeliveryResponse, error)); ok {
+		return returnFunc(ctx, deliveryID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) api.WebhookDeliveryResponse); ok {
+		r0 = returnFunc(ctx, deliveryID)
+	} else {
+		r0 = ret.Get(0).(api.WebhookDeliveryResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_GetDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDelivery'
+type MockWebhookService_GetDelivery_Call struct {
+	*mock.Call
+}
+
+// GetDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveryID string
+func (_e *MockWebhookService_Expecter) GetDelivery(ctx interface{}, deliveryID interface{}) *MockWebhookService_GetDelivery_Call {
+	return &MockWebhookService_GetDelivery_Call{Call: _e.mock.On("GetDelivery", ctx, deliveryID)}
+}
+
+func (_c *MockWebhookService_GetDelivery_Call) Run(run func(ctx context.Context, deliveryID string)) *MockWebhookService_GetDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetDelivery_Call) Return(webhookDeliveryResponse api.WebhookDeliveryResponse, err error) *MockWebhookService_GetDelivery_Call {
+	_c.Call.Return(webhookDeliveryResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_GetDelivery_Call) RunAndReturn(run func(ctx context.Context, deliveryID string) (api.WebhookDeliveryResponse, error)) *MockWebhookService_GetDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSubscription provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) GetSubscription(ctx context.Context, subscriptionID string) (api.WebhookSubscriptionResponse, error) {
+	ret := _mock.Called(ctx, subscriptionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSubscription")
+	}
+
+	var r0 api.WebhookSubscriptionResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (api.WebhookSubscriptionResponse, error)); ok {
+		return returnFunc(ctx, subscriptionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) api.WebhookSubscriptionResponse); ok {
+		r0 = returnFunc(ctx, subscriptionID)
+	} else {
+		r0 = ret.Get(0).(api.WebhookSubscriptionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, subscriptionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_GetSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSubscription'
+type MockWebhookService_GetSubscription_Call struct {
+	*mock.Call
+}
+
+// GetSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+func (_e *MockWebhookService_Expecter) GetSubscription(ctx interface{}, subscriptionID interface{}) *MockWebhookService_GetSubscription_Call {
+	return &MockWebhookService_GetSubscription_Call{Call: _e.mock.On("GetSubscription", ctx, subscriptionID)}
+}
+
+func (_c *MockWebhookService_GetSubscription_Call) Run(run func(ctx context.Context, subscriptionID string)) *MockWebhookService_GetSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_GetSubscription_Call) Return(webhookSubscriptionResponse api.WebhookSubscriptionResponse, err error) *MockWebhookService_GetSubscription_Call {
+	_c.Call.Return(webhookSubscriptionResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_GetSubscription_Call) RunAndReturn(run func(ctx context.Context, subscriptionID string) (api.WebhookSubscriptionResponse, error)) *MockWebhookService_GetSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListDeliveries provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) ListDeliveries(ctx context.Context, req api.WebhookDeliveryListRequest) (api.WebhookDeliveryListResponse, error) {
+	ret := _mock.Called(ctx, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeliveries")
+	}
+
+	var r0 api.WebhookDeliveryListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.WebhookDeliveryListRequest) (api.WebhookDeliveryListResponse, error)); ok {
+		return returnFunc(ctx, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, api.WebhookDeliveryListRequest) api.WebhookDeliveryListResponse); ok {
+		r0 = returnFunc(ctx, req)
+	} else {
+		r0 = ret.Get(0).(api.WebhookDeliveryListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, api.WebhookDeliveryListRequest) error); ok {
+		r1 = returnFunc(ctx, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_ListDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListDeliveries'
+type MockWebhookService_ListDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req api.WebhookDeliveryListRequest
+func (_e *MockWebhookService_Expecter) ListDeliveries(ctx interface{}, req interface{}) *MockWebhookService_ListDeliveries_Call {
+	return &MockWebhookService_ListDeliveries_Call{Call: _e.mock.On("ListDeliveries", ctx, req)}
+}
+
+func (_c *MockWebhookService_ListDeliveries_Call) Run(run func(ctx context.Context, req api.WebhookDeliveryListRequest)) *MockWebhookService_ListDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 api.WebhookDeliveryListRequest
+		if args[1] != nil {
+			arg1 = args[1].(api.WebhookDeliveryListRequest)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ListDeliveries_Call) Return(webhookDeliveryListResponse api.WebhookDeliveryListResponse, err error) *MockWebhookService_ListDeliveries_Call {
+	_c.Call.Return(webhookDeliveryListResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_ListDeliveries_Call) RunAndReturn(run func(ctx context.Context, req api.WebhookDeliveryListRequest) (api.WebhookDeliveryListResponse, error)) *MockWebhookService_ListDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListSubscriptions provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) ListSubscriptions(ctx context.Context) (api.WebhookSubscriptionListResponse, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 api.WebhookSubscriptionListResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (api.WebhookSubscriptionListResponse, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) api.WebhookSubscriptionListResponse); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(api.WebhookSubscriptionListResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_ListSubscriptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListSubscriptions'
+type MockWebhookService_ListSubscriptions_Call struct {
+	*mock.Call
+}
+
+// ListSubscriptions is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockWebhookService_Expecter) ListSubscriptions(ctx interface{}) *MockWebhookService_ListSubscriptions_Call {
+	return &MockWebhookService_ListSubscriptions_Call{Call: _e.mock.On("ListSubscriptions", ctx)}
+}
+
+func (_c *MockWebhookService_ListSubscriptions_Call) Run(run func(ctx context.Context)) *MockWebhookService_ListSubscriptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_ListSubscriptions_Call) Return(webhookSubscriptionListResponse api.WebhookSubscriptionListResponse, err error) *MockWebhookService_ListSubscriptions_Call {
+	_c.Call.Return(webhookSubscriptionListResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_ListSubscriptions_Call) RunAndReturn(run func(ctx context.Context) (api.WebhookSubscriptionListResponse, error)) *MockWebhookService_ListSubscriptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Publish provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) Publish(ctx context.Context, event events.Event) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, events.Event) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookService_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockWebhookService_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event events.Event
+func (_e *MockWebhookService_Expecter) Publish(ctx interface{}, event interface{}) *MockWebhookService_Publish_Call {
+	return &MockWebhookService_Publish_Call{Call: _e.mock.On("Publish", ctx, event)}
+}
+
+func (_c *MockWebhookService_Publish_Call) Run(run func(ctx context.Context, event events.Event)) *MockWebhookService_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 events.Event
+		if args[1] != nil {
+			arg1 = args[1].(events.Event)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_Publish_Call) Return(err error) *MockWebhookService_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookService_Publish_Call) RunAndReturn(run func(ctx context.Context, event events.Event) error) *MockWebhookService_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedeliverDelivery provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) RedeliverDelivery(ctx context.Context, deliveryID string) (api.WebhookDeliveryResponse, error) {
+	ret := _mock.Called(ctx, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeliverDelivery")
+	}
+
+	var r0 api.WebhookDeliveryResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (api.WebhookDeliveryResponse, error)); ok {
+		return returnFunc(ctx, deliveryID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) api.WebhookDeliveryResponse); ok {
+		r0 = returnFunc(ctx, deliveryID)
+	} else {
+		r0 = ret.Get(0).(api.WebhookDeliveryResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_RedeliverDelivery_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverDelivery'
+type MockWebhookService_RedeliverDelivery_Call struct {
+	*mock.Call
+}
+
+// RedeliverDelivery is a helper method to define mock.On call
+//   - ctx context.Context
+//   - deliveryID string
+func (_e *MockWebhookService_Expecter) RedeliverDelivery(ctx interface{}, deliveryID interface{}) *MockWebhookService_RedeliverDelivery_Call {
+	return &MockWebhookService_RedeliverDelivery_Call{Call: _e.mock.On("RedeliverDelivery", ctx, deliveryID)}
+}
+
+func (_c *MockWebhookService_RedeliverDelivery_Call) Run(run func(ctx context.Context, deliveryID string)) *MockWebhookService_RedeliverDelivery_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_RedeliverDelivery_Call) Return(webhookDeliveryResponse api.WebhookDeliveryResponse, err error) *MockWebhookService_RedeliverDelivery_Call {
+	_c.Call.Return(webhookDeliveryResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_RedeliverDelivery_Call) RunAndReturn(run func(ctx context.Context, deliveryID string) (api.WebhookDeliveryResponse, error)) *MockWebhookService_RedeliverDelivery_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSubscription provides a mock function for the type MockWebhookService
+func (_mock *MockWebhookService) UpdateSubscription(ctx context.Context, subscriptionID string, req api.WebhookSubscriptionRequest) (api.WebhookSubscriptionResponse, error) {
+	ret := _mock.Called(ctx, subscriptionID, req)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSubscription")
+	}
+
+	var r0 api.WebhookSubscriptionResponse
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.WebhookSubscriptionRequest) (api.WebhookSubscriptionResponse, error)); ok {
+		return returnFunc(ctx, subscriptionID, req)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, api.WebhookSubscriptionRequest) api.WebhookSubscriptionResponse); ok {
+		r0 = returnFunc(ctx, subscriptionID, req)
+	} else {
+		r0 = ret.Get(0).(api.WebhookSubscriptionResponse)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, api.WebhookSubscriptionRequest) error); ok {
+		r1 = returnFunc(ctx, subscriptionID, req)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookService_UpdateSubscription_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSubscription'
+type MockWebhookService_UpdateSubscription_Call struct {
+	*mock.Call
+}
+
+// UpdateSubscription is a helper method to define mock.On call
+//   - ctx context.Context
+//   - subscriptionID string
+//   - req api.WebhookSubscriptionRequest
+func (_e *MockWebhookService_Expecter) UpdateSubscription(ctx interface{}, subscriptionID interface{}, req interface{}) *MockWebhookService_UpdateSubscription_Call {
+	return &MockWebhookService_UpdateSubscription_Call{Call: _e.mock.On("UpdateSubscription", ctx, subscriptionID, req)}
+}
+
+func (_c *MockWebhookService_UpdateSubscription_Call) Run(run func(ctx context.Context, subscriptionID string, req api.WebhookSubscriptionRequest)) *MockWebhookService_UpdateSubscription_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 api.WebhookSubscriptionRequest
+		if args[2] != nil {
+			arg2 = args[2].(api.WebhookSubscriptionRequest)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateSubscription_Call) Return(webhookSubscriptionResponse api.WebhookSubscriptionResponse, err error) *MockWebhookService_UpdateSubscription_Call {
+	_c.Call.Return(webhookSubscriptionResponse, err)
+	return _c
+}
+
+func (_c *MockWebhookService_UpdateSubscription_Call) RunAndReturn(run func(ctx context.Context, subscriptionID string, req api.WebhookSubscriptionRequest) (api.WebhookSubscriptionResponse, error)) *MockWebhookService_UpdateSubscription_Call {
+	_c.Call.Return(run)
+	return _c
+}
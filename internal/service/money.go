@@ -0,0 +1,76 @@
+package service
+
+import (
+	"github.com/shopspring/decimal"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+// moneyConverter translates between API decimal strings and the int64 minor units stored in the DB.
+type moneyConverter struct {
+	currencies *currency.Registry
+	policy     amount.Policy
+}
+
+func newMoneyConverter(currencies *currency.Registry, policy amount.Policy) moneyConverter {
+	return moneyConverter{
+		currencies: currencies,
+		policy:     policy,
+	}
+}
+
+// resolveCurrency returns the registered currency for code, or the default currency when code is empty.
+func (c moneyConverter) resolveCurrency(code string) (currency.Currency, error) {
+	resolved, ok := c.currencies.Resolve(code)
+	if !ok {
+		return currency.Currency{}, errs.ErrUnsupportedCurrency
+	}
+
+	return resolved, nil
+}
+
+// formatMinorUnits renders a stored amount. Currencies that were removed from the registry after
+// the amount was written are formatted with the default currency's exponent.
+func (c moneyConverter) formatMinorUnits(minorUnits int64, code string) string {
+	resolved, ok := c.currencies.Resolve(code)
+	if !ok {
+		resolved = currency.Currency{Code: code, Exponent: c.currencies.Default().Exponent}
+	}
+
+	return resolved.Format(minorUnits)
+}
+
+func (moneyConverter) toMinorUnits(value string, cur currency.Currency) (int64, error) {
+	parsed, err := decimal.NewFromString(value)
+	if err != nil {
+		return 0, errs.ErrInvalidAmountFormat
+	}
+
+	return amount.ToMinorUnits(parsed, cur)
+}
+
+// toTransactionMinorUnits converts an amount that is about to be posted, enforcing the amount policy
+// of sourceType on top of the format checks done by toMinorUnits.
+func (c moneyConverter) toTransactionMinorUnits(value string, cur currency.Currency, sourceType string) (int64, error) {
+	parsed, err := decimal.NewFromString(value)
+	if err != nil {
+		return 0, errs.ErrInvalidAmountFormat
+	}
+
+	return c.policy.ToMinorUnits(sourceType, parsed, cur)
+}
+
+func (c moneyConverter) parseOptionalMinorUnits(value string, cur currency.Currency) (*int64, error) {
+	if value == "" {
+		return nil, nil //nolint: nilnil // Empty value means "no filter"
+	}
+
+	minorUnits, err := c.toMinorUnits(value, cur)
+	if err != nil {
+		return nil, err
+	}
+
+	return &minorUnits, nil
+}
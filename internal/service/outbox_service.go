@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+// OutboxService relays the events written to the transactional outbox to an events.EventPublisher.
+// An event that fails to publish is retried with exponential backoff until it is accepted, so
+// every event is delivered at least once.
+type OutboxService interface {
+	// RelayEvents publishes due events in batches until none are left, or a batch had a failure,
+	// and returns how many were published and how many were rescheduled.
+	RelayEvents(ctx context.Context) (published, failed int, err error)
+	// Backlog returns the number of unpublished events and the age of the oldest one.
+	Backlog(ctx context.Context) (pending int64, lag time.Duration, err error)
+	// PurgePublishedEvents deletes the events published longer than the retention period ago.
+	PurgePublishedEvents(ctx context.Context) (int64, error)
+}
+
+type outboxService struct {
+	moneyConverter
+
+	repo      db.OutboxRepository
+	publisher events.EventPublisher
+	config    config.OutboxConfig
+	now       func() time.Time
+}
+
+// NewOutboxService is built by the server only, as the admin commands never relay events.
+func NewOutboxService(
+	repo db.OutboxRepository, currencies *currency.Registry, publisher events.EventPublisher, cfg config.OutboxConfig,
+) OutboxService {
+	return &outboxService{
+		moneyConverter: newMoneyConverter(currencies, amount.NewPolicy(nil)),
+		repo:           repo,
+		publisher:      publisher,
+		config:         cfg,
+		now:            time.Now,
+	}
+}
+
+func (s *outboxService) RelayEvents(ctx context.Context) (published, failed int, err error) {
+	ctx, span := startSpan(ctx, "OutboxService.RelayEvents")
+	defer func() {
+		span.SetAttributes(attribute.Int("outbox.published", published), attribute.Int("outbox.failed", failed))
+		endSpan(span, err)
+	}()
+
+	for {
+		batch, err := s.repo.ClaimOutboxEvents(ctx, s.config.BatchSize, s.config.Lease)
+		if err != nil {
+			return published, failed, fmt.Errorf("RelayEvents error: %w", err)
+		}
+
+		batchFailed := 0
+
+		for _, event := range batch {
+			if err := s.publish(ctx, event); err != nil {
+				if err := s.reschedule(ctx, event, err); err != nil {
+					return published, failed, fmt.Errorf("RelayEvents error: %w", err)
+				}
+
+				batchFailed++
+
+				continue
+			}
+
+			if err := s.repo.MarkOutboxEventPublished(ctx, event.ID); err != nil {
+				// The event goes out again once its lease runs out, which at-least-once allows.
+				return published, failed, fmt.Errorf("RelayEvents error: %w", err)
+			}
+
+			published++
+		}
+
+		failed += batchFailed
+
+		// A failure usually means the sink is down: leave the rest for the next run instead of
+		// going through the whole backlog against it.
+		if len(batch) < s.config.BatchSize || batchFailed > 0 {
+			return published, failed, nil
+		}
+	}
+}
+
+func (s *outboxService) publish(ctx context.Context, event db.OutboxEvent) error {
+	envelope, err := s.toEvent(event)
+	if err != nil {
+		return err
+	}
+
+	return s.publisher.Publish(ctx, envelope)
+}
+
+// reschedule records a failed attempt and backs off exponentially from MinBackoff, doubling with
+// every attempt up to MaxBackoff.
+func (s *outboxService) reschedule(ctx context.Context, event db.OutboxEvent, cause error) error {
+	backoff := s.config.MinBackoff
+	for attempt := 1; attempt < event.Attempts && backoff < s.config.MaxBackoff; attempt++ {
+		backoff *= 2
+	}
+
+	backoff = min(backoff, s.config.MaxBackoff)
+
+	logrus.WithContext(ctx).WithError(cause).WithFields(logrus.Fields{
+		"event_id":   event.EventID,
+		"event_type": event.EventType,
+		"attempts":   event.Attempts,
+		"retry_in":   backoff.String(),
+	}).Warn("Failed to publish outbox event")
+
+	if err := s.repo.MarkOutboxEventFailed(ctx, event.ID, s.now().Add(backoff), cause.Error()); err != nil {
+		return fmt.Errorf("failed to reschedule event %s: %w", event.EventID, err)
+	}
+
+	return nil
+}
+
+func (s *outboxService) Backlog(ctx context.Context) (pending int64, lag time.Duration, err error) {
+	backlog, err := s.repo.GetOutboxBacklog(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Backlog error: %w", err)
+	}
+
+	if backlog.OldestCreatedAt != nil {
+		lag = max(s.now().Sub(*backlog.OldestCreatedAt), 0)
+	}
+
+	return backlog.Pending, lag, nil
+}
+
+func (s *outboxService) PurgePublishedEvents(ctx context.Context) (int64, error) {
+	if s.config.Retention <= 0 {
+		return 0, nil
+	}
+
+	purged, err := s.repo.PurgeOutboxEvents(ctx, s.now().Add(-s.config.Retention))
+	if err != nil {
+		return 0, fmt.Errorf("PurgePublishedEvents error: %w", err)
+	}
+
+	return purged, nil
+}
+
+// toEvent wraps an outbox row in the published envelope. Balance changes are converted to their API
+// representation; payloads of other event types are passed through as stored.
+func (s *outboxService) toEvent(event db.OutboxEvent) (events.Event, error) {
+	data := json.RawMessage(event.Payload)
+
+	if event.EventType == db.EventTypeBalanceChanged {
+		var change db.BalanceChange
+		if err := json.Unmarshal([]byte(event.Payload), &change); err != nil {
+			return events.Event{}, fmt.Errorf("failed to decode %s payload: %w", event.EventType, err)
+		}
+
+		encoded, err := json.Marshal(s.toBalanceChangedEvent(change))
+		if err != nil {
+			return events.Event{}, fmt.Errorf("failed to encode %s payload: %w", event.EventType, err)
+		}
+
+		data = encoded
+	}
+
+	return events.Event{
+		ID:         event.EventID.String(),
+		Type:       event.EventType,
+		Key:        event.AggregateKey,
+		Sequence:   event.ID,
+		OccurredAt: event.CreatedAt.UTC(),
+		Data:       data,
+	}, nil
+}
+
+// toBalanceChangedEvent is shared with the balance streams, which replay events from the outbox.
+func (c moneyConverter) toBalanceChangedEvent(change db.BalanceChange) api.BalanceChangedEvent {
+	event := api.BalanceChangedEvent{
+		UserID:        change.UserID,
+		TransactionID: change.TransactionID,
+		State:         change.State,
+		SourceType:    change.SourceType,
+		Amount:        c.formatMinorUnits(change.Amount, change.Currency),
+		Currency:      change.Currency,
+		Balance:       c.formatMinorUnits(change.Balance, change.Currency),
+		Available:     c.formatMinorUnits(change.Balance-change.Reserved, change.Currency),
+		Reserved:      c.formatMinorUnits(change.Reserved, change.Currency),
+		Principal:     change.Principal,
+	}
+
+	if change.ReversalOf != nil {
+		event.ReversalOf = *change.ReversalOf
+	}
+
+	if change.Reason != nil {
+		event.Reason = *change.Reason
+	}
+
+	return event
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+)
+
+var testOutboxConfig = config.OutboxConfig{
+	BatchSize:  2,
+	Lease:      time.Minute,
+	MinBackoff: time.Second,
+	MaxBackoff: 10 * time.Second,
+	Retention:  24 * time.Hour,
+}
+
+func newTestOutboxService(repo db.OutboxRepository, publisher events.EventPublisher, now time.Time) *outboxService {
+	service := NewOutboxService(repo, currency.DefaultRegistry(), publisher, testOutboxConfig).(*outboxService) //nolint: forcetypeassert // Test helper
+
+	service.now = func() time.Time { return now }
+
+	return service
+}
+
+func newTestOutboxEvent(id uint64, attempts int) db.OutboxEvent {
+	return db.OutboxEvent{
+		ID:           id,
+		EventID:      uuid.New(),
+		EventType:    db.EventTypeBalanceChanged,
+		AggregateKey: "1",
+		Payload: `{"user_id":1,"currency":"USD","transaction_id":"txn-1","state":"win","source_type":"game",` +
+			`"amount":1050,"balance":5000,"reserved":1000,"principal":"api_key:ops"}`,
+		Attempts:  attempts,
+		CreatedAt: time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestRelayEvents(t *testing.T) {
+	now := time.Date(2025, 8, 18, 12, 0, 5, 0, time.UTC)
+	errSink := errors.New("sink unavailable")
+
+	tests := []struct {
+		name              string
+		mockSetup         func(*db.MockOutboxRepository, *events.MockEventPublisher)
+		expectedPublished int
+		expectedFailed    int
+		expectedError     string
+	}{
+		{
+			name: "drains full batches",
+			mockSetup: func(mockRepo *db.MockOutboxRepository, mockPublisher *events.MockEventPublisher) {
+				mockRepo.EXPECT().ClaimOutboxEvents(mock.Anything, 2, time.Minute).
+					Return([]db.OutboxEvent{newTestOutboxEvent(1, 1), newTestOutboxEvent(2, 1)}, nil).Once()
+				mockRepo.EXPECT().ClaimOutboxEvents(mock.Anything, 2, time.Minute).
+					Return([]db.OutboxEvent{newTestOutboxEvent(3, 1)}, nil).Once()
+				mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Times(3)
+				mockRepo.EXPECT().MarkOutboxEventPublished(mock.Anything, mock.Anything).Return(nil).Times(3)
+			},
+			expectedPublished: 3,
+		},
+		{
+			name: "failed event backs off exponentially and stops the run",
+			mockSetup: func(mockRepo *db.MockOutboxRepository, mockPublisher *events.MockEventPublisher) {
+				mockRepo.EXPECT().ClaimOutboxEvents(mock.Anything, 2, time.Minute).
+					Return([]db.OutboxEvent{newTestOutboxEvent(1, 3), newTestOutboxEvent(2, 1)}, nil).Once()
+				mockPublisher.EXPECT().Publish(mock.Anything, mock.MatchedBy(func(event events.Event) bool {
+					return event.Sequence == 1
+				})).Return(errSink).Once()
+				mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(nil).Once()
+				mockRepo.EXPECT().MarkOutboxEventFailed(mock.Anything, uint64(1), now.Add(4*time.Second), "sink unavailable").
+					Return(nil).Once()
+				mockRepo.EXPECT().MarkOutboxEventPublished(mock.Anything, uint64(2)).Return(nil).Once()
+			},
+			expectedPublished: 1,
+			expectedFailed:    1,
+		},
+		{
+			name: "backoff is capped",
+			mockSetup: func(mockRepo *db.MockOutboxRepository, mockPublisher *events.MockEventPublisher) {
+				mockRepo.EXPECT().ClaimOutboxEvents(mock.Anything, 2, time.Minute).
+					Return([]db.OutboxEvent{newTestOutboxEvent(1, 40)}, nil).Once()
+				mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).Return(errSink).Once()
+				mockRepo.EXPECT().MarkOutboxEventFailed(mock.Anything, uint64(1), now.Add(10*time.Second), "sink unavailable").
+					Return(nil).Once()
+			},
+			expectedFailed: 1,
+		},
+		{
+			name: "claim error",
+			mockSetup: func(mockRepo *db.MockOutboxRepository, _ *events.MockEventPublisher) {
+				mockRepo.EXPECT().ClaimOutboxEvents(mock.Anything, 2, time.Minute).
+					Return(nil, errors.New("connection reset")).Once()
+			},
+			expectedError: "RelayEvents error: connection reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockOutboxRepository(t)
+			mockPublisher := events.NewMockEventPublisher(t)
+			tt.mockSetup(mockRepo, mockPublisher)
+
+			published, failed, err := newTestOutboxService(mockRepo, mockPublisher, now).RelayEvents(context.Background())
+
+			if tt.expectedError != "" {
+				require.EqualError(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tt.expectedPublished, published)
+			assert.Equal(t, tt.expectedFailed, failed)
+		})
+	}
+}
+
+func TestRelayEvents_Envelope(t *testing.T) {
+	event := newTestOutboxEvent(7, 1)
+
+	mockRepo := db.NewMockOutboxRepository(t)
+	mockRepo.EXPECT().ClaimOutboxEvents(mock.Anything, 2, time.Minute).Return([]db.OutboxEvent{event}, nil).Once()
+	mockRepo.EXPECT().MarkOutboxEventPublished(mock.Anything, uint64(7)).Return(nil).Once()
+
+	var published events.Event
+
+	mockPublisher := events.NewMockEventPublisher(t)
+	mockPublisher.EXPECT().Publish(mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, e events.Event) error {
+		published = e
+
+		return nil
+	}).Once()
+
+	_, _, err := newTestOutboxService(mockRepo, mockPublisher, time.Now()).RelayEvents(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, event.EventID.String(), published.ID)
+	assert.Equal(t, db.EventTypeBalanceChanged, published.Type)
+	assert.Equal(t, "1", published.Key)
+	assert.Equal(t, uint64(7), published.Sequence)
+	assert.Equal(t, event.CreatedAt, published.OccurredAt)
+	assert.JSONEq(t, `{"userId":1,"transactionId":"txn-1","state":"win","sourceType":"game","amount":"10.50",`+
+		`"currency":"USD","balance":"50.00","available":"40.00","reserved":"10.00","principal":"api_key:ops"}`,
+		string(published.Data))
+
+	var data map[string]any
+	require.NoError(t, json.Unmarshal(published.Data, &data))
+	assert.NotContains(t, data, "reversalOf")
+}
+
+func TestOutboxBacklog(t *testing.T) {
+	now := time.Date(2025, 8, 18, 12, 0, 30, 0, time.UTC)
+	oldest := now.Add(-30 * time.Second)
+
+	mockRepo := db.NewMockOutboxRepository(t)
+	mockRepo.EXPECT().GetOutboxBacklog(mock.Anything).
+		Return(db.OutboxBacklog{Pending: 4, OldestCreatedAt: &oldest}, nil).Once()
+	mockRepo.EXPECT().GetOutboxBacklog(mock.Anything).Return(db.OutboxBacklog{}, nil).Once()
+
+	service := newTestOutboxService(mockRepo, events.NewMockEventPublisher(t), now)
+
+	pending, lag, err := service.Backlog(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), pending)
+	assert.Equal(t, 30*time.Second, lag)
+
+	pending, lag, err = service.Backlog(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, pending)
+	assert.Zero(t, lag)
+}
+
+func TestPurgePublishedEvents(t *testing.T) {
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	mockRepo := db.NewMockOutboxRepository(t)
+	mockRepo.EXPECT().PurgeOutboxEvents(mock.Anything, now.Add(-24*time.Hour)).Return(12, nil).Once()
+
+	purged, err := newTestOutboxService(mockRepo, events.NewMockEventPublisher(t), now).
+		PurgePublishedEvents(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), purged)
+}
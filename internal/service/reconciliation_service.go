@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+type ReconciliationService interface {
+	// Reconcile compares every wallet's cached balance with its ledger balance. With repair set,
+	// each drift is corrected by an adjustment transaction.
+	Reconcile(ctx context.Context, repair bool) (api.ReconciliationReport, error)
+}
+
+type reconciliationService struct {
+	moneyConverter
+
+	repo db.ReconciliationRepository
+	now  func() time.Time
+}
+
+func newReconciliationService(
+	repo db.ReconciliationRepository, currencies *currency.Registry, policy amount.Policy,
+) ReconciliationService {
+	return &reconciliationService{
+		moneyConverter: newMoneyConverter(currencies, policy),
+		repo:           repo,
+		now:            time.Now,
+	}
+}
+
+func (s *reconciliationService) Reconcile(ctx context.Context, repair bool) (api.ReconciliationReport, error) {
+	report := api.ReconciliationReport{
+		CheckedAt: s.now().UTC(),
+		Repair:    repair,
+		Drifts:    []api.BalanceDrift{},
+	}
+
+	drifts, err := s.repo.FindBalanceDrift(ctx)
+	if err != nil {
+		return report, fmt.Errorf("FindBalanceDrift error: %w", err)
+	}
+
+	for _, drift := range drifts {
+		item := s.toBalanceDrift(drift)
+
+		if repair {
+			repaired, err := s.repo.RepairBalanceDrift(ctx, drift.WalletID)
+			if err != nil {
+				return report, fmt.Errorf("RepairBalanceDrift error for wallet %d: %w", drift.WalletID, err)
+			}
+
+			// The drift may have changed, or been fixed by a concurrent run, since it was found.
+			if repaired.Drift() != 0 {
+				item = s.toBalanceDrift(repaired)
+				item.Repaired = true
+			}
+		}
+
+		logrus.WithContext(ctx).WithFields(logrus.Fields{
+			"user_id":  item.UserID,
+			"currency": item.Currency,
+			"drift":    item.Drift,
+			"repaired": item.Repaired,
+		}).Warn("Balance drift detected")
+
+		report.Drifts = append(report.Drifts, item)
+	}
+
+	return report, nil
+}
+
+func (s *reconciliationService) toBalanceDrift(drift db.BalanceDrift) api.BalanceDrift {
+	return api.BalanceDrift{
+		UserID:          drift.UserID,
+		Currency:        drift.Currency,
+		Balance:         s.formatMinorUnits(drift.CachedBalance, drift.Currency),
+		ComputedBalance: s.formatMinorUnits(drift.ComputedBalance, drift.Currency),
+		Drift:           s.formatMinorUnits(drift.Drift(), drift.Currency),
+	}
+}
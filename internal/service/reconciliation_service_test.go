@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+
+	usdDrift := db.BalanceDrift{WalletID: 10, UserID: 1, Currency: "USD", CachedBalance: 10000, ComputedBalance: 9000}
+	jpyDrift := db.BalanceDrift{WalletID: 11, UserID: 2, Currency: "JPY", CachedBalance: 500, ComputedBalance: 700}
+
+	tests := []struct {
+		name           string
+		repair         bool
+		mockSetup      func(*db.MockReconciliationRepository)
+		expectedResult api.ReconciliationReport
+		expectedError  error
+	}{
+		{
+			name: "no drift",
+			mockSetup: func(mockRepo *db.MockReconciliationRepository) {
+				mockRepo.EXPECT().FindBalanceDrift(ctx).Return(nil, nil)
+			},
+			expectedResult: api.ReconciliationReport{CheckedAt: now, Drifts: []api.BalanceDrift{}},
+		},
+		{
+			name: "report only",
+			mockSetup: func(mockRepo *db.MockReconciliationRepository) {
+				mockRepo.EXPECT().FindBalanceDrift(ctx).Return([]db.BalanceDrift{usdDrift, jpyDrift}, nil)
+			},
+			expectedResult: api.ReconciliationReport{
+				CheckedAt: now,
+				Drifts: []api.BalanceDrift{
+					{UserID: 1, Currency: "USD", Balance: "100.00", ComputedBalance: "90.00", Drift: "10.00"},
+					{UserID: 2, Currency: "JPY", Balance: "500", ComputedBalance: "700", Drift: "-200"},
+				},
+			},
+		},
+		{
+			name:   "repair",
+			repair: true,
+			mockSetup: func(mockRepo *db.MockReconciliationRepository) {
+				mockRepo.EXPECT().FindBalanceDrift(ctx).Return([]db.BalanceDrift{usdDrift, jpyDrift}, nil)
+				mockRepo.EXPECT().RepairBalanceDrift(ctx, uint64(10)).Return(usdDrift, nil)
+				// Already repaired by a concurrent run.
+				mockRepo.EXPECT().RepairBalanceDrift(ctx, uint64(11)).
+					Return(db.BalanceDrift{WalletID: 11, UserID: 2, Currency: "JPY", CachedBalance: 500, ComputedBalance: 500}, nil)
+			},
+			expectedResult: api.ReconciliationReport{
+				CheckedAt: now,
+				Repair:    true,
+				Drifts: []api.BalanceDrift{
+					{UserID: 1, Currency: "USD", Balance: "100.00", ComputedBalance: "90.00", Drift: "10.00", Repaired: true},
+					{UserID: 2, Currency: "JPY", Balance: "500", ComputedBalance: "700", Drift: "-200"},
+				},
+			},
+		},
+		{
+			name: "database error",
+			mockSetup: func(mockRepo *db.MockReconciliationRepository) {
+				mockRepo.EXPECT().FindBalanceDrift(ctx).Return(nil, errors.New("database connection error"))
+			},
+			expectedError: errors.New("FindBalanceDrift error: database connection error"),
+		},
+		{
+			name:   "repair error",
+			repair: true,
+			mockSetup: func(mockRepo *db.MockReconciliationRepository) {
+				mockRepo.EXPECT().FindBalanceDrift(ctx).Return([]db.BalanceDrift{usdDrift}, nil)
+				mockRepo.EXPECT().RepairBalanceDrift(ctx, uint64(10)).Return(db.BalanceDrift{}, errors.New("deadlock"))
+			},
+			expectedError: errors.New("RepairBalanceDrift error for wallet 10: deadlock"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockReconciliationRepository(t)
+			tt.mockSetup(mockRepo)
+
+			service := newReconciliationService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil)).(*reconciliationService) //nolint: forcetypeassert // Test helper
+			service.now = func() time.Time { return now }
+
+			result, err := service.Reconcile(ctx, tt.repair)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
@@ -1,13 +1,83 @@
 package service
 
-import "github.com/TiPSYDiPSY/home-task/internal/db"
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/signing"
+)
 
 type Container struct {
-	UserService UserService
+	UserService           UserService
+	AccountService        AccountService
+	HoldService           HoldService
+	ReconciliationService ReconciliationService
+	HealthService         HealthService
+	ExportService         ExportService
+	AdjustmentService     AdjustmentService
+	WebhookService        WebhookService
+	// BalanceStreamService is only built by the server, and is nil when streams are disabled.
+	BalanceStreamService BalanceStreamService
+	Currencies           *currency.Registry
+	// SignatureVerifier authenticates the Source-Type of requests. Nil disables signing.
+	SignatureVerifier *signing.Verifier
+	// Authenticator resolves back-office principals from API keys and bearer tokens.
+	Authenticator *auth.Authenticator
 }
 
-func NewContainer(ds *db.PostgresDBDataStore) Container {
+func NewContainer(
+	ds *db.PostgresDBDataStore,
+	currencies *currency.Registry,
+	policy amount.Policy,
+	adjustments config.AdjustmentConfig,
+	webhooks config.WebhookConfig,
+) Container {
 	return Container{
-		UserService: newUserService(ds),
+		UserService:           newUserService(ds, currencies, policy),
+		AccountService:        newAccountService(ds),
+		HoldService:           newHoldService(ds, currencies, policy),
+		ReconciliationService: newReconciliationService(ds, currencies, policy),
+		HealthService:         newHealthService(ds),
+		ExportService:         newExportService(ds, currencies, policy),
+		AdjustmentService:     newAdjustmentService(ds, currencies, policy, adjustments.FourEyes),
+		WebhookService:        newWebhookService(ds, currencies, policy, webhooks),
+		Currencies:            currencies,
 	}
 }
+
+// principalOf returns the authenticated caller recorded on the transactions a request writes.
+func principalOf(ctx context.Context) string {
+	principal, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	return principal.String()
+}
+
+const tracerName = "github.com/TiPSYDiPSY/home-task/internal/service"
+
+// startSpan starts the span of a service method. The repository calls made with the returned
+// context become its children.
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// endSpan records err, if any, and ends span.
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -2,89 +2,424 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel/attribute"
 	"gorm.io/gorm"
 
 	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
 
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
 	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
 	"github.com/TiPSYDiPSY/home-task/internal/model/api"
 )
 
 type UserService interface {
-	GetBalance(ctx context.Context, userID uint64) (api.BalanceResponse, error)
+	GetBalance(ctx context.Context, userID uint64, currencyCode string) (api.BalanceResponse, error)
+	ListWallets(ctx context.Context, userID uint64) (api.WalletListResponse, error)
 	UpdateBalance(ctx context.Context, req api.TransactionRequest, UserID uint64, SourceType string) error
+	ListTransactions(ctx context.Context, userID uint64, req api.TransactionListRequest) (api.TransactionListResponse, error)
+	ReverseTransaction(
+		ctx context.Context, req api.ReversalRequest, userID uint64, originalTransactionID, sourceType string,
+	) error
+	GetTransaction(ctx context.Context, userID uint64, transactionID string) (api.TransactionDetails, error)
 }
 
 type userService struct {
-	repo                  db.UserRepository
-	centsToDollarsDecimal decimal.Decimal // Move to struct field to avoid global variable
+	moneyConverter
+
+	repo db.UserRepository
 }
 
 const (
-	CentsToDollarsMultiplier = 100
-	DecimalPlaces            = 2
+	DefaultTransactionsLimit = 20
 )
 
-func newUserService(repo db.UserRepository) UserService {
+func newUserService(repo db.UserRepository, currencies *currency.Registry, policy amount.Policy) UserService {
 	return &userService{
-		repo:                  repo,
-		centsToDollarsDecimal: decimal.NewFromInt(CentsToDollarsMultiplier),
+		moneyConverter: newMoneyConverter(currencies, policy),
+		repo:           repo,
 	}
 }
 
-func (s *userService) GetBalance(ctx context.Context, userID uint64) (api.BalanceResponse, error) {
+func (s *userService) GetBalance(
+	ctx context.Context, userID uint64, currencyCode string,
+) (_ api.BalanceResponse, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetBalance", attribute.Int64("user.id", int64(userID)))
+	defer func() { endSpan(span, err) }()
+
+	cur, err := s.resolveCurrency(currencyCode)
+	if err != nil {
+		return api.BalanceResponse{}, err
+	}
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return api.BalanceResponse{}, err
+	}
+
+	return s.toBalanceResponse(user.Wallet(cur.Code)), nil
+}
+
+func (s *userService) ListWallets(ctx context.Context, userID uint64) (_ api.WalletListResponse, err error) {
+	ctx, span := startSpan(ctx, "UserService.ListWallets", attribute.Int64("user.id", int64(userID)))
+	defer func() { endSpan(span, err) }()
+
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return api.WalletListResponse{}, err
+	}
+
+	result := api.WalletListResponse{
+		UserID:  user.ID,
+		Wallets: make([]api.BalanceResponse, 0, len(user.Wallets)),
+	}
+
+	for _, wallet := range user.Wallets {
+		result.Wallets = append(result.Wallets, s.toBalanceResponse(wallet))
+	}
+
+	return result, nil
+}
+
+func (s *userService) getUser(ctx context.Context, userID uint64) (db.User, error) {
 	user, err := s.repo.GetUserData(ctx, userID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return api.BalanceResponse{}, errs.ErrUserNotFound
+			return db.User{}, errs.ErrUserNotFound
 		}
 
-		return api.BalanceResponse{}, fmt.Errorf("GetUserData error: %w", err)
+		return db.User{}, fmt.Errorf("GetUserData error: %w", err)
 	}
 
-	centsDecimal := decimal.NewFromInt(user.Balance)
-	dollarsDecimal := centsDecimal.Div(s.centsToDollarsDecimal)
+	return user, nil
+}
 
+func (c moneyConverter) toBalanceResponse(wallet db.Wallet) api.BalanceResponse {
 	return api.BalanceResponse{
-		UserID:  user.ID,
-		Balance: dollarsDecimal.StringFixed(DecimalPlaces),
-	}, nil
+		UserID:    wallet.UserID,
+		Currency:  wallet.Currency,
+		Balance:   c.formatMinorUnits(wallet.Balance, wallet.Currency),
+		Available: c.formatMinorUnits(wallet.Available(), wallet.Currency),
+		Reserved:  c.formatMinorUnits(wallet.Reserved, wallet.Currency),
+	}
 }
 
-func (s *userService) UpdateBalance(ctx context.Context, req api.TransactionRequest, userID uint64, sourceType string) error {
-	amount, err := decimal.NewFromString(req.Amount)
+func (s *userService) UpdateBalance(
+	ctx context.Context, req api.TransactionRequest, userID uint64, sourceType string,
+) (err error) {
+	ctx, span := startSpan(ctx, "UserService.UpdateBalance",
+		attribute.Int64("user.id", int64(userID)),
+		attribute.String("source_type", sourceType),
+		attribute.String("transaction.id", req.TransactionID),
+	)
+
+	defer func() {
+		metrics.ObserveBalanceUpdate(sourceType, balanceUpdateOutcome(err))
+		endSpan(span, err)
+	}()
+
+	cur, err := s.resolveCurrency(req.Currency)
 	if err != nil {
-		return errs.ErrInvalidAmountFormat
+		return err
 	}
 
-	if req.State == "lose" {
-		amount = amount.Neg()
+	minorUnits, err := s.toTransactionMinorUnits(req.Amount, cur, sourceType)
+	if err != nil {
+		return err
 	}
 
-	amountInCents := amount.Mul(s.centsToDollarsDecimal).IntPart()
+	if req.State == "lose" {
+		minorUnits = -minorUnits
+	}
 
 	if err := s.repo.UpdateUserBalance(ctx, db.Transaction{
 		UserID:        userID,
 		State:         req.State,
 		SourceType:    sourceType,
 		TransactionID: req.TransactionID,
-		Amount:        amountInCents,
+		Amount:        minorUnits,
+		Currency:      cur.Code,
+		Principal:     principalOf(ctx),
 	}); err != nil {
 		switch {
+		case errors.Is(err, db.ErrIdempotentReplay):
+			// A retry of an already applied transaction gets the original (successful) outcome.
+			return nil
 		case errors.Is(err, db.ErrUserNotFound):
 			return errs.ErrUserNotFound
 		case errors.Is(err, db.ErrDuplicateTransaction):
 			return errs.ErrTransactionExists
 		case errors.Is(err, db.ErrInsufficientFunds):
 			return errs.ErrInsufficientFunds
+		case errors.Is(err, db.ErrAccountFrozen):
+			return errs.ErrAccountFrozen
+		case errors.Is(err, db.ErrAccountClosed):
+			return errs.ErrAccountClosed
 		default:
 			return fmt.Errorf("UpdateUserBalance error: %w", err)
 		}
 	}
 
+	metrics.ObserveTransactionAmount(sourceType, cur.Code, decimal.New(minorUnits, -cur.Exponent).InexactFloat64())
+
+	return nil
+}
+
+// balanceUpdateOutcome classifies the result of UpdateBalance for the balance update metrics.
+func balanceUpdateOutcome(err error) string {
+	switch {
+	case err == nil:
+		return metrics.OutcomeSuccess
+	case errors.Is(err, errs.ErrTransactionExists):
+		return metrics.OutcomeDuplicate
+	case errors.Is(err, errs.ErrInsufficientFunds):
+		return metrics.OutcomeInsufficientFunds
+	case errors.Is(err, errs.ErrUserNotFound):
+		return metrics.OutcomeNotFound
+	case errors.Is(err, errs.ErrAccountFrozen), errors.Is(err, errs.ErrAccountClosed):
+		return metrics.OutcomeAccountBlocked
+	case errors.Is(err, errs.ErrInvalidAmountFormat), errors.Is(err, errs.ErrAmountNotPositive),
+		errors.Is(err, errs.ErrAmountBelowMinimum), errors.Is(err, errs.ErrAmountAboveMaximum),
+		errors.Is(err, errs.ErrAmountOverflow), errors.Is(err, errs.ErrUnsupportedCurrency):
+		return metrics.OutcomeRejected
+	default:
+		return metrics.OutcomeError
+	}
+}
+
+func (s *userService) ListTransactions(
+	ctx context.Context, userID uint64, req api.TransactionListRequest,
+) (_ api.TransactionListResponse, err error) {
+	ctx, span := startSpan(ctx, "UserService.ListTransactions", attribute.Int64("user.id", int64(userID)))
+	defer func() { endSpan(span, err) }()
+
+	filter, err := s.buildTransactionFilter(req)
+	if err != nil {
+		return api.TransactionListResponse{}, err
+	}
+
+	if _, err := s.getUser(ctx, userID); err != nil {
+		return api.TransactionListResponse{}, err
+	}
+
+	pageSize := filter.Limit
+	// Fetch one extra row to find out whether there is a next page.
+	filter.Limit++
+
+	transactions, err := s.repo.ListUserTransactions(ctx, userID, filter)
+	if err != nil {
+		return api.TransactionListResponse{}, fmt.Errorf("ListUserTransactions error: %w", err)
+	}
+
+	result := api.TransactionListResponse{
+		UserID:       userID,
+		Transactions: make([]api.TransactionResponse, 0, min(len(transactions), pageSize)),
+	}
+
+	if len(transactions) > pageSize {
+		transactions = transactions[:pageSize]
+		last := transactions[len(transactions)-1]
+		result.NextCursor = encodeCursor(db.TransactionCursor{ProcessedAt: last.ProcessedAt, ID: last.ID})
+	}
+
+	for _, transaction := range transactions {
+		item := api.TransactionResponse{
+			TransactionID: transaction.TransactionID,
+			State:         transaction.State,
+			SourceType:    transaction.SourceType,
+			Amount:        s.formatMinorUnits(transaction.Amount, transaction.Currency),
+			Currency:      transaction.Currency,
+			ProcessedAt:   transaction.ProcessedAt,
+		}
+
+		if transaction.ReversalOf != nil {
+			item.ReversalOf = *transaction.ReversalOf
+		}
+
+		result.Transactions = append(result.Transactions, item)
+	}
+
+	return result, nil
+}
+
+func (s *userService) ReverseTransaction(
+	ctx context.Context, req api.ReversalRequest, userID uint64, originalTransactionID, sourceType string,
+) (err error) {
+	ctx, span := startSpan(ctx, "UserService.ReverseTransaction",
+		attribute.Int64("user.id", int64(userID)),
+		attribute.String("source_type", sourceType),
+		attribute.String("transaction.id", req.TransactionID),
+		attribute.String("transaction.reversal_of", originalTransactionID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	if err := s.repo.ReverseTransaction(ctx, db.Transaction{
+		UserID:        userID,
+		State:         db.StateReversal,
+		SourceType:    sourceType,
+		TransactionID: req.TransactionID,
+		ReversalOf:    &originalTransactionID,
+		Principal:     principalOf(ctx),
+	}); err != nil {
+		switch {
+		case errors.Is(err, db.ErrIdempotentReplay):
+			return nil
+		case errors.Is(err, db.ErrUserNotFound):
+			return errs.ErrUserNotFound
+		case errors.Is(err, db.ErrDuplicateTransaction):
+			return errs.ErrTransactionExists
+		case errors.Is(err, db.ErrInsufficientFunds):
+			return errs.ErrInsufficientFunds
+		case errors.Is(err, db.ErrAccountFrozen):
+			return errs.ErrAccountFrozen
+		case errors.Is(err, db.ErrAccountClosed):
+			return errs.ErrAccountClosed
+		case errors.Is(err, db.ErrTransactionNotFound):
+			return errs.ErrTransactionNotFound
+		case errors.Is(err, db.ErrAlreadyReversed):
+			return errs.ErrAlreadyReversed
+		case errors.Is(err, db.ErrNotReversible):
+			return errs.ErrNotReversible
+		default:
+			return fmt.Errorf("ReverseTransaction error: %w", err)
+		}
+	}
+
 	return nil
 }
+
+func (s *userService) GetTransaction(
+	ctx context.Context, userID uint64, transactionID string,
+) (_ api.TransactionDetails, err error) {
+	ctx, span := startSpan(ctx, "UserService.GetTransaction",
+		attribute.Int64("user.id", int64(userID)),
+		attribute.String("transaction.id", transactionID),
+	)
+	defer func() { endSpan(span, err) }()
+
+	transaction, err := s.repo.GetUserTransaction(ctx, userID, transactionID)
+	if err != nil {
+		if errors.Is(err, db.ErrTransactionNotFound) {
+			return api.TransactionDetails{}, errs.ErrTransactionNotFound
+		}
+
+		return api.TransactionDetails{}, fmt.Errorf("GetUserTransaction error: %w", err)
+	}
+
+	return s.toTransactionDetails(transaction), nil
+}
+
+func (c moneyConverter) toTransactionDetails(transaction db.Transaction) api.TransactionDetails {
+	details := api.TransactionDetails{
+		UserID:        transaction.UserID,
+		TransactionID: transaction.TransactionID,
+		State:         transaction.State,
+		SourceType:    transaction.SourceType,
+		Amount:        c.formatMinorUnits(transaction.Amount, transaction.Currency),
+		Currency:      transaction.Currency,
+		ProcessedAt:   transaction.ProcessedAt,
+		Principal:     transaction.Principal,
+	}
+
+	if transaction.ReversalOf != nil {
+		details.ReversalOf = *transaction.ReversalOf
+	}
+
+	if transaction.Reason != nil {
+		details.Reason = *transaction.Reason
+	}
+
+	return details
+}
+
+func (s *userService) buildTransactionFilter(req api.TransactionListRequest) (db.TransactionFilter, error) {
+	// Amount bounds are interpreted in the filtered currency, or the default one when there is none.
+	cur, err := s.resolveCurrency(req.Currency)
+	if err != nil {
+		return db.TransactionFilter{}, err
+	}
+
+	filter := db.TransactionFilter{
+		State:      req.State,
+		SourceType: req.SourceType,
+		Limit:      req.Limit,
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultTransactionsLimit
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return db.TransactionFilter{}, err
+		}
+
+		filter.After = &cursor
+	}
+
+	if req.Currency != "" {
+		filter.Currency = cur.Code
+	}
+
+	if filter.From, err = parseTime(req.From); err != nil {
+		return db.TransactionFilter{}, err
+	}
+
+	if filter.To, err = parseTime(req.To); err != nil {
+		return db.TransactionFilter{}, err
+	}
+
+	if filter.MinAmount, err = s.parseOptionalMinorUnits(req.MinAmount, cur); err != nil {
+		return db.TransactionFilter{}, err
+	}
+
+	if filter.MaxAmount, err = s.parseOptionalMinorUnits(req.MaxAmount, cur); err != nil {
+		return db.TransactionFilter{}, err
+	}
+
+	return filter, nil
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil //nolint: nilnil // Empty value means "no filter"
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, errs.ErrInvalidTimeFormat
+	}
+
+	return &parsed, nil
+}
+
+func encodeCursor(cursor db.TransactionCursor) string {
+	//nolint: errchkjson // TransactionCursor always marshals
+	raw, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(value string) (db.TransactionCursor, error) {
+	var cursor db.TransactionCursor
+
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return cursor, errs.ErrInvalidCursor
+	}
+
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return cursor, errs.ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
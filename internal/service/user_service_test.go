@@ -3,19 +3,34 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"gorm.io/gorm"
 
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
 	"github.com/TiPSYDiPSY/home-task/internal/db"
+	"github.com/TiPSYDiPSY/home-task/internal/metrics"
 	"github.com/TiPSYDiPSY/home-task/internal/model/api"
 )
 
 func TestNewUserService(t *testing.T) {
 	mockRepo := db.NewMockUserRepository(t)
-	service := newUserService(mockRepo)
+	service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
 
 	assert.NotNil(t, service)
 	assert.Implements(t, (*UserService)(nil), service)
@@ -27,6 +42,7 @@ func TestGetBalance(t *testing.T) {
 	tests := []struct {
 		name           string
 		userID         uint64
+		currency       string
 		mockSetup      func(*db.MockUserRepository)
 		expectedResult api.BalanceResponse
 		expectedError  error
@@ -35,14 +51,17 @@ func TestGetBalance(t *testing.T) {
 			name:   "successful balance retrieval",
 			userID: 1,
 			mockSetup: func(mockRepo *db.MockUserRepository) {
-				mockRepo.EXPECT().GetUserData(ctx, uint64(1)).Return(db.User{
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{
 					ID:      1,
-					Balance: 1500,
+					Wallets: []db.Wallet{{UserID: 1, Currency: "USD", Balance: 1500}},
 				}, nil)
 			},
 			expectedResult: api.BalanceResponse{
-				UserID:  1,
-				Balance: "15.00",
+				UserID:    1,
+				Currency:  "USD",
+				Balance:   "15.00",
+				Available: "15.00",
+				Reserved:  "0.00",
 			},
 			expectedError: nil,
 		},
@@ -50,7 +69,7 @@ func TestGetBalance(t *testing.T) {
 			name:   "user not found",
 			userID: 999,
 			mockSetup: func(mockRepo *db.MockUserRepository) {
-				mockRepo.EXPECT().GetUserData(ctx, uint64(999)).Return(db.User{}, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(999)).Return(db.User{}, gorm.ErrRecordNotFound)
 			},
 			expectedResult: api.BalanceResponse{},
 			expectedError:  errs.ErrUserNotFound,
@@ -59,41 +78,73 @@ func TestGetBalance(t *testing.T) {
 			name:   "database error",
 			userID: 1,
 			mockSetup: func(mockRepo *db.MockUserRepository) {
-				mockRepo.EXPECT().GetUserData(ctx, uint64(1)).Return(db.User{}, errors.New("database connection error"))
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{}, errors.New("database connection error"))
 			},
 			expectedResult: api.BalanceResponse{},
 			expectedError:  errors.New("GetUserData error: database connection error"),
 		},
 		{
-			name:   "zero balance",
+			name:   "user without wallet has zero balance",
 			userID: 2,
 			mockSetup: func(mockRepo *db.MockUserRepository) {
-				mockRepo.EXPECT().GetUserData(ctx, uint64(2)).Return(db.User{
-					ID:      2,
-					Balance: 0,
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(2)).Return(db.User{ID: 2}, nil)
+			},
+			expectedResult: api.BalanceResponse{
+				UserID:    2,
+				Currency:  "USD",
+				Balance:   "0.00",
+				Available: "0.00",
+				Reserved:  "0.00",
+			},
+			expectedError: nil,
+		},
+		{
+			name:   "balance with active holds",
+			userID: 4,
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(4)).Return(db.User{
+					ID:      4,
+					Wallets: []db.Wallet{{UserID: 4, Currency: "USD", Balance: 5000, Reserved: 1250}},
 				}, nil)
 			},
 			expectedResult: api.BalanceResponse{
-				UserID:  2,
-				Balance: "0.00",
+				UserID:    4,
+				Currency:  "USD",
+				Balance:   "50.00",
+				Available: "37.50",
+				Reserved:  "12.50",
 			},
 			expectedError: nil,
 		},
 		{
-			name:   "large balance",
-			userID: 3,
+			name:     "wallet in a zero-decimal currency",
+			userID:   3,
+			currency: "jpy",
 			mockSetup: func(mockRepo *db.MockUserRepository) {
-				mockRepo.EXPECT().GetUserData(ctx, uint64(3)).Return(db.User{
-					ID:      3,
-					Balance: 123456789,
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(3)).Return(db.User{
+					ID: 3,
+					Wallets: []db.Wallet{
+						{UserID: 3, Currency: "USD", Balance: 100},
+						{UserID: 3, Currency: "JPY", Balance: 123456789},
+					},
 				}, nil)
 			},
 			expectedResult: api.BalanceResponse{
-				UserID:  3,
-				Balance: "1234567.89",
+				UserID:    3,
+				Currency:  "JPY",
+				Balance:   "123456789",
+				Available: "123456789",
+				Reserved:  "0",
 			},
 			expectedError: nil,
 		},
+		{
+			name:          "unsupported currency",
+			userID:        1,
+			currency:      "XXX",
+			mockSetup:     func(mockRepo *db.MockUserRepository) {},
+			expectedError: errs.ErrUnsupportedCurrency,
+		},
 	}
 
 	for _, tt := range tests {
@@ -101,8 +152,8 @@ func TestGetBalance(t *testing.T) {
 			mockRepo := db.NewMockUserRepository(t)
 			tt.mockSetup(mockRepo)
 
-			service := newUserService(mockRepo)
-			result, err := service.GetBalance(ctx, tt.userID)
+			service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+			result, err := service.GetBalance(ctx, tt.userID, tt.currency)
 
 			if tt.expectedError != nil {
 				assert.Error(t, err)
@@ -115,6 +166,31 @@ func TestGetBalance(t *testing.T) {
 	}
 }
 
+func TestListWallets(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := db.NewMockUserRepository(t)
+	mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{
+		ID: 1,
+		Wallets: []db.Wallet{
+			{UserID: 1, Currency: "USD", Balance: 1050},
+			{UserID: 1, Currency: "KWD", Balance: 1234, Reserved: 1000},
+		},
+	}, nil)
+
+	service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+	result, err := service.ListWallets(ctx, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, api.WalletListResponse{
+		UserID: 1,
+		Wallets: []api.BalanceResponse{
+			{UserID: 1, Currency: "USD", Balance: "10.50", Available: "10.50", Reserved: "0.00"},
+			{UserID: 1, Currency: "KWD", Balance: "1.234", Available: "0.234", Reserved: "1.000"},
+		},
+	}, result)
+}
+
 func TestUpdateBalance(t *testing.T) {
 	ctx := context.Background()
 
@@ -140,10 +216,11 @@ func TestUpdateBalance(t *testing.T) {
 					UserID:        1,
 					State:         "win",
 					SourceType:    "game",
+					Currency:      "USD",
 					TransactionID: "txn-123",
 					Amount:        1050,
 				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -161,10 +238,11 @@ func TestUpdateBalance(t *testing.T) {
 					UserID:        2,
 					State:         "lose",
 					SourceType:    "game",
+					Currency:      "USD",
 					TransactionID: "txn-456",
 					Amount:        -525,
 				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -194,10 +272,11 @@ func TestUpdateBalance(t *testing.T) {
 					UserID:        999,
 					State:         "win",
 					SourceType:    "game",
+					Currency:      "USD",
 					TransactionID: "txn-999",
 					Amount:        1000,
 				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(db.ErrUserNotFound)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(db.ErrUserNotFound)
 			},
 			expectedError: errs.ErrUserNotFound,
 		},
@@ -215,13 +294,72 @@ func TestUpdateBalance(t *testing.T) {
 					UserID:        1,
 					State:         "win",
 					SourceType:    "game",
+					Currency:      "USD",
 					TransactionID: "txn-duplicate",
 					Amount:        1000,
 				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(db.ErrDuplicateTransaction)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(db.ErrDuplicateTransaction)
 			},
 			expectedError: errors.New("transaction already exists"),
 		},
+		{
+			name: "transaction in a three-decimal currency",
+			request: api.TransactionRequest{
+				State:         "lose",
+				Amount:        "1.234",
+				Currency:      "kwd",
+				TransactionID: "txn-kwd",
+			},
+			userID:     1,
+			sourceType: "game",
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				expectedTransaction := db.Transaction{
+					UserID:        1,
+					State:         "lose",
+					SourceType:    "game",
+					Currency:      "KWD",
+					TransactionID: "txn-kwd",
+					Amount:        -1234,
+				}
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(nil)
+			},
+			expectedError: nil,
+		},
+		{
+			name: "unsupported currency",
+			request: api.TransactionRequest{
+				State:         "win",
+				Amount:        "1",
+				Currency:      "XXX",
+				TransactionID: "txn-xxx",
+			},
+			userID:        1,
+			sourceType:    "game",
+			mockSetup:     func(mockRepo *db.MockUserRepository) {},
+			expectedError: errs.ErrUnsupportedCurrency,
+		},
+		{
+			name: "idempotent replay",
+			request: api.TransactionRequest{
+				State:         "win",
+				Amount:        "10.00",
+				TransactionID: "txn-replay",
+			},
+			userID:     1,
+			sourceType: "game",
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				expectedTransaction := db.Transaction{
+					UserID:        1,
+					State:         "win",
+					SourceType:    "game",
+					Currency:      "USD",
+					TransactionID: "txn-replay",
+					Amount:        1000,
+				}
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(db.ErrIdempotentReplay)
+			},
+			expectedError: nil,
+		},
 		{
 			name: "insufficient funds",
 			request: api.TransactionRequest{
@@ -236,10 +374,11 @@ func TestUpdateBalance(t *testing.T) {
 					UserID:        1,
 					State:         "lose",
 					SourceType:    "game",
+					Currency:      "USD",
 					TransactionID: "txn-insufficient",
 					Amount:        -10000,
 				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(db.ErrInsufficientFunds)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(db.ErrInsufficientFunds)
 			},
 			expectedError: errors.New("insufficient funds"),
 		},
@@ -257,10 +396,11 @@ func TestUpdateBalance(t *testing.T) {
 					UserID:        1,
 					State:         "win",
 					SourceType:    "game",
+					Currency:      "USD",
 					TransactionID: "txn-db-error",
 					Amount:        1000,
 				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(errors.New("database connection error"))
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(errors.New("database connection error"))
 			},
 			expectedError: errors.New("UpdateUserBalance error"),
 		},
@@ -271,19 +411,10 @@ func TestUpdateBalance(t *testing.T) {
 				Amount:        "0.00",
 				TransactionID: "txn-zero",
 			},
-			userID:     1,
-			sourceType: "game",
-			mockSetup: func(mockRepo *db.MockUserRepository) {
-				expectedTransaction := db.Transaction{
-					UserID:        1,
-					State:         "win",
-					SourceType:    "game",
-					TransactionID: "txn-zero",
-					Amount:        0,
-				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
-			},
-			expectedError: nil,
+			userID:        1,
+			sourceType:    "game",
+			mockSetup:     func(_ *db.MockUserRepository) {},
+			expectedError: errs.ErrAmountNotPositive,
 		},
 		{
 			name: "decimal amount with many places",
@@ -292,19 +423,22 @@ func TestUpdateBalance(t *testing.T) {
 				Amount:        "10.999",
 				TransactionID: "txn-decimal",
 			},
-			userID:     1,
-			sourceType: "game",
-			mockSetup: func(mockRepo *db.MockUserRepository) {
-				expectedTransaction := db.Transaction{
-					UserID:        1,
-					State:         "win",
-					SourceType:    "game",
-					TransactionID: "txn-decimal",
-					Amount:        1099,
-				}
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
+			userID:        1,
+			sourceType:    "game",
+			mockSetup:     func(_ *db.MockUserRepository) {},
+			expectedError: errs.ErrInvalidAmountFormat,
+		},
+		{
+			name: "exponent notation below the minor unit",
+			request: api.TransactionRequest{
+				State:         "win",
+				Amount:        "1e-3",
+				TransactionID: "txn-exponent",
 			},
-			expectedError: nil,
+			userID:        1,
+			sourceType:    "game",
+			mockSetup:     func(_ *db.MockUserRepository) {},
+			expectedError: errs.ErrInvalidAmountFormat,
 		},
 	}
 
@@ -313,7 +447,7 @@ func TestUpdateBalance(t *testing.T) {
 			mockRepo := db.NewMockUserRepository(t)
 			tt.mockSetup(mockRepo)
 
-			service := newUserService(mockRepo)
+			service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
 			err := service.UpdateBalance(ctx, tt.request, tt.userID, tt.sourceType)
 
 			if tt.expectedError != nil {
@@ -326,6 +460,84 @@ func TestUpdateBalance(t *testing.T) {
 	}
 }
 
+func TestUpdateBalance_RecordsPrincipal(t *testing.T) {
+	ctx := auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindAPIKey, ID: "backoffice"})
+
+	mockRepo := db.NewMockUserRepository(t)
+	mockRepo.EXPECT().UpdateUserBalance(mock.Anything, db.Transaction{
+		UserID:        1,
+		State:         "win",
+		SourceType:    "payment",
+		Currency:      "USD",
+		TransactionID: "txn-backoffice",
+		Amount:        1000,
+		Principal:     "api_key:backoffice",
+	}).Return(nil)
+
+	service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+	err := service.UpdateBalance(ctx, api.TransactionRequest{
+		State:         "win",
+		Amount:        "10.00",
+		TransactionID: "txn-backoffice",
+	}, 1, "payment")
+
+	assert.NoError(t, err)
+}
+
+func TestUpdateBalance_Span(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previousProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previousProvider)
+
+	var repoSpan trace.SpanContext
+
+	mockRepo := db.NewMockUserRepository(t)
+	mockRepo.EXPECT().UpdateUserBalance(mock.Anything, mock.Anything).
+		Run(func(ctx context.Context, _ db.Transaction) {
+			repoSpan = trace.SpanContextFromContext(ctx)
+		}).
+		Return(db.ErrInsufficientFunds)
+
+	service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+	err := service.UpdateBalance(context.Background(), api.TransactionRequest{
+		State:         "lose",
+		Amount:        "10.00",
+		TransactionID: "txn-span",
+	}, 1, "game")
+
+	assert.ErrorIs(t, err, errs.ErrInsufficientFunds)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+
+	assert.Equal(t, "UserService.UpdateBalance", spans[0].Name())
+	assert.Equal(t, spans[0].SpanContext().SpanID(), repoSpan.SpanID(), "repository calls must run inside the span")
+	assert.Contains(t, spans[0].Attributes(), attribute.String("transaction.id", "txn-span"))
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+}
+
+func TestBalanceUpdateOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: metrics.OutcomeSuccess},
+		{err: errs.ErrTransactionExists, want: metrics.OutcomeDuplicate},
+		{err: errs.ErrInsufficientFunds, want: metrics.OutcomeInsufficientFunds},
+		{err: errs.ErrUserNotFound, want: metrics.OutcomeNotFound},
+		{err: errs.ErrAccountClosed, want: metrics.OutcomeAccountBlocked},
+		{err: fmt.Errorf("%w: 10.999", errs.ErrInvalidAmountFormat), want: metrics.OutcomeRejected},
+		{err: errors.New("UpdateUserBalance error: connection reset"), want: metrics.OutcomeError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, balanceUpdateOutcome(tt.err))
+		})
+	}
+}
+
 func TestUserService_EdgeCases(t *testing.T) {
 	ctx := context.Background()
 
@@ -349,7 +561,7 @@ func TestUserService_EdgeCases(t *testing.T) {
 			},
 			expectedAmountInCents: 99999999,
 			mockSetup: func(mockRepo *db.MockUserRepository, expectedTransaction db.Transaction) {
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -362,11 +574,8 @@ func TestUserService_EdgeCases(t *testing.T) {
 				Amount:        "-10.00",
 				TransactionID: "txn-negative-win",
 			},
-			expectedAmountInCents: -1000,
-			mockSetup: func(mockRepo *db.MockUserRepository, expectedTransaction db.Transaction) {
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
-			},
-			expectedError: nil,
+			mockSetup:     func(_ *db.MockUserRepository, _ db.Transaction) {},
+			expectedError: errs.ErrAmountNotPositive,
 		},
 		{
 			name:       "amount with single decimal place",
@@ -379,7 +588,7 @@ func TestUserService_EdgeCases(t *testing.T) {
 			},
 			expectedAmountInCents: 1050,
 			mockSetup: func(mockRepo *db.MockUserRepository, expectedTransaction db.Transaction) {
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -394,7 +603,7 @@ func TestUserService_EdgeCases(t *testing.T) {
 			},
 			expectedAmountInCents: -5000,
 			mockSetup: func(mockRepo *db.MockUserRepository, expectedTransaction db.Transaction) {
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -409,7 +618,7 @@ func TestUserService_EdgeCases(t *testing.T) {
 			},
 			expectedAmountInCents: 1,
 			mockSetup: func(mockRepo *db.MockUserRepository, expectedTransaction db.Transaction) {
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
+				mockRepo.EXPECT().UpdateUserBalance(mock.Anything, expectedTransaction).Return(nil)
 			},
 			expectedError: nil,
 		},
@@ -422,23 +631,21 @@ func TestUserService_EdgeCases(t *testing.T) {
 				Amount:        "12.345",
 				TransactionID: "txn-three-decimals",
 			},
-			expectedAmountInCents: 1234,
-			mockSetup: func(mockRepo *db.MockUserRepository, expectedTransaction db.Transaction) {
-				mockRepo.EXPECT().UpdateUserBalance(ctx, expectedTransaction).Return(nil)
-			},
-			expectedError: nil,
+			mockSetup:     func(_ *db.MockUserRepository, _ db.Transaction) {},
+			expectedError: errs.ErrInvalidAmountFormat,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := db.NewMockUserRepository(t)
-			service := newUserService(mockRepo)
+			service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
 
 			expectedTransaction := db.Transaction{
 				UserID:        tt.userID,
 				State:         tt.request.State,
 				SourceType:    tt.sourceType,
+				Currency:      "USD",
 				TransactionID: tt.request.TransactionID,
 				Amount:        tt.expectedAmountInCents,
 			}
@@ -457,6 +664,58 @@ func TestUserService_EdgeCases(t *testing.T) {
 	}
 }
 
+func TestUpdateBalance_AmountPolicy(t *testing.T) {
+	ctx := context.Background()
+
+	limits, err := amount.ParseLimits("game:1:500")
+	assert.NoError(t, err)
+
+	policy := amount.NewPolicy(limits)
+
+	tests := []struct {
+		name          string
+		sourceType    string
+		request       api.TransactionRequest
+		expectedError error
+	}{
+		{
+			name:          "negative lose amount",
+			sourceType:    "game",
+			request:       api.TransactionRequest{State: "lose", Amount: "-50", TransactionID: "txn-1"},
+			expectedError: errs.ErrAmountNotPositive,
+		},
+		{
+			name:          "below source minimum",
+			sourceType:    "game",
+			request:       api.TransactionRequest{State: "win", Amount: "0.50", TransactionID: "txn-2"},
+			expectedError: errs.ErrAmountBelowMinimum,
+		},
+		{
+			name:          "above source maximum",
+			sourceType:    "game",
+			request:       api.TransactionRequest{State: "win", Amount: "500.01", TransactionID: "txn-3"},
+			expectedError: errs.ErrAmountAboveMaximum,
+		},
+		{
+			name:          "overflows int64 minor units",
+			sourceType:    "payment",
+			request:       api.TransactionRequest{State: "win", Amount: "92233720368547758.08", TransactionID: "txn-4"},
+			expectedError: errs.ErrAmountOverflow,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockUserRepository(t)
+			service := newUserService(mockRepo, currency.DefaultRegistry(), policy)
+
+			err := service.UpdateBalance(ctx, tt.request, 1, tt.sourceType)
+
+			assert.ErrorIs(t, err, tt.expectedError)
+		})
+	}
+}
+
 func TestUserService_BalanceConversion(t *testing.T) {
 	ctx := context.Background()
 
@@ -476,14 +735,14 @@ func TestUserService_BalanceConversion(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := db.NewMockUserRepository(t)
-			service := newUserService(mockRepo)
+			service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
 
-			mockRepo.EXPECT().GetUserData(ctx, uint64(1)).Return(db.User{
+			mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{
 				ID:      1,
-				Balance: tt.balanceInCents,
+				Wallets: []db.Wallet{{UserID: 1, Currency: "USD", Balance: tt.balanceInCents}},
 			}, nil)
 
-			result, err := service.GetBalance(ctx, 1)
+			result, err := service.GetBalance(ctx, 1, "")
 
 			assert.NoError(t, err)
 			assert.Equal(t, tt.expectedBalance, result.Balance)
@@ -491,3 +750,268 @@ func TestUserService_BalanceConversion(t *testing.T) {
 		})
 	}
 }
+
+func TestListTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	processedAt := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	firstID := uuid.MustParse("5b4b6a3c-7c55-4d61-9a3f-0d2b8f0c1a01")
+	secondID := uuid.MustParse("5b4b6a3c-7c55-4d61-9a3f-0d2b8f0c1a02")
+	minAmount := int64(500)
+	reversedID := "txn-1"
+
+	tests := []struct {
+		name           string
+		userID         uint64
+		request        api.TransactionListRequest
+		mockSetup      func(*db.MockUserRepository)
+		expectedResult api.TransactionListResponse
+		expectedError  error
+	}{
+		{
+			name:    "single page with default limit",
+			userID:  1,
+			request: api.TransactionListRequest{},
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{ID: 1}, nil)
+				mockRepo.EXPECT().ListUserTransactions(mock.Anything, uint64(1), db.TransactionFilter{
+					Limit: DefaultTransactionsLimit + 1,
+				}).Return([]db.Transaction{
+					{ID: firstID, TransactionID: "txn-1", State: "lose", SourceType: "game", Currency: "USD", Amount: -525, ProcessedAt: processedAt},
+				}, nil)
+			},
+			expectedResult: api.TransactionListResponse{
+				UserID: 1,
+				Transactions: []api.TransactionResponse{
+					{TransactionID: "txn-1", State: "lose", SourceType: "game", Amount: "-5.25", Currency: "USD", ProcessedAt: processedAt},
+				},
+			},
+		},
+		{
+			name:    "reversal keeps the sign of its balance change",
+			userID:  1,
+			request: api.TransactionListRequest{},
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{ID: 1}, nil)
+				mockRepo.EXPECT().ListUserTransactions(mock.Anything, uint64(1), db.TransactionFilter{
+					Limit: DefaultTransactionsLimit + 1,
+				}).Return([]db.Transaction{
+					{
+						ID: secondID, TransactionID: "rev-1", State: db.StateReversal, SourceType: "game", Currency: "USD",
+						Amount: -1050, ProcessedAt: processedAt, ReversalOf: &reversedID,
+					},
+					{ID: firstID, TransactionID: "txn-1", State: "win", SourceType: "game", Currency: "USD", Amount: 1050, ProcessedAt: processedAt},
+				}, nil)
+			},
+			expectedResult: api.TransactionListResponse{
+				UserID: 1,
+				Transactions: []api.TransactionResponse{
+					{
+						TransactionID: "rev-1", State: db.StateReversal, SourceType: "game", Amount: "-10.50", Currency: "USD",
+						ProcessedAt: processedAt, ReversalOf: "txn-1",
+					},
+					{TransactionID: "txn-1", State: "win", SourceType: "game", Amount: "10.50", Currency: "USD", ProcessedAt: processedAt},
+				},
+			},
+		},
+		{
+			name:   "next cursor is returned when more rows exist",
+			userID: 1,
+			request: api.TransactionListRequest{
+				Limit:     1,
+				State:     "win",
+				MinAmount: "5",
+			},
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{ID: 1}, nil)
+				mockRepo.EXPECT().ListUserTransactions(mock.Anything, uint64(1), db.TransactionFilter{
+					State:     "win",
+					MinAmount: &minAmount,
+					Limit:     2,
+				}).Return([]db.Transaction{
+					{ID: secondID, TransactionID: "txn-2", State: "win", SourceType: "payment", Currency: "USD", Amount: 1000, ProcessedAt: processedAt},
+					{ID: firstID, TransactionID: "txn-1", State: "win", SourceType: "game", Currency: "USD", Amount: 500, ProcessedAt: processedAt},
+				}, nil)
+			},
+			expectedResult: api.TransactionListResponse{
+				UserID: 1,
+				Transactions: []api.TransactionResponse{
+					{TransactionID: "txn-2", State: "win", SourceType: "payment", Amount: "10.00", Currency: "USD", ProcessedAt: processedAt},
+				},
+				NextCursor: encodeCursor(db.TransactionCursor{ProcessedAt: processedAt, ID: secondID}),
+			},
+		},
+		{
+			name:          "invalid cursor",
+			userID:        1,
+			request:       api.TransactionListRequest{Cursor: "not-a-cursor"},
+			mockSetup:     func(mockRepo *db.MockUserRepository) {},
+			expectedError: errs.ErrInvalidCursor,
+		},
+		{
+			name:          "invalid amount filter",
+			userID:        1,
+			request:       api.TransactionListRequest{MaxAmount: "abc"},
+			mockSetup:     func(mockRepo *db.MockUserRepository) {},
+			expectedError: errs.ErrInvalidAmountFormat,
+		},
+		{
+			name:          "invalid time filter",
+			userID:        1,
+			request:       api.TransactionListRequest{From: "yesterday"},
+			mockSetup:     func(mockRepo *db.MockUserRepository) {},
+			expectedError: errs.ErrInvalidTimeFormat,
+		},
+		{
+			name:    "user not found",
+			userID:  999,
+			request: api.TransactionListRequest{},
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(999)).Return(db.User{}, gorm.ErrRecordNotFound)
+			},
+			expectedError: errs.ErrUserNotFound,
+		},
+		{
+			name:    "database error",
+			userID:  1,
+			request: api.TransactionListRequest{},
+			mockSetup: func(mockRepo *db.MockUserRepository) {
+				mockRepo.EXPECT().GetUserData(mock.Anything, uint64(1)).Return(db.User{ID: 1}, nil)
+				mockRepo.EXPECT().ListUserTransactions(mock.Anything, uint64(1), db.TransactionFilter{
+					Limit: DefaultTransactionsLimit + 1,
+				}).Return(nil, errors.New("database connection error"))
+			},
+			expectedError: errors.New("ListUserTransactions error: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockUserRepository(t)
+			tt.mockSetup(mockRepo)
+
+			service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+			result, err := service.ListTransactions(ctx, tt.userID, tt.request)
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedResult, result)
+			}
+		})
+	}
+}
+
+func TestTransactionCursorRoundTrip(t *testing.T) {
+	cursor := db.TransactionCursor{
+		ProcessedAt: time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC),
+		ID:          uuid.MustParse("5b4b6a3c-7c55-4d61-9a3f-0d2b8f0c1a01"),
+	}
+
+	decoded, err := decodeCursor(encodeCursor(cursor))
+
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+}
+
+func TestReverseTransaction(t *testing.T) {
+	ctx := context.Background()
+	originalID := "txn-123"
+
+	expectedReversal := db.Transaction{
+		UserID:        1,
+		State:         db.StateReversal,
+		SourceType:    "game",
+		TransactionID: "rev-123",
+		ReversalOf:    &originalID,
+	}
+
+	tests := []struct {
+		name          string
+		repoErr       error
+		expectedError error
+	}{
+		{name: "successful reversal"},
+		{name: "idempotent replay", repoErr: db.ErrIdempotentReplay},
+		{name: "user not found", repoErr: db.ErrUserNotFound, expectedError: errs.ErrUserNotFound},
+		{name: "duplicate reversal ID", repoErr: db.ErrDuplicateTransaction, expectedError: errs.ErrTransactionExists},
+		{name: "insufficient funds", repoErr: db.ErrInsufficientFunds, expectedError: errs.ErrInsufficientFunds},
+		{name: "unknown original", repoErr: db.ErrTransactionNotFound, expectedError: errs.ErrTransactionNotFound},
+		{name: "double reversal", repoErr: db.ErrAlreadyReversed, expectedError: errs.ErrAlreadyReversed},
+		{name: "reversal of reversal", repoErr: db.ErrNotReversible, expectedError: errs.ErrNotReversible},
+		{
+			name:          "database error",
+			repoErr:       errors.New("database connection error"),
+			expectedError: errors.New("ReverseTransaction error: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockUserRepository(t)
+			mockRepo.EXPECT().ReverseTransaction(mock.Anything, expectedReversal).Return(tt.repoErr)
+
+			service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+			err := service.ReverseTransaction(ctx, api.ReversalRequest{TransactionID: "rev-123"}, 1, originalID, "game")
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetTransaction(t *testing.T) {
+	ctx := context.Background()
+	processedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	reason := "goodwill"
+
+	tests := []struct {
+		name            string
+		repoTransaction db.Transaction
+		repoErr         error
+		expected        api.TransactionDetails
+		expectedError   error
+	}{
+		{
+			name: "manual adjustment",
+			repoTransaction: db.Transaction{
+				UserID: 1, TransactionID: "manual-1", State: db.StateAdjustment, SourceType: "server",
+				Amount: -325, Currency: "EUR", ProcessedAt: processedAt, Principal: "operator:alice", Reason: &reason,
+			},
+			expected: api.TransactionDetails{
+				UserID: 1, TransactionID: "manual-1", State: db.StateAdjustment, SourceType: "server",
+				Amount: "-3.25", Currency: "EUR", ProcessedAt: processedAt, Principal: "operator:alice", Reason: reason,
+			},
+		},
+		{name: "not found", repoErr: db.ErrTransactionNotFound, expectedError: errs.ErrTransactionNotFound},
+		{
+			name:          "database error",
+			repoErr:       errors.New("database connection error"),
+			expectedError: errors.New("GetUserTransaction error: database connection error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := db.NewMockUserRepository(t)
+			mockRepo.EXPECT().GetUserTransaction(mock.Anything, uint64(1), "manual-1").Return(tt.repoTransaction, tt.repoErr)
+
+			service := newUserService(mockRepo, currency.DefaultRegistry(), amount.NewPolicy(nil))
+			result, err := service.GetTransaction(ctx, 1, "manual-1")
+
+			if tt.expectedError != nil {
+				assert.Error(t, err)
+				assert.Equal(t, tt.expectedError.Error(), err.Error())
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
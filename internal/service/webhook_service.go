@@ -0,0 +1,564 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+// DeliveryIDHeader identifies a webhook delivery, which keeps its ID across retries.
+const DeliveryIDHeader = "X-Webhook-Delivery-Id"
+
+const (
+	DefaultWebhookDeliveriesLimit = 20
+
+	webhookSecretPrefix = "whsec_"
+	webhookSecretBytes  = 32
+)
+
+// WebhookService manages the webhook subscriptions of partners and delivers balance change events
+// to them. As an events.EventPublisher it turns the events of the outbox relay into one delivery
+// per matching subscription, so only committed balance changes are ever delivered.
+type WebhookService interface {
+	events.EventPublisher
+
+	// CreateSubscription generates the signing secret, which is only returned here.
+	CreateSubscription(ctx context.Context, req api.WebhookSubscriptionRequest) (api.WebhookSubscriptionResponse, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (api.WebhookSubscriptionResponse, error)
+	ListSubscriptions(ctx context.Context) (api.WebhookSubscriptionListResponse, error)
+	UpdateSubscription(
+		ctx context.Context, subscriptionID string, req api.WebhookSubscriptionRequest,
+	) (api.WebhookSubscriptionResponse, error)
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+
+	ListDeliveries(ctx context.Context, req api.WebhookDeliveryListRequest) (api.WebhookDeliveryListResponse, error)
+	GetDelivery(ctx context.Context, deliveryID string) (api.WebhookDeliveryResponse, error)
+	// RedeliverDelivery queues a dead-lettered delivery again.
+	RedeliverDelivery(ctx context.Context, deliveryID string) (api.WebhookDeliveryResponse, error)
+
+	// DeliverWebhooks sends due deliveries in batches until none are left, or a batch had a failure.
+	// It returns how many were delivered, how many failed and will be retried, and how many failed
+	// for the last time and were dead-lettered.
+	DeliverWebhooks(ctx context.Context) (delivered, failed, deadLettered int, err error)
+}
+
+type webhookService struct {
+	moneyConverter
+
+	repo   db.WebhookRepository
+	config config.WebhookConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+func newWebhookService(
+	repo db.WebhookRepository, currencies *currency.Registry, policy amount.Policy, cfg config.WebhookConfig,
+) WebhookService {
+	return &webhookService{
+		moneyConverter: newMoneyConverter(currencies, policy),
+		repo:           repo,
+		config:         cfg,
+		client:         &http.Client{Timeout: cfg.Timeout},
+		now:            time.Now,
+	}
+}
+
+func (s *webhookService) CreateSubscription(
+	ctx context.Context, req api.WebhookSubscriptionRequest,
+) (_ api.WebhookSubscriptionResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.CreateSubscription")
+	defer func() { endSpan(span, err) }()
+
+	operator := principalOf(ctx)
+	if operator == "" {
+		return api.WebhookSubscriptionResponse{}, errs.ErrOperatorRequired
+	}
+
+	subscription, err := s.toSubscription(req)
+	if err != nil {
+		return api.WebhookSubscriptionResponse{}, err
+	}
+
+	if subscription.Secret, err = newWebhookSecret(); err != nil {
+		return api.WebhookSubscriptionResponse{}, err
+	}
+
+	subscription.CreatedBy = operator
+
+	created, err := s.repo.CreateWebhookSubscription(ctx, subscription)
+	if err != nil {
+		return api.WebhookSubscriptionResponse{}, mapWebhookError("CreateSubscription", err)
+	}
+
+	response := toWebhookSubscriptionResponse(created)
+	response.Secret = created.Secret
+
+	return response, nil
+}
+
+func (s *webhookService) GetSubscription(
+	ctx context.Context, subscriptionID string,
+) (api.WebhookSubscriptionResponse, error) {
+	id, err := parseWebhookID(subscriptionID, errs.ErrWebhookSubscriptionNotFound)
+	if err != nil {
+		return api.WebhookSubscriptionResponse{}, err
+	}
+
+	subscription, err := s.repo.GetWebhookSubscription(ctx, id)
+	if err != nil {
+		return api.WebhookSubscriptionResponse{}, mapWebhookError("GetSubscription", err)
+	}
+
+	return toWebhookSubscriptionResponse(subscription), nil
+}
+
+func (s *webhookService) ListSubscriptions(ctx context.Context) (api.WebhookSubscriptionListResponse, error) {
+	subscriptions, err := s.repo.ListWebhookSubscriptions(ctx, false)
+	if err != nil {
+		return api.WebhookSubscriptionListResponse{}, fmt.Errorf("ListSubscriptions error: %w", err)
+	}
+
+	response := api.WebhookSubscriptionListResponse{
+		Subscriptions: make([]api.WebhookSubscriptionResponse, 0, len(subscriptions)),
+	}
+
+	for _, subscription := range subscriptions {
+		response.Subscriptions = append(response.Subscriptions, toWebhookSubscriptionResponse(subscription))
+	}
+
+	return response, nil
+}
+
+func (s *webhookService) UpdateSubscription(
+	ctx context.Context, subscriptionID string, req api.WebhookSubscriptionRequest,
+) (_ api.WebhookSubscriptionResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.UpdateSubscription",
+		attribute.String("webhook.subscription_id", subscriptionID))
+	defer func() { endSpan(span, err) }()
+
+	id, err := parseWebhookID(subscriptionID, errs.ErrWebhookSubscriptionNotFound)
+	if err != nil {
+		return api.WebhookSubscriptionResponse{}, err
+	}
+
+	subscription, err := s.toSubscription(req)
+	if err != nil {
+		return api.WebhookSubscriptionResponse{}, err
+	}
+
+	subscription.ID = id
+
+	updated, err := s.repo.UpdateWebhookSubscription(ctx, subscription)
+	if err != nil {
+		return api.WebhookSubscriptionResponse{}, mapWebhookError("UpdateSubscription", err)
+	}
+
+	return toWebhookSubscriptionResponse(updated), nil
+}
+
+func (s *webhookService) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	id, err := parseWebhookID(subscriptionID, errs.ErrWebhookSubscriptionNotFound)
+	if err != nil {
+		return err
+	}
+
+	return mapWebhookError("DeleteSubscription", s.repo.DeleteWebhookSubscription(ctx, id))
+}
+
+func (s *webhookService) ListDeliveries(
+	ctx context.Context, req api.WebhookDeliveryListRequest,
+) (api.WebhookDeliveryListResponse, error) {
+	filter := db.WebhookDeliveryFilter{
+		Status: req.Status,
+		Limit:  req.Limit,
+	}
+
+	if filter.Limit == 0 {
+		filter.Limit = DefaultWebhookDeliveriesLimit
+	}
+
+	if req.SubscriptionID != "" {
+		id, err := parseWebhookID(req.SubscriptionID, errs.ErrWebhookSubscriptionNotFound)
+		if err != nil {
+			return api.WebhookDeliveryListResponse{}, err
+		}
+
+		filter.SubscriptionID = id
+	}
+
+	deliveries, err := s.repo.ListWebhookDeliveries(ctx, filter)
+	if err != nil {
+		return api.WebhookDeliveryListResponse{}, fmt.Errorf("ListDeliveries error: %w", err)
+	}
+
+	response := api.WebhookDeliveryListResponse{
+		Deliveries: make([]api.WebhookDeliveryResponse, 0, len(deliveries)),
+	}
+
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, toWebhookDeliveryResponse(delivery, false))
+	}
+
+	return response, nil
+}
+
+func (s *webhookService) GetDelivery(ctx context.Context, deliveryID string) (api.WebhookDeliveryResponse, error) {
+	id, err := parseWebhookID(deliveryID, errs.ErrWebhookDeliveryNotFound)
+	if err != nil {
+		return api.WebhookDeliveryResponse{}, err
+	}
+
+	delivery, err := s.repo.GetWebhookDelivery(ctx, id)
+	if err != nil {
+		return api.WebhookDeliveryResponse{}, mapWebhookError("GetDelivery", err)
+	}
+
+	return toWebhookDeliveryResponse(delivery, true), nil
+}
+
+func (s *webhookService) RedeliverDelivery(
+	ctx context.Context, deliveryID string,
+) (_ api.WebhookDeliveryResponse, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.RedeliverDelivery", attribute.String("webhook.delivery_id", deliveryID))
+	defer func() { endSpan(span, err) }()
+
+	id, err := parseWebhookID(deliveryID, errs.ErrWebhookDeliveryNotFound)
+	if err != nil {
+		return api.WebhookDeliveryResponse{}, err
+	}
+
+	delivery, err := s.repo.RedeliverWebhookDelivery(ctx, id)
+	if err != nil {
+		return api.WebhookDeliveryResponse{}, mapWebhookError("RedeliverDelivery", err)
+	}
+
+	return toWebhookDeliveryResponse(delivery, false), nil
+}
+
+// Publish queues event for every active subscription whose filters match it. Events other than
+// balance changes are ignored.
+func (s *webhookService) Publish(ctx context.Context, event events.Event) error {
+	if event.Type != db.EventTypeBalanceChanged {
+		return nil
+	}
+
+	var change api.BalanceChangedEvent
+	if err := json.Unmarshal(event.Data, &change); err != nil {
+		return fmt.Errorf("failed to decode %s event: %w", event.Type, err)
+	}
+
+	subscriptions, err := s.repo.ListWebhookSubscriptions(ctx, true)
+	if err != nil {
+		return fmt.Errorf("Publish error: %w", err)
+	}
+
+	eventID, err := uuid.Parse(event.ID)
+	if err != nil {
+		return fmt.Errorf("invalid event ID %q: %w", event.ID, err)
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	var deliveries []db.WebhookDelivery
+
+	for _, subscription := range subscriptions {
+		if !matchesSubscription(subscription, change) {
+			continue
+		}
+
+		deliveries = append(deliveries, db.WebhookDelivery{
+			SubscriptionID: subscription.ID,
+			EventID:        eventID,
+			EventType:      event.Type,
+			Payload:        string(payload),
+			Status:         db.WebhookDeliveryStatusPending,
+			NextAttemptAt:  s.now(),
+		})
+	}
+
+	if err := s.repo.EnqueueWebhookDeliveries(ctx, deliveries); err != nil {
+		return fmt.Errorf("Publish error: %w", err)
+	}
+
+	return nil
+}
+
+func (s *webhookService) DeliverWebhooks(ctx context.Context) (delivered, failed, deadLettered int, err error) {
+	ctx, span := startSpan(ctx, "WebhookService.DeliverWebhooks")
+	defer func() {
+		span.SetAttributes(
+			attribute.Int("webhook.delivered", delivered),
+			attribute.Int("webhook.failed", failed),
+			attribute.Int("webhook.dead_lettered", deadLettered),
+		)
+		endSpan(span, err)
+	}()
+
+	for {
+		batch, err := s.repo.ClaimWebhookDeliveries(ctx, s.config.BatchSize, s.config.Lease)
+		if err != nil {
+			return delivered, failed, deadLettered, fmt.Errorf("DeliverWebhooks error: %w", err)
+		}
+
+		batchFailures := 0
+
+		for _, delivery := range batch {
+			if delivery.Subscription == nil {
+				// Deleted since it was claimed, along with the delivery.
+				continue
+			}
+
+			statusCode, sendErr := s.send(ctx, delivery)
+			if sendErr == nil {
+				if err := s.repo.MarkWebhookDeliveryDelivered(ctx, delivery.ID, statusCode); err != nil {
+					return delivered, failed, deadLettered, fmt.Errorf("DeliverWebhooks error: %w", err)
+				}
+
+				delivered++
+
+				continue
+			}
+
+			batchFailures++
+
+			dead, err := s.recordFailure(ctx, delivery, statusCode, sendErr)
+			if err != nil {
+				return delivered, failed, deadLettered, fmt.Errorf("DeliverWebhooks error: %w", err)
+			}
+
+			if dead {
+				deadLettered++
+			} else {
+				failed++
+			}
+		}
+
+		// Failures are often an endpoint that is down: leave the rest for the next run.
+		if len(batch) < s.config.BatchSize || batchFailures > 0 {
+			return delivered, failed, deadLettered, nil
+		}
+	}
+}
+
+// send POSTs the stored payload, signed with the subscription secret.
+func (s *webhookService) send(ctx context.Context, delivery db.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+
+	header := make(http.Header)
+	header.Set(events.EventIDHeader, delivery.EventID.String())
+	header.Set(events.EventTypeHeader, delivery.EventType)
+	header.Set(DeliveryIDHeader, delivery.ID.String())
+	header.Set(events.SignatureHeader, events.Sign(delivery.Subscription.Secret, s.now(), body))
+
+	return events.PostJSON(ctx, s.client, delivery.Subscription.URL, body, header)
+}
+
+// recordFailure schedules the next attempt with exponential backoff, or dead-letters the delivery
+// once MaxAttempts is reached. It reports whether the delivery was dead-lettered.
+func (s *webhookService) recordFailure(
+	ctx context.Context, delivery db.WebhookDelivery, statusCode int, cause error,
+) (bool, error) {
+	log := logrus.WithContext(ctx).WithError(cause).WithFields(logrus.Fields{
+		"delivery_id":     delivery.ID,
+		"subscription_id": delivery.SubscriptionID,
+		"attempts":        delivery.Attempts,
+	})
+
+	var retryAt *time.Time
+
+	if delivery.Attempts < s.config.MaxAttempts {
+		backoff := s.config.MinBackoff
+		for attempt := 1; attempt < delivery.Attempts && backoff < s.config.MaxBackoff; attempt++ {
+			backoff *= 2
+		}
+
+		next := s.now().Add(min(backoff, s.config.MaxBackoff))
+		retryAt = &next
+
+		log.WithField("retry_at", next).Warn("Webhook delivery failed")
+	} else {
+		log.Error("Webhook delivery failed for the last time and was dead-lettered")
+	}
+
+	if err := s.repo.MarkWebhookDeliveryFailed(ctx, delivery.ID, statusCode, cause.Error(), retryAt); err != nil {
+		return false, err
+	}
+
+	return retryAt == nil, nil
+}
+
+func (s *webhookService) toSubscription(req api.WebhookSubscriptionRequest) (db.WebhookSubscription, error) {
+	if req.MinAmount != "" {
+		minAmount, err := decimal.NewFromString(req.MinAmount)
+		if err != nil || minAmount.IsNegative() {
+			return db.WebhookSubscription{}, errs.ErrInvalidAmountFormat
+		}
+	}
+
+	currencies := make([]string, 0, len(req.Currencies))
+	for _, code := range req.Currencies {
+		cur, err := s.resolveCurrency(code)
+		if err != nil {
+			return db.WebhookSubscription{}, err
+		}
+
+		currencies = append(currencies, cur.Code)
+	}
+
+	active := true
+	if req.Active != nil {
+		active = *req.Active
+	}
+
+	return db.WebhookSubscription{
+		URL:         req.URL,
+		Description: req.Description,
+		SourceTypes: strings.Join(req.SourceTypes, ","),
+		States:      strings.Join(req.States, ","),
+		Currencies:  strings.Join(currencies, ","),
+		MinAmount:   req.MinAmount,
+		Active:      active,
+	}, nil
+}
+
+func matchesSubscription(subscription db.WebhookSubscription, change api.BalanceChangedEvent) bool {
+	if !filterMatches(subscription.SourceTypes, change.SourceType) ||
+		!filterMatches(subscription.States, change.State) ||
+		!filterMatches(subscription.Currencies, change.Currency) {
+		return false
+	}
+
+	if subscription.MinAmount == "" {
+		return true
+	}
+
+	minAmount, err := decimal.NewFromString(subscription.MinAmount)
+	if err != nil {
+		return false
+	}
+
+	changed, err := decimal.NewFromString(change.Amount)
+	if err != nil {
+		return false
+	}
+
+	return changed.Abs().GreaterThanOrEqual(minAmount)
+}
+
+// filterMatches reports whether value is in the comma-separated filter. An empty filter matches anything.
+func filterMatches(filter, value string) bool {
+	return filter == "" || slices.Contains(strings.Split(filter, ","), value)
+}
+
+func splitFilter(filter string) []string {
+	if filter == "" {
+		return []string{}
+	}
+
+	return strings.Split(filter, ",")
+}
+
+func newWebhookSecret() (string, error) {
+	secret := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	return webhookSecretPrefix + hex.EncodeToString(secret), nil
+}
+
+// parseWebhookID maps a malformed ID to notFound: no subscription or delivery can have it.
+func parseWebhookID(id string, notFound error) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, notFound
+	}
+
+	return parsed, nil
+}
+
+func mapWebhookError(operation string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, db.ErrWebhookSubscriptionNotFound):
+		return errs.ErrWebhookSubscriptionNotFound
+	case errors.Is(err, db.ErrWebhookDeliveryNotFound):
+		return errs.ErrWebhookDeliveryNotFound
+	case errors.Is(err, db.ErrWebhookDeliveryNotDead):
+		return errs.ErrWebhookDeliveryNotDead
+	default:
+		return fmt.Errorf("%s error: %w", operation, err)
+	}
+}
+
+func toWebhookSubscriptionResponse(subscription db.WebhookSubscription) api.WebhookSubscriptionResponse {
+	return api.WebhookSubscriptionResponse{
+		SubscriptionID: subscription.ID.String(),
+		URL:            subscription.URL,
+		Description:    subscription.Description,
+		SourceTypes:    splitFilter(subscription.SourceTypes),
+		States:         splitFilter(subscription.States),
+		Currencies:     splitFilter(subscription.Currencies),
+		MinAmount:      subscription.MinAmount,
+		Active:         subscription.Active,
+		CreatedBy:      subscription.CreatedBy,
+		CreatedAt:      subscription.CreatedAt,
+		UpdatedAt:      subscription.UpdatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery db.WebhookDelivery, withPayload bool) api.WebhookDeliveryResponse {
+	response := api.WebhookDeliveryResponse{
+		DeliveryID:     delivery.ID.String(),
+		SubscriptionID: delivery.SubscriptionID.String(),
+		EventID:        delivery.EventID.String(),
+		EventType:      delivery.EventType,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		DeliveredAt:    delivery.DeliveredAt,
+		CreatedAt:      delivery.CreatedAt,
+	}
+
+	if delivery.LastStatusCode != nil {
+		response.LastStatusCode = *delivery.LastStatusCode
+	}
+
+	if delivery.LastError != nil {
+		response.LastError = *delivery.LastError
+	}
+
+	if delivery.Status == db.WebhookDeliveryStatusPending {
+		nextAttemptAt := delivery.NextAttemptAt
+		response.NextAttemptAt = &nextAttemptAt
+	}
+
+	if withPayload {
+		response.Payload = json.RawMessage(delivery.Payload)
+	}
+
+	return response
+}
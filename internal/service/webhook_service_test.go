@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/amount"
+	"github.com/TiPSYDiPSY/home-task/internal/auth"
+	"github.com/TiPSYDiPSY/home-task/internal/config"
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	"github.com/TiPSYDiPSY/home-task/internal/db"
+	errs "github.com/TiPSYDiPSY/home-task/internal/errors"
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	"github.com/TiPSYDiPSY/home-task/internal/model/api"
+)
+
+var testWebhookConfig = config.WebhookConfig{
+	BatchSize:   10,
+	Timeout:     time.Second,
+	Lease:       time.Minute,
+	MaxAttempts: 3,
+	MinBackoff:  10 * time.Second,
+	MaxBackoff:  time.Minute,
+}
+
+func newTestWebhookService(repo db.WebhookRepository, now time.Time) *webhookService {
+	service := newWebhookService( //nolint: forcetypeassert // Test helper
+		repo, currency.DefaultRegistry(), amount.NewPolicy(nil), testWebhookConfig,
+	).(*webhookService)
+
+	service.now = func() time.Time { return now }
+
+	return service
+}
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	operatorCtx := auth.WithPrincipal(context.Background(), auth.Principal{Kind: auth.KindJWT, ID: "alice"})
+	request := api.WebhookSubscriptionRequest{
+		URL:         "https://partner.example.com/hooks",
+		SourceTypes: []string{"game", "payment"},
+		Currencies:  []string{"usd"},
+		MinAmount:   "100",
+	}
+
+	t.Run("generates the secret and records the operator", func(t *testing.T) {
+		mockRepo := db.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().CreateWebhookSubscription(mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, subscription db.WebhookSubscription) (db.WebhookSubscription, error) {
+				subscription.ID = uuid.New()
+
+				return subscription, nil
+			}).Once()
+
+		created, err := newTestWebhookService(mockRepo, time.Now()).CreateSubscription(operatorCtx, request)
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(created.Secret, "whsec_"))
+		assert.Len(t, created.Secret, len("whsec_")+2*webhookSecretBytes)
+		assert.Equal(t, "jwt:alice", created.CreatedBy)
+		assert.Equal(t, []string{"game", "payment"}, created.SourceTypes)
+		assert.Equal(t, []string{}, created.States)
+		assert.Equal(t, []string{"USD"}, created.Currencies)
+		assert.True(t, created.Active)
+	})
+
+	tests := []struct {
+		name        string
+		ctx         context.Context //nolint: containedctx // Test case input
+		request     api.WebhookSubscriptionRequest
+		expectedErr error
+	}{
+		{
+			name:        "no operator",
+			ctx:         context.Background(),
+			request:     request,
+			expectedErr: errs.ErrOperatorRequired,
+		},
+		{
+			name:        "negative minimum amount",
+			ctx:         operatorCtx,
+			request:     api.WebhookSubscriptionRequest{URL: request.URL, MinAmount: "-1"},
+			expectedErr: errs.ErrInvalidAmountFormat,
+		},
+		{
+			name:        "unknown currency",
+			ctx:         operatorCtx,
+			request:     api.WebhookSubscriptionRequest{URL: request.URL, Currencies: []string{"XYZ"}},
+			expectedErr: errs.ErrUnsupportedCurrency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newTestWebhookService(db.NewMockWebhookRepository(t), time.Now()).
+				CreateSubscription(tt.ctx, tt.request)
+
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestWebhookPublish(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	all := db.WebhookSubscription{ID: uuid.New(), Active: true}
+	wins := db.WebhookSubscription{ID: uuid.New(), States: "win,adjustment", Active: true}
+	payments := db.WebhookSubscription{ID: uuid.New(), SourceTypes: "payment", Active: true}
+	euros := db.WebhookSubscription{ID: uuid.New(), Currencies: "EUR", Active: true}
+	large := db.WebhookSubscription{ID: uuid.New(), MinAmount: "10.00", Active: true}
+	small := db.WebhookSubscription{ID: uuid.New(), MinAmount: "10.50", Active: true}
+
+	event := events.Event{
+		ID:   uuid.NewString(),
+		Type: db.EventTypeBalanceChanged,
+		Key:  "1",
+		Data: json.RawMessage(`{"userId":1,"transactionId":"txn-1","state":"lose","sourceType":"game",` +
+			`"amount":"-10.50","currency":"USD","balance":"39.50","available":"39.50","reserved":"0.00"}`),
+	}
+
+	mockRepo := db.NewMockWebhookRepository(t)
+	mockRepo.EXPECT().ListWebhookSubscriptions(mock.Anything, true).
+		Return([]db.WebhookSubscription{all, wins, payments, euros, large, small}, nil).Once()
+	mockRepo.EXPECT().EnqueueWebhookDeliveries(mock.Anything, mock.MatchedBy(func(deliveries []db.WebhookDelivery) bool {
+		if len(deliveries) != 3 {
+			return false
+		}
+
+		for i, subscription := range []db.WebhookSubscription{all, large, small} {
+			if deliveries[i].SubscriptionID != subscription.ID || deliveries[i].EventID.String() != event.ID ||
+				deliveries[i].Status != db.WebhookDeliveryStatusPending || !deliveries[i].NextAttemptAt.Equal(now) {
+				return false
+			}
+		}
+
+		var payload events.Event
+
+		return json.Unmarshal([]byte(deliveries[0].Payload), &payload) == nil && payload.ID == event.ID
+	})).Return(nil).Once()
+
+	require.NoError(t, newTestWebhookService(mockRepo, now).Publish(context.Background(), event))
+
+	// Other event types are not delivered to webhooks.
+	require.NoError(t, newTestWebhookService(mockRepo, now).
+		Publish(context.Background(), events.Event{Type: "user.created"}))
+}
+
+func TestDeliverWebhooks(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	var received []*http.Request
+
+	var status int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	subscription := &db.WebhookSubscription{ID: uuid.New(), URL: server.URL, Secret: "whsec_test", Active: true}
+
+	newDelivery := func(attempts int) db.WebhookDelivery {
+		return db.WebhookDelivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			Subscription:   subscription,
+			EventID:        uuid.New(),
+			EventType:      db.EventTypeBalanceChanged,
+			Payload:        `{"id":"1"}`,
+			Attempts:       attempts,
+		}
+	}
+
+	t.Run("delivered with a valid signature", func(t *testing.T) {
+		status = http.StatusNoContent
+		received = nil
+		delivery := newDelivery(1)
+
+		mockRepo := db.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().ClaimWebhookDeliveries(mock.Anything, 10, time.Minute).
+			Return([]db.WebhookDelivery{delivery}, nil).Once()
+		mockRepo.EXPECT().MarkWebhookDeliveryDelivered(mock.Anything, delivery.ID, http.StatusNoContent).
+			Return(nil).Once()
+
+		delivered, failed, dead, err := newTestWebhookService(mockRepo, now).DeliverWebhooks(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 0, 0}, []int{delivered, failed, dead})
+
+		require.Len(t, received, 1)
+		assert.Equal(t, delivery.EventID.String(), received[0].Header.Get(events.EventIDHeader))
+		assert.Equal(t, delivery.ID.String(), received[0].Header.Get(DeliveryIDHeader))
+		assert.NoError(t, events.VerifySignature("whsec_test", received[0].Header.Get(events.SignatureHeader),
+			[]byte(delivery.Payload), now, time.Minute))
+	})
+
+	t.Run("failure backs off exponentially", func(t *testing.T) {
+		status = http.StatusServiceUnavailable
+		delivery := newDelivery(2)
+
+		mockRepo := db.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().ClaimWebhookDeliveries(mock.Anything, 10, time.Minute).
+			Return([]db.WebhookDelivery{delivery}, nil).Once()
+		mockRepo.EXPECT().MarkWebhookDeliveryFailed(mock.Anything, delivery.ID, http.StatusServiceUnavailable,
+			mock.Anything, mock.MatchedBy(func(retryAt *time.Time) bool {
+				return retryAt != nil && retryAt.Equal(now.Add(20*time.Second))
+			})).Return(nil).Once()
+
+		delivered, failed, dead, err := newTestWebhookService(mockRepo, now).DeliverWebhooks(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 1, 0}, []int{delivered, failed, dead})
+	})
+
+	t.Run("last attempt is dead-lettered", func(t *testing.T) {
+		status = http.StatusInternalServerError
+		delivery := newDelivery(3)
+
+		mockRepo := db.NewMockWebhookRepository(t)
+		mockRepo.EXPECT().ClaimWebhookDeliveries(mock.Anything, 10, time.Minute).
+			Return([]db.WebhookDelivery{delivery}, nil).Once()
+		mockRepo.EXPECT().MarkWebhookDeliveryFailed(mock.Anything, delivery.ID, http.StatusInternalServerError,
+			mock.Anything, (*time.Time)(nil)).Return(nil).Once()
+
+		delivered, failed, dead, err := newTestWebhookService(mockRepo, now).DeliverWebhooks(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []int{0, 0, 1}, []int{delivered, failed, dead})
+	})
+}
+
+func TestWebhookService_MalformedIDs(t *testing.T) {
+	service := newTestWebhookService(db.NewMockWebhookRepository(t), time.Now())
+
+	_, err := service.GetSubscription(context.Background(), "not-a-uuid")
+	require.ErrorIs(t, err, errs.ErrWebhookSubscriptionNotFound)
+
+	_, err = service.RedeliverDelivery(context.Background(), "not-a-uuid")
+	require.ErrorIs(t, err, errs.ErrWebhookDeliveryNotFound)
+}
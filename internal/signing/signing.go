@@ -0,0 +1,132 @@
+package signing
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A request is signed with HMAC-SHA256 over its method, path, timestamp and body, joined by newlines:
+//
+//	POST\n/user/1/transaction\n1755518400\n{"state":"win",...}
+//
+// The hex-encoded result goes into the SignatureHeader and the Unix timestamp into the TimestampHeader.
+const (
+	SignatureHeader = "X-Signature"
+	TimestampHeader = "X-Signature-Timestamp"
+
+	DefaultMaxSkew = 5 * time.Minute
+
+	decimalBase = 10
+	bitSize     = 64
+)
+
+var (
+	ErrInvalidSecret    = errors.New("invalid signing secret")
+	ErrUnknownSource    = errors.New("no signing secret for source")
+	ErrInvalidTimestamp = errors.New("invalid signature timestamp")
+	ErrStaleTimestamp   = errors.New("signature timestamp is outside the allowed window")
+	ErrInvalidSignature = errors.New("invalid signature")
+)
+
+// Verifier checks request signatures against the secrets of each source. A source may have several
+// secrets at once, so a secret can be rotated by adding the new one, moving clients over and then
+// removing the old one.
+type Verifier struct {
+	secrets map[string][][]byte
+	maxSkew time.Duration
+	now     func() time.Time
+}
+
+// NewVerifier builds a verifier from secrets keyed by source. Requests whose timestamp is more than
+// maxSkew away from the current time are rejected; a non-positive maxSkew uses DefaultMaxSkew.
+func NewVerifier(secrets map[string][]string, maxSkew time.Duration) *Verifier {
+	if maxSkew <= 0 {
+		maxSkew = DefaultMaxSkew
+	}
+
+	v := &Verifier{
+		secrets: make(map[string][][]byte, len(secrets)),
+		maxSkew: maxSkew,
+		now:     time.Now,
+	}
+
+	for source, sourceSecrets := range secrets {
+		key := strings.ToLower(source)
+		for _, secret := range sourceSecrets {
+			v.secrets[key] = append(v.secrets[key], []byte(secret))
+		}
+	}
+
+	return v
+}
+
+// Sign returns the hex-encoded signature of a request.
+func Sign(secret []byte, method, path, timestamp string, body []byte) string {
+	return hex.EncodeToString(sum(secret, method, path, timestamp, body))
+}
+
+func sum(secret []byte, method, path, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(method + "\n" + path + "\n" + timestamp + "\n"))
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
+
+// Verify checks that signature was produced by one of the secrets of source for the given request.
+func (v *Verifier) Verify(source, method, path, timestamp string, body []byte, signature string) error {
+	secrets := v.secrets[strings.ToLower(source)]
+	if len(secrets) == 0 {
+		return fmt.Errorf("%w %q", ErrUnknownSource, source)
+	}
+
+	unix, err := strconv.ParseInt(timestamp, decimalBase, bitSize)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+
+	if skew := v.now().Sub(time.Unix(unix, 0)).Abs(); skew > v.maxSkew {
+		return ErrStaleTimestamp
+	}
+
+	given, err := hex.DecodeString(signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	for _, secret := range secrets {
+		if hmac.Equal(given, sum(secret, method, path, timestamp, body)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
+
+// ParseSecrets parses "SOURCE:SECRET" pairs, e.g. "game:s3cr3t,game:n3w-s3cr3t,payment:p4y". Repeating
+// a source gives it several valid secrets. Secrets may contain colons but not commas.
+func ParseSecrets(value string) (map[string][]string, error) {
+	secrets := make(map[string][]string)
+
+	if strings.TrimSpace(value) == "" {
+		return secrets, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		source, secret, found := strings.Cut(strings.TrimSpace(item), ":")
+		if !found || source == "" || secret == "" {
+			return nil, fmt.Errorf("%w for %q", ErrInvalidSecret, source)
+		}
+
+		source = strings.ToLower(source)
+		secrets[source] = append(secrets[source], secret)
+	}
+
+	return secrets, nil
+}
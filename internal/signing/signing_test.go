@@ -0,0 +1,114 @@
+package signing
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerify(t *testing.T) {
+	now := time.Date(2025, 8, 18, 12, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte(`{"state":"win","amount":"10.00","transactionId":"txn-1"}`)
+
+	verifier := NewVerifier(map[string][]string{
+		"game":    {"old-secret", "new-secret"},
+		"PAYMENT": {"payment-secret"},
+	}, time.Minute)
+	verifier.now = func() time.Time { return now }
+
+	sign := func(secret string) string {
+		return Sign([]byte(secret), "POST", "/user/1/transaction", timestamp, body)
+	}
+
+	tests := []struct {
+		name      string
+		source    string
+		path      string
+		timestamp string
+		body      []byte
+		signature string
+		wantErr   error
+	}{
+		{name: "current secret", source: "game", signature: sign("new-secret")},
+		{name: "previous secret during rotation", source: "game", signature: sign("old-secret")},
+		{name: "source is case insensitive", source: "Payment", signature: sign("payment-secret")},
+		{name: "secret of another source", source: "payment", signature: sign("new-secret"), wantErr: ErrInvalidSignature},
+		{name: "source without secrets", source: "server", signature: sign("new-secret"), wantErr: ErrUnknownSource},
+		{name: "tampered body", source: "game", body: []byte(`{"amount":"1000.00"}`), signature: sign("new-secret"), wantErr: ErrInvalidSignature},
+		{name: "different path", source: "game", path: "/user/2/transaction", signature: sign("new-secret"), wantErr: ErrInvalidSignature},
+		{name: "signature is not hex", source: "game", signature: "not-hex", wantErr: ErrInvalidSignature},
+		{name: "timestamp is not a number", source: "game", timestamp: "yesterday", signature: sign("new-secret"), wantErr: ErrInvalidTimestamp},
+		{
+			name:      "stale timestamp",
+			source:    "game",
+			timestamp: strconv.FormatInt(now.Add(-2*time.Minute).Unix(), 10),
+			signature: sign("new-secret"),
+			wantErr:   ErrStaleTimestamp,
+		},
+		{
+			name:      "timestamp too far in the future",
+			source:    "game",
+			timestamp: strconv.FormatInt(now.Add(2*time.Minute).Unix(), 10),
+			signature: sign("new-secret"),
+			wantErr:   ErrStaleTimestamp,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path, ts, requestBody := "/user/1/transaction", timestamp, body
+			if tt.path != "" {
+				path = tt.path
+			}
+
+			if tt.timestamp != "" {
+				ts = tt.timestamp
+			}
+
+			if tt.body != nil {
+				requestBody = tt.body
+			}
+
+			err := verifier.Verify(tt.source, "POST", path, ts, requestBody, tt.signature)
+
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestParseSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    map[string][]string
+		wantErr bool
+	}{
+		{name: "empty", value: "", want: map[string][]string{}},
+		{
+			name:  "several sources and rotated secrets",
+			value: "game:a, GAME:b,payment:c:d",
+			want:  map[string][]string{"game": {"a", "b"}, "payment": {"c:d"}},
+		},
+		{name: "missing secret", value: "game:", wantErr: true},
+		{name: "missing source", value: ":secret", wantErr: true},
+		{name: "no separator", value: "game", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSecrets(tt.value)
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSecret)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
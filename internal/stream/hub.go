@@ -0,0 +1,162 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+)
+
+const (
+	// DefaultBuffer is how many events a subscriber may fall behind before it is dropped.
+	DefaultBuffer = 64
+
+	reconnectDelay = time.Second
+)
+
+var ErrHubClosed = errors.New("balance streams are shutting down")
+
+// Hub hands the events of a Notifier to the subscriptions of this replica by event key.
+//
+// Subscriptions never block the hub: one that falls behind by more than its buffer is dropped, as
+// are all of them when the notifier loses its connection, since events published meanwhile are
+// missed. Either way the subscriber should reconnect and resume from the last event it received.
+type Hub struct {
+	notifier Notifier
+	buffer   int
+
+	mu            sync.Mutex
+	subscriptions map[string]map[*Subscription]struct{}
+	closed        bool
+}
+
+// Subscription receives the events of one key until its channel is closed.
+type Subscription struct {
+	hub    *Hub
+	key    string
+	events chan events.Event
+}
+
+func NewHub(notifier Notifier, buffer int) *Hub {
+	if buffer <= 0 {
+		buffer = DefaultBuffer
+	}
+
+	return &Hub{
+		notifier:      notifier,
+		buffer:        buffer,
+		subscriptions: make(map[string]map[*Subscription]struct{}),
+	}
+}
+
+// Run listens to the notifier, reconnecting whenever it fails, and blocks until ctx is cancelled.
+func (h *Hub) Run(ctx context.Context) {
+	for {
+		err := h.notifier.Listen(ctx, h.dispatch)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logrus.WithContext(ctx).WithError(err).Warn("Balance stream notifier failed, dropping open streams")
+		h.dropAll()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+func (h *Hub) Subscribe(key string) (*Subscription, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrHubClosed
+	}
+
+	subscription := &Subscription{
+		hub:    h,
+		key:    key,
+		events: make(chan events.Event, h.buffer),
+	}
+
+	if h.subscriptions[key] == nil {
+		h.subscriptions[key] = make(map[*Subscription]struct{})
+	}
+
+	h.subscriptions[key][subscription] = struct{}{}
+
+	return subscription, nil
+}
+
+// Shutdown closes every subscription and refuses new ones, so open streams end before the server
+// stops.
+func (h *Hub) Shutdown() {
+	h.mu.Lock()
+	h.closed = true
+	h.mu.Unlock()
+
+	h.dropAll()
+}
+
+func (h *Hub) dispatch(event events.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for subscription := range h.subscriptions[event.Key] {
+		select {
+		case subscription.events <- event:
+		default:
+			h.remove(subscription)
+		}
+	}
+}
+
+func (h *Hub) dropAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, subscriptions := range h.subscriptions {
+		for subscription := range subscriptions {
+			h.remove(subscription)
+		}
+	}
+}
+
+// remove must be called with h.mu held.
+func (h *Hub) remove(subscription *Subscription) {
+	subscriptions, ok := h.subscriptions[subscription.key]
+	if !ok {
+		return
+	}
+
+	if _, ok := subscriptions[subscription]; !ok {
+		return
+	}
+
+	delete(subscriptions, subscription)
+
+	if len(subscriptions) == 0 {
+		delete(h.subscriptions, subscription.key)
+	}
+
+	close(subscription.events)
+}
+
+// Events is closed when the subscription is dropped or closed.
+func (s *Subscription) Events() <-chan events.Event {
+	return s.events
+}
+
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+
+	s.hub.remove(s)
+}
@@ -0,0 +1,116 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+)
+
+func TestHubDispatch(t *testing.T) {
+	hub := NewHub(NewLocalNotifier(), 1)
+
+	first, err := hub.Subscribe("1")
+	require.NoError(t, err)
+
+	second, err := hub.Subscribe("2")
+	require.NoError(t, err)
+
+	hub.dispatch(events.Event{Key: "1", Sequence: 1})
+	hub.dispatch(events.Event{Key: "3", Sequence: 2})
+
+	assert.Equal(t, uint64(1), (<-first.Events()).Sequence)
+	assert.Empty(t, second.Events())
+
+	// A second event overflows the buffer of the first subscriber, which is dropped.
+	hub.dispatch(events.Event{Key: "1", Sequence: 3})
+	hub.dispatch(events.Event{Key: "1", Sequence: 4})
+
+	assert.Equal(t, uint64(3), (<-first.Events()).Sequence)
+
+	_, open := <-first.Events()
+	assert.False(t, open)
+
+	second.Close()
+	second.Close()
+
+	_, open = <-second.Events()
+	assert.False(t, open)
+	assert.Empty(t, hub.subscriptions)
+}
+
+func TestHubShutdown(t *testing.T) {
+	hub := NewHub(NewLocalNotifier(), 0)
+
+	subscription, err := hub.Subscribe("1")
+	require.NoError(t, err)
+
+	hub.Shutdown()
+
+	_, open := <-subscription.Events()
+	assert.False(t, open)
+
+	_, err = hub.Subscribe("1")
+	require.ErrorIs(t, err, ErrHubClosed)
+}
+
+func TestHubRun(t *testing.T) {
+	t.Run("relays the events of the notifier", func(t *testing.T) {
+		notifier := NewLocalNotifier()
+		hub := NewHub(notifier, 0)
+
+		subscription, err := hub.Subscribe("1")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan struct{})
+
+		go func() {
+			defer close(done)
+
+			hub.Run(ctx)
+		}()
+
+		// The hub listens asynchronously, so publish until it has.
+		assert.Eventually(t, func() bool {
+			require.NoError(t, notifier.Publish(ctx, events.Event{Key: "1", Sequence: 1}))
+
+			return len(subscription.Events()) > 0
+		}, time.Second, time.Millisecond)
+
+		cancel()
+		<-done
+	})
+
+	t.Run("drops every subscription when the notifier fails", func(t *testing.T) {
+		notifier := NewMockNotifier(t)
+		hub := NewHub(notifier, 0)
+
+		subscription, err := hub.Subscribe("1")
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		done := make(chan struct{})
+
+		notifier.EXPECT().Listen(mock.Anything, mock.Anything).Return(errors.New("connection lost")).Once()
+
+		go func() {
+			defer close(done)
+
+			hub.Run(ctx)
+		}()
+
+		_, open := <-subscription.Events()
+		assert.False(t, open)
+
+		// The hub waits before it listens again, which cancelling interrupts.
+		cancel()
+		<-done
+	})
+}
@@ -0,0 +1,153 @@
+// Code generated by mockery; DO NOT EDIT.
+// github.com/vektra/mockery
+// template: testify
+
+package stream
+
+import (
+	"context"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// NewMockNotifier creates a new instance of MockNotifier. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockNotifier(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockNotifier {
+	mock := &MockNotifier{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockNotifier is an autogenerated mock type for the Notifier type
+type MockNotifier struct {
+	mock.Mock
+}
+
+type MockNotifier_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockNotifier) EXPECT() *MockNotifier_Expecter {
+	return &MockNotifier_Expecter{mock: &_m.Mock}
+}
+
+// Listen provides a mock function for the type MockNotifier
+func (_mock *MockNotifier) Listen(ctx context.Context, handle func(events.Event)) error {
+	ret := _mock.Called(ctx, handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Listen")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, func(events.Event)) error); ok {
+		r0 = returnFunc(ctx, handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotifier_Listen_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Listen'
+type MockNotifier_Listen_Call struct {
+	*mock.Call
+}
+
+// Listen is a helper method to define mock.On call
+//   - ctx context.Context
+//   - handle func(events.Event)
+func (_e *MockNotifier_Expecter) Listen(ctx interface{}, handle interface{}) *MockNotifier_Listen_Call {
+	return &MockNotifier_Listen_Call{Call: _e.mock.On("Listen", ctx, handle)}
+}
+
+func (_c *MockNotifier_Listen_Call) Run(run func(ctx context.Context, handle func(events.Event))) *MockNotifier_Listen_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 func(events.Event)
+		if args[1] != nil {
+			arg1 = args[1].(func(events.Event))
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifier_Listen_Call) Return(err error) *MockNotifier_Listen_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotifier_Listen_Call) RunAndReturn(run func(ctx context.Context, handle func(events.Event)) error) *MockNotifier_Listen_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Publish provides a mock function for the type MockNotifier
+func (_mock *MockNotifier) Publish(ctx context.Context, event events.Event) error {
+	ret := _mock.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, events.Event) error); ok {
+		r0 = returnFunc(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockNotifier_Publish_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Publish'
+type MockNotifier_Publish_Call struct {
+	*mock.Call
+}
+
+// Publish is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event events.Event
+func (_e *MockNotifier_Expecter) Publish(ctx interface{}, event interface{}) *MockNotifier_Publish_Call {
+	return &MockNotifier_Publish_Call{Call: _e.mock.On("Publish", ctx, event)}
+}
+
+func (_c *MockNotifier_Publish_Call) Run(run func(ctx context.Context, event events.Event)) *MockNotifier_Publish_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 events.Event
+		if args[1] != nil {
+			arg1 = args[1].(events.Event)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockNotifier_Publish_Call) Return(err error) *MockNotifier_Publish_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockNotifier_Publish_Call) RunAndReturn(run func(ctx context.Context, event events.Event) error) *MockNotifier_Publish_Call {
+	_c.Call.Return(run)
+	return _c
+}
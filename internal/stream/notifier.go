@@ -0,0 +1,58 @@
+// Package stream fans balance change events out to the balance streams open on every replica.
+package stream
+
+import (
+	"context"
+	"sync"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+)
+
+// Notifier carries events from the replica that relays them out of the outbox to every replica.
+// The outbox relay publishes to it, and the Hub of each replica listens.
+type Notifier interface {
+	events.EventPublisher
+	// Listen passes the events published on any replica to handle, one at a time, until ctx is
+	// cancelled or the notifier loses its connection, which is reported as an error.
+	Listen(ctx context.Context, handle func(events.Event)) error
+}
+
+// LocalNotifier only reaches the process it runs in, so it suits a single replica.
+type LocalNotifier struct {
+	mu       sync.RWMutex
+	handlers map[int]func(events.Event)
+	nextID   int
+}
+
+func NewLocalNotifier() *LocalNotifier {
+	return &LocalNotifier{
+		handlers: make(map[int]func(events.Event)),
+	}
+}
+
+func (n *LocalNotifier) Publish(_ context.Context, event events.Event) error {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	for _, handle := range n.handlers {
+		handle(event)
+	}
+
+	return nil
+}
+
+func (n *LocalNotifier) Listen(ctx context.Context, handle func(events.Event)) error {
+	n.mu.Lock()
+	id := n.nextID
+	n.nextID++
+	n.handlers[id] = handle
+	n.mu.Unlock()
+
+	<-ctx.Done()
+
+	n.mu.Lock()
+	delete(n.handlers, id)
+	n.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/sirupsen/logrus"
+
+	"github.com/TiPSYDiPSY/home-task/internal/events"
+)
+
+// DefaultChannel is the Postgres notification channel of balance change events.
+const DefaultChannel = "balance_changes"
+
+// PostgresNotifier sends events through LISTEN/NOTIFY of the service's own database, so replicas
+// need nothing else to share them. A NOTIFY payload is limited to 8000 bytes, several times the
+// size of a balance change event.
+type PostgresNotifier struct {
+	dsn     string
+	channel string
+
+	mu sync.Mutex
+	// conn publishes. It is opened on first use and again after an error.
+	conn *pgx.Conn
+}
+
+// NewPostgresNotifier connects with dsn, in the libpq keyword/value or URL format.
+func NewPostgresNotifier(dsn, channel string) *PostgresNotifier {
+	return &PostgresNotifier{
+		dsn:     dsn,
+		channel: channel,
+	}
+}
+
+func (n *PostgresNotifier) Publish(ctx context.Context, event events.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		if n.conn, err = pgx.Connect(ctx, n.dsn); err != nil {
+			return fmt.Errorf("failed to connect notifier: %w", err)
+		}
+	}
+
+	if _, err := n.conn.Exec(ctx, "SELECT pg_notify($1, $2)", n.channel, string(payload)); err != nil {
+		_ = n.conn.Close(context.Background())
+		n.conn = nil
+
+		return fmt.Errorf("failed to notify %s: %w", n.channel, err)
+	}
+
+	return nil
+}
+
+func (n *PostgresNotifier) Listen(ctx context.Context, handle func(events.Event)) error {
+	conn, err := pgx.Connect(ctx, n.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to connect notifier: %w", err)
+	}
+	defer conn.Close(context.Background()) //nolint: errcheck // Nothing is left to flush
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{n.channel}.Sanitize()); err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", n.channel, err)
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			return fmt.Errorf("failed to wait for notification: %w", err)
+		}
+
+		var event events.Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			logrus.WithContext(ctx).WithError(err).Warn("Ignoring malformed notification")
+
+			continue
+		}
+
+		handle(event)
+	}
+}
+
+// Close closes the publishing connection. Listen closes its own when its context is cancelled.
+func (n *PostgresNotifier) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+
+	err := n.conn.Close(context.Background())
+	n.conn = nil
+
+	if err != nil {
+		return fmt.Errorf("failed to close notifier: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+// Codes are part of the API contract: once published they are never renamed or reused. The
+// lower-case codes of the error body that problem details replaced, such as account_frozen, were
+// renamed once with that breaking change; the README lists them next to their successors.
+const (
+	CodeInvalidRequest         = "INVALID_REQUEST"
+	CodeValidationFailed       = "VALIDATION_FAILED"
+	CodeInvalidSourceType      = "INVALID_SOURCE_TYPE"
+	CodeRequestTooLarge        = "REQUEST_TOO_LARGE"
+	CodeAuthenticationRequired = "AUTHENTICATION_REQUIRED"
+	CodeInvalidCredentials     = "INVALID_CREDENTIALS"
+	CodeInvalidSignature       = "INVALID_SIGNATURE"
+	CodeMissingScope           = "MISSING_SCOPE"
+	CodeInternalError          = "INTERNAL_ERROR"
+
+	CodeUserNotFound      = "USER_NOT_FOUND"
+	CodeUserFrozen        = "USER_FROZEN"
+	CodeUserClosed        = "USER_CLOSED"
+	CodeExternalIDTaken   = "EXTERNAL_ID_TAKEN"
+	CodeBalanceNotZero    = "BALANCE_NOT_ZERO"
+	CodeInsufficientFunds = "INSUFFICIENT_FUNDS"
+
+	CodeInvalidAmount       = "INVALID_AMOUNT"
+	CodeAmountNotPositive   = "AMOUNT_NOT_POSITIVE"
+	CodeAmountZero          = "AMOUNT_ZERO"
+	CodeAmountBelowMinimum  = "AMOUNT_BELOW_MINIMUM"
+	CodeAmountAboveMaximum  = "AMOUNT_ABOVE_MAXIMUM"
+	CodeAmountOutOfRange    = "AMOUNT_OUT_OF_RANGE"
+	CodeUnsupportedCurrency = "UNSUPPORTED_CURRENCY"
+	CodeCurrencyMismatch    = "CURRENCY_MISMATCH"
+	CodeInvalidCursor       = "INVALID_CURSOR"
+	CodeInvalidTimeFormat   = "INVALID_TIME_FORMAT"
+
+	CodeDuplicateTransaction = "DUPLICATE_TRANSACTION"
+	CodeTransactionNotFound  = "TRANSACTION_NOT_FOUND"
+	CodeAlreadyReversed      = "ALREADY_REVERSED"
+	CodeNotReversible        = "NOT_REVERSIBLE"
+
+	CodeHoldNotFound  = "HOLD_NOT_FOUND"
+	CodeDuplicateHold = "DUPLICATE_HOLD"
+	CodeHoldNotActive = "HOLD_NOT_ACTIVE"
+
+	CodeAdjustmentNotFound   = "ADJUSTMENT_NOT_FOUND"
+	CodeAdjustmentNotPending = "ADJUSTMENT_NOT_PENDING"
+	CodeSelfApproval         = "SELF_APPROVAL"
+
+	CodeWebhookSubscriptionNotFound = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+	CodeWebhookDeliveryNotFound     = "WEBHOOK_DELIVERY_NOT_FOUND"
+	CodeWebhookDeliveryNotDead      = "WEBHOOK_DELIVERY_NOT_DEAD"
+
+	CodeStreamsUnavailable = "STREAMS_UNAVAILABLE"
+)
+
+// domainError is how a domain error is answered. An empty detail uses the error text, for
+// errors that carry the limit they broke.
+type domainError struct {
+	err    error
+	status int
+	code   string
+	detail string
+}
+
+// domainErrors is checked in order, so errors a service wraps around others come first: the
+// amount policy and account status reject a request before anything else is looked at.
+var domainErrors = []domainError{
+	{customErrors.ErrAmountNotPositive, http.StatusBadRequest, CodeAmountNotPositive, "amount must be greater than zero"},
+	{customErrors.ErrAmountBelowMinimum, http.StatusBadRequest, CodeAmountBelowMinimum, ""},
+	{customErrors.ErrAmountAboveMaximum, http.StatusBadRequest, CodeAmountAboveMaximum, ""},
+	{customErrors.ErrAmountOverflow, http.StatusBadRequest, CodeAmountOutOfRange, "amount is too large"},
+	{customErrors.ErrAccountFrozen, http.StatusForbidden, CodeUserFrozen, "account is frozen for this operation"},
+	{customErrors.ErrAccountClosed, http.StatusForbidden, CodeUserClosed, "account is closed"},
+
+	{customErrors.ErrUserNotFound, http.StatusNotFound, CodeUserNotFound, "user not found"},
+	{customErrors.ErrExternalIDExists, http.StatusConflict, CodeExternalIDTaken, "external ID is already in use"},
+	{
+		customErrors.ErrNonZeroBalance, http.StatusConflict, CodeBalanceNotZero,
+		"account can only be closed with zero balance and no active holds",
+	},
+	{
+		customErrors.ErrInsufficientFunds, http.StatusBadRequest, CodeInsufficientFunds,
+		"insufficient funds for this transaction",
+	},
+	{customErrors.ErrInvalidAmountFormat, http.StatusBadRequest, CodeInvalidAmount, "invalid amount format"},
+	{customErrors.ErrAmountZero, http.StatusBadRequest, CodeAmountZero, "amount must not be zero"},
+	{customErrors.ErrUnsupportedCurrency, http.StatusBadRequest, CodeUnsupportedCurrency, "unsupported currency"},
+	{
+		customErrors.ErrCurrencyMismatch, http.StatusUnprocessableEntity, CodeCurrencyMismatch,
+		"payout currency does not match the hold currency",
+	},
+	{customErrors.ErrInvalidCursor, http.StatusBadRequest, CodeInvalidCursor, "invalid cursor"},
+	{customErrors.ErrInvalidTimeFormat, http.StatusBadRequest, CodeInvalidTimeFormat, "invalid time format"},
+
+	{
+		customErrors.ErrTransactionExists, http.StatusConflict, CodeDuplicateTransaction,
+		"transaction ID already used with a different payload",
+	},
+	{customErrors.ErrTransactionNotFound, http.StatusNotFound, CodeTransactionNotFound, "transaction not found"},
+	{customErrors.ErrAlreadyReversed, http.StatusConflict, CodeAlreadyReversed, "transaction has already been reversed"},
+	{
+		customErrors.ErrNotReversible, http.StatusUnprocessableEntity, CodeNotReversible,
+		"this transaction type cannot be reversed",
+	},
+
+	{customErrors.ErrHoldNotFound, http.StatusNotFound, CodeHoldNotFound, "hold not found"},
+	{customErrors.ErrHoldExists, http.StatusConflict, CodeDuplicateHold, "hold ID already used with a different payload"},
+	{
+		customErrors.ErrHoldNotActive, http.StatusConflict, CodeHoldNotActive,
+		"hold is already settled, released or expired",
+	},
+
+	{customErrors.ErrAdjustmentNotFound, http.StatusNotFound, CodeAdjustmentNotFound, "adjustment not found"},
+	{
+		customErrors.ErrAdjustmentExists, http.StatusConflict, CodeDuplicateTransaction,
+		"transaction ID already used with a different payload",
+	},
+	{
+		customErrors.ErrAdjustmentNotPending, http.StatusConflict, CodeAdjustmentNotPending,
+		"adjustment is already posted or rejected",
+	},
+	{
+		customErrors.ErrSelfApproval, http.StatusForbidden, CodeSelfApproval,
+		"adjustments must be approved by a different operator",
+	},
+	{customErrors.ErrOperatorRequired, http.StatusUnauthorized, CodeAuthenticationRequired, "authentication required"},
+
+	{
+		customErrors.ErrWebhookSubscriptionNotFound, http.StatusNotFound, CodeWebhookSubscriptionNotFound,
+		"webhook subscription not found",
+	},
+	{
+		customErrors.ErrWebhookDeliveryNotFound, http.StatusNotFound, CodeWebhookDeliveryNotFound,
+		"webhook delivery not found",
+	},
+	{
+		customErrors.ErrWebhookDeliveryNotDead, http.StatusConflict, CodeWebhookDeliveryNotDead,
+		"only dead-lettered deliveries can be redelivered",
+	},
+
+	{
+		customErrors.ErrStreamsUnavailable, http.StatusServiceUnavailable, CodeStreamsUnavailable,
+		"balance streams are unavailable",
+	},
+}
+
+// Lookup returns the code and detail a domain error is answered with, for transports that carry
+// them in their own status, and false for errors without a mapping.
+func Lookup(err error) (string, string, bool) {
+	mapping, ok := lookup(err)
+	if !ok {
+		return "", "", false
+	}
+
+	return mapping.code, mapping.detailFor(err), true
+}
+
+func (m domainError) detailFor(err error) string {
+	if m.detail == "" {
+		return err.Error()
+	}
+
+	return m.detail
+}
+
+func lookup(err error) (domainError, bool) {
+	for _, mapping := range domainErrors {
+		if errors.Is(err, mapping.err) {
+			return mapping, true
+		}
+	}
+
+	return domainError{}, false
+}
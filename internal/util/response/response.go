@@ -3,14 +3,27 @@ package response
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
 )
 
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 error response. Code is stable and meant for clients to branch on;
+// Detail is for humans and may change.
+type Problem struct {
+	Type    string                         `json:"type"`
+	Title   string                         `json:"title"`
+	Status  int                            `json:"status"`
+	Detail  string                         `json:"detail,omitempty"`
+	Code    string                         `json:"code"`
+	Errors  []customErrors.ValidationError `json:"errors,omitempty"`
+	TraceID string                         `json:"traceId,omitempty"` //nolint: tagliatelle // Per API spec
 }
 
 type SuccessResponse struct {
@@ -19,42 +32,79 @@ type SuccessResponse struct {
 }
 
 func JSON(ctx context.Context, w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
+	writeJSON(ctx, w, "application/json", statusCode, data)
+}
 
-	if err := json.NewEncoder(w).Encode(data); err != nil {
-		logrus.WithContext(ctx).WithError(err).Error("Failed to encode JSON response")
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
-	}
+// Error writes a problem with the given code. Server errors are logged at error level, client
+// errors at warning level.
+func Error(ctx context.Context, w http.ResponseWriter, statusCode int, code, detail string) {
+	writeProblem(ctx, w, newProblem(ctx, statusCode, code, detail))
+}
+
+func BadRequest(ctx context.Context, w http.ResponseWriter, detail string) {
+	Error(ctx, w, http.StatusBadRequest, CodeInvalidRequest, detail)
 }
 
-func Error(ctx context.Context, w http.ResponseWriter, statusCode int, message string, details ...string) {
-	log := logrus.WithContext(ctx)
+// FromError writes the problem a domain error maps to. Validation errors list the offending
+// fields; errors without a mapping are logged and answered with 500 Internal Server Error.
+func FromError(ctx context.Context, w http.ResponseWriter, err error) {
+	var validationErrors customErrors.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		problem := newProblem(ctx, http.StatusBadRequest, CodeValidationFailed, validationErrors.Error())
+		problem.Errors = validationErrors
+		writeProblem(ctx, w, problem)
+
+		return
+	}
+
+	if mapping, ok := lookup(err); ok {
+		Error(ctx, w, mapping.status, mapping.code, mapping.detailFor(err))
 
-	response := ErrorResponse{
-		Error: http.StatusText(statusCode),
+		return
 	}
 
-	if message != "" {
-		response.Message = message
+	logrus.WithContext(ctx).WithError(err).Error("Unmapped error")
+	Error(ctx, w, http.StatusInternalServerError, CodeInternalError, "internal server error")
+}
+
+func newProblem(ctx context.Context, statusCode int, code, detail string) Problem {
+	problem := Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(statusCode),
+		Status: statusCode,
+		Detail: detail,
+		Code:   code,
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		problem.TraceID = spanContext.TraceID().String()
 	}
 
-	if statusCode >= http.StatusInternalServerError {
-		log.WithFields(logrus.Fields{
-			"status_code": statusCode,
-			"message":     message,
-			"details":     details,
-		}).Error("Server error occurred")
+	return problem
+}
+
+func writeProblem(ctx context.Context, w http.ResponseWriter, problem Problem) {
+	log := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"status_code": problem.Status,
+		"code":        problem.Code,
+		"message":     problem.Detail,
+	})
+
+	if problem.Status >= http.StatusInternalServerError {
+		log.Error("Server error occurred")
 	} else {
-		log.WithFields(logrus.Fields{
-			"status_code": statusCode,
-			"message":     message,
-		}).Warn("Client error occurred")
+		log.Warn("Client error occurred")
 	}
 
-	JSON(ctx, w, statusCode, response)
+	writeJSON(ctx, w, ProblemContentType, problem.Status, problem)
 }
 
-func BadRequest(ctx context.Context, w http.ResponseWriter, message string) {
-	Error(ctx, w, http.StatusBadRequest, message)
+func writeJSON(ctx context.Context, w http.ResponseWriter, contentType string, statusCode int, data interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		logrus.WithContext(ctx).WithError(err).Error("Failed to encode JSON response")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+	}
 }
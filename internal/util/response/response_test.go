@@ -0,0 +1,135 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		wantHTTPCode int
+		wantBody     string
+	}{
+		{
+			name:         "mapped error",
+			err:          customErrors.ErrInsufficientFunds,
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "INSUFFICIENT_FUNDS",
+				"detail": "insufficient funds for this transaction"
+			}`,
+		},
+		{
+			name:         "wrapped error",
+			err:          fmt.Errorf("failed to apply transaction: %w", customErrors.ErrTransactionExists),
+			wantHTTPCode: http.StatusConflict,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Conflict",
+				"status": 409,
+				"code": "DUPLICATE_TRANSACTION",
+				"detail": "transaction ID already used with a different payload"
+			}`,
+		},
+		{
+			name:         "account status wins over the error it wraps",
+			err:          fmt.Errorf("%w: %w", customErrors.ErrAccountFrozen, customErrors.ErrInsufficientFunds),
+			wantHTTPCode: http.StatusForbidden,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Forbidden",
+				"status": 403,
+				"code": "USER_FROZEN",
+				"detail": "account is frozen for this operation"
+			}`,
+		},
+		{
+			name:         "error text as detail",
+			err:          fmt.Errorf("%w: maximum is 100.00 USD", customErrors.ErrAmountAboveMaximum),
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "AMOUNT_ABOVE_MAXIMUM",
+				"detail": "amount is above the maximum: maximum is 100.00 USD"
+			}`,
+		},
+		{
+			name: "validation errors",
+			err: customErrors.ValidationErrors{
+				{Field: "amount", Message: "amount is required"},
+				{Field: "transactionId", Message: "transactionId is required"},
+			},
+			wantHTTPCode: http.StatusBadRequest,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Bad Request",
+				"status": 400,
+				"code": "VALIDATION_FAILED",
+				"detail": "validation failed: amount is required, transactionId is required",
+				"errors": [
+					{"field": "amount", "message": "amount is required"},
+					{"field": "transactionId", "message": "transactionId is required"}
+				]
+			}`,
+		},
+		{
+			name:         "unmapped error",
+			err:          errors.New("connection refused"),
+			wantHTTPCode: http.StatusInternalServerError,
+			wantBody: `{
+				"type": "about:blank",
+				"title": "Internal Server Error",
+				"status": 500,
+				"code": "INTERNAL_ERROR",
+				"detail": "internal server error"
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			FromError(t.Context(), rr, tt.err)
+
+			assert.Equal(t, tt.wantHTTPCode, rr.Code)
+			assert.Equal(t, ProblemContentType, rr.Header().Get("Content-Type"))
+			assert.JSONEq(t, tt.wantBody, rr.Body.String())
+		})
+	}
+}
+
+func TestError_TraceID(t *testing.T) {
+	traceID := trace.TraceID{0x4b, 0xf9, 0x2f, 0x35, 0x77, 0xb3, 0x4d, 0xa6, 0xa3, 0xce, 0x92, 0x9d, 0x0e, 0x0e, 0x47, 0x36}
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: traceID,
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa, 0x0b, 0xa9, 0x02, 0xb7},
+	}))
+
+	rr := httptest.NewRecorder()
+	Error(ctx, rr, http.StatusUnauthorized, CodeAuthenticationRequired, "authentication required")
+
+	assert.JSONEq(t, `{
+		"type": "about:blank",
+		"title": "Unauthorized",
+		"status": 401,
+		"code": "AUTHENTICATION_REQUIRED",
+		"detail": "authentication required",
+		"traceId": "4bf92f3577b34da6a3ce929d0e0e4736"
+	}`, rr.Body.String())
+}
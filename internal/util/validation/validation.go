@@ -3,51 +3,145 @@ package validation
 import (
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
 )
 
+// reservedTransactionIDPrefixes start the IDs of transactions the wallet writes itself, such as
+// hold settlements and reconciliation corrections, so clients cannot collide with them.
+var reservedTransactionIDPrefixes = []string{"hold:", "reconciliation:"}
+
 type Validator struct {
-	validate *validator.Validate
+	validate   *validator.Validate
+	currencies *currency.Registry
 }
 
-const (
-	maxDecimalPlaces = 2
-)
+type Option func(*Validator)
+
+// WithCurrencies sets the registry used by the currency and amount tags.
+// Without it the built-in currencies are used.
+func WithCurrencies(currencies *currency.Registry) Option {
+	return func(v *Validator) {
+		v.currencies = currencies
+	}
+}
 
-func NewValidator() *Validator {
+func NewValidator(opts ...Option) *Validator {
 	v := &Validator{
 		validate: validator.New(),
 	}
 
-	if err := v.validate.RegisterValidation("decimal2", validateDecimal2); err != nil {
-		panic(fmt.Sprintf("failed to register decimal2 validator: %v", err))
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.currencies == nil {
+		v.currencies = currency.DefaultRegistry()
+	}
+
+	v.validate.RegisterTagNameFunc(fieldName)
+
+	if err := v.validate.RegisterValidation("amount", v.validateAmount); err != nil {
+		panic(fmt.Sprintf("failed to register amount validator: %v", err))
+	}
+
+	if err := v.validate.RegisterValidation("currency", v.validateCurrency); err != nil {
+		panic(fmt.Sprintf("failed to register currency validator: %v", err))
+	}
+
+	if err := v.validate.RegisterValidation("transaction_id", validateTransactionID); err != nil {
+		panic(fmt.Sprintf("failed to register transaction_id validator: %v", err))
 	}
 
 	return v
 }
 
-func validateDecimal2(fl validator.FieldLevel) bool {
+// validateAmount checks that the amount has no more decimal places than the minor-unit exponent
+// of the sibling Currency field. Structs without one, or with an empty one, use the default currency.
+// Exponent notation is rejected: "1e-3" has no '.' to count decimal places after.
+func (v *Validator) validateAmount(fl validator.FieldLevel) bool {
 	value := fl.Field().String()
 
+	if strings.ContainsAny(value, "eE") {
+		return false
+	}
+
+	cur, ok := v.currencies.Resolve(siblingCurrency(fl))
+	if !ok {
+		// Reported by the currency tag.
+		return true
+	}
+
 	if idx := strings.IndexByte(value, '.'); idx != -1 {
-		return len(value)-idx-1 <= maxDecimalPlaces
+		return len(value)-idx-1 <= int(cur.Exponent)
 	}
 
 	return true
 }
 
+func (v *Validator) validateCurrency(fl validator.FieldLevel) bool {
+	_, ok := v.currencies.Resolve(fl.Field().String())
+
+	return ok
+}
+
+func validateTransactionID(fl validator.FieldLevel) bool {
+	value := fl.Field().String()
+
+	for _, prefix := range reservedTransactionIDPrefixes {
+		if strings.HasPrefix(value, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func siblingCurrency(fl validator.FieldLevel) string {
+	parent := reflect.Indirect(fl.Parent())
+	if parent.Kind() != reflect.Struct {
+		return ""
+	}
+
+	field := parent.FieldByName("Currency")
+	if !field.IsValid() || field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
+
+// fieldName names a field as clients send it: by its JSON key for bodies and its query
+// parameter for queries, falling back to the Go name.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "query"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// ValidateStruct returns customErrors.ValidationErrors with one entry per invalid field.
 func (v *Validator) ValidateStruct(s any) error {
 	if err := v.validate.Struct(s); err != nil {
-		var validationErrors validator.ValidationErrors
-		if errors.As(err, &validationErrors) {
-			var errorMessages []string
-			for _, validationErr := range validationErrors {
-				errorMessages = append(errorMessages, getErrorMessage(validationErr))
+		var fieldErrors validator.ValidationErrors
+		if errors.As(err, &fieldErrors) {
+			validationErrors := make(customErrors.ValidationErrors, 0, len(fieldErrors))
+			for _, fieldErr := range fieldErrors {
+				validationErrors = append(validationErrors, customErrors.ValidationError{
+					Field:   fieldErr.Field(),
+					Message: getErrorMessage(fieldErr),
+				})
 			}
 
-			return fmt.Errorf("validation failed: %s", strings.Join(errorMessages, ", "))
+			return validationErrors
 		}
 
 		return fmt.Errorf("validation error: %w", err)
@@ -60,10 +154,24 @@ func getErrorMessage(fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
 		return fe.Field() + " is required"
+	case "required_if":
+		return fmt.Sprintf("%s is required when %s", fe.Field(), strings.Replace(fe.Param(), " ", " is ", 1))
 	case "oneof":
 		return fmt.Sprintf("%s must be one of [%s]", fe.Field(), fe.Param())
-	case "decimal2":
-		return fe.Field() + " must have at most 2 decimal places"
+	case "amount":
+		return fe.Field() + " must be a plain decimal with no more decimal places than its currency allows"
+	case "currency":
+		return fe.Field() + " is not a supported currency"
+	case "transaction_id":
+		return fmt.Sprintf("%s must not start with %s", fe.Field(), strings.Join(reservedTransactionIDPrefixes, " or "))
+	case "min":
+		return fmt.Sprintf("%s must be at least %s", fe.Field(), fe.Param())
+	case "max":
+		return fmt.Sprintf("%s must be at most %s", fe.Field(), fe.Param())
+	case "numeric":
+		return fe.Field() + " must be numeric"
+	case "datetime":
+		return fe.Field() + " must be an RFC 3339 timestamp"
 	default:
 		return fe.Field() + " is invalid"
 	}
@@ -3,25 +3,39 @@ package validation
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TiPSYDiPSY/home-task/internal/currency"
+	customErrors "github.com/TiPSYDiPSY/home-task/internal/errors"
 	"github.com/TiPSYDiPSY/home-task/internal/model/api"
 )
 
-func TestDecimal2Validator(t *testing.T) {
+func TestAmountValidator(t *testing.T) {
 	validator := NewValidator()
 
 	tests := []struct {
-		name    string
-		amount  string
-		wantErr bool
+		name     string
+		amount   string
+		currency string
+		wantErr  bool
 	}{
-		{"valid - no decimal", "100", false},
-		{"valid - one decimal", "100.5", false},
-		{"valid - two decimals", "100.50", false},
-		{"invalid - three decimals", "100.501", true},
-		{"invalid - four decimals", "0.0401", true},
-		{"valid - zero with decimals", "0.00", false},
-		{"valid - negative", "-10.50", false},
-		{"invalid - negative with three decimals", "-10.505", true},
+		{"valid - no decimal", "100", "", false},
+		{"valid - one decimal", "100.5", "", false},
+		{"valid - two decimals", "100.50", "", false},
+		{"invalid - three decimals", "100.501", "", true},
+		{"invalid - four decimals", "0.0401", "", true},
+		{"valid - zero with decimals", "0.00", "", false},
+		{"valid - negative", "-10.50", "", false},
+		{"invalid - negative with three decimals", "-10.505", "", true},
+		{"valid - JPY without decimals", "1500", "JPY", false},
+		{"invalid - JPY with decimals", "1500.5", "JPY", true},
+		{"valid - KWD with three decimals", "1.234", "KWD", false},
+		{"invalid - KWD with four decimals", "1.2345", "KWD", true},
+		{"valid - lowercase currency", "1.23", "eur", false},
+		{"invalid - unknown currency", "1", "XXX", true},
+		{"invalid - exponent below the minor unit", "1e-3", "", true},
+		{"invalid - EUR with three decimals", "0.001", "EUR", true},
+		{"invalid - exponent notation", "1E2", "", true},
 	}
 
 	for _, tt := range tests {
@@ -29,6 +43,7 @@ func TestDecimal2Validator(t *testing.T) {
 			req := api.TransactionRequest{
 				State:         "win",
 				Amount:        tt.amount,
+				Currency:      tt.currency,
 				TransactionID: "test-123",
 			}
 
@@ -39,3 +54,58 @@ func TestDecimal2Validator(t *testing.T) {
 		})
 	}
 }
+
+func TestAmountValidatorWithCustomRegistry(t *testing.T) {
+	registry, err := currency.NewRegistry("XTS", currency.Currency{Code: "XTS", Exponent: 4})
+	assert.NoError(t, err)
+
+	validator := NewValidator(WithCurrencies(registry))
+
+	err = validator.ValidateStruct(api.TransactionRequest{State: "win", Amount: "1.2345", TransactionID: "test-123"})
+	assert.NoError(t, err)
+
+	err = validator.ValidateStruct(api.TransactionRequest{State: "win", Amount: "1.23456", TransactionID: "test-123"})
+	assert.Equal(t, customErrors.ValidationErrors{{
+		Field:   "amount",
+		Message: "amount must be a plain decimal with no more decimal places than its currency allows",
+	}}, err)
+}
+
+func TestTransactionIDValidator(t *testing.T) {
+	validator := NewValidator()
+
+	tests := []struct {
+		name          string
+		transactionID string
+		wantErr       error
+	}{
+		{name: "client ID", transactionID: "e48a6dd8-09bc-4cb2-b036-59c8b497b7e2"},
+		{name: "reserved word inside the ID", transactionID: "bet-hold:42"},
+		{
+			name:          "hold settlement prefix",
+			transactionID: "hold:42",
+			wantErr: customErrors.ValidationErrors{{
+				Field:   "transactionId",
+				Message: "transactionId must not start with hold: or reconciliation:",
+			}},
+		},
+		{
+			name:          "reconciliation prefix",
+			transactionID: "reconciliation:42",
+			wantErr: customErrors.ValidationErrors{{
+				Field:   "transactionId",
+				Message: "transactionId must not start with hold: or reconciliation:",
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validator.ValidateStruct(api.TransactionRequest{State: "win", Amount: "1", TransactionID: tt.transactionID})
+			assert.Equal(t, tt.wantErr, err)
+
+			err = validator.ValidateStruct(api.ReversalRequest{TransactionID: tt.transactionID})
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}